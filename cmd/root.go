@@ -42,6 +42,7 @@ func Run() {
 	cmd.AddCommand(factory.Build(commands.Pull))
 	cmd.AddCommand(factory.Build(commands.App))
 	cmd.AddCommand(factory.Build(commands.User))
+	cmd.AddCommand(factory.Build(commands.APIKeys))
 	cmd.AddCommand(factory.Build(commands.Secrets))
 	cmd.AddCommand(factory.Build(commands.Logs))
 	cmd.AddCommand(factory.Build(commands.Function))
@@ -86,6 +86,21 @@ func TestCLI(t *testing.T) {
 			args:        []string{"app", "describe"},
 			firstLine:   "Displays information about your Realm app",
 		},
+		{
+			description: "the app analyze command",
+			args:        []string{"app", "analyze"},
+			firstLine:   "Find the unused components and circular function calls in your local Realm app",
+		},
+		{
+			description: "the app bundle command",
+			args:        []string{"app", "bundle"},
+			firstLine:   "Save your local Realm app as a single bundle file",
+		},
+		{
+			description: "the app unbundle command",
+			args:        []string{"app", "unbundle"},
+			firstLine:   "Restore a Realm app bundle into your local directory",
+		},
 		{
 			description: "the user create command",
 			args:        []string{"user", "create"},
@@ -116,6 +131,71 @@ func TestCLI(t *testing.T) {
 			args:        []string{"user", "delete"},
 			firstLine:   "Delete an application user from your Realm app",
 		},
+		{
+			description: "the user import command",
+			args:        []string{"user", "import"},
+			firstLine:   "Import application users into your Realm app from a file",
+		},
+		{
+			description: "the user export command",
+			args:        []string{"user", "export"},
+			firstLine:   "Export the application users of your Realm app to a file",
+		},
+		{
+			description: "the user cleanup command",
+			args:        []string{"user", "cleanup"},
+			firstLine:   "Disable, delete or revoke the sessions of the inactive users of your Realm app",
+		},
+		{
+			description: "the user pending confirm command",
+			args:        []string{"user", "pending", "confirm"},
+			firstLine:   "Confirm pending Email/Password users of your Realm app",
+		},
+		{
+			description: "the user pending resend command",
+			args:        []string{"user", "pending", "resend"},
+			firstLine:   "Resend the confirmation email to pending Email/Password users of your Realm app",
+		},
+		{
+			description: "the user pending delete command",
+			args:        []string{"user", "pending", "delete"},
+			firstLine:   "Delete pending Email/Password users from your Realm app",
+		},
+		{
+			description: "the user reset-password send command",
+			args:        []string{"user", "reset-password", "send"},
+			firstLine:   "Send a password reset email to Email/Password users of your Realm app",
+		},
+		{
+			description: "the user reset-password run-func command",
+			args:        []string{"user", "reset-password", "run-func"},
+			firstLine:   "Run the password reset function for an Email/Password user of your Realm app",
+		},
+		{
+			description: "the apikeys list command",
+			args:        []string{"apikeys", "list"},
+			firstLine:   "List the API Keys of your Realm app",
+		},
+		{
+			description: "the apikeys create command",
+			args:        []string{"apikeys", "create"},
+			firstLine:   "Create an API Key for your Realm app",
+		},
+		{
+			description: "the apikeys enable command",
+			args:        []string{"apikeys", "enable"},
+			firstLine:   "Enable API Keys of your Realm app",
+		},
+		{
+			description: "the apikeys disable command",
+			args:        []string{"apikeys", "disable"},
+			firstLine:   "Disable API Keys of your Realm app",
+		},
+		{
+			description: "the apikeys delete command",
+			args:        []string{"apikeys", "delete"},
+			firstLine:   "Delete API Keys from your Realm app",
+		},
 		{
 			description: "the secrets create command",
 			args:        []string{"secrets", "create"},
@@ -136,16 +216,66 @@ func TestCLI(t *testing.T) {
 			args:        []string{"secrets", "delete"},
 			firstLine:   "Delete a Secret from your Realm app",
 		},
+		{
+			description: "the function list command",
+			args:        []string{"function", "list"},
+			firstLine:   "List the Functions in your Realm app",
+		},
+		{
+			description: "the function describe command",
+			args:        []string{"function", "describe"},
+			firstLine:   "Describe a Function from your Realm app",
+		},
 		{
 			description: "the function run command",
 			args:        []string{"function", "run"},
 			firstLine:   "Run a Function from your Realm app",
 		},
+		{
+			description: "the function push command",
+			args:        []string{"function", "push"},
+			firstLine:   "Update a single Function of your Realm app from your local directory",
+		},
+		{
+			description: "the function pull command",
+			args:        []string{"function", "pull"},
+			firstLine:   "Update a single Function in your local directory from your Realm app",
+		},
+		{
+			description: "the function test command",
+			args:        []string{"function", "test"},
+			firstLine:   "Test the Functions of your Realm app against the test cases in your tests directory",
+		},
+		{
+			description: "the function bench command",
+			args:        []string{"function", "bench"},
+			firstLine:   "Benchmark the latency of a Function from your Realm app under load",
+		},
 		{
 			description: "the logs list command",
 			args:        []string{"logs", "list"},
 			firstLine:   "Lists the Logs in your Realm app",
 		},
+		{
+			description: "the logs tail command",
+			args:        []string{"logs", "tail"},
+			firstLine:   "Tail the Logs in your Realm app and optionally forward them",
+		},
+		{
+			description: "the logs export command",
+			args:        []string{"logs", "export"},
+			firstLine:   "Export the Logs in your Realm app to a file",
+		},
+		{
+			description: "the logs stats command",
+			args:        []string{"logs", "stats"},
+			firstLine:   "Display statistics of the Logs in your Realm app",
+		},
+		{
+			description: "the logs watch command",
+			args:        []string{"logs", "watch"},
+			firstLine:   "Watch the Logs in your Realm app and alert when rules fire",
+		},
 		{
 			description: "the schema datamodels command",
 			args:        []string{"schema", "datamodels"},
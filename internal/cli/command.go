@@ -18,6 +18,7 @@ import (
 // Optionally, a Command may implement any of the other interfaces found below.
 // The order of operations is:
 //   1. CommandFlagger.Flags: use this hook to register flags to parse
+//      CommandArgs.Args: use this hook to accept positional args
 //   2. CommandInputs.Resolve: use this hook to prompt for any flags not provided
 //   3. CommandPreparer.Setup: use this hook to use setup the command (e.g. create clients/services)
 //   4. Command.Handler: this is the command hook
@@ -39,6 +40,11 @@ type CommandFlags interface {
 	Flags() []flags.Flag
 }
 
+// CommandArgs provides access for commands to accept and validate positional args
+type CommandArgs interface {
+	Args(args []string) error
+}
+
 // CommandInputs returns the command inputs
 type CommandInputs interface {
 	Inputs() InputResolver
@@ -81,6 +81,12 @@ func (factory *CommandFactory) Build(command CommandDefinition) *cobra.Command {
 			}
 		}
 
+		if command, ok := command.Command.(CommandArgs); ok {
+			cmd.Args = func(c *cobra.Command, a []string) error {
+				return command.Args(a)
+			}
+		}
+
 		cmd.PersistentPreRun = func(c *cobra.Command, a []string) {
 			factory.ensureUI()
 			cmd.SetIn(factory.inReader)
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -8,7 +9,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/10gen/realm-cli/internal/cli/user"
 	"github.com/10gen/realm-cli/internal/telemetry"
+	"github.com/10gen/realm-cli/internal/terminal"
 	"github.com/10gen/realm-cli/internal/utils/api"
 	"github.com/10gen/realm-cli/internal/utils/test/assert"
 	"github.com/10gen/realm-cli/internal/utils/test/mock"
@@ -169,3 +172,33 @@ func (client mockVersionClient) Get(_ string) (*http.Response, error) {
 }`, version, osArch, url))),
 	}, nil
 }
+
+type argsCommand struct {
+	args []string
+}
+
+func (cmd *argsCommand) Args(args []string) error {
+	if len(args) > 1 {
+		return errors.New("too many args")
+	}
+	cmd.args = args
+	return nil
+}
+
+func (cmd *argsCommand) Handler(profile *user.Profile, ui terminal.UI, clients Clients) error {
+	return nil
+}
+
+func TestCommandFactoryArgs(t *testing.T) {
+	t.Run("should validate the args with a command which accepts them", func(t *testing.T) {
+		factory := &CommandFactory{profile: mock.NewProfile(t)}
+
+		command := &argsCommand{}
+		cmd := factory.Build(CommandDefinition{CommandMeta: CommandMeta{Use: "test"}, Command: command})
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		cmd.SetArgs([]string{"one", "two"})
+
+		assert.Equal(t, errors.New("too many args"), cmd.Execute())
+	})
+}
@@ -12,6 +12,9 @@ const (
 
 	// ProjectFlagName is the '--project' flag name
 	ProjectFlagName = "project"
+
+	// ProfileLoadFlagName is the '--profile-load' flag name
+	ProfileLoadFlagName = "profile-load"
 )
 
 // AppFlag is the '--app' flag
@@ -80,3 +83,16 @@ func ProductFlag(value *[]string) flags.Flag {
 		},
 	}
 }
+
+// ProfileLoadFlag is the '--profile-load' flag
+func ProfileLoadFlag(value *bool) flags.Flag {
+	return flags.BoolFlag{
+		Value: value,
+		Meta: flags.Meta{
+			Name: ProfileLoadFlagName,
+			Usage: flags.Usage{
+				Description: "Report the time spent loading each component of your local Realm app",
+			},
+		},
+	}
+}
@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"time"
+
+	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/local"
+	"github.com/10gen/realm-cli/internal/terminal"
+)
+
+const (
+	headerLoadComponent = "Component"
+	headerLoadFiles     = "Files"
+	headerLoadCached    = "Cached"
+	headerLoadDuration  = "Duration"
+)
+
+// LoadApp loads the local Realm app found at the provided path, reusing any unchanged files
+// stored in the CLI profile's app cache if a profile is provided, and reports the time spent
+// loading each of the app components if profileLoad is set
+func LoadApp(ui terminal.UI, profile *user.Profile, path string, profileLoad bool) (local.App, error) {
+	var opts local.LoadOptions
+	if profile != nil {
+		opts.CachePath = profile.AppCachePath()
+	}
+	if profileLoad {
+		opts.Profile = &local.LoadProfile{}
+	}
+
+	app, err := local.LoadAppWithOptions(path, opts)
+	if err != nil {
+		return local.App{}, err
+	}
+
+	if opts.Profile != nil {
+		ui.Print(loadProfileLog(opts.Profile))
+	}
+	return app, nil
+}
+
+// LoadWorkingDirectoryApp loads the local Realm app found at the CLI profile's working directory,
+// reporting whether one was found. An app which fails to load is treated as not found
+func LoadWorkingDirectoryApp(ui terminal.UI, profile *user.Profile) (local.App, bool) {
+	app, ok, err := local.FindApp(profile.WorkingDirectory)
+	if err != nil || !ok {
+		return local.App{}, false
+	}
+
+	loaded, err := LoadApp(ui, profile, app.RootDir, false)
+	if err != nil {
+		return local.App{}, false
+	}
+	return loaded, true
+}
+
+func loadProfileLog(profile *local.LoadProfile) terminal.Log {
+	rows := make([]map[string]interface{}, 0, len(profile.Components))
+	for _, component := range profile.Components {
+		rows = append(rows, map[string]interface{}{
+			headerLoadComponent: component.Name,
+			headerLoadFiles:     component.Files,
+			headerLoadCached:    component.Cached,
+			headerLoadDuration:  component.Duration.Round(time.Microsecond).String(),
+		})
+	}
+
+	return terminal.NewTableLog(
+		"Loaded local app in "+profile.Total.Round(time.Microsecond).String(),
+		[]string{headerLoadComponent, headerLoadFiles, headerLoadCached, headerLoadDuration},
+		rows...,
+	)
+}
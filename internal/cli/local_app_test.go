@@ -0,0 +1,62 @@
+package cli_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestLoadApp(t *testing.T) {
+	wd, wdErr := os.Getwd()
+	assert.Nil(t, wdErr)
+
+	projectRoot := filepath.Join(wd, "testdata", "project")
+
+	t.Run("should load the local app without printing anything", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		app, err := cli.LoadApp(ui, nil, projectRoot, false)
+		assert.Nil(t, err)
+		assert.Equal(t, projectRoot, app.RootDir)
+		assert.Equal(t, "", out.String())
+	})
+
+	t.Run("should report the time spent loading each app component when profile load is set", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		_, err := cli.LoadApp(ui, nil, projectRoot, true)
+		assert.Nil(t, err)
+
+		lines := strings.Split(out.String(), "\n")
+		assert.True(t, strings.HasPrefix(lines[0], "Loaded local app in "), "expected load time to be reported")
+		assert.Equal(t, []string{"Component", "Files", "Cached", "Duration"}, strings.Fields(lines[1]))
+		assert.Equal(t, "secrets", strings.Fields(lines[3])[0])
+	})
+
+	t.Run("should cache the local app files in the cli profile", func(t *testing.T) {
+		profile, teardown := mock.NewProfileFromTmpDir(t, "cli_load_app_test")
+		defer teardown()
+
+		assert.Nil(t, os.Mkdir(filepath.Join(profile.WorkingDirectory, "values"), os.ModePerm))
+		for path, contents := range map[string]string{
+			"realm_config.json":  `{"config_version":20210101,"name":"eggcorn"}`,
+			"values/answer.json": `{"name":"answer","value":42}`,
+		} {
+			assert.Nil(t, ioutil.WriteFile(filepath.Join(profile.WorkingDirectory, path), []byte(contents), 0666))
+		}
+
+		_, ui := mock.NewUI()
+
+		_, err := cli.LoadApp(ui, profile, profile.WorkingDirectory, false)
+		assert.Nil(t, err)
+
+		_, err = os.Stat(profile.AppCachePath())
+		assert.Nil(t, err)
+	})
+}
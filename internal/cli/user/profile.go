@@ -19,10 +19,14 @@ const (
 	// HostingAssetCacheDir is the hosting asset cache dir
 	HostingAssetCacheDir = ".asset-cache"
 
+	// AppCacheDir is the local app file cache dir
+	AppCacheDir = ".app-cache"
+
 	envPrefix   = "realm"
 	profileType = "yaml"
 
 	extJSON = ".json"
+	extGob  = ".gob"
 )
 
 // set of supported CLI user profile flags
@@ -284,3 +288,8 @@ func (p Profile) SetLastVersionCheck(t time.Time) {
 func (p Profile) HostingAssetCachePath() string {
 	return filepath.Join(p.dir, HostingAssetCacheDir, p.Name+extJSON)
 }
+
+// AppCachePath returns the CLI profile's local app file cache path
+func (p Profile) AppCachePath() string {
+	return filepath.Join(p.dir, AppCacheDir, p.Name+extGob)
+}
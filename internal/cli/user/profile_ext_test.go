@@ -54,4 +54,9 @@ func TestProfile(t *testing.T) {
 		cachePath := fmt.Sprintf("%s/%s/%s.json", profile.Dir(), user.HostingAssetCacheDir, profile.Name)
 		assert.Equal(t, cachePath, profile.HostingAssetCachePath())
 	})
+
+	t.Run("Should provide a path the the app cache file", func(t *testing.T) {
+		cachePath := fmt.Sprintf("%s/%s/%s.gob", profile.Dir(), user.AppCacheDir, profile.Name)
+		assert.Equal(t, cachePath, profile.AppCachePath())
+	})
 }
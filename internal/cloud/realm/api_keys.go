@@ -0,0 +1,116 @@
+package realm
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/10gen/realm-cli/internal/utils/api"
+)
+
+const (
+	apiKeysPathPattern       = appPathPattern + "/api_keys"
+	apiKeyPathPattern        = apiKeysPathPattern + "/%s"
+	apiKeyDisablePathPattern = apiKeyPathPattern + "/disable"
+	apiKeyEnablePathPattern  = apiKeyPathPattern + "/enable"
+)
+
+// APIKey is a Realm app api key
+type APIKey struct {
+	ID       string `json:"_id"`
+	Name     string `json:"name"`
+	Disabled bool   `json:"disabled"`
+	Key      string `json:"key,omitempty"`
+}
+
+func (c *client) APIKeys(groupID, appID string) ([]APIKey, error) {
+	res, resErr := c.do(
+		http.MethodGet,
+		fmt.Sprintf(apiKeysPathPattern, groupID, appID),
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return nil, resErr
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, api.ErrUnexpectedStatusCode{"api keys", res.StatusCode}
+	}
+	defer res.Body.Close()
+
+	var apiKeys []APIKey
+	if err := json.NewDecoder(res.Body).Decode(&apiKeys); err != nil {
+		return nil, err
+	}
+	return apiKeys, nil
+}
+
+type createAPIKeyRequest struct {
+	Name string `json:"name"`
+}
+
+func (c *client) CreateAPIKey(groupID, appID, apiKeyName string) (APIKey, error) {
+	res, resErr := c.doJSON(
+		http.MethodPost,
+		fmt.Sprintf(apiKeysPathPattern, groupID, appID),
+		createAPIKeyRequest{apiKeyName},
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return APIKey{}, resErr
+	}
+	if res.StatusCode != http.StatusCreated {
+		return APIKey{}, api.ErrUnexpectedStatusCode{"create api key", res.StatusCode}
+	}
+	defer res.Body.Close()
+
+	var apiKey APIKey
+	if err := json.NewDecoder(res.Body).Decode(&apiKey); err != nil {
+		return APIKey{}, err
+	}
+	return apiKey, nil
+}
+
+func (c *client) DeleteAPIKey(groupID, appID, apiKeyID string) error {
+	res, resErr := c.do(
+		http.MethodDelete,
+		fmt.Sprintf(apiKeyPathPattern, groupID, appID, apiKeyID),
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return resErr
+	}
+	if res.StatusCode != http.StatusNoContent {
+		return api.ErrUnexpectedStatusCode{Action: "delete api key", Actual: res.StatusCode}
+	}
+	return nil
+}
+
+func (c *client) DisableAPIKey(groupID, appID, apiKeyID string) error {
+	res, resErr := c.do(
+		http.MethodPut,
+		fmt.Sprintf(apiKeyDisablePathPattern, groupID, appID, apiKeyID),
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return resErr
+	}
+	if res.StatusCode != http.StatusNoContent {
+		return api.ErrUnexpectedStatusCode{Action: "disable api key", Actual: res.StatusCode}
+	}
+	return nil
+}
+
+func (c *client) EnableAPIKey(groupID, appID, apiKeyID string) error {
+	res, resErr := c.do(
+		http.MethodPut,
+		fmt.Sprintf(apiKeyEnablePathPattern, groupID, appID, apiKeyID),
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return resErr
+	}
+	if res.StatusCode != http.StatusNoContent {
+		return api.ErrUnexpectedStatusCode{Action: "enable api key", Actual: res.StatusCode}
+	}
+	return nil
+}
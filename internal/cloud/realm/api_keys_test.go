@@ -0,0 +1,88 @@
+package realm_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/api"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestRealmAPIKeys(t *testing.T) {
+	type request struct {
+		Method string
+		Path   string
+	}
+
+	var requests []request
+	statusCode := http.StatusNoContent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, request{r.Method, r.URL.Path})
+
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`[{"_id":"key-1","name":"one","disabled":false},{"_id":"key-2","name":"two","disabled":true}]`))
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"_id":"key-3","name":"three","disabled":false,"key":"secret"}`))
+		default:
+			w.WriteHeader(statusCode)
+		}
+	}))
+	defer server.Close()
+
+	profile := mock.NewProfile(t)
+	profile.SetSession(user.Session{AccessToken: "accessToken"})
+
+	client := realm.NewAuthClient(server.URL, profile)
+
+	const apiKeysPath = "/api/admin/v3.0/groups/groupID/apps/appID/api_keys"
+
+	t.Run("should list the api keys", func(t *testing.T) {
+		requests = nil
+
+		apiKeys, err := client.APIKeys("groupID", "appID")
+		assert.Nil(t, err)
+		assert.Equal(t, []realm.APIKey{
+			{ID: "key-1", Name: "one"},
+			{ID: "key-2", Name: "two", Disabled: true},
+		}, apiKeys)
+		assert.Equal(t, []request{{http.MethodGet, apiKeysPath}}, requests)
+	})
+
+	t.Run("should create an api key", func(t *testing.T) {
+		requests = nil
+
+		apiKey, err := client.CreateAPIKey("groupID", "appID", "three")
+		assert.Nil(t, err)
+		assert.Equal(t, realm.APIKey{ID: "key-3", Name: "three", Key: "secret"}, apiKey)
+		assert.Equal(t, []request{{http.MethodPost, apiKeysPath}}, requests)
+	})
+
+	t.Run("should delete, disable and enable an api key", func(t *testing.T) {
+		requests = nil
+
+		assert.Nil(t, client.DeleteAPIKey("groupID", "appID", "key-1"))
+		assert.Nil(t, client.DisableAPIKey("groupID", "appID", "key-1"))
+		assert.Nil(t, client.EnableAPIKey("groupID", "appID", "key-1"))
+
+		assert.Equal(t, []request{
+			{http.MethodDelete, apiKeysPath + "/key-1"},
+			{http.MethodPut, apiKeysPath + "/key-1/disable"},
+			{http.MethodPut, apiKeysPath + "/key-1/enable"},
+		}, requests)
+	})
+
+	t.Run("should return an error with an unexpected status code", func(t *testing.T) {
+		statusCode = http.StatusOK
+		defer func() { statusCode = http.StatusNoContent }()
+
+		assert.Equal(t, api.ErrUnexpectedStatusCode{"delete api key", http.StatusOK}, client.DeleteAPIKey("groupID", "appID", "key-1"))
+		assert.Equal(t, api.ErrUnexpectedStatusCode{"disable api key", http.StatusOK}, client.DisableAPIKey("groupID", "appID", "key-1"))
+		assert.Equal(t, api.ErrUnexpectedStatusCode{"enable api key", http.StatusOK}, client.EnableAPIKey("groupID", "appID", "key-1"))
+	})
+}
@@ -13,6 +13,7 @@ import (
 
 const (
 	adminAPI   = "/api/admin/v3.0"
+	clientAPI  = "/api/client/v2.0"
 	privateAPI = "/api/private/v1.0"
 
 	requestOriginHeader = "X-BAAS-Request-Origin"
@@ -53,14 +54,26 @@ type Client interface {
 	DeleteSecret(groupID, appID, secretID string) error
 	UpdateSecret(groupID, appID, secretID, name, value string) error
 
+	APIKeys(groupID, appID string) ([]APIKey, error)
 	CreateAPIKey(groupID, appID, apiKeyName string) (APIKey, error)
+	DeleteAPIKey(groupID, appID, apiKeyID string) error
+	DisableAPIKey(groupID, appID, apiKeyID string) error
+	EnableAPIKey(groupID, appID, apiKeyID string) error
+
 	CreateUser(groupID, appID, email, password string) (User, error)
 	DeleteUser(groupID, appID, userID string) error
 	DisableUser(groupID, appID, userID string) error
 	EnableUser(groupID, appID, userID string) error
 	FindUsers(groupID, appID string, filter UserFilter) ([]User, error)
+	UsersPages(groupID, appID string, filter UserFilter, handlePage func(page []User) error) error
 	RevokeUserSessions(groupID, appID, userID string) error
 
+	ConfirmPendingUser(groupID, appID, email string) error
+	DeletePendingUser(groupID, appID, email string) error
+	ResendPendingUserConfirmation(groupID, appID, email string) error
+	SendResetPasswordEmail(clientAppID, email string) error
+	CallResetPasswordFunction(clientAppID, email, password string, args []interface{}) error
+
 	HostingAssets(groupID, appID string) ([]HostingAsset, error)
 	HostingAssetUpload(groupID, appID, rootDir string, asset HostingAsset) error
 	HostingAssetRemove(groupID, appID, path string) error
@@ -68,9 +81,12 @@ type Client interface {
 	HostingCacheInvalidate(groupID, appID, path string) error
 
 	Functions(groupID, appID string) ([]Function, error)
+	Function(groupID, appID, functionID string) (FunctionDefinition, error)
+	UpdateFunction(groupID, appID, functionID string, function FunctionDefinition) error
 	AppDebugExecuteFunction(groupID, appID, userID, name string, args []interface{}) (ExecutionResults, error)
 
 	Logs(groupID, appID string, opts LogsOptions) (Logs, error)
+	LogsPages(groupID, appID string, opts LogsOptions, handlePage func(page Logs) error) (bool, error)
 
 	SchemaModels(groupID, appID, language string) ([]SchemaModel, error)
 
@@ -11,6 +11,7 @@ import (
 // Routes for functions
 const (
 	FunctionsPattern               = appPathPattern + "/functions"
+	FunctionPattern                = FunctionsPattern + "/%s"
 	AppDebugExecuteFunctionPattern = appPathPattern + "/debug/execute_function"
 )
 
@@ -28,8 +29,21 @@ type ExecutionResults struct {
 
 // Function is a realm Function
 type Function struct {
-	ID   string `json:"_id"`
-	Name string `json:"name"`
+	ID           string                 `json:"_id"`
+	Name         string                 `json:"name"`
+	Private      bool                   `json:"private"`
+	CanEvaluate  map[string]interface{} `json:"can_evaluate,omitempty"`
+	RunAsSystem  bool                   `json:"run_as_system"`
+	LastModified int64                  `json:"last_modified,omitempty"`
+}
+
+// FunctionDefinition is the full definition of a realm Function
+type FunctionDefinition struct {
+	Function
+	Source                  string `json:"source"`
+	DisableArgLogs          bool   `json:"disable_arg_logs"`
+	RunAsUserID             string `json:"run_as_user_id,omitempty"`
+	RunAsUserIDScriptSource string `json:"run_as_user_id_script_source,omitempty"`
 }
 
 func (c *client) AppDebugExecuteFunction(groupID, appID, userID, name string, args []interface{}) (ExecutionResults, error) {
@@ -83,3 +97,40 @@ func (c *client) Functions(groupID, appID string) ([]Function, error) {
 	}
 	return result, nil
 }
+
+func (c *client) Function(groupID, appID, functionID string) (FunctionDefinition, error) {
+	res, err := c.do(
+		http.MethodGet,
+		fmt.Sprintf(FunctionPattern, groupID, appID, functionID),
+		api.RequestOptions{},
+	)
+	if err != nil {
+		return FunctionDefinition{}, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return FunctionDefinition{}, api.ErrUnexpectedStatusCode{"get function", res.StatusCode}
+	}
+	defer res.Body.Close()
+
+	var function FunctionDefinition
+	if err := json.NewDecoder(res.Body).Decode(&function); err != nil {
+		return FunctionDefinition{}, err
+	}
+	return function, nil
+}
+
+func (c *client) UpdateFunction(groupID, appID, functionID string, function FunctionDefinition) error {
+	res, err := c.doJSON(
+		http.MethodPut,
+		fmt.Sprintf(FunctionPattern, groupID, appID, functionID),
+		function,
+		api.RequestOptions{},
+	)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != http.StatusNoContent {
+		return api.ErrUnexpectedStatusCode{"update function", res.StatusCode}
+	}
+	return nil
+}
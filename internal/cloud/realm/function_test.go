@@ -60,6 +60,29 @@ func TestFunctions(t *testing.T) {
 
 			assert.Equal(t, 1, len(functions))
 			assert.Equal(t, "test", functions[0].Name)
+			assert.True(t, functions[0].Private, "expected function to be private")
+
+			t.Run("and get the full definition of the function", func(t *testing.T) {
+				function, err := client.Function(groupID, app.ID, functions[0].ID)
+				assert.Nil(t, err)
+
+				assert.Equal(t, functions[0].ID, function.ID)
+				assert.Equal(t, "test", function.Name)
+				assert.True(t, function.Private, "expected function to be private")
+				assert.Equal(t, "exports = function(){\n  return \"successful test\";\n};", function.Source)
+
+				t.Run("and update the function", func(t *testing.T) {
+					function.Private = false
+					function.Source = "exports = function(){\n  return \"updated test\";\n};"
+					assert.Nil(t, client.UpdateFunction(groupID, app.ID, function.ID, function))
+
+					updated, err := client.Function(groupID, app.ID, function.ID)
+					assert.Nil(t, err)
+
+					assert.False(t, updated.Private, "expected function to not be private")
+					assert.Equal(t, function.Source, updated.Source)
+				})
+			})
 		})
 	})
 }
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,8 +16,10 @@ const (
 
 	logsQueryEndDate    = "end_date"
 	logsQueryErrorsOnly = "errors_only"
+	logsQuerySkip       = "skip"
 	logsQueryStartDate  = "start_date"
 	logsQueryType       = "type"
+	logsQueryUserID     = "user_id"
 
 	logsDateFormat = "2006-01-02T15:04:05.999Z07:00"
 )
@@ -54,6 +57,8 @@ type LogsOptions struct {
 	Types      []string
 	Start      time.Time
 	End        time.Time
+	UserID     string
+	Limit      int // the max number of logs to fetch, where 0 fetches every log in the range
 }
 
 // Logs is an array of Realm app logs
@@ -65,6 +70,7 @@ func (l Logs) Swap(i, j int)      { l[i], l[j] = l[j], l[i] }
 
 // Log is a Realm app log
 type Log struct {
+	ID                    string        `json:"_id"`
 	Messages              []interface{} `json:"messages"`
 	Type                  string        `json:"type"`
 	Started               time.Time     `json:"started"`
@@ -89,10 +95,23 @@ type LogAuthEvent struct {
 }
 
 type logsResponse struct {
-	Logs []Log `json:"logs"`
+	Logs        []Log  `json:"logs"`
+	NextEndDate string `json:"nextEndDate"`
+	NextSkip    int    `json:"nextSkip"`
 }
 
 func (c *client) Logs(groupID, appID string, opts LogsOptions) (Logs, error) {
+	var logs Logs
+	if _, err := c.LogsPages(groupID, appID, opts, func(page Logs) error {
+		logs = append(logs, page...)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+func (c *client) LogsPages(groupID, appID string, opts LogsOptions, handlePage func(page Logs) error) (bool, error) {
 	query := map[string]string{}
 	if len(opts.Types) > 0 {
 		query[logsQueryType] = strings.Join(opts.Types, ",")
@@ -100,6 +119,9 @@ func (c *client) Logs(groupID, appID string, opts LogsOptions) (Logs, error) {
 	if opts.ErrorsOnly {
 		query[logsQueryErrorsOnly] = trueVal
 	}
+	if opts.UserID != "" {
+		query[logsQueryUserID] = opts.UserID
+	}
 	if !opts.Start.IsZero() {
 		query[logsQueryStartDate] = opts.Start.Format(logsDateFormat)
 	}
@@ -107,22 +129,62 @@ func (c *client) Logs(groupID, appID string, opts LogsOptions) (Logs, error) {
 		query[logsQueryEndDate] = opts.End.Format(logsDateFormat)
 	}
 
+	var count int
+	for {
+		res, err := c.logsPage(groupID, appID, query)
+		if err != nil {
+			return false, err
+		}
+
+		page := Logs(res.Logs)
+		if opts.Limit > 0 && count+len(page) >= opts.Limit {
+			capped := count+len(page) > opts.Limit || res.NextEndDate != ""
+			return capped, handlePage(page[:opts.Limit-count])
+		}
+
+		if err := handlePage(page); err != nil {
+			return false, err
+		}
+		count += len(page)
+
+		if res.NextEndDate == "" || len(page) == 0 {
+			return false, nil
+		}
+
+		var nextSkip string
+		if res.NextSkip > 0 {
+			nextSkip = strconv.Itoa(res.NextSkip)
+		}
+		if res.NextEndDate == query[logsQueryEndDate] && nextSkip == query[logsQuerySkip] {
+			return false, nil // guard against a cursor which does not advance
+		}
+
+		query[logsQueryEndDate] = res.NextEndDate
+		if nextSkip != "" {
+			query[logsQuerySkip] = nextSkip
+		} else {
+			delete(query, logsQuerySkip)
+		}
+	}
+}
+
+func (c *client) logsPage(groupID, appID string, query map[string]string) (logsResponse, error) {
 	res, err := c.do(
 		http.MethodGet,
 		fmt.Sprintf(logsPathPattern, groupID, appID),
 		api.RequestOptions{Query: query},
 	)
 	if err != nil {
-		return nil, err
+		return logsResponse{}, err
 	}
 	if res.StatusCode != http.StatusOK {
-		return nil, api.ErrUnexpectedStatusCode{"get logs", res.StatusCode}
+		return logsResponse{}, api.ErrUnexpectedStatusCode{"get logs", res.StatusCode}
 	}
 	defer res.Body.Close()
 
 	var out logsResponse
 	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
-		return nil, err
+		return logsResponse{}, err
 	}
-	return out.Logs, nil
+	return out, nil
 }
@@ -1,11 +1,17 @@
 package realm_test
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/10gen/realm-cli/internal/cli/user"
 	"github.com/10gen/realm-cli/internal/cloud/realm"
 	u "github.com/10gen/realm-cli/internal/utils/test"
 	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
 )
 
 func TestRealmLogs(t *testing.T) {
@@ -25,3 +31,143 @@ func TestRealmLogs(t *testing.T) {
 		})
 	})
 }
+
+func TestRealmLogsPages(t *testing.T) {
+	pages := map[string]string{
+		"":                       `{"logs":[{"type":"FUNCTION","function_name":"a"},{"type":"FUNCTION","function_name":"b"}],"nextEndDate":"2021-06-22T07:54:42Z","nextSkip":1}`,
+		"2021-06-22T07:54:42Z|1": `{"logs":[{"type":"FUNCTION","function_name":"c"},{"type":"FUNCTION","function_name":"d"}],"nextEndDate":"2021-06-21T07:54:42Z"}`,
+		"2021-06-21T07:54:42Z|":  `{"logs":[{"type":"FUNCTION","function_name":"e"}]}`,
+	}
+
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		var key string
+		if endDate := query.Get("end_date"); endDate != "" {
+			key = endDate + "|" + query.Get("skip")
+		}
+		queries = append(queries, key)
+
+		w.Write([]byte(pages[key]))
+	}))
+	defer server.Close()
+
+	profile := mock.NewProfile(t)
+	profile.SetSession(user.Session{AccessToken: "accessToken"})
+
+	client := realm.NewAuthClient(server.URL, profile)
+
+	names := func(logs realm.Logs) []string {
+		out := make([]string, 0, len(logs))
+		for _, log := range logs {
+			out = append(out, log.FunctionName)
+		}
+		return out
+	}
+
+	t.Run("should follow the pagination cursors until every log is fetched", func(t *testing.T) {
+		queries = nil
+
+		var fetched [][]string
+		capped, err := client.LogsPages("groupID", "appID", realm.LogsOptions{}, func(page realm.Logs) error {
+			fetched = append(fetched, names(page))
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.False(t, capped, "expected logs to not be capped")
+
+		assert.Equal(t, [][]string{{"a", "b"}, {"c", "d"}, {"e"}}, fetched)
+		assert.Equal(t, []string{"", "2021-06-22T07:54:42Z|1", "2021-06-21T07:54:42Z|"}, queries)
+	})
+
+	for _, tc := range []struct {
+		limit  int
+		names  []string
+		capped bool
+	}{
+		{limit: 1, names: []string{"a"}, capped: true},
+		{limit: 2, names: []string{"a", "b"}, capped: true},
+		{limit: 3, names: []string{"a", "b", "c"}, capped: true},
+		{limit: 5, names: []string{"a", "b", "c", "d", "e"}},
+		{limit: 10, names: []string{"a", "b", "c", "d", "e"}},
+	} {
+		t.Run(fmt.Sprintf("should stop fetching logs once the limit of %d is reached", tc.limit), func(t *testing.T) {
+			logs, err := client.Logs("groupID", "appID", realm.LogsOptions{Limit: tc.limit})
+			assert.Nil(t, err)
+			assert.Equal(t, tc.names, names(logs))
+
+			capped, err := client.LogsPages("groupID", "appID", realm.LogsOptions{Limit: tc.limit}, func(page realm.Logs) error { return nil })
+			assert.Nil(t, err)
+			assert.Equal(t, tc.capped, capped)
+		})
+	}
+
+	t.Run("should stop fetching logs when the page handler fails", func(t *testing.T) {
+		queries = nil
+
+		_, err := client.LogsPages("groupID", "appID", realm.LogsOptions{}, func(page realm.Logs) error {
+			return errors.New("something bad happened")
+		})
+		assert.Equal(t, errors.New("something bad happened"), err)
+		assert.Equal(t, []string{""}, queries)
+	})
+}
+
+func TestRealmLogsPagesNonAdvancingCursor(t *testing.T) {
+	for _, tc := range []struct {
+		description string
+		pages       map[string]string
+		names       []string
+		queries     []string
+	}{
+		{
+			description: "should stop fetching logs when a page repeats the same cursor",
+			pages: map[string]string{
+				"":                       `{"logs":[{"type":"FUNCTION","function_name":"a"}],"nextEndDate":"2021-06-22T07:54:42Z","nextSkip":1}`,
+				"2021-06-22T07:54:42Z|1": `{"logs":[{"type":"FUNCTION","function_name":"b"}],"nextEndDate":"2021-06-22T07:54:42Z","nextSkip":1}`,
+			},
+			names:   []string{"a", "b"},
+			queries: []string{"", "2021-06-22T07:54:42Z|1"},
+		},
+		{
+			description: "should stop fetching logs when an empty page has a cursor",
+			pages: map[string]string{
+				"":                      `{"logs":[{"type":"FUNCTION","function_name":"a"}],"nextEndDate":"2021-06-22T07:54:42Z"}`,
+				"2021-06-22T07:54:42Z|": `{"logs":[],"nextEndDate":"2021-06-21T07:54:42Z"}`,
+				"2021-06-21T07:54:42Z|": `{"logs":[{"type":"FUNCTION","function_name":"unreachable"}]}`,
+			},
+			names:   []string{"a"},
+			queries: []string{"", "2021-06-22T07:54:42Z|"},
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			var queries []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				query := r.URL.Query()
+
+				var key string
+				if endDate := query.Get("end_date"); endDate != "" {
+					key = endDate + "|" + query.Get("skip")
+				}
+				queries = append(queries, key)
+
+				w.Write([]byte(tc.pages[key]))
+			}))
+			defer server.Close()
+
+			profile := mock.NewProfile(t)
+			profile.SetSession(user.Session{AccessToken: "accessToken"})
+
+			logs, err := realm.NewAuthClient(server.URL, profile).Logs("groupID", "appID", realm.LogsOptions{})
+			assert.Nil(t, err)
+
+			names := make([]string, 0, len(logs))
+			for _, log := range logs {
+				names = append(names, log.FunctionName)
+			}
+			assert.Equal(t, tc.names, names)
+			assert.Equal(t, tc.queries, queries)
+		})
+	}
+}
@@ -13,14 +13,14 @@ import (
 )
 
 const (
-	apiKeysPathPattern      = appPathPattern + "/api_keys"
-	pendingUsersPathPattern = appPathPattern + "/user_registrations/pending_users"
+	pendingUsersPathPattern = userRegistrationsPathPattern + "/pending_users"
 	usersPathPattern        = appPathPattern + "/users"
 	userPathPattern         = usersPathPattern + "/%s"
 	userDisablePathPattern  = userPathPattern + "/disable"
 	userEnablePathPattern   = userPathPattern + "/enable"
 	userLogoutPathPattern   = userPathPattern + "/logout"
 
+	usersQueryAfter         = "after"
 	usersQueryStatus        = "status"
 	usersQueryProviderTypes = "provider_types"
 )
@@ -71,14 +71,6 @@ func isValidUserState(us UserState) bool {
 	return false
 }
 
-// APIKey is a Realm app api key
-type APIKey struct {
-	ID       string `json:"_id"`
-	Name     string `json:"name"`
-	Disabled bool   `json:"disabled"`
-	Key      string `json:"key"`
-}
-
 // User is a Realm app user
 type User struct {
 	ID                     string                 `json:"_id"`
@@ -88,6 +80,13 @@ type User struct {
 	Data                   map[string]interface{} `json:"data,omitempty"`
 	CreationDate           int64                  `json:"creation_date"`
 	LastAuthenticationDate int64                  `json:"last_authentication_date"`
+	LoginIDs               []UserLoginID          `json:"login_ids,omitempty"`
+}
+
+// UserLoginID is a Realm app pending user login id
+type UserLoginID struct {
+	IDType string `json:"id_type"`
+	ID     string `json:"id"`
 }
 
 // UserIdentity is a Realm app user identity
@@ -179,32 +178,6 @@ func (apts AuthProviderTypes) join(sep string) string {
 
 }
 
-type createAPIKeyRequest struct {
-	Name string `json:"name"`
-}
-
-func (c *client) CreateAPIKey(groupID, appID, apiKeyName string) (APIKey, error) {
-	res, resErr := c.doJSON(
-		http.MethodPost,
-		fmt.Sprintf(apiKeysPathPattern, groupID, appID),
-		createAPIKeyRequest{apiKeyName},
-		api.RequestOptions{},
-	)
-	if resErr != nil {
-		return APIKey{}, resErr
-	}
-	if res.StatusCode != http.StatusCreated {
-		return APIKey{}, api.ErrUnexpectedStatusCode{"create api key", res.StatusCode}
-	}
-	defer res.Body.Close()
-
-	var apiKey APIKey
-	if err := json.NewDecoder(res.Body).Decode(&apiKey); err != nil {
-		return APIKey{}, err
-	}
-	return apiKey, nil
-}
-
 type createUserRequest struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
@@ -286,13 +259,53 @@ type UserFilter struct {
 }
 
 func (c *client) FindUsers(groupID, appID string, filter UserFilter) ([]User, error) {
-	if filter.Pending {
-		return c.getPendingUsers(groupID, appID, filter.IDs)
+	var users []User
+	if err := c.UsersPages(groupID, appID, filter, func(page []User) error {
+		users = append(users, page...)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// UsersPages fetches the users matching the filter one page at a time, following the
+// pagination cursor until every user is fetched or the page handler returns an error
+func (c *client) UsersPages(groupID, appID string, filter UserFilter, handlePage func(page []User) error) error {
+	if filter.Pending || len(filter.IDs) > 0 {
+		var users []User
+		var err error
+		if filter.Pending {
+			users, err = c.getPendingUsers(groupID, appID, filter.IDs)
+		} else {
+			users, err = c.getUsersByIDs(groupID, appID, filter.IDs, filter.State, filter.Providers)
+		}
+		if err != nil {
+			return err
+		}
+		return handlePage(users)
 	}
-	if len(filter.IDs) == 0 {
-		return c.getUsers(groupID, appID, filter.State, filter.Providers)
+
+	var after string
+	for {
+		users, err := c.getUsers(groupID, appID, filter.State, filter.Providers, after)
+		if err != nil {
+			return err
+		}
+		if len(users) == 0 {
+			return nil
+		}
+
+		if err := handlePage(users); err != nil {
+			return err
+		}
+
+		next := users[len(users)-1].ID
+		if next == after {
+			return nil // guard against a cursor which does not advance
+		}
+		after = next
 	}
-	return c.getUsersByIDs(groupID, appID, filter.IDs, filter.State, filter.Providers)
 }
 
 func (c *client) RevokeUserSessions(groupID, appID, userID string) error {
@@ -369,8 +382,11 @@ func (c *client) getUser(groupID, appID, userID string) (User, error) {
 	return user, nil
 }
 
-func (c *client) getUsers(groupID, appID string, userState UserState, authProviderTypes AuthProviderTypes) ([]User, error) {
+func (c *client) getUsers(groupID, appID string, userState UserState, authProviderTypes AuthProviderTypes, after string) ([]User, error) {
 	options := api.RequestOptions{Query: make(map[string]string)}
+	if after != "" {
+		options.Query[usersQueryAfter] = after
+	}
 	if userState != UserStateNil {
 		options.Query[usersQueryStatus] = string(userState)
 	}
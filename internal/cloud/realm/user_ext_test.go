@@ -1,7 +1,10 @@
 package realm_test
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/10gen/realm-cli/internal/cli/user"
@@ -9,6 +12,7 @@ import (
 	"github.com/10gen/realm-cli/internal/local"
 	u "github.com/10gen/realm-cli/internal/utils/test"
 	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -200,3 +204,65 @@ func TestRealmUsers(t *testing.T) {
 		})
 	})
 }
+
+func TestRealmUsersPages(t *testing.T) {
+	pages := map[string]string{
+		"":       `[{"_id":"user-1"},{"_id":"user-2"}]`,
+		"user-2": `[{"_id":"user-3"}]`,
+		"user-3": `[]`,
+	}
+
+	var queries []string
+	var statuses []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		queries = append(queries, query.Get("after"))
+		statuses = append(statuses, query.Get("status"))
+
+		w.Write([]byte(pages[query.Get("after")]))
+	}))
+	defer server.Close()
+
+	profile := mock.NewProfile(t)
+	profile.SetSession(user.Session{AccessToken: "accessToken"})
+
+	client := realm.NewAuthClient(server.URL, profile)
+
+	ids := func(users []realm.User) []string {
+		out := make([]string, 0, len(users))
+		for _, user := range users {
+			out = append(out, user.ID)
+		}
+		return out
+	}
+
+	t.Run("should follow the pagination cursor until every user is fetched", func(t *testing.T) {
+		queries, statuses = nil, nil
+
+		var fetched [][]string
+		assert.Nil(t, client.UsersPages("groupID", "appID", realm.UserFilter{State: realm.UserStateEnabled}, func(page []realm.User) error {
+			fetched = append(fetched, ids(page))
+			return nil
+		}))
+
+		assert.Equal(t, [][]string{{"user-1", "user-2"}, {"user-3"}}, fetched)
+		assert.Equal(t, []string{"", "user-2", "user-3"}, queries)
+		assert.Equal(t, []string{"enabled", "enabled", "enabled"}, statuses)
+	})
+
+	t.Run("should stop fetching users once the page handler returns an error", func(t *testing.T) {
+		queries = nil
+
+		err := client.UsersPages("groupID", "appID", realm.UserFilter{}, func(page []realm.User) error {
+			return errors.New("something bad happened")
+		})
+		assert.Equal(t, errors.New("something bad happened"), err)
+		assert.Equal(t, []string{""}, queries)
+	})
+
+	t.Run("should find every user", func(t *testing.T) {
+		users, err := client.FindUsers("groupID", "appID", realm.UserFilter{})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"user-1", "user-2", "user-3"}, ids(users))
+	})
+}
@@ -0,0 +1,110 @@
+package realm
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/10gen/realm-cli/internal/utils/api"
+)
+
+const (
+	userRegistrationsPathPattern      = appPathPattern + "/user_registrations"
+	pendingUserPathPattern            = userRegistrationsPathPattern + "/by_email/%s"
+	pendingUserConfirmPathPattern     = pendingUserPathPattern + "/confirm"
+	pendingUserSendConfirmPathPattern = pendingUserPathPattern + "/send_confirm"
+	localUserPassPathPattern          = clientAPI + "/app/%s/auth/providers/local-userpass"
+	localUserPassResetSendPathPattern = localUserPassPathPattern + "/reset/send"
+	localUserPassResetCallPathPattern = localUserPassPathPattern + "/reset/call"
+)
+
+func (c *client) ConfirmPendingUser(groupID, appID, email string) error {
+	res, resErr := c.do(
+		http.MethodPost,
+		fmt.Sprintf(pendingUserConfirmPathPattern, groupID, appID, url.PathEscape(email)),
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return resErr
+	}
+	if res.StatusCode != http.StatusNoContent {
+		return api.ErrUnexpectedStatusCode{Action: "confirm pending user", Actual: res.StatusCode}
+	}
+	return nil
+}
+
+func (c *client) DeletePendingUser(groupID, appID, email string) error {
+	res, resErr := c.do(
+		http.MethodDelete,
+		fmt.Sprintf(pendingUserPathPattern, groupID, appID, url.PathEscape(email)),
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return resErr
+	}
+	if res.StatusCode != http.StatusNoContent {
+		return api.ErrUnexpectedStatusCode{Action: "delete pending user", Actual: res.StatusCode}
+	}
+	return nil
+}
+
+func (c *client) ResendPendingUserConfirmation(groupID, appID, email string) error {
+	res, resErr := c.do(
+		http.MethodPost,
+		fmt.Sprintf(pendingUserSendConfirmPathPattern, groupID, appID, url.PathEscape(email)),
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return resErr
+	}
+	if res.StatusCode != http.StatusNoContent {
+		return api.ErrUnexpectedStatusCode{Action: "resend pending user confirmation", Actual: res.StatusCode}
+	}
+	return nil
+}
+
+type sendResetPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+// SendResetPasswordEmail requests a password reset email through the client API of the app,
+// the same as its users do, so the provider must be configured to send reset emails
+func (c *client) SendResetPasswordEmail(clientAppID, email string) error {
+	res, resErr := c.doJSON(
+		http.MethodPost,
+		fmt.Sprintf(localUserPassResetSendPathPattern, clientAppID),
+		sendResetPasswordRequest{email},
+		api.RequestOptions{NoAuth: true},
+	)
+	if resErr != nil {
+		return resErr
+	}
+	res.Body.Close()
+	return nil
+}
+
+type callResetPasswordFunctionRequest struct {
+	Email     string        `json:"email"`
+	Password  string        `json:"password"`
+	Arguments []interface{} `json:"arguments"`
+}
+
+// CallResetPasswordFunction calls the password reset function through the client API of the app,
+// the same as its users do, so the provider must be configured to run a reset function
+func (c *client) CallResetPasswordFunction(clientAppID, email, password string, args []interface{}) error {
+	if args == nil {
+		args = []interface{}{}
+	}
+
+	res, resErr := c.doJSON(
+		http.MethodPost,
+		fmt.Sprintf(localUserPassResetCallPathPattern, clientAppID),
+		callResetPasswordFunctionRequest{email, password, args},
+		api.RequestOptions{NoAuth: true},
+	)
+	if resErr != nil {
+		return resErr
+	}
+	res.Body.Close()
+	return nil
+}
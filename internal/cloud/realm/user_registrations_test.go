@@ -0,0 +1,68 @@
+package realm_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestRealmUserRegistrations(t *testing.T) {
+	type request struct {
+		Method        string
+		Path          string
+		Body          string
+		Authorization string
+	}
+
+	var requests []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		assert.Nil(t, err)
+
+		requests = append(requests, request{r.Method, r.URL.EscapedPath(), string(body), r.Header.Get("Authorization")})
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	profile := mock.NewProfile(t)
+	profile.SetSession(user.Session{AccessToken: "accessToken"})
+
+	client := realm.NewAuthClient(server.URL, profile)
+
+	const pendingUserPath = "/api/admin/v3.0/groups/groupID/apps/appID/user_registrations/by_email/user+1@domain.com"
+
+	t.Run("should confirm, resend the confirmation of and delete a pending user", func(t *testing.T) {
+		requests = nil
+
+		assert.Nil(t, client.ConfirmPendingUser("groupID", "appID", "user+1@domain.com"))
+		assert.Nil(t, client.ResendPendingUserConfirmation("groupID", "appID", "user+1@domain.com"))
+		assert.Nil(t, client.DeletePendingUser("groupID", "appID", "user+1@domain.com"))
+
+		assert.Equal(t, []request{
+			{http.MethodPost, pendingUserPath + "/confirm", "", "Bearer accessToken"},
+			{http.MethodPost, pendingUserPath + "/send_confirm", "", "Bearer accessToken"},
+			{http.MethodDelete, pendingUserPath, "", "Bearer accessToken"},
+		}, requests)
+	})
+
+	t.Run("should send a password reset email and call the password reset function", func(t *testing.T) {
+		requests = nil
+
+		assert.Nil(t, client.SendResetPasswordEmail("eggcorn-abcde", "user@domain.com"))
+		assert.Nil(t, client.CallResetPasswordFunction("eggcorn-abcde", "user@domain.com", "password", []interface{}{"token", 1}))
+		assert.Nil(t, client.CallResetPasswordFunction("eggcorn-abcde", "user@domain.com", "password", nil))
+
+		const resetPath = "/api/client/v2.0/app/eggcorn-abcde/auth/providers/local-userpass/reset"
+		assert.Equal(t, []request{
+			{http.MethodPost, resetPath + "/send", `{"email":"user@domain.com"}`, ""},
+			{http.MethodPost, resetPath + "/call", `{"email":"user@domain.com","password":"password","arguments":["token",1]}`, ""},
+			{http.MethodPost, resetPath + "/call", `{"email":"user@domain.com","password":"password","arguments":[]}`, ""},
+		}, requests)
+	})
+}
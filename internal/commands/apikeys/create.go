@@ -0,0 +1,151 @@
+package apikeys
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/terminal"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// outStdout is the "--out" value which writes the key to stdout
+const outStdout = "-"
+
+// CommandMetaCreate is the command meta for the `apikeys create` command
+var CommandMetaCreate = cli.CommandMeta{
+	Use:         "create",
+	Display:     "apikeys create",
+	Description: "Create an API Key for your Realm app",
+	HelpText: `Creates an API Key with the name specified by "--name". The generated key is
+only available once, when the API Key is created.
+
+Use "--out" to write the key to a file rather than displaying it, so the key is not
+left in your terminal history. Use "--out -" to write only the key to stdout, so it
+can be piped into another command.`,
+}
+
+// CommandCreate is the `apikeys create` command
+type CommandCreate struct {
+	inputs createInputs
+}
+
+type createInputs struct {
+	cli.ProjectInputs
+	Name string
+	Out  string
+}
+
+type createOutputs struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	Key     string `json:"key,omitempty"`
+}
+
+// Flags is the command flags
+func (cmd *CommandCreate) Flags() []flags.Flag {
+	return []flags.Flag{
+		cli.AppFlagWithContext(&cmd.inputs.App, "to create its api keys"),
+		cli.ProjectFlag(&cmd.inputs.Project),
+		cli.ProductFlag(&cmd.inputs.Products),
+		flags.StringFlag{
+			Value: &cmd.inputs.Name,
+			Meta: flags.Meta{
+				Name:      "name",
+				Shorthand: "n",
+				Usage: flags.Usage{
+					Description: "Name the api key",
+				},
+			},
+		},
+		flags.StringFlag{
+			Value: &cmd.inputs.Out,
+			Meta: flags.Meta{
+				Name: "out",
+				Usage: flags.Usage{
+					Description:   "Specify the filepath to write the generated key to",
+					AllowedFormat: `<path> or "-" for stdout`,
+				},
+			},
+		},
+	}
+}
+
+// Inputs is the command inputs
+func (cmd *CommandCreate) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandCreate) Handler(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	apiKey, err := clients.Realm.CreateAPIKey(app.GroupID, app.ID, cmd.inputs.Name)
+	if err != nil {
+		return fmt.Errorf("failed to create api key: %s", err)
+	}
+
+	outputs := createOutputs{ID: apiKey.ID, Name: apiKey.Name, Enabled: !apiKey.Disabled}
+
+	switch cmd.inputs.Out {
+	case "":
+		outputs.Key = apiKey.Key
+		ui.Print(terminal.NewJSONLog("Successfully created api key", outputs))
+	case outStdout:
+		// the key is written as is, so it is not wrapped by the output format when piped
+		if _, err := fmt.Fprintln(ui.Out(), apiKey.Key); err != nil {
+			return fmt.Errorf("created api key %s but failed to write its key to stdout: %s", apiKey.ID, err)
+		}
+	default:
+		if err := writeKey(cmd.inputs.Out, apiKey.Key); err != nil {
+			return fmt.Errorf("created api key %s but failed to write its key to %s: %s", apiKey.ID, cmd.inputs.Out, err)
+		}
+		ui.Print(
+			terminal.NewJSONLog("Successfully created api key", outputs),
+			terminal.NewTextLog("The key was written to %s", cmd.inputs.Out),
+		)
+	}
+	return nil
+}
+
+func (i *createInputs) Resolve(profile *user.Profile, ui terminal.UI) error {
+	if err := i.ProjectInputs.Resolve(ui, profile.WorkingDirectory, false); err != nil {
+		return err
+	}
+
+	if i.Name == "" {
+		if err := ui.AskOne(&i.Name, &survey.Input{Message: "API Key Name"}); err != nil {
+			return err
+		}
+	}
+
+	if i.Out != "" && i.Out != outStdout && !filepath.IsAbs(i.Out) {
+		i.Out = filepath.Join(profile.WorkingDirectory, i.Out)
+	}
+	return nil
+}
+
+// writeKey writes the key to a file only readable by the current user
+func writeKey(path, key string) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// an existing file keeps its permissions when it is opened
+	if err := file.Chmod(0600); err != nil {
+		return err
+	}
+
+	_, err = file.WriteString(key + "\n")
+	return err
+}
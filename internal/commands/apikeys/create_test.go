@@ -0,0 +1,165 @@
+package apikeys
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestAPIKeysCreateInputsResolve(t *testing.T) {
+	for _, tc := range []struct {
+		description string
+		out         string
+		expectedOut string
+	}{
+		{"should leave an empty out as is", "", ""},
+		{"should leave stdout as is", outStdout, outStdout},
+		{"should resolve a relative out filepath against the working directory", "key.txt", filepath.Join("/working/dir", "key.txt")},
+		{"should leave an absolute out filepath as is", "/path/to/key.txt", "/path/to/key.txt"},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			profile := mock.NewProfile(t)
+			profile.WorkingDirectory = "/working/dir"
+
+			inputs := createInputs{
+				ProjectInputs: cli.ProjectInputs{Project: "project", App: "app"},
+				Name:          "server",
+				Out:           tc.out,
+			}
+			assert.Nil(t, inputs.Resolve(profile, nil))
+			assert.Equal(t, tc.expectedOut, inputs.Out)
+		})
+	}
+}
+
+func TestAPIKeysCreateHandler(t *testing.T) {
+	app := realm.App{ID: "app-id", GroupID: "group-id", Name: "eggcorn"}
+
+	newRealmClient := func() mock.RealmClient {
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{app}, nil
+		}
+		realmClient.CreateAPIKeyFn = func(groupID, appID, apiKeyName string) (realm.APIKey, error) {
+			assert.Equal(t, "group-id", groupID)
+			assert.Equal(t, "app-id", appID)
+			return realm.APIKey{ID: "key-1", Name: apiKeyName, Key: "secret-key"}, nil
+		}
+		return realmClient
+	}
+
+	t.Run("should display the created api key along with its key", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		cmd := &CommandCreate{createInputs{Name: "server"}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: newRealmClient()}))
+
+		assert.Equal(t, `Successfully created api key
+{
+  "id": "key-1",
+  "name": "server",
+  "enabled": true,
+  "key": "secret-key"
+}
+`, out.String())
+	})
+
+	t.Run("should write only the key to stdout", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		cmd := &CommandCreate{createInputs{Name: "server", Out: outStdout}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: newRealmClient()}))
+
+		assert.Equal(t, "secret-key\n", out.String())
+	})
+
+	t.Run("should write only the raw key to stdout with the json output format", func(t *testing.T) {
+		out := new(bytes.Buffer)
+		ui := mock.NewUIWithOptions(mock.UIOptions{UseJSON: true}, out)
+
+		cmd := &CommandCreate{createInputs{Name: "server", Out: outStdout}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: newRealmClient()}))
+
+		assert.Equal(t, "secret-key\n", out.String())
+	})
+
+	t.Run("should write the key to a file without displaying it", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer teardown()
+
+		path := filepath.Join(tmpDir, "key.txt")
+
+		out, ui := mock.NewUI()
+
+		cmd := &CommandCreate{createInputs{Name: "server", Out: path}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: newRealmClient()}))
+
+		assert.Equal(t, `Successfully created api key
+{
+  "id": "key-1",
+  "name": "server",
+  "enabled": true
+}
+The key was written to `+path+"\n", out.String())
+
+		data, err := ioutil.ReadFile(path)
+		assert.Nil(t, err)
+		assert.Equal(t, "secret-key\n", string(data))
+
+		if runtime.GOOS != "windows" {
+			info, err := os.Stat(path)
+			assert.Nil(t, err)
+			assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+		}
+	})
+
+	t.Run("should restrict the permissions of an existing file the key is written to", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("file permissions are not supported on windows")
+		}
+
+		tmpDir, teardown, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer teardown()
+
+		path := filepath.Join(tmpDir, "key.txt")
+		assert.Nil(t, ioutil.WriteFile(path, []byte("old-key\n"), 0644))
+		assert.Nil(t, os.Chmod(path, 0644))
+
+		_, ui := mock.NewUI()
+
+		cmd := &CommandCreate{createInputs{Name: "server", Out: path}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: newRealmClient()}))
+
+		data, err := ioutil.ReadFile(path)
+		assert.Nil(t, err)
+		assert.Equal(t, "secret-key\n", string(data))
+
+		info, err := os.Stat(path)
+		assert.Nil(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	})
+
+	t.Run("should return an error when the api key fails to create", func(t *testing.T) {
+		_, ui := mock.NewUI()
+
+		realmClient := newRealmClient()
+		realmClient.CreateAPIKeyFn = func(groupID, appID, apiKeyName string) (realm.APIKey, error) {
+			return realm.APIKey{}, errors.New("something bad happened")
+		}
+
+		cmd := &CommandCreate{createInputs{Name: "server"}}
+		assert.Equal(t, errors.New("failed to create api key: something bad happened"), cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+	})
+}
@@ -0,0 +1,96 @@
+package apikeys
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/terminal"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+)
+
+// CommandMetaDelete is the command meta for the `apikeys delete` command
+var CommandMetaDelete = cli.CommandMeta{
+	Use:         "delete",
+	Display:     "apikeys delete",
+	Description: "Delete API Keys from your Realm app",
+	HelpText: `Removes the API Keys specified by their ID or Name with "--api-key" flags. You
+will be prompted to select the API Keys to delete if none are specified.`,
+}
+
+// CommandDelete is the `apikeys delete` command
+type CommandDelete struct {
+	inputs deleteInputs
+}
+
+type deleteInputs struct {
+	cli.ProjectInputs
+	multiAPIKeyInputs
+}
+
+// Flags is the command flags
+func (cmd *CommandDelete) Flags() []flags.Flag {
+	return []flags.Flag{
+		cli.AppFlagWithContext(&cmd.inputs.App, "to delete its api keys"),
+		cli.ProjectFlag(&cmd.inputs.Project),
+		cli.ProductFlag(&cmd.inputs.Products),
+		apiKeysFlag(&cmd.inputs.APIKeys, "Specify the name or ID of the api key(s) to delete"),
+	}
+}
+
+// Inputs is the command inputs
+func (cmd *CommandDelete) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandDelete) Handler(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	apiKeys, err := clients.Realm.APIKeys(app.GroupID, app.ID)
+	if err != nil {
+		return err
+	}
+
+	selected, err := cmd.inputs.resolveAPIKeys(ui, apiKeys, "delete")
+	if err != nil {
+		return err
+	}
+
+	if len(selected) == 0 {
+		ui.Print(terminal.NewTextLog("No api keys to delete"))
+		return nil
+	}
+
+	outputs := make(apiKeyOutputs, 0, len(selected))
+	for _, apiKey := range selected {
+		err := clients.Realm.DeleteAPIKey(app.GroupID, app.ID, apiKey.ID)
+		outputs = append(outputs, apiKeyOutput{apiKey, err})
+	}
+
+	sort.SliceStable(outputs, getAPIKeyOutputComparerBySuccess(outputs))
+
+	ui.Print(terminal.NewTableLog(
+		fmt.Sprintf("Deleted %d api key(s)", len(outputs)),
+		tableHeaders(headerDeleted, headerDetails),
+		tableRows(outputs, tableRowDelete)...,
+	))
+	return nil
+}
+
+func tableRowDelete(output apiKeyOutput, row map[string]interface{}) {
+	var details string
+	if output.err != nil {
+		details = output.err.Error()
+	}
+	row[headerDeleted] = output.err == nil
+	row[headerDetails] = details
+}
+
+func (i *deleteInputs) Resolve(profile *user.Profile, ui terminal.UI) error {
+	return i.ProjectInputs.Resolve(ui, profile.WorkingDirectory, false)
+}
@@ -0,0 +1,79 @@
+package apikeys
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+var testAPIKeys = []realm.APIKey{
+	{ID: "key-1", Name: "server"},
+	{ID: "key-2", Name: "backup", Disabled: true},
+	{ID: "key-3", Name: "client"},
+}
+
+func newTestRealmClient() mock.RealmClient {
+	realmClient := mock.RealmClient{}
+	realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+		return []realm.App{{ID: "app-id", GroupID: "group-id", Name: "eggcorn"}}, nil
+	}
+	realmClient.APIKeysFn = func(groupID, appID string) ([]realm.APIKey, error) {
+		return testAPIKeys, nil
+	}
+	return realmClient
+}
+
+func TestAPIKeysDeleteHandler(t *testing.T) {
+	t.Run("should delete the api keys and display the results", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		var deletedIDs []string
+		realmClient := newTestRealmClient()
+		realmClient.DeleteAPIKeyFn = func(groupID, appID, apiKeyID string) error {
+			deletedIDs = append(deletedIDs, apiKeyID)
+			if apiKeyID == "key-3" {
+				return errors.New("something bad happened")
+			}
+			return nil
+		}
+
+		cmd := &CommandDelete{deleteInputs{multiAPIKeyInputs: multiAPIKeyInputs{[]string{"key-1", "client"}}}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, []string{"key-1", "key-3"}, deletedIDs)
+		assert.Equal(t, strings.Join([]string{
+			"Deleted 2 api key(s)",
+			"  ID     Name    Deleted  Details               ",
+			"  -----  ------  -------  ----------------------",
+			"  key-3  client  false    something bad happened",
+			"  key-1  server  true                           ",
+			"",
+		}, "\n"), out.String())
+	})
+
+	t.Run("should display a message when there are no api keys to delete", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		realmClient := newTestRealmClient()
+		realmClient.APIKeysFn = func(groupID, appID string) ([]realm.APIKey, error) {
+			return nil, nil
+		}
+
+		cmd := &CommandDelete{}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, "No api keys to delete\n", out.String())
+	})
+
+	t.Run("should return an error when an api key cannot be found", func(t *testing.T) {
+		_, ui := mock.NewUI()
+
+		cmd := &CommandDelete{deleteInputs{multiAPIKeyInputs: multiAPIKeyInputs{[]string{"key-4"}}}}
+		assert.Equal(t, errors.New("unable to find api key: key-4"), cmd.Handler(nil, ui, cli.Clients{Realm: newTestRealmClient()}))
+	})
+}
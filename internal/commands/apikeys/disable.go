@@ -0,0 +1,97 @@
+package apikeys
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/terminal"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+)
+
+// CommandMetaDisable is the command meta for the `apikeys disable` command
+var CommandMetaDisable = cli.CommandMeta{
+	Use:         "disable",
+	Display:     "apikeys disable",
+	Description: "Disable API Keys of your Realm app",
+	HelpText: `Deactivates the API Keys specified by their ID or Name with "--api-key" flags. An
+API Key that has been disabled will not be allowed to log in. You will be prompted
+to select the API Keys to disable if none are specified.`,
+}
+
+// CommandDisable is the `apikeys disable` command
+type CommandDisable struct {
+	inputs disableInputs
+}
+
+type disableInputs struct {
+	cli.ProjectInputs
+	multiAPIKeyInputs
+}
+
+// Flags is the command flags
+func (cmd *CommandDisable) Flags() []flags.Flag {
+	return []flags.Flag{
+		cli.AppFlagWithContext(&cmd.inputs.App, "to disable its api keys"),
+		cli.ProjectFlag(&cmd.inputs.Project),
+		cli.ProductFlag(&cmd.inputs.Products),
+		apiKeysFlag(&cmd.inputs.APIKeys, "Specify the name or ID of the api key(s) to disable"),
+	}
+}
+
+// Inputs is the command inputs
+func (cmd *CommandDisable) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandDisable) Handler(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	apiKeys, err := clients.Realm.APIKeys(app.GroupID, app.ID)
+	if err != nil {
+		return err
+	}
+
+	selected, err := cmd.inputs.resolveAPIKeys(ui, apiKeys, "disable")
+	if err != nil {
+		return err
+	}
+
+	if len(selected) == 0 {
+		ui.Print(terminal.NewTextLog("No api keys to disable"))
+		return nil
+	}
+
+	outputs := make(apiKeyOutputs, 0, len(selected))
+	for _, apiKey := range selected {
+		err := clients.Realm.DisableAPIKey(app.GroupID, app.ID, apiKey.ID)
+		outputs = append(outputs, apiKeyOutput{apiKey, err})
+	}
+
+	sort.SliceStable(outputs, getAPIKeyOutputComparerBySuccess(outputs))
+
+	ui.Print(terminal.NewTableLog(
+		fmt.Sprintf("Disabled %d api key(s)", len(outputs)),
+		tableHeaders(headerEnabled, headerDetails),
+		tableRows(outputs, tableRowDisable)...,
+	))
+	return nil
+}
+
+func tableRowDisable(output apiKeyOutput, row map[string]interface{}) {
+	var details string
+	if output.err != nil {
+		details = output.err.Error()
+	}
+	row[headerEnabled] = output.err != nil && !output.apiKey.Disabled
+	row[headerDetails] = details
+}
+
+func (i *disableInputs) Resolve(profile *user.Profile, ui terminal.UI) error {
+	return i.ProjectInputs.Resolve(ui, profile.WorkingDirectory, false)
+}
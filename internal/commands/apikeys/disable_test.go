@@ -0,0 +1,38 @@
+package apikeys
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestAPIKeysDisableHandler(t *testing.T) {
+	out, ui := mock.NewUI()
+
+	var disabledIDs []string
+	realmClient := newTestRealmClient()
+	realmClient.DisableAPIKeyFn = func(groupID, appID, apiKeyID string) error {
+		disabledIDs = append(disabledIDs, apiKeyID)
+		if apiKeyID == "key-3" {
+			return errors.New("something bad happened")
+		}
+		return nil
+	}
+
+	cmd := &CommandDisable{disableInputs{multiAPIKeyInputs: multiAPIKeyInputs{[]string{"key-1", "key-3"}}}}
+	assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+	assert.Equal(t, []string{"key-1", "key-3"}, disabledIDs)
+	assert.Equal(t, strings.Join([]string{
+		"Disabled 2 api key(s)",
+		"  ID     Name    Enabled  Details               ",
+		"  -----  ------  -------  ----------------------",
+		"  key-3  client  true     something bad happened",
+		"  key-1  server  false                          ",
+		"",
+	}, "\n"), out.String())
+}
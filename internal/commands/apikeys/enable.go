@@ -0,0 +1,97 @@
+package apikeys
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/terminal"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+)
+
+// CommandMetaEnable is the command meta for the `apikeys enable` command
+var CommandMetaEnable = cli.CommandMeta{
+	Use:         "enable",
+	Display:     "apikeys enable",
+	Description: "Enable API Keys of your Realm app",
+	HelpText: `Activates the API Keys specified by their ID or Name with "--api-key" flags, so
+they can be used to log in again. You will be prompted to select the API Keys to
+enable if none are specified.`,
+}
+
+// CommandEnable is the `apikeys enable` command
+type CommandEnable struct {
+	inputs enableInputs
+}
+
+type enableInputs struct {
+	cli.ProjectInputs
+	multiAPIKeyInputs
+}
+
+// Flags is the command flags
+func (cmd *CommandEnable) Flags() []flags.Flag {
+	return []flags.Flag{
+		cli.AppFlagWithContext(&cmd.inputs.App, "to enable its api keys"),
+		cli.ProjectFlag(&cmd.inputs.Project),
+		cli.ProductFlag(&cmd.inputs.Products),
+		apiKeysFlag(&cmd.inputs.APIKeys, "Specify the name or ID of the api key(s) to enable"),
+	}
+}
+
+// Inputs is the command inputs
+func (cmd *CommandEnable) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandEnable) Handler(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	apiKeys, err := clients.Realm.APIKeys(app.GroupID, app.ID)
+	if err != nil {
+		return err
+	}
+
+	selected, err := cmd.inputs.resolveAPIKeys(ui, apiKeys, "enable")
+	if err != nil {
+		return err
+	}
+
+	if len(selected) == 0 {
+		ui.Print(terminal.NewTextLog("No api keys to enable"))
+		return nil
+	}
+
+	outputs := make(apiKeyOutputs, 0, len(selected))
+	for _, apiKey := range selected {
+		err := clients.Realm.EnableAPIKey(app.GroupID, app.ID, apiKey.ID)
+		outputs = append(outputs, apiKeyOutput{apiKey, err})
+	}
+
+	sort.SliceStable(outputs, getAPIKeyOutputComparerBySuccess(outputs))
+
+	ui.Print(terminal.NewTableLog(
+		fmt.Sprintf("Enabled %d api key(s)", len(outputs)),
+		tableHeaders(headerEnabled, headerDetails),
+		tableRows(outputs, tableRowEnable)...,
+	))
+	return nil
+}
+
+func tableRowEnable(output apiKeyOutput, row map[string]interface{}) {
+	var details string
+	if output.err != nil {
+		details = output.err.Error()
+	}
+	row[headerEnabled] = output.err == nil || !output.apiKey.Disabled
+	row[headerDetails] = details
+}
+
+func (i *enableInputs) Resolve(profile *user.Profile, ui terminal.UI) error {
+	return i.ProjectInputs.Resolve(ui, profile.WorkingDirectory, false)
+}
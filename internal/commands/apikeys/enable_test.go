@@ -0,0 +1,38 @@
+package apikeys
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestAPIKeysEnableHandler(t *testing.T) {
+	out, ui := mock.NewUI()
+
+	var enabledIDs []string
+	realmClient := newTestRealmClient()
+	realmClient.EnableAPIKeyFn = func(groupID, appID, apiKeyID string) error {
+		enabledIDs = append(enabledIDs, apiKeyID)
+		if apiKeyID == "key-2" {
+			return errors.New("something bad happened")
+		}
+		return nil
+	}
+
+	cmd := &CommandEnable{enableInputs{multiAPIKeyInputs: multiAPIKeyInputs{[]string{"server", "key-2"}}}}
+	assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+	assert.Equal(t, []string{"key-1", "key-2"}, enabledIDs)
+	assert.Equal(t, strings.Join([]string{
+		"Enabled 2 api key(s)",
+		"  ID     Name    Enabled  Details               ",
+		"  -----  ------  -------  ----------------------",
+		"  key-2  backup  false    something bad happened",
+		"  key-1  server  true                           ",
+		"",
+	}, "\n"), out.String())
+}
@@ -0,0 +1,88 @@
+package apikeys
+
+import (
+	"fmt"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/terminal"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+const (
+	flagAPIKey      = "api-key"
+	flagAPIKeyShort = "k"
+)
+
+func apiKeysFlag(value *[]string, description string) flags.StringSliceFlag {
+	return flags.StringSliceFlag{
+		Value: value,
+		Meta: flags.Meta{
+			Name:      flagAPIKey,
+			Shorthand: flagAPIKeyShort,
+			Usage: flags.Usage{
+				Description: description,
+			},
+		},
+	}
+}
+
+type multiAPIKeyInputs struct {
+	APIKeys []string
+}
+
+// resolveAPIKeys finds the api keys specified by either their ID or name,
+// otherwise the api keys are selected from the available api keys
+func (i multiAPIKeyInputs) resolveAPIKeys(ui terminal.UI, apiKeys []realm.APIKey, action string) ([]realm.APIKey, error) {
+	if len(i.APIKeys) > 0 {
+		apiKeysByID := make(map[string]realm.APIKey, len(apiKeys))
+		apiKeysByName := make(map[string]realm.APIKey, len(apiKeys))
+		for _, apiKey := range apiKeys {
+			apiKeysByID[apiKey.ID] = apiKey
+			apiKeysByName[apiKey.Name] = apiKey
+		}
+
+		resolved := make([]realm.APIKey, 0, len(i.APIKeys))
+		for _, identifier := range i.APIKeys {
+			if apiKey, ok := apiKeysByID[identifier]; ok {
+				resolved = append(resolved, apiKey)
+			} else if apiKey, ok := apiKeysByName[identifier]; ok {
+				resolved = append(resolved, apiKey)
+			} else {
+				return nil, fmt.Errorf("unable to find api key: %s", identifier)
+			}
+		}
+		return resolved, nil
+	}
+
+	if len(apiKeys) == 0 {
+		return nil, nil
+	}
+
+	options := make([]string, 0, len(apiKeys))
+	apiKeysByOption := make(map[string]realm.APIKey, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		option := displayAPIKeyOption(apiKey)
+
+		options = append(options, option)
+		apiKeysByOption[option] = apiKey
+	}
+
+	var selections []string
+	if err := ui.AskOne(
+		&selections,
+		&survey.MultiSelect{
+			Message: fmt.Sprintf("Which API Key(s) would you like to %s?", action),
+			Options: options,
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	resolved := make([]realm.APIKey, 0, len(selections))
+	for _, selection := range selections {
+		resolved = append(resolved, apiKeysByOption[selection])
+	}
+	return resolved, nil
+}
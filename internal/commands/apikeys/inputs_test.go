@@ -0,0 +1,43 @@
+package apikeys
+
+import (
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestResolveAPIKeys(t *testing.T) {
+	t.Run("should return the api keys specified by either their id or name", func(t *testing.T) {
+		inputs := multiAPIKeyInputs{[]string{"client", "key-1"}}
+
+		apiKeys, err := inputs.resolveAPIKeys(nil, testAPIKeys, "delete")
+		assert.Nil(t, err)
+		assert.Equal(t, []realm.APIKey{testAPIKeys[2], testAPIKeys[0]}, apiKeys)
+	})
+
+	t.Run("should prompt for the api keys when none are specified", func(t *testing.T) {
+		_, console, _, ui, consoleErr := mock.NewVT10XConsole()
+		assert.Nil(t, consoleErr)
+		defer console.Close()
+
+		doneCh := make(chan struct{})
+		go func() {
+			defer close(doneCh)
+			console.ExpectString("Which API Key(s) would you like to disable?")
+			console.Send("key-3")
+			console.Send(" ")
+			console.SendLine("")
+			console.ExpectEOF()
+		}()
+
+		apiKeys, err := multiAPIKeyInputs{}.resolveAPIKeys(ui, testAPIKeys, "disable")
+
+		console.Tty().Close()
+		<-doneCh
+
+		assert.Nil(t, err)
+		assert.Equal(t, []realm.APIKey{testAPIKeys[2]}, apiKeys)
+	})
+}
@@ -0,0 +1,82 @@
+package apikeys
+
+import (
+	"fmt"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/terminal"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+)
+
+// CommandMetaList is the command meta for the `apikeys list` command
+var CommandMetaList = cli.CommandMeta{
+	Use:         "list",
+	Aliases:     []string{"ls"},
+	Display:     "apikeys list",
+	Description: "List the API Keys of your Realm app",
+	HelpText: `This will display the IDs and Names of the API Keys in your Realm app, along
+with whether or not they are enabled. The keys themselves are only available when
+an API Key is created.`,
+}
+
+// CommandList is the `apikeys list` command
+type CommandList struct {
+	inputs listInputs
+}
+
+type listInputs struct {
+	cli.ProjectInputs
+}
+
+// Flags is the command flags
+func (cmd *CommandList) Flags() []flags.Flag {
+	return []flags.Flag{
+		cli.AppFlagWithContext(&cmd.inputs.App, "to list its api keys"),
+		cli.ProjectFlag(&cmd.inputs.Project),
+		cli.ProductFlag(&cmd.inputs.Products),
+	}
+}
+
+// Inputs is the command inputs
+func (cmd *CommandList) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandList) Handler(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	apiKeys, err := clients.Realm.APIKeys(app.GroupID, app.ID)
+	if err != nil {
+		return err
+	}
+
+	if len(apiKeys) == 0 {
+		ui.Print(terminal.NewTextLog("No available api keys to show"))
+		return nil
+	}
+
+	outputs := make(apiKeyOutputs, 0, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		outputs = append(outputs, apiKeyOutput{apiKey, nil})
+	}
+
+	ui.Print(terminal.NewTableLog(
+		fmt.Sprintf("Found %d api keys", len(apiKeys)),
+		tableHeaders(headerEnabled),
+		tableRows(outputs, tableRowList)...,
+	))
+	return nil
+}
+
+func tableRowList(output apiKeyOutput, row map[string]interface{}) {
+	row[headerEnabled] = !output.apiKey.Disabled
+}
+
+func (i *listInputs) Resolve(profile *user.Profile, ui terminal.UI) error {
+	return i.ProjectInputs.Resolve(ui, profile.WorkingDirectory, false)
+}
@@ -0,0 +1,80 @@
+package apikeys
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestAPIKeysListHandler(t *testing.T) {
+	app := realm.App{ID: "app-id", GroupID: "group-id", Name: "eggcorn"}
+
+	t.Run("should display the api keys", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		var capturedGroupID, capturedAppID string
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{app}, nil
+		}
+		realmClient.APIKeysFn = func(groupID, appID string) ([]realm.APIKey, error) {
+			capturedGroupID = groupID
+			capturedAppID = appID
+			return []realm.APIKey{
+				{ID: "key-1", Name: "server"},
+				{ID: "key-2", Name: "backup", Disabled: true},
+			}, nil
+		}
+
+		cmd := &CommandList{}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, strings.Join([]string{
+			"Found 2 api keys",
+			"  ID     Name    Enabled",
+			"  -----  ------  -------",
+			"  key-1  server  true   ",
+			"  key-2  backup  false  ",
+			"",
+		}, "\n"), out.String())
+		assert.Equal(t, "group-id", capturedGroupID)
+		assert.Equal(t, "app-id", capturedAppID)
+	})
+
+	t.Run("should display an empty state message when there are no api keys", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{app}, nil
+		}
+		realmClient.APIKeysFn = func(groupID, appID string) ([]realm.APIKey, error) {
+			return nil, nil
+		}
+
+		cmd := &CommandList{}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, "No available api keys to show\n", out.String())
+	})
+
+	t.Run("should return an error when the api keys cannot be found", func(t *testing.T) {
+		_, ui := mock.NewUI()
+
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{app}, nil
+		}
+		realmClient.APIKeysFn = func(groupID, appID string) ([]realm.APIKey, error) {
+			return nil, errors.New("something bad happened")
+		}
+
+		cmd := &CommandList{}
+		assert.Equal(t, errors.New("something bad happened"), cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+	})
+}
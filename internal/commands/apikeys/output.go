@@ -0,0 +1,54 @@
+package apikeys
+
+import (
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/terminal"
+)
+
+const (
+	headerID      = "ID"
+	headerName    = "Name"
+	headerEnabled = "Enabled"
+	headerDeleted = "Deleted"
+	headerDetails = "Details"
+)
+
+type apiKeyOutputs []apiKeyOutput
+
+type apiKeyOutput struct {
+	apiKey realm.APIKey
+	err    error
+}
+
+func getAPIKeyOutputComparerBySuccess(outputs apiKeyOutputs) func(i, j int) bool {
+	return func(i, j int) bool {
+		return outputs[i].err != nil && outputs[j].err == nil
+	}
+}
+
+type tableRowModifier func(apiKeyOutput, map[string]interface{})
+
+func tableHeaders(additionalHeaders ...string) []string {
+	return append([]string{headerID, headerName}, additionalHeaders...)
+}
+
+func tableRows(outputs apiKeyOutputs, modifier tableRowModifier) []map[string]interface{} {
+	rows := make([]map[string]interface{}, 0, len(outputs))
+	for _, output := range outputs {
+		rows = append(rows, tableRow(output, modifier))
+	}
+	return rows
+}
+
+func tableRow(output apiKeyOutput, modifier tableRowModifier) map[string]interface{} {
+	row := map[string]interface{}{
+		headerID:   output.apiKey.ID,
+		headerName: output.apiKey.Name,
+	}
+	modifier(output, row)
+	return row
+}
+
+func displayAPIKeyOption(apiKey realm.APIKey) string {
+	return apiKey.ID + terminal.DelimiterInline + apiKey.Name
+}
@@ -0,0 +1,202 @@
+package app
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/local"
+	"github.com/10gen/realm-cli/internal/terminal"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// CommandMetaAnalyze is the command meta for the `app analyze` command
+var CommandMetaAnalyze = cli.CommandMeta{
+	Use:         "analyze",
+	Display:     "app analyze",
+	Description: "Find the unused components and circular function calls in your local Realm app",
+	HelpText: `Builds a dependency graph of your local Realm app from its function sources,
+triggers, endpoints, incoming webhooks, custom resolvers, auth providers, rules
+and log forwarders. Reports the functions, values and services which nothing
+references, along with any functions which call each other in a cycle.
+
+Public functions are assumed to be called by clients unless "include-public" is
+set. Secrets are only stored in your remote Realm app, so they are reported when
+"include-secrets" is set. The dependency graph can be saved as a Graphviz DOT
+file with "dot".`,
+}
+
+// CommandAnalyze is the `app analyze` command
+type CommandAnalyze struct {
+	inputs analyzeInputs
+}
+
+type analyzeInputs struct {
+	LocalPath      string
+	RemoteApp      string
+	Project        string
+	IncludePublic  bool
+	IncludeSecrets bool
+	DOTPath        string
+}
+
+// Flags is the command flags
+func (cmd *CommandAnalyze) Flags() []flags.Flag {
+	return []flags.Flag{
+		flags.StringFlag{
+			Value: &cmd.inputs.LocalPath,
+			Meta: flags.Meta{
+				Name: "local",
+				Usage: flags.Usage{
+					Description: "Specify the local filepath of a Realm app to analyze",
+				},
+			},
+		},
+		flags.StringFlag{
+			Value: &cmd.inputs.RemoteApp,
+			Meta: flags.Meta{
+				Name: flagRemoteApp,
+				Usage: flags.Usage{
+					Description: "Specify the name or ID of a remote Realm app to read secrets from",
+				},
+			},
+		},
+		flags.BoolFlag{
+			Value: &cmd.inputs.IncludePublic,
+			Meta: flags.Meta{
+				Name: "include-public",
+				Usage: flags.Usage{
+					Description: "Report public functions which nothing in the Realm app calls as unused",
+				},
+			},
+		},
+		flags.BoolFlag{
+			Value: &cmd.inputs.IncludeSecrets,
+			Meta: flags.Meta{
+				Name: "include-secrets",
+				Usage: flags.Usage{
+					Description: "Report the secrets of the remote Realm app which nothing references as unused",
+				},
+			},
+		},
+		flags.StringFlag{
+			Value: &cmd.inputs.DOTPath,
+			Meta: flags.Meta{
+				Name: "dot",
+				Usage: flags.Usage{
+					Description: "Save the dependency graph as a Graphviz DOT file at the specified filepath",
+				},
+			},
+		},
+		cli.ProjectFlag(&cmd.inputs.Project),
+	}
+}
+
+// Inputs is the command inputs
+func (cmd *CommandAnalyze) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandAnalyze) Handler(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := cli.LoadApp(ui, profile, cmd.inputs.LocalPath, false)
+	if err != nil {
+		return err
+	}
+
+	opts := local.AnalyzeOptions{IncludePublic: cmd.inputs.IncludePublic}
+
+	if cmd.inputs.IncludeSecrets {
+		remoteApp, err := cli.ResolveApp(ui, clients.Realm, realm.AppFilter{GroupID: cmd.inputs.Project, App: cmd.inputs.RemoteApp})
+		if err != nil {
+			return err
+		}
+
+		secrets, err := clients.Realm.Secrets(remoteApp.GroupID, remoteApp.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, secret := range secrets {
+			opts.Secrets = append(opts.Secrets, secret.Name)
+		}
+	}
+
+	analysis := local.Analyze(app, opts)
+
+	if cmd.inputs.DOTPath != "" {
+		if err := ioutil.WriteFile(cmd.inputs.DOTPath, []byte(analysis.DOT()), 0666); err != nil {
+			return err
+		}
+		ui.Print(terminal.NewTextLog("Saved dependency graph to %s", cmd.inputs.DOTPath))
+	}
+
+	logs := []terminal.Log{
+		newAnalysisListLog("Unused functions", analysis.UnusedFunctions),
+		newAnalysisListLog("Unused values", analysis.UnusedValues),
+	}
+	if cmd.inputs.IncludeSecrets {
+		logs = append(logs, newAnalysisListLog("Unused secrets", analysis.UnusedSecrets))
+	}
+	logs = append(logs, newAnalysisListLog("Unused services", analysis.UnusedServices))
+
+	calls := make([]string, 0, len(analysis.CircularCalls))
+	for _, cycle := range analysis.CircularCalls {
+		calls = append(calls, strings.Join(cycle, " -> "))
+	}
+	logs = append(logs, newAnalysisListLog("Circular function calls", calls))
+
+	ui.Print(logs...)
+	return nil
+}
+
+func newAnalysisListLog(message string, items []string) terminal.Log {
+	data := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		data = append(data, item)
+	}
+	return terminal.NewListLog(fmt.Sprintf("%s (%d)", message, len(items)), data...)
+}
+
+func (i *analyzeInputs) Resolve(profile *user.Profile, ui terminal.UI) error {
+	searchPath := i.LocalPath
+	if searchPath == "" {
+		searchPath = profile.WorkingDirectory
+	}
+
+	app, _, err := local.FindApp(searchPath)
+	if err != nil {
+		return err
+	}
+
+	if i.LocalPath == "" && app.RootDir == "" {
+		if err := ui.AskOne(&i.LocalPath, &survey.Input{Message: "App filepath (local)"}); err != nil {
+			return err
+		}
+
+		app, _, err = local.FindApp(i.LocalPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	if app.RootDir != "" {
+		i.LocalPath = app.RootDir
+	}
+
+	if i.IncludeSecrets && i.RemoteApp == "" {
+		i.RemoteApp = app.Option()
+	}
+
+	if i.DOTPath != "" && !filepath.IsAbs(i.DOTPath) {
+		i.DOTPath = filepath.Join(profile.WorkingDirectory, i.DOTPath)
+	}
+
+	return nil
+}
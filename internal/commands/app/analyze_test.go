@@ -0,0 +1,129 @@
+package app
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/local"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestAppAnalyzeHandler(t *testing.T) {
+	setup := func(t *testing.T) (string, func()) {
+		t.Helper()
+
+		profile, teardown := mock.NewProfileFromTmpDir(t, "app_analyze_test")
+
+		for path, contents := range map[string]string{
+			local.FileRealmConfig.String():                                `{"config_version":20210101,"app_id":"eggcorn-abcde","name":"eggcorn"}`,
+			filepath.Join(local.NameFunctions, local.FileConfig.String()): `[{"name":"ping","private":true},{"name":"pong","private":true},{"name":"unused","private":true}]`,
+			filepath.Join(local.NameFunctions, "ping.js"):                 `exports = () => context.functions.execute("pong");`,
+			filepath.Join(local.NameFunctions, "pong.js"):                 `exports = () => context.functions.execute("ping", context.values.get("key"));`,
+			filepath.Join(local.NameFunctions, "unused.js"):               `exports = () => {};`,
+			filepath.Join(local.NameValues, "key.json"):                   `{"name":"key","value":"keySecret","from_secret":true}`,
+			filepath.Join(local.NameValues, "unused.json"):                `{"name":"unused","value":"unused","from_secret":false}`,
+			filepath.Join(local.NameTriggers, "t1.json"):                  `{"name":"t1","type":"SCHEDULED","function_name":"ping","config":{"schedule":"0 0 * * 1"}}`,
+		} {
+			assert.Nil(t, os.MkdirAll(filepath.Dir(filepath.Join(profile.WorkingDirectory, path)), os.ModePerm))
+			assert.Nil(t, ioutil.WriteFile(filepath.Join(profile.WorkingDirectory, path), []byte(contents), 0666))
+		}
+
+		return profile.WorkingDirectory, teardown
+	}
+
+	t.Run("should report the unused components and circular function calls", func(t *testing.T) {
+		appDir, teardown := setup(t)
+		defer teardown()
+
+		out, ui := mock.NewUI()
+
+		cmd := &CommandAnalyze{analyzeInputs{LocalPath: appDir}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{}))
+
+		assert.Equal(t, `Unused functions (1)
+  unused
+Unused values (1)
+  unused
+Unused services (0)
+Circular function calls (1)
+  ping -> pong -> ping
+`, out.String())
+	})
+
+	t.Run("should report the unused secrets of the remote app", func(t *testing.T) {
+		appDir, teardown := setup(t)
+		defer teardown()
+
+		out, ui := mock.NewUI()
+
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{ID: "appID", GroupID: "groupID", ClientAppID: "eggcorn-abcde"}}, nil
+		}
+		realmClient.SecretsFn = func(groupID, appID string) ([]realm.Secret, error) {
+			return []realm.Secret{{Name: "keySecret"}, {Name: "oldSecret"}}, nil
+		}
+
+		cmd := &CommandAnalyze{analyzeInputs{LocalPath: appDir, RemoteApp: "eggcorn-abcde", IncludeSecrets: true}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		assert.True(t, strings.Contains(out.String(), "Unused secrets (1)\n  oldSecret\n"), "expected unused secrets to be reported:\n%s", out.String())
+	})
+
+	t.Run("should return an error when the secrets cannot be read", func(t *testing.T) {
+		appDir, teardown := setup(t)
+		defer teardown()
+
+		_, ui := mock.NewUI()
+
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{ID: "appID", GroupID: "groupID"}}, nil
+		}
+		realmClient.SecretsFn = func(groupID, appID string) ([]realm.Secret, error) {
+			return nil, errors.New("something bad happened")
+		}
+
+		cmd := &CommandAnalyze{analyzeInputs{LocalPath: appDir, IncludeSecrets: true}}
+		assert.Equal(t, errors.New("something bad happened"), cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+	})
+
+	t.Run("should save the dependency graph as a dot file", func(t *testing.T) {
+		appDir, teardown := setup(t)
+		defer teardown()
+
+		out, ui := mock.NewUI()
+
+		dotPath := filepath.Join(appDir, "graph.dot")
+
+		cmd := &CommandAnalyze{analyzeInputs{LocalPath: appDir, DOTPath: dotPath}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{}))
+
+		assert.True(t, strings.HasPrefix(out.String(), "Saved dependency graph to "+dotPath+"\n"), "expected graph to be saved")
+
+		dot, err := ioutil.ReadFile(dotPath)
+		assert.Nil(t, err)
+		assert.True(t, strings.Contains(string(dot), `  "trigger:t1" -> "function:ping";`), "expected dot to contain the trigger")
+	})
+}
+
+func TestAppAnalyzeInputs(t *testing.T) {
+	t.Run("should resolve the app from the working directory", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+		profile.WorkingDirectory = filepath.Join(profile.WorkingDirectory, "testdata/diff/hosting")
+
+		i := analyzeInputs{IncludeSecrets: true, DOTPath: "graph.dot"}
+		assert.Nil(t, i.Resolve(profile, nil))
+
+		assert.Equal(t, filepath.Join(profile.WorkingDirectory, ".."), i.LocalPath)
+		assert.Equal(t, "eggcorn-abcde", i.RemoteApp)
+		assert.Equal(t, filepath.Join(profile.WorkingDirectory, "graph.dot"), i.DOTPath)
+	})
+}
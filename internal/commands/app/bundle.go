@@ -0,0 +1,148 @@
+package app
+
+import (
+	"path/filepath"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/local"
+	"github.com/10gen/realm-cli/internal/terminal"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// CommandMetaBundle is the command meta for the `app bundle` command
+var CommandMetaBundle = cli.CommandMeta{
+	Use:         "bundle",
+	Display:     "app bundle",
+	Description: "Save your local Realm app as a single bundle file",
+	HelpText: `Loads the Realm app found in your local directory and writes its full definition,
+including function sources, rules, schemas and hosting metadata, to a single JSON
+file. Hosting files can optionally be saved alongside the bundle as a .zip archive.
+Bundles can be restored with "app unbundle" or deployed with "push --bundle".`,
+}
+
+// CommandBundle is the `app bundle` command
+type CommandBundle struct {
+	inputs bundleInputs
+}
+
+type bundleInputs struct {
+	LocalPath      string
+	Out            string
+	IncludeHosting bool
+}
+
+// Flags is the command flags
+func (cmd *CommandBundle) Flags() []flags.Flag {
+	return []flags.Flag{
+		flags.StringFlag{
+			Value: &cmd.inputs.LocalPath,
+			Meta: flags.Meta{
+				Name: "local",
+				Usage: flags.Usage{
+					Description: "Specify the local filepath of a Realm app to bundle",
+				},
+			},
+		},
+		flags.StringFlag{
+			Value: &cmd.inputs.Out,
+			Meta: flags.Meta{
+				Name: "out",
+				Usage: flags.Usage{
+					Description: "Specify the filepath to write the bundle to",
+				},
+			},
+		},
+		flags.BoolFlag{
+			Value: &cmd.inputs.IncludeHosting,
+			Meta: flags.Meta{
+				Name:      "include-hosting",
+				Shorthand: "s",
+				Usage: flags.Usage{
+					Description: "Include Realm app hosting files as a .zip archive alongside the bundle",
+				},
+			},
+		},
+	}
+}
+
+// Inputs is the command inputs
+func (cmd *CommandBundle) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandBundle) Handler(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := local.LoadApp(cmd.inputs.LocalPath)
+	if err != nil {
+		return err
+	}
+
+	var hostingArchivePath string
+	if cmd.inputs.IncludeHosting {
+		hostingArchivePath = local.HostingArchivePath(cmd.inputs.Out)
+	}
+
+	bundle, err := local.NewBundle(app, hostingArchivePath)
+	if err != nil {
+		return err
+	}
+
+	if err := bundle.Write(cmd.inputs.Out); err != nil {
+		return err
+	}
+
+	logs := []terminal.Log{terminal.NewTextLog("Saved app bundle to: %s", cmd.inputs.Out)}
+	if hostingArchivePath != "" {
+		logs = append(logs, terminal.NewDebugLog("Saved hosting files to: %s", hostingArchivePath))
+	}
+
+	ui.Print(logs...)
+	return nil
+}
+
+func (i *bundleInputs) Resolve(profile *user.Profile, ui terminal.UI) error {
+	searchPath := i.LocalPath
+	if searchPath == "" {
+		searchPath = profile.WorkingDirectory
+	}
+
+	app, _, err := local.FindApp(searchPath)
+	if err != nil {
+		return err
+	}
+
+	if i.LocalPath == "" && app.RootDir == "" {
+		if err := ui.AskOne(&i.LocalPath, &survey.Input{Message: "App filepath (local)"}); err != nil {
+			return err
+		}
+
+		app, _, err = local.FindApp(i.LocalPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	if app.RootDir != "" {
+		i.LocalPath = app.RootDir
+	}
+
+	if i.Out == "" {
+		var defaultOut string
+		if app.AppData != nil {
+			defaultOut = app.Name() + ".json"
+		}
+
+		if err := ui.AskOne(&i.Out, &survey.Input{Message: "Bundle filepath", Default: defaultOut}); err != nil {
+			return err
+		}
+	}
+
+	if !filepath.IsAbs(i.Out) {
+		i.Out = filepath.Join(profile.WorkingDirectory, i.Out)
+	}
+
+	return nil
+}
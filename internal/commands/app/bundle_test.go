@@ -0,0 +1,155 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/local"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+
+	"github.com/Netflix/go-expect"
+)
+
+func TestAppBundleHandler(t *testing.T) {
+	wd, err := os.Getwd()
+	assert.Nil(t, err)
+
+	t.Run("should write the local app as a bundle", func(t *testing.T) {
+		profile, teardown := mock.NewProfileFromTmpDir(t, "app_bundle_test")
+		defer teardown()
+
+		out, ui := mock.NewUI()
+
+		bundlePath := filepath.Join(profile.WorkingDirectory, "eggcorn.json")
+
+		cmd := &CommandBundle{bundleInputs{
+			LocalPath: filepath.Join(wd, "testdata/diff"),
+			Out:       bundlePath,
+		}}
+
+		assert.Nil(t, cmd.Handler(profile, ui, cli.Clients{}))
+		assert.Equal(t, "Saved app bundle to: "+bundlePath+"\n", out.String())
+
+		bundle, err := local.LoadBundle(bundlePath)
+		assert.Nil(t, err)
+		assert.Equal(t, "eggcorn-abcde", bundle.AppData.ID())
+		assert.True(t, bundle.Hosting != nil, "expected bundle to have hosting metadata")
+		assert.Equal(t, "", bundle.Hosting.Archive)
+
+		_, err = os.Stat(local.HostingArchivePath(bundlePath))
+		assert.True(t, os.IsNotExist(err), "expected hosting archive to not exist")
+	})
+
+	t.Run("should write the hosting files alongside the bundle when include hosting is set", func(t *testing.T) {
+		profile, teardown := mock.NewProfileFromTmpDir(t, "app_bundle_test")
+		defer teardown()
+
+		out, ui := mock.NewUI()
+
+		bundlePath := filepath.Join(profile.WorkingDirectory, "eggcorn.json")
+		hostingArchivePath := filepath.Join(profile.WorkingDirectory, "eggcorn.hosting.zip")
+
+		cmd := &CommandBundle{bundleInputs{
+			LocalPath:      filepath.Join(wd, "testdata/diff"),
+			Out:            bundlePath,
+			IncludeHosting: true,
+		}}
+
+		assert.Nil(t, cmd.Handler(profile, ui, cli.Clients{}))
+		assert.Equal(t, `Saved app bundle to: `+bundlePath+`
+Saved hosting files to: `+hostingArchivePath+`
+`, out.String())
+
+		bundle, err := local.LoadBundle(bundlePath)
+		assert.Nil(t, err)
+		assert.Equal(t, "eggcorn.hosting.zip", bundle.Hosting.Archive)
+
+		_, err = os.Stat(hostingArchivePath)
+		assert.Nil(t, err)
+	})
+
+	t.Run("should return an error if local path does not resolve to an app directory", func(t *testing.T) {
+		_, ui := mock.NewUI()
+
+		cmd := &CommandBundle{bundleInputs{LocalPath: "./some/path"}}
+		assert.Equal(t, "failed to find app at ./some/path", cmd.Handler(nil, ui, cli.Clients{}).Error())
+	})
+}
+
+func TestAppBundleInputs(t *testing.T) {
+	for _, tc := range []struct {
+		description    string
+		inputs         bundleInputs
+		prepareProfile func(p *user.Profile)
+		procedure      func(c *expect.Console)
+		test           func(t *testing.T, i bundleInputs, p *user.Profile)
+	}{
+		{
+			description: "should resolve empty inputs when inside an app directory by prompting for the bundle filepath",
+			prepareProfile: func(p *user.Profile) {
+				p.WorkingDirectory = filepath.Join(p.WorkingDirectory, "testdata/diff/hosting")
+			},
+			procedure: func(c *expect.Console) {
+				c.ExpectString("Bundle filepath")
+				c.SendLine("")
+			},
+			test: func(t *testing.T, i bundleInputs, p *user.Profile) {
+				assert.Equal(t, filepath.Join(p.WorkingDirectory, ".."), i.LocalPath)
+				assert.Equal(t, filepath.Join(p.WorkingDirectory, "eggcorn.json"), i.Out)
+			},
+		},
+		{
+			description: "should resolve empty inputs when outside an app directory by prompting for the local path",
+			procedure: func(c *expect.Console) {
+				c.ExpectString("App filepath (local)")
+				c.SendLine("./testdata/diff")
+				c.ExpectString("Bundle filepath")
+				c.SendLine("app.json")
+			},
+			test: func(t *testing.T, i bundleInputs, p *user.Profile) {
+				assert.Equal(t, filepath.Join(p.WorkingDirectory, "testdata/diff"), i.LocalPath)
+				assert.Equal(t, filepath.Join(p.WorkingDirectory, "app.json"), i.Out)
+			},
+		},
+		{
+			description: "should not prompt for inputs that are provided",
+			inputs: bundleInputs{
+				LocalPath: "./testdata/diff",
+				Out:       "/path/to/app.json",
+			},
+			procedure: func(c *expect.Console) {},
+			test: func(t *testing.T, i bundleInputs, p *user.Profile) {
+				assert.Equal(t, filepath.Join(p.WorkingDirectory, "testdata/diff"), i.LocalPath)
+				assert.Equal(t, "/path/to/app.json", i.Out)
+			},
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			profile := mock.NewProfileFromWd(t)
+			if tc.prepareProfile != nil {
+				tc.prepareProfile(profile)
+			}
+
+			_, console, _, ui, consoleErr := mock.NewVT10XConsole()
+			assert.Nil(t, consoleErr)
+			defer console.Close()
+
+			doneCh := make(chan (struct{}))
+			go func() {
+				defer close(doneCh)
+				tc.procedure(console)
+			}()
+
+			assert.Nil(t, tc.inputs.Resolve(profile, ui))
+
+			console.Tty().Close() // flush the writers
+			<-doneCh              // wait for procedure to complete
+
+			tc.test(t, tc.inputs, profile)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -15,6 +16,7 @@ import (
 )
 
 const (
+	flagAllLocal            = "all-local"
 	flagIncludeNodeModules  = "include-node-modules"
 	flagIncludePackageJSON  = "include-package-json"
 	flagIncludeDependencies = "include-dependencies"
@@ -48,6 +50,10 @@ type diffInputs struct {
 	IncludeNodeModules  bool
 	IncludePackageJSON  bool
 	IncludeHosting      bool
+	ProfileLoad         bool
+	AllLocal            bool
+
+	localApps []local.App
 }
 
 // Flags is the command flags
@@ -113,6 +119,17 @@ func (cmd *CommandDiff) Flags() []flags.Flag {
 				},
 			},
 		},
+		flags.BoolFlag{
+			Value: &cmd.inputs.AllLocal,
+			Meta: flags.Meta{
+				Name: flagAllLocal,
+				Usage: flags.Usage{
+					Description: "Diff every Realm app found within the local filepath",
+					Note:        "Each app is diffed against the remote Realm app matching its ID or name",
+				},
+			},
+		},
+		cli.ProfileLoadFlag(&cmd.inputs.ProfileLoad),
 		cli.ProjectFlag(&cmd.inputs.Project),
 	}
 }
@@ -124,36 +141,89 @@ func (cmd *CommandDiff) Inputs() cli.InputResolver {
 
 // Handler is the command handler
 func (cmd *CommandDiff) Handler(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
-	app, err := local.LoadApp(cmd.inputs.LocalPath)
+	if cmd.inputs.AllLocal {
+		return cmd.diffAll(profile, ui, clients)
+	}
+
+	diffs, err := cmd.diffs(profile, ui, clients)
 	if err != nil {
 		return err
 	}
 
+	printDiffs(ui, diffs)
+	return nil
+}
+
+func (cmd *CommandDiff) diffAll(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
+	rows := make([]map[string]interface{}, 0, len(cmd.inputs.localApps))
+
+	var failed int
+	for _, app := range cmd.inputs.localApps {
+		path := relativePath(profile.WorkingDirectory, app.RootDir)
+		ui.Print(terminal.NewTextLog("Diffing app: %s", path))
+
+		appCmd := CommandDiff{cmd.inputs}
+		appCmd.inputs.AllLocal = false
+		appCmd.inputs.LocalPath = app.RootDir
+		appCmd.inputs.RemoteApp = app.Option()
+
+		row := map[string]interface{}{headerApp: app.Option(), headerPath: path}
+
+		diffs, err := appCmd.diffs(profile, ui, clients)
+		if err != nil {
+			failed++
+			row[headerDetails] = err.Error()
+			ui.Print(terminal.NewErrorLog(err))
+		} else {
+			row[headerChanges] = len(diffs)
+			printDiffs(ui, diffs)
+		}
+		rows = append(rows, row)
+	}
+
+	ui.Print(terminal.NewTableLog(
+		fmt.Sprintf("Diffed %d of %d local apps", len(rows)-failed, len(rows)),
+		[]string{headerApp, headerPath, headerChanges, headerDetails},
+		rows...,
+	))
+
+	if failed > 0 {
+		return fmt.Errorf("failed to diff %d of %d local apps", failed, len(rows))
+	}
+	return nil
+}
+
+func (cmd *CommandDiff) diffs(profile *user.Profile, ui terminal.UI, clients cli.Clients) ([]string, error) {
+	app, err := cli.LoadApp(ui, profile, cmd.inputs.LocalPath, cmd.inputs.ProfileLoad)
+	if err != nil {
+		return nil, err
+	}
+
 	appToDiff, err := cli.ResolveApp(ui, clients.Realm, realm.AppFilter{GroupID: cmd.inputs.Project, App: cmd.inputs.RemoteApp})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	diffs, err := clients.Realm.Diff(appToDiff.GroupID, appToDiff.ID, app.AppData)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if cmd.inputs.IncludeNodeModules || cmd.inputs.IncludePackageJSON || cmd.inputs.IncludeDependencies {
 		appDependencies, err := cmd.inputs.resolveAppDependencies(app.RootDir)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		uploadPath, cleanup, err := appDependencies.PrepareUpload()
 		if err != nil {
-			return err
+			return nil, err
 		}
 		defer cleanup()
 
 		dependenciesDiff, err := clients.Realm.DiffDependencies(appToDiff.GroupID, appToDiff.ID, uploadPath)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		diffs = append(diffs, dependenciesDiff.Strings()...)
 	}
@@ -161,34 +231,36 @@ func (cmd *CommandDiff) Handler(profile *user.Profile, ui terminal.UI, clients c
 	if cmd.inputs.IncludeHosting {
 		hosting, err := local.FindAppHosting(app.RootDir)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		appAssets, err := clients.Realm.HostingAssets(appToDiff.GroupID, appToDiff.ID)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		hostingDiffs, err := hosting.Diffs(profile.HostingAssetCachePath(), appToDiff.ID, appAssets)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		diffs = append(diffs, hostingDiffs.Strings()...)
 	}
 
+	return diffs, nil
+}
+
+func printDiffs(ui terminal.UI, diffs []string) {
 	if len(diffs) == 0 {
 		// there are no diffs
 		ui.Print(terminal.NewTextLog("Deployed app is identical to proposed version"))
-		return nil
+		return
 	}
 
 	ui.Print(terminal.NewTextLog(
 		"The following reflects the proposed changes to your Realm app\n%s",
 		strings.Join(diffs, "\n"),
 	))
-
-	return nil
 }
 
 func (i *diffInputs) Resolve(profile *user.Profile, ui terminal.UI) error {
@@ -201,6 +273,10 @@ func (i *diffInputs) Resolve(profile *user.Profile, ui terminal.UI) error {
 		}
 	}
 
+	if i.AllLocal {
+		return i.resolveLocalApps(profile)
+	}
+
 	searchPath := i.LocalPath
 	if searchPath == "" {
 		searchPath = profile.WorkingDirectory
@@ -233,6 +309,28 @@ func (i *diffInputs) Resolve(profile *user.Profile, ui terminal.UI) error {
 	return nil
 }
 
+func (i *diffInputs) resolveLocalApps(profile *user.Profile) error {
+	if i.RemoteApp != "" {
+		return fmt.Errorf(errDependencyFlagConflictTemplate, flagAllLocal, flagRemoteApp)
+	}
+
+	searchPath := i.LocalPath
+	if searchPath == "" {
+		searchPath = profile.WorkingDirectory
+	}
+
+	apps, err := local.FindApps(searchPath)
+	if err != nil {
+		return err
+	}
+	if len(apps) == 0 {
+		return errors.New("no Realm apps found in " + searchPath)
+	}
+
+	i.localApps = apps
+	return nil
+}
+
 func (i *diffInputs) resolveAppDependencies(rootDir string) (local.Dependencies, error) {
 	if i.IncludePackageJSON {
 		return local.FindPackageJSON(rootDir)
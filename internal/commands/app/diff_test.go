@@ -266,6 +266,49 @@ Removed hosting files
   - /deleteme.html
 Modified hosting files
   * /404.html
+`, out.String())
+	})
+
+	t.Run("with all local set should diff every local app and summarize the results", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+		profile.WorkingDirectory = filepath.Join(profile.WorkingDirectory, "testdata")
+
+		var i diffInputs
+		i.AllLocal = true
+		assert.Nil(t, i.Resolve(profile, nil))
+
+		out, ui := mock.NewUI()
+
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return apps, nil
+		}
+
+		var diffCalls int
+		realmClient.DiffFn = func(groupID, appID string, appData interface{}) ([]string, error) {
+			diffCalls++
+			if diffCalls == 2 {
+				return nil, errors.New("something bad happened")
+			}
+			return []string{"diff1", "diff2"}, nil
+		}
+
+		cmd := &CommandDiff{i}
+
+		err := cmd.Handler(profile, ui, cli.Clients{Realm: realmClient})
+		assert.Equal(t, errors.New("failed to diff 1 of 2 local apps"), err)
+
+		assert.Equal(t, `Diffing app: dependencies
+The following reflects the proposed changes to your Realm app
+diff1
+diff2
+Diffing app: diff
+something bad happened
+Diffed 1 of 2 local apps
+  App            Path          Changes  Details               
+  -------------  ------------  -------  ----------------------
+  eggcorn-abcde  dependencies  2                              
+  eggcorn-abcde  diff                   something bad happened
 `, out.String())
 	})
 }
@@ -338,6 +381,18 @@ func TestAppDiffInputs(t *testing.T) {
 				assert.Equal(t, "different-app", i.RemoteApp)
 			},
 		},
+		{
+			description:    "should find every app within the local path when all local is set",
+			inputs:         diffInputs{AllLocal: true, LocalPath: "testdata"},
+			prepareProfile: func(p *user.Profile) {},
+			procedure:      func(c *expect.Console) {},
+			test: func(t *testing.T, i diffInputs, p *user.Profile) {
+				assert.Equal(t, 2, len(i.localApps))
+				assert.Equal(t, filepath.Join(p.WorkingDirectory, "testdata/dependencies"), i.localApps[0].RootDir)
+				assert.Equal(t, filepath.Join(p.WorkingDirectory, "testdata/diff"), i.localApps[1].RootDir)
+				assert.Equal(t, "", i.RemoteApp)
+			},
+		},
 	} {
 		t.Run(tc.description, func(t *testing.T) {
 			profile := mock.NewProfile(t)
@@ -362,4 +417,16 @@ func TestAppDiffInputs(t *testing.T) {
 			tc.test(t, tc.inputs, profile)
 		})
 	}
+
+	t.Run("should return an error when all local and remote are both set", func(t *testing.T) {
+		i := diffInputs{AllLocal: true, RemoteApp: "eggcorn"}
+		assert.Equal(t, errors.New(`cannot use both "all-local" and "remote" at the same time`), i.Resolve(mock.NewProfile(t), nil))
+	})
+
+	t.Run("should return an error when all local is set and no apps are found", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		i := diffInputs{AllLocal: true, LocalPath: filepath.Join(profile.WorkingDirectory, "testdata/diff/hosting")}
+		assert.Equal(t, errors.New("no Realm apps found in "+i.LocalPath), i.Resolve(profile, nil))
+	})
 }
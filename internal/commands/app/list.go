@@ -1,10 +1,13 @@
 package app
 
 import (
+	"errors"
 	"fmt"
+	"path/filepath"
 
 	"github.com/10gen/realm-cli/internal/cli"
 	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/local"
 	"github.com/10gen/realm-cli/internal/terminal"
 	"github.com/10gen/realm-cli/internal/utils/flags"
 )
@@ -15,25 +18,63 @@ var CommandMetaList = cli.CommandMeta{
 	Aliases:     []string{"ls"},
 	Display:     "apps list",
 	Description: "List the Realm apps you have access to",
-	HelpText:    `Lists and filters your Realm apps.`,
+	HelpText: `Lists and filters your Realm apps. Use the "--local" flag to instead list the
+Realm apps found within a local directory, such as a repository containing many apps.
+The directory is provided as an argument, for example "app list --local apps/", and
+defaults to the current working directory.`,
 }
 
 // CommandList is the `app list` command
 type CommandList struct {
-	inputs cli.ProjectInputs
+	inputs listInputs
+}
+
+type listInputs struct {
+	cli.ProjectInputs
+	Local     bool
+	LocalPath string
 }
 
 // Flags is the command flags
 func (cmd *CommandList) Flags() []flags.Flag {
 	return []flags.Flag{
+		flags.BoolFlag{
+			Value: &cmd.inputs.Local,
+			Meta: flags.Meta{
+				Name: "local",
+				Usage: flags.Usage{
+					Description: "List the Realm apps found within a local directory",
+					Note:        "Searches the current working directory if no directory argument is provided",
+				},
+			},
+		},
 		cli.AppFlagWithDescription(&cmd.inputs.App, "Filter the list of Realm apps by name"),
 		cli.ProjectFlag(&cmd.inputs.Project),
 		cli.ProductFlag(&cmd.inputs.Products),
 	}
 }
 
+// Args is the command args
+func (cmd *CommandList) Args(args []string) error {
+	if len(args) == 0 {
+		return nil
+	}
+	if !cmd.inputs.Local {
+		return errors.New(`a directory argument is only accepted with the "--local" flag`)
+	}
+	if len(args) > 1 {
+		return fmt.Errorf("accepts at most 1 directory argument, received %d", len(args))
+	}
+	cmd.inputs.LocalPath = args[0]
+	return nil
+}
+
 // Handler is the command handler
 func (cmd *CommandList) Handler(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
+	if cmd.inputs.Local {
+		return cmd.listLocal(profile, ui)
+	}
+
 	apps, err := clients.Realm.FindApps(cmd.inputs.Filter())
 	if err != nil {
 		return err
@@ -51,3 +92,50 @@ func (cmd *CommandList) Handler(profile *user.Profile, ui terminal.UI, clients c
 	ui.Print(terminal.NewListLog(fmt.Sprintf("Found %d apps", len(rows)), rows...))
 	return nil
 }
+
+func (cmd *CommandList) listLocal(profile *user.Profile, ui terminal.UI) error {
+	dir := cmd.inputs.LocalPath
+	if dir == "" {
+		dir = "."
+	}
+	displayDir := dir
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(profile.WorkingDirectory, dir)
+	}
+
+	apps, err := local.FindApps(dir)
+	if err != nil {
+		return err
+	}
+
+	if len(apps) == 0 {
+		ui.Print(terminal.NewTextLog("No local apps found in %s", displayDir))
+		return nil
+	}
+
+	rows := make([]map[string]interface{}, 0, len(apps))
+	for _, app := range apps {
+		rows = append(rows, map[string]interface{}{
+			headerID:            app.ID(),
+			headerName:          app.Name(),
+			headerConfigVersion: app.ConfigVersion(),
+			headerPath:          relativePath(profile.WorkingDirectory, app.RootDir),
+		})
+	}
+
+	ui.Print(terminal.NewTableLog(
+		fmt.Sprintf("Found %d local apps", len(rows)),
+		[]string{headerID, headerName, headerConfigVersion, headerPath},
+		rows...,
+	))
+	return nil
+}
+
+// relativePath returns the path relative to the working directory if possible
+func relativePath(wd, path string) string {
+	rel, err := filepath.Rel(wd, path)
+	if err != nil {
+		return path
+	}
+	return rel
+}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -70,7 +71,7 @@ func TestAppListHandler(t *testing.T) {
 				return apps, nil
 			}
 
-			cmd := &CommandList{tc.inputs}
+			cmd := &CommandList{listInputs{ProjectInputs: tc.inputs}}
 			assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
 
 			assert.Equal(t, tc.expectedAppFilter, appFilter)
@@ -82,3 +83,51 @@ func TestAppListHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestAppListLocalHandler(t *testing.T) {
+	t.Run("should list the local apps found within the directory", func(t *testing.T) {
+		profile := mock.NewProfileFromWd(t)
+
+		out, ui := mock.NewUI()
+
+		cmd := &CommandList{listInputs{Local: true}}
+		assert.Nil(t, cmd.Args([]string{"testdata"}))
+		assert.Nil(t, cmd.Handler(profile, ui, cli.Clients{}))
+
+		assert.Equal(t, `Found 2 local apps
+  ID             Name     Config Version  Path                 
+  -------------  -------  --------------  ---------------------
+  eggcorn-abcde  eggcorn  20210101        testdata/dependencies
+  eggcorn-abcde  eggcorn  20210101        testdata/diff        
+`, out.String())
+	})
+
+	t.Run("should print a message when no local apps are found", func(t *testing.T) {
+		profile, teardown := mock.NewProfileFromTmpDir(t, "app_list_local_test")
+		defer teardown()
+
+		out, ui := mock.NewUI()
+
+		cmd := &CommandList{listInputs{Local: true}}
+		assert.Nil(t, cmd.Handler(profile, ui, cli.Clients{}))
+
+		assert.Equal(t, "No local apps found in .\n", out.String())
+	})
+}
+
+func TestAppListArgs(t *testing.T) {
+	t.Run("should accept no args", func(t *testing.T) {
+		cmd := &CommandList{}
+		assert.Nil(t, cmd.Args(nil))
+	})
+
+	t.Run("should error when a directory is provided without the local flag", func(t *testing.T) {
+		cmd := &CommandList{}
+		assert.Equal(t, errors.New(`a directory argument is only accepted with the "--local" flag`), cmd.Args([]string{"apps/"}))
+	})
+
+	t.Run("should error when more than one directory is provided", func(t *testing.T) {
+		cmd := &CommandList{listInputs{Local: true}}
+		assert.Equal(t, errors.New("accepts at most 1 directory argument, received 2"), cmd.Args([]string{"apps/", "more/"}))
+	})
+}
@@ -12,6 +12,11 @@ const (
 	headerName    = "Name"
 	headerDeleted = "Deleted"
 	headerDetails = "Details"
+
+	headerApp           = "App"
+	headerChanges       = "Changes"
+	headerConfigVersion = "Config Version"
+	headerPath          = "Path"
 )
 
 type newAppOutputs struct {
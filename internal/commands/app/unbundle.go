@@ -0,0 +1,190 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/local"
+	"github.com/10gen/realm-cli/internal/terminal"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// CommandMetaUnbundle is the command meta for the `app unbundle` command
+var CommandMetaUnbundle = cli.CommandMeta{
+	Use:         "unbundle",
+	Display:     "app unbundle",
+	Description: "Restore a Realm app bundle into your local directory",
+	HelpText: `Writes the Realm app saved in a bundle file as a local directory, such as
+"app unbundle app.json --local app". If the bundle was saved with its hosting
+files, they will be restored as well.
+
+The app is written with the bundle's own config version unless "--config-version"
+is provided. A bundle can be converted to config version 20210101 from 20180301 or
+20200603, which drops the hosting config as 20210101 apps do not keep it. A bundle
+with config version 20210101 can only be converted back when its functions are
+each a single source without TypeScript or imports and it has no HTTPS Endpoints.
+Use "pull" to export the app with the desired config version otherwise.`,
+}
+
+// unbundleConfigVersions are the config versions a bundle can be converted to
+var unbundleConfigVersions = []string{
+	realm.AppConfigVersion20180301.String(),
+	realm.AppConfigVersion20200603.String(),
+	realm.AppConfigVersion20210101.String(),
+}
+
+// CommandUnbundle is the `app unbundle` command
+type CommandUnbundle struct {
+	inputs unbundleInputs
+}
+
+type unbundleInputs struct {
+	Bundle     string
+	LocalPath  string
+	AppVersion realm.AppConfigVersion
+}
+
+// Flags is the command flags
+func (cmd *CommandUnbundle) Flags() []flags.Flag {
+	return []flags.Flag{
+		flags.StringFlag{
+			Value: &cmd.inputs.Bundle,
+			Meta: flags.Meta{
+				Name: "bundle",
+				Usage: flags.Usage{
+					Description: "Specify the filepath of the bundle to restore, if not passed as an argument",
+				},
+			},
+		},
+		flags.StringFlag{
+			Value: &cmd.inputs.LocalPath,
+			Meta: flags.Meta{
+				Name: "local",
+				Usage: flags.Usage{
+					Description: "Specify the local filepath to write the Realm app to",
+				},
+			},
+		},
+		flags.CustomFlag{
+			Value: &cmd.inputs.AppVersion,
+			Meta: flags.Meta{
+				Name: "config-version",
+				Usage: flags.Usage{
+					Description:   "Specify the app config version to write the app as",
+					DefaultValue:  "<bundle config version>",
+					AllowedValues: unbundleConfigVersions,
+				},
+			},
+		},
+	}
+}
+
+// Args is the command args
+func (cmd *CommandUnbundle) Args(args []string) error {
+	if len(args) == 0 {
+		return nil
+	}
+	if len(args) > 1 {
+		return fmt.Errorf("accepts at most 1 bundle filepath argument, received %d", len(args))
+	}
+	if cmd.inputs.Bundle != "" && cmd.inputs.Bundle != args[0] {
+		return errors.New(`cannot specify a bundle filepath argument along with a different "--bundle"`)
+	}
+	cmd.inputs.Bundle = args[0]
+	return nil
+}
+
+// Inputs is the command inputs
+func (cmd *CommandUnbundle) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandUnbundle) Handler(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
+	bundle, err := local.LoadBundle(cmd.inputs.Bundle)
+	if err != nil {
+		return err
+	}
+
+	proceed, err := checkUnbundleDestination(ui, cmd.inputs.LocalPath)
+	if err != nil {
+		return err
+	} else if !proceed {
+		return nil
+	}
+
+	if _, err := bundle.Unbundle(cmd.inputs.LocalPath, cmd.inputs.AppVersion); err != nil {
+		return err
+	}
+
+	pathRelative, err := filepath.Rel(profile.WorkingDirectory, cmd.inputs.LocalPath)
+	if err != nil {
+		return err
+	}
+
+	ui.Print(terminal.NewTextLog("Successfully unbundled app: %s", pathRelative))
+	return nil
+}
+
+func (i *unbundleInputs) Resolve(profile *user.Profile, ui terminal.UI) error {
+	if i.AppVersion != realm.AppConfigVersionZero && !isUnbundleConfigVersion(i.AppVersion) {
+		return fmt.Errorf("unsupported config version %s, use one of [%s] instead", i.AppVersion, strings.Join(unbundleConfigVersions, ", "))
+	}
+
+	if i.Bundle == "" {
+		if err := ui.AskOne(&i.Bundle, &survey.Input{Message: "Bundle filepath"}); err != nil {
+			return err
+		}
+	}
+
+	if !filepath.IsAbs(i.Bundle) {
+		i.Bundle = filepath.Join(profile.WorkingDirectory, i.Bundle)
+	}
+
+	if i.LocalPath == "" {
+		name := filepath.Base(i.Bundle)
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+
+		if err := ui.AskOne(&i.LocalPath, &survey.Input{Message: "App filepath (local)", Default: name}); err != nil {
+			return err
+		}
+	}
+
+	if !filepath.IsAbs(i.LocalPath) {
+		i.LocalPath = filepath.Join(profile.WorkingDirectory, i.LocalPath)
+	}
+
+	return nil
+}
+
+func isUnbundleConfigVersion(configVersion realm.AppConfigVersion) bool {
+	for _, v := range unbundleConfigVersions {
+		if v == configVersion.String() {
+			return true
+		}
+	}
+	return false
+}
+
+func checkUnbundleDestination(ui terminal.UI, path string) (bool, error) {
+	if ui.AutoConfirm() {
+		return true, nil
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return true, nil
+		}
+		return false, err
+	}
+
+	return ui.Confirm("Directory '%s' already exists, do you still wish to proceed?", path)
+}
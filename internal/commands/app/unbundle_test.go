@@ -0,0 +1,189 @@
+package app
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/local"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestAppUnbundleHandler(t *testing.T) {
+	wd, err := os.Getwd()
+	assert.Nil(t, err)
+
+	setup := func(t *testing.T) (string, func()) {
+		t.Helper()
+
+		profile, teardown := mock.NewProfileFromTmpDir(t, "app_unbundle_test")
+
+		app, err := local.LoadApp(filepath.Join(wd, "testdata/diff"))
+		assert.Nil(t, err)
+
+		bundlePath := filepath.Join(profile.WorkingDirectory, "eggcorn.json")
+
+		bundle, err := local.NewBundle(app, local.HostingArchivePath(bundlePath))
+		assert.Nil(t, err)
+		assert.Nil(t, bundle.Write(bundlePath))
+
+		return bundlePath, teardown
+	}
+
+	t.Run("should write the bundle as a local app", func(t *testing.T) {
+		bundlePath, teardown := setup(t)
+		defer teardown()
+
+		profile := mock.NewProfile(t)
+		profile.WorkingDirectory = filepath.Dir(bundlePath)
+
+		out, ui := mock.NewUI()
+
+		cmd := &CommandUnbundle{unbundleInputs{
+			Bundle:    bundlePath,
+			LocalPath: filepath.Join(profile.WorkingDirectory, "eggcorn"),
+		}}
+
+		assert.Nil(t, cmd.Handler(profile, ui, cli.Clients{}))
+		assert.Equal(t, "Successfully unbundled app: eggcorn\n", out.String())
+
+		app, err := local.LoadApp(filepath.Join(profile.WorkingDirectory, "eggcorn"))
+		assert.Nil(t, err)
+		assert.Equal(t, local.FileRealmConfig, app.Config)
+		assert.Equal(t, "eggcorn-abcde", app.ID())
+
+		index, err := ioutil.ReadFile(filepath.Join(profile.WorkingDirectory, "eggcorn", local.NameHosting, local.NameFiles, "index.html"))
+		assert.Nil(t, err)
+
+		expectedIndex, err := ioutil.ReadFile(filepath.Join(wd, "testdata/diff", local.NameHosting, local.NameFiles, "index.html"))
+		assert.Nil(t, err)
+		assert.Equal(t, string(expectedIndex), string(index))
+	})
+
+	t.Run("should unbundle the bundle with the config version", func(t *testing.T) {
+		bundlePath, teardown := setup(t)
+		defer teardown()
+
+		profile := mock.NewProfile(t)
+		profile.WorkingDirectory = filepath.Dir(bundlePath)
+
+		out, ui := mock.NewUI()
+
+		cmd := &CommandUnbundle{unbundleInputs{
+			Bundle:     bundlePath,
+			LocalPath:  filepath.Join(profile.WorkingDirectory, "eggcorn"),
+			AppVersion: realm.AppConfigVersion20180301,
+		}}
+
+		assert.Nil(t, cmd.Handler(profile, ui, cli.Clients{}))
+		assert.Equal(t, "Successfully unbundled app: eggcorn\n", out.String())
+
+		app, err := local.LoadApp(filepath.Join(profile.WorkingDirectory, "eggcorn"))
+		assert.Nil(t, err)
+		assert.Equal(t, local.FileStitch, app.Config)
+		assert.Equal(t, realm.AppConfigVersion20180301, app.ConfigVersion())
+	})
+
+	t.Run("should return an error when the config version cannot be converted to", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		i := unbundleInputs{Bundle: "app.json", LocalPath: "app", AppVersion: realm.AppConfigVersion(20190101)}
+		assert.Equal(t, errors.New("unsupported config version 20190101, use one of [20180301, 20200603, 20210101] instead"), i.Resolve(profile, nil))
+	})
+
+	t.Run("should not write the bundle if the user does not wish to overwrite the existing directory", func(t *testing.T) {
+		bundlePath, teardown := setup(t)
+		defer teardown()
+
+		profile := mock.NewProfile(t)
+		profile.WorkingDirectory = filepath.Dir(bundlePath)
+
+		_, console, _, ui, consoleErr := mock.NewVT10XConsole()
+		assert.Nil(t, consoleErr)
+		defer console.Close()
+
+		doneCh := make(chan (struct{}))
+		go func() {
+			defer close(doneCh)
+			console.ExpectString("Directory '" + profile.WorkingDirectory + "' already exists, do you still wish to proceed?")
+			console.SendLine("n")
+			console.ExpectEOF()
+		}()
+
+		cmd := &CommandUnbundle{unbundleInputs{
+			Bundle:    bundlePath,
+			LocalPath: profile.WorkingDirectory,
+		}}
+
+		assert.Nil(t, cmd.Handler(profile, ui, cli.Clients{}))
+
+		console.Tty().Close() // flush the writers
+		<-doneCh              // wait for procedure to complete
+
+		_, err := os.Stat(filepath.Join(profile.WorkingDirectory, local.FileRealmConfig.String()))
+		assert.True(t, os.IsNotExist(err), "expected app to not be written")
+	})
+}
+
+func TestAppUnbundleInputs(t *testing.T) {
+	t.Run("should prompt for the bundle and local filepaths", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		_, console, _, ui, consoleErr := mock.NewVT10XConsole()
+		assert.Nil(t, consoleErr)
+		defer console.Close()
+
+		doneCh := make(chan (struct{}))
+		go func() {
+			defer close(doneCh)
+			console.ExpectString("Bundle filepath")
+			console.SendLine("eggcorn.json")
+			console.ExpectString("App filepath (local)")
+			console.SendLine("")
+		}()
+
+		var i unbundleInputs
+		assert.Nil(t, i.Resolve(profile, ui))
+
+		console.Tty().Close() // flush the writers
+		<-doneCh              // wait for procedure to complete
+
+		assert.Equal(t, filepath.Join(profile.WorkingDirectory, "eggcorn.json"), i.Bundle)
+		assert.Equal(t, filepath.Join(profile.WorkingDirectory, "eggcorn"), i.LocalPath)
+	})
+
+	t.Run("should not prompt for inputs that are provided", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		_, ui := mock.NewUI()
+
+		i := unbundleInputs{Bundle: "/path/to/eggcorn.json", LocalPath: "app"}
+		assert.Nil(t, i.Resolve(profile, ui))
+
+		assert.Equal(t, "/path/to/eggcorn.json", i.Bundle)
+		assert.Equal(t, filepath.Join(profile.WorkingDirectory, "app"), i.LocalPath)
+	})
+}
+
+func TestAppUnbundleArgs(t *testing.T) {
+	t.Run("should set the bundle filepath from the arg", func(t *testing.T) {
+		cmd := &CommandUnbundle{}
+		assert.Nil(t, cmd.Args([]string{"app.json"}))
+		assert.Equal(t, "app.json", cmd.inputs.Bundle)
+	})
+
+	t.Run("should return an error with more than one arg", func(t *testing.T) {
+		cmd := &CommandUnbundle{}
+		assert.Equal(t, errors.New("accepts at most 1 bundle filepath argument, received 2"), cmd.Args([]string{"app.json", "other.json"}))
+	})
+
+	t.Run("should return an error when the arg differs from the bundle flag", func(t *testing.T) {
+		cmd := &CommandUnbundle{unbundleInputs{Bundle: "other.json"}}
+		assert.Equal(t, errors.New(`cannot specify a bundle filepath argument along with a different "--bundle"`), cmd.Args([]string{"app.json"}))
+	})
+}
@@ -3,6 +3,7 @@ package commands
 import (
 	"github.com/10gen/realm-cli/internal/cli"
 	"github.com/10gen/realm-cli/internal/commands/accesslist"
+	"github.com/10gen/realm-cli/internal/commands/apikeys"
 	"github.com/10gen/realm-cli/internal/commands/app"
 	"github.com/10gen/realm-cli/internal/commands/function"
 	"github.com/10gen/realm-cli/internal/commands/login"
@@ -74,6 +75,18 @@ var (
 				Command:     &app.CommandDescribe{},
 				CommandMeta: app.CommandMetaDescribe,
 			},
+			{
+				Command:     &app.CommandAnalyze{},
+				CommandMeta: app.CommandMetaAnalyze,
+			},
+			{
+				Command:     &app.CommandBundle{},
+				CommandMeta: app.CommandMetaBundle,
+			},
+			{
+				Command:     &app.CommandUnbundle{},
+				CommandMeta: app.CommandMetaUnbundle,
+			},
 		},
 	}
 
@@ -108,6 +121,84 @@ var (
 				Command:     &user.CommandDelete{},
 				CommandMeta: user.CommandMetaDelete,
 			},
+			{
+				Command:     &user.CommandImport{},
+				CommandMeta: user.CommandMetaImport,
+			},
+			{
+				Command:     &user.CommandExport{},
+				CommandMeta: user.CommandMetaExport,
+			},
+			{
+				Command:     &user.CommandCleanup{},
+				CommandMeta: user.CommandMetaCleanup,
+			},
+			{
+				CommandMeta: cli.CommandMeta{
+					Use:         "pending",
+					Description: "Manage the pending Email/Password users of your Realm app",
+				},
+				SubCommands: []cli.CommandDefinition{
+					{
+						Command:     &user.CommandPendingConfirm{},
+						CommandMeta: user.CommandMetaPendingConfirm,
+					},
+					{
+						Command:     &user.CommandPendingResend{},
+						CommandMeta: user.CommandMetaPendingResend,
+					},
+					{
+						Command:     &user.CommandPendingDelete{},
+						CommandMeta: user.CommandMetaPendingDelete,
+					},
+				},
+			},
+			{
+				CommandMeta: cli.CommandMeta{
+					Use:         "reset-password",
+					Description: "Reset the passwords of the Email/Password users of your Realm app",
+				},
+				SubCommands: []cli.CommandDefinition{
+					{
+						Command:     &user.CommandResetPasswordSend{},
+						CommandMeta: user.CommandMetaResetPasswordSend,
+					},
+					{
+						Command:     &user.CommandResetPasswordRunFunc{},
+						CommandMeta: user.CommandMetaResetPasswordRunFunc,
+					},
+				},
+			},
+		},
+	}
+
+	APIKeys = cli.CommandDefinition{
+		CommandMeta: cli.CommandMeta{
+			Use:         "apikeys",
+			Aliases:     []string{"apikey", "api-keys"},
+			Description: "Manage the API Keys of your Realm app",
+		},
+		SubCommands: []cli.CommandDefinition{
+			{
+				Command:     &apikeys.CommandList{},
+				CommandMeta: apikeys.CommandMetaList,
+			},
+			{
+				Command:     &apikeys.CommandCreate{},
+				CommandMeta: apikeys.CommandMetaCreate,
+			},
+			{
+				Command:     &apikeys.CommandEnable{},
+				CommandMeta: apikeys.CommandMetaEnable,
+			},
+			{
+				Command:     &apikeys.CommandDisable{},
+				CommandMeta: apikeys.CommandMetaDisable,
+			},
+			{
+				Command:     &apikeys.CommandDelete{},
+				CommandMeta: apikeys.CommandMetaDelete,
+			},
 		},
 	}
 
@@ -144,10 +235,34 @@ var (
 			Description: "Interact with the Functions of your Realm app",
 		},
 		SubCommands: []cli.CommandDefinition{
+			{
+				Command:     &function.CommandList{},
+				CommandMeta: function.CommandMetaList,
+			},
+			{
+				Command:     &function.CommandDescribe{},
+				CommandMeta: function.CommandMetaDescribe,
+			},
 			{
 				Command:     &function.CommandRun{},
 				CommandMeta: function.CommandMetaRun,
 			},
+			{
+				Command:     &function.CommandPush{},
+				CommandMeta: function.CommandMetaPush,
+			},
+			{
+				Command:     &function.CommandPull{},
+				CommandMeta: function.CommandMetaPull,
+			},
+			{
+				Command:     &function.CommandTest{},
+				CommandMeta: function.CommandMetaTest,
+			},
+			{
+				Command:     &function.CommandBench{},
+				CommandMeta: function.CommandMetaBench,
+			},
 		},
 	}
 
@@ -162,6 +277,22 @@ var (
 				Command:     &logs.CommandList{},
 				CommandMeta: logs.CommandMetaList,
 			},
+			{
+				Command:     &logs.CommandTail{},
+				CommandMeta: logs.CommandMetaTail,
+			},
+			{
+				Command:     &logs.CommandExport{},
+				CommandMeta: logs.CommandMetaExport,
+			},
+			{
+				Command:     &logs.CommandStats{},
+				CommandMeta: logs.CommandMetaStats,
+			},
+			{
+				Command:     &logs.CommandWatch{},
+				CommandMeta: logs.CommandMetaWatch,
+			},
 		},
 	}
 
@@ -0,0 +1,402 @@
+package function
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/terminal"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+	"github.com/10gen/realm-cli/internal/utils/stats"
+)
+
+const (
+	defaultBenchRequests    = 100
+	defaultBenchConcurrency = 10
+
+	headerMetric = "Metric"
+	headerMin    = "Min"
+	headerP50    = "P50"
+	headerP95    = "P95"
+	headerP99    = "P99"
+	headerMax    = "Max"
+	headerError  = "Error"
+	headerCount  = "Count"
+
+	metricLatency       = "latency"
+	metricExecutionTime = "execution time"
+)
+
+// CommandMetaBench is the command meta for the `function bench` command
+var CommandMetaBench = cli.CommandMeta{
+	Use:         "bench",
+	Display:     "function bench",
+	Description: "Benchmark the latency of a Function from your Realm app under load",
+	HelpText: `Runs a Function of your Realm app the number of times specified by "--requests",
+with up to "--concurrency" runs in progress at once. Once complete, the following
+will be displayed:
+  - The min, p50, p95, p99 and max of the latency measured by the CLI
+  - The min, p50, p95, p99 and max of the execution time reported by Realm
+  - The error rate, along with a count of each error returned, where a run
+    which throws counts as an error with the first line of its error logs
+
+The args are sent to your Realm app as relaxed Extended JSON, the same as
+"function run" sends them.
+
+Use "--output-format json" to display the results as JSON, and "--csv" to save
+each request's latency, execution time and error to a CSV file.`,
+}
+
+// CommandBench is the `function bench` command
+type CommandBench struct {
+	inputs benchInputs
+}
+
+type benchInputs struct {
+	cli.ProjectInputs
+	Name        string
+	Args        []string
+	User        string
+	Requests    int
+	Concurrency int
+	CSVPath     string
+}
+
+// Flags is the command flags
+func (cmd *CommandBench) Flags() []flags.Flag {
+	return []flags.Flag{
+		cli.AppFlagWithContext(&cmd.inputs.App, "to benchmark its function"),
+		cli.ProjectFlag(&cmd.inputs.Project),
+		cli.ProductFlag(&cmd.inputs.Products),
+		flags.StringFlag{
+			Value: &cmd.inputs.Name,
+			Meta: flags.Meta{
+				Name:  "name",
+				Usage: flags.Usage{Description: "Specify the name of the function to benchmark"},
+			},
+		},
+		flags.StringArrayFlag{
+			Value: &cmd.inputs.Args,
+			Meta: flags.Meta{
+				Name: flagArgs,
+				Usage: flags.Usage{
+					Description: "Specify the args, as Extended JSON, to pass to your function",
+				},
+			},
+		},
+		flags.StringFlag{
+			Value: &cmd.inputs.User,
+			Meta: flags.Meta{
+				Name: "user",
+				Usage: flags.Usage{
+					Description:   "Specify which user to run the function as",
+					DefaultValue:  "<none>",
+					AllowedValues: []string{"<none>", "<userID>"},
+					Note:          "Using <none> will run as the System user",
+				},
+			},
+		},
+		flags.IntFlag{
+			Value:        &cmd.inputs.Requests,
+			DefaultValue: defaultBenchRequests,
+			Meta: flags.Meta{
+				Name: "requests",
+				Usage: flags.Usage{
+					Description: "Specify the number of times to run the function",
+				},
+			},
+		},
+		flags.IntFlag{
+			Value:        &cmd.inputs.Concurrency,
+			DefaultValue: defaultBenchConcurrency,
+			Meta: flags.Meta{
+				Name: "concurrency",
+				Usage: flags.Usage{
+					Description: "Specify the max number of function runs in progress at once",
+				},
+			},
+		},
+		flags.StringFlag{
+			Value: &cmd.inputs.CSVPath,
+			Meta: flags.Meta{
+				Name: "csv",
+				Usage: flags.Usage{
+					Description: "Save the raw samples as CSV to the specified filepath",
+				},
+			},
+		},
+	}
+}
+
+// Inputs is the command inputs
+func (cmd *CommandBench) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandBench) Handler(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
+	args, err := toEJSON(parseArgs(cmd.inputs.Args), false)
+	if err != nil {
+		return err
+	}
+
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	function, err := resolveFunction(ui, clients.Realm, app.GroupID, app.ID, cmd.inputs.Name)
+	if err != nil {
+		return err
+	}
+
+	s := ui.Spinner(fmt.Sprintf("Running function %s %d times...", function.Name, cmd.inputs.Requests), terminal.SpinnerOptions{})
+
+	runBench := func() ([]benchSample, time.Duration) {
+		s.Start()
+		defer s.Stop()
+
+		start := time.Now()
+		samples := runBenchSamples(cmd.inputs.Requests, cmd.inputs.Concurrency, func() benchSample {
+			return benchRequest(clients.Realm, app.GroupID, app.ID, cmd.inputs.User, function.Name, args)
+		})
+		return samples, time.Since(start)
+	}
+
+	samples, elapsed := runBench()
+
+	report := newBenchReport(samples)
+
+	ui.Print(terminal.NewTextLog(
+		"Ran function %s %d times with a concurrency of %d in %s (%.2f requests/s)",
+		function.Name,
+		len(samples),
+		cmd.inputs.Concurrency,
+		elapsed.Round(time.Millisecond),
+		float64(len(samples))/elapsed.Seconds(),
+	))
+
+	ui.Print(terminal.NewTableLog(
+		"Function latencies",
+		[]string{headerMetric, headerMin, headerP50, headerP95, headerP99, headerMax},
+		report.latencyRows()...,
+	))
+
+	ui.Print(terminal.NewTextLog("Error rate: %.2f%% (%d of %d requests failed)", report.errorRate()*100, report.errors, len(samples)))
+	if report.errors > 0 {
+		ui.Print(terminal.NewTableLog("Errors", []string{headerError, headerCount}, report.errorRows()...))
+	}
+
+	if cmd.inputs.CSVPath != "" {
+		if err := writeBenchSamples(cmd.inputs.CSVPath, samples); err != nil {
+			return err
+		}
+		ui.Print(terminal.NewTextLog("Saved samples to %s", cmd.inputs.CSVPath))
+	}
+
+	return nil
+}
+
+func (i *benchInputs) Resolve(profile *user.Profile, ui terminal.UI) error {
+	if i.Requests < 1 {
+		return fmt.Errorf("requests must be at least 1 but got %d", i.Requests)
+	}
+	if i.Concurrency < 1 {
+		return fmt.Errorf("concurrency must be at least 1 but got %d", i.Concurrency)
+	}
+	if i.Concurrency > i.Requests {
+		i.Concurrency = i.Requests
+	}
+
+	if i.CSVPath != "" && !filepath.IsAbs(i.CSVPath) {
+		i.CSVPath = filepath.Join(profile.WorkingDirectory, i.CSVPath)
+	}
+
+	return i.ProjectInputs.Resolve(ui, profile.WorkingDirectory, true)
+}
+
+// benchSample is the outcome of a single function run
+type benchSample struct {
+	Latency       time.Duration
+	ExecutionTime time.Duration
+	Err           error
+}
+
+func benchRequest(client realm.Client, groupID, appID, userID, name string, args []interface{}) benchSample {
+	start := time.Now()
+	response, err := client.AppDebugExecuteFunction(groupID, appID, userID, name, args)
+
+	sample := benchSample{Latency: time.Since(start), Err: err}
+	if err == nil {
+		// the execution time is left unset when Realm reports it in an unknown format
+		sample.ExecutionTime, _ = time.ParseDuration(response.Stats.ExecutionTime)
+
+		// a function which throws succeeds as a request and reports its error in the error logs
+		if errorLog := firstErrorLog(response.ErrorLogs); errorLog != "" {
+			sample.Err = errors.New(errorLog)
+		}
+	}
+	return sample
+}
+
+// firstErrorLog returns the first line of the first non-empty error log, so errors are grouped
+// by their message rather than their stack traces
+func firstErrorLog(errorLogs []string) string {
+	for _, errorLog := range errorLogs {
+		if errorLog = strings.TrimSpace(errorLog); errorLog != "" {
+			return strings.SplitN(errorLog, "\n", 2)[0]
+		}
+	}
+	return ""
+}
+
+// runBenchSamples calls the request function the number of times requested
+// with up to the concurrency in progress at once, the samples are returned in request order
+func runBenchSamples(requests, concurrency int, request func() benchSample) []benchSample {
+	samples := make([]benchSample, requests)
+
+	var wg sync.WaitGroup
+	jobCh := make(chan int)
+
+	for n := 0; n < concurrency; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobCh {
+				samples[i] = request()
+			}
+		}()
+	}
+
+	for i := 0; i < requests; i++ {
+		jobCh <- i
+	}
+	close(jobCh)
+
+	wg.Wait()
+	return samples
+}
+
+type benchReport struct {
+	requests       int
+	errors         int
+	latencies      []time.Duration
+	executionTimes []time.Duration
+	errorCounts    map[string]int
+}
+
+func newBenchReport(samples []benchSample) benchReport {
+	report := benchReport{requests: len(samples), errorCounts: map[string]int{}}
+
+	for _, sample := range samples {
+		report.latencies = append(report.latencies, sample.Latency)
+
+		if sample.Err != nil {
+			report.errors++
+			report.errorCounts[sample.Err.Error()]++
+			continue
+		}
+		if sample.ExecutionTime > 0 {
+			report.executionTimes = append(report.executionTimes, sample.ExecutionTime)
+		}
+	}
+
+	sort.Slice(report.latencies, func(i, j int) bool { return report.latencies[i] < report.latencies[j] })
+	sort.Slice(report.executionTimes, func(i, j int) bool { return report.executionTimes[i] < report.executionTimes[j] })
+
+	return report
+}
+
+func (r benchReport) errorRate() float64 {
+	if r.requests == 0 {
+		return 0
+	}
+	return float64(r.errors) / float64(r.requests)
+}
+
+func (r benchReport) latencyRows() []map[string]interface{} {
+	rows := []map[string]interface{}{}
+	for _, metric := range []struct {
+		name      string
+		durations []time.Duration
+	}{
+		{metricLatency, r.latencies},
+		{metricExecutionTime, r.executionTimes},
+	} {
+		if len(metric.durations) == 0 {
+			continue
+		}
+		rows = append(rows, map[string]interface{}{
+			headerMetric: metric.name,
+			headerMin:    metric.durations[0].Round(time.Microsecond),
+			headerP50:    stats.Percentile(metric.durations, 50).Round(time.Microsecond),
+			headerP95:    stats.Percentile(metric.durations, 95).Round(time.Microsecond),
+			headerP99:    stats.Percentile(metric.durations, 99).Round(time.Microsecond),
+			headerMax:    metric.durations[len(metric.durations)-1].Round(time.Microsecond),
+		})
+	}
+	return rows
+}
+
+// errorRows returns the count of each error, the most frequent first
+func (r benchReport) errorRows() []map[string]interface{} {
+	errs := make([]string, 0, len(r.errorCounts))
+	for err := range r.errorCounts {
+		errs = append(errs, err)
+	}
+	sort.Slice(errs, func(i, j int) bool {
+		if r.errorCounts[errs[i]] != r.errorCounts[errs[j]] {
+			return r.errorCounts[errs[i]] > r.errorCounts[errs[j]]
+		}
+		return errs[i] < errs[j]
+	})
+
+	rows := make([]map[string]interface{}, 0, len(errs))
+	for _, err := range errs {
+		rows = append(rows, map[string]interface{}{headerError: err, headerCount: r.errorCounts[err]})
+	}
+	return rows
+}
+
+func writeBenchSamples(path string, samples []benchSample) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+	if err := w.Write([]string{"request", "latency_ms", "execution_time_ms", "error"}); err != nil {
+		return err
+	}
+
+	for i, sample := range samples {
+		var executionTime, errMsg string
+		if sample.ExecutionTime > 0 {
+			executionTime = durationMS(sample.ExecutionTime)
+		}
+		if sample.Err != nil {
+			errMsg = sample.Err.Error()
+		}
+		if err := w.Write([]string{strconv.Itoa(i + 1), durationMS(sample.Latency), executionTime, errMsg}); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+func durationMS(d time.Duration) string {
+	return strconv.FormatFloat(float64(d)/float64(time.Millisecond), 'f', 3, 64)
+}
@@ -0,0 +1,207 @@
+package function
+
+import (
+	"encoding/csv"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestFunctionBenchHandler(t *testing.T) {
+	t.Run("should run the function the requested number of times and report the results", func(t *testing.T) {
+		profile, teardown := mock.NewProfileFromTmpDir(t, "function_bench_handler")
+		defer teardown()
+
+		var calls, inFlight, maxInFlight int32
+
+		rc := mock.RealmClient{}
+		rc.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{ID: "appID", GroupID: "groupID", Name: "test-app"}}, nil
+		}
+		rc.FunctionsFn = func(groupID, appID string) ([]realm.Function, error) {
+			return []realm.Function{{Name: "test"}}, nil
+		}
+		rc.AppDebugExecuteFunctionFn = func(groupID, appID, userID, name string, args []interface{}) (realm.ExecutionResults, error) {
+			current := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				max := atomic.LoadInt32(&maxInFlight)
+				if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+					break
+				}
+			}
+
+			time.Sleep(time.Millisecond)
+
+			if atomic.AddInt32(&calls, 1)%4 == 0 {
+				return realm.ExecutionResults{}, errors.New("something bad happened")
+			}
+			var results realm.ExecutionResults
+			results.Stats.ExecutionTime = "5ms"
+			return results, nil
+		}
+
+		out, ui := mock.NewUI()
+
+		csvPath := filepath.Join(profile.WorkingDirectory, "samples.csv")
+
+		cmd := &CommandBench{benchInputs{
+			ProjectInputs: cli.ProjectInputs{Project: "groupID", App: "test-app"},
+			Name:          "test",
+			Requests:      20,
+			Concurrency:   5,
+			CSVPath:       csvPath,
+		}}
+		assert.Nil(t, cmd.Handler(profile, ui, cli.Clients{Realm: rc}))
+
+		assert.Equal(t, int32(20), calls)
+		assert.True(t, maxInFlight <= 5, "expected at most 5 requests in progress at once but got %d", maxInFlight)
+
+		output := out.String()
+		for _, expected := range []string{
+			"Ran function test 20 times with a concurrency of 5 in ",
+			"Function latencies\n  Metric ",
+			"\n  latency ",
+			"\n  execution time  5ms ",
+			"Error rate: 25.00% (5 of 20 requests failed)\n",
+			"Errors\n  Error                   Count\n  ----------------------  -----\n  something bad happened  5    \n",
+			"Saved samples to " + csvPath + "\n",
+		} {
+			assert.True(t, strings.Contains(output, expected), "expected output to contain %q:\n%s", expected, output)
+		}
+
+		file, err := os.Open(csvPath)
+		assert.Nil(t, err)
+		defer file.Close()
+
+		records, err := csv.NewReader(file).ReadAll()
+		assert.Nil(t, err)
+		assert.Equal(t, 21, len(records))
+		assert.Equal(t, []string{"request", "latency_ms", "execution_time_ms", "error"}, records[0])
+		assert.Equal(t, "1", records[1][0])
+	})
+}
+
+func TestBenchRequest(t *testing.T) {
+	for _, tc := range []struct {
+		description string
+		errorLogs   []string
+		expectedErr error
+	}{
+		{
+			description: "should not count a run without error logs as an error",
+		},
+		{
+			description: "should not count a run with empty error logs as an error",
+			errorLogs:   []string{"", " "},
+		},
+		{
+			description: "should count a run with error logs as an error with the first line of the first error log",
+			errorLogs:   []string{"", "Error: something bad happened\n    at test (function.js:1:7)"},
+			expectedErr: errors.New("Error: something bad happened"),
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			rc := mock.RealmClient{}
+			rc.AppDebugExecuteFunctionFn = func(groupID, appID, userID, name string, args []interface{}) (realm.ExecutionResults, error) {
+				var results realm.ExecutionResults
+				results.ErrorLogs = tc.errorLogs
+				results.Stats.ExecutionTime = "5ms"
+				return results, nil
+			}
+
+			sample := benchRequest(rc, "groupID", "appID", "", "test", nil)
+			assert.Equal(t, tc.expectedErr, sample.Err)
+			assert.Equal(t, 5*time.Millisecond, sample.ExecutionTime)
+		})
+	}
+}
+
+func TestFunctionBenchInputs(t *testing.T) {
+	for _, tc := range []struct {
+		description string
+		inputs      benchInputs
+		expectedErr error
+	}{
+		{
+			description: "should return an error when requests is less than one",
+			inputs:      benchInputs{Requests: 0, Concurrency: 1},
+			expectedErr: errors.New("requests must be at least 1 but got 0"),
+		},
+		{
+			description: "should return an error when concurrency is less than one",
+			inputs:      benchInputs{Requests: 1, Concurrency: -1},
+			expectedErr: errors.New("concurrency must be at least 1 but got -1"),
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			profile := mock.NewProfile(t)
+
+			assert.Equal(t, tc.expectedErr, tc.inputs.Resolve(profile, nil))
+		})
+	}
+
+	t.Run("should limit the concurrency to the number of requests", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		i := benchInputs{ProjectInputs: cli.ProjectInputs{App: "test-app"}, Requests: 3, Concurrency: 10, CSVPath: "samples.csv"}
+		assert.Nil(t, i.Resolve(profile, nil))
+
+		assert.Equal(t, 3, i.Concurrency)
+		assert.Equal(t, filepath.Join(profile.WorkingDirectory, "samples.csv"), i.CSVPath)
+	})
+}
+
+func TestFunctionBenchReport(t *testing.T) {
+	var samples []benchSample
+	for i := 1; i <= 100; i++ {
+		samples = append(samples, benchSample{
+			Latency:       time.Duration(i) * time.Millisecond,
+			ExecutionTime: time.Duration(i) * time.Microsecond,
+		})
+	}
+	samples = append(samples,
+		benchSample{Latency: 200 * time.Millisecond, Err: errors.New("timed out")},
+		benchSample{Latency: 300 * time.Millisecond, Err: errors.New("timed out")},
+		benchSample{Latency: 400 * time.Millisecond, Err: errors.New("not found")},
+		benchSample{Latency: 500 * time.Millisecond, ExecutionTime: 0},
+	)
+
+	report := newBenchReport(samples)
+
+	assert.Equal(t, 3, report.errors)
+	assert.Equal(t, float64(3)/104, report.errorRate())
+
+	assert.Equal(t, []map[string]interface{}{
+		{
+			headerMetric: metricLatency,
+			headerMin:    1 * time.Millisecond,
+			headerP50:    52 * time.Millisecond,
+			headerP95:    99 * time.Millisecond,
+			headerP99:    400 * time.Millisecond,
+			headerMax:    500 * time.Millisecond,
+		},
+		{
+			headerMetric: metricExecutionTime,
+			headerMin:    1 * time.Microsecond,
+			headerP50:    50 * time.Microsecond,
+			headerP95:    95 * time.Microsecond,
+			headerP99:    99 * time.Microsecond,
+			headerMax:    100 * time.Microsecond,
+		},
+	}, report.latencyRows())
+
+	assert.Equal(t, []map[string]interface{}{
+		{headerError: "timed out", headerCount: 2},
+		{headerError: "not found", headerCount: 1},
+	}, report.errorRows())
+}
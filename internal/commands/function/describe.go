@@ -0,0 +1,255 @@
+package function
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/local"
+	"github.com/10gen/realm-cli/internal/terminal"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+)
+
+const (
+	flagDiffLocal = "diff-local"
+
+	configPrivate                 = "private"
+	configCanEvaluate             = "can_evaluate"
+	configDisableArgLogs          = "disable_arg_logs"
+	configRunAsSystem             = "run_as_system"
+	configRunAsUserID             = "run_as_user_id"
+	configRunAsUserIDScriptSource = "run_as_user_id_script_source"
+)
+
+// configFields are the function config fields compared between the deployed and local function
+var configFields = []string{
+	configPrivate,
+	configCanEvaluate,
+	configDisableArgLogs,
+	configRunAsSystem,
+	configRunAsUserID,
+	configRunAsUserIDScriptSource,
+}
+
+// CommandMetaDescribe is the command meta for the `function describe` command
+var CommandMetaDescribe = cli.CommandMeta{
+	Use:         "describe",
+	Display:     "function describe",
+	Description: "Describe a Function from your Realm app",
+	HelpText: `This will display the full config and source of a deployed Function in your
+Realm app. Specify the name of the Function to describe as an argument, such as
+"function describe greet", or select the Function from a prompt.
+
+Use "--diff-local" with the filepath of your local Realm app, such as
+"--diff-local .", to instead display the differences between the deployed
+Function and the Function of the same name in your local Realm app.`,
+}
+
+// CommandDescribe is the `function describe` command
+type CommandDescribe struct {
+	inputs describeInputs
+}
+
+type describeInputs struct {
+	cli.ProjectInputs
+	Name      string
+	DiffLocal string
+}
+
+// Flags is the command flags
+func (cmd *CommandDescribe) Flags() []flags.Flag {
+	return []flags.Flag{
+		cli.AppFlagWithContext(&cmd.inputs.App, "to describe its function"),
+		cli.ProjectFlag(&cmd.inputs.Project),
+		cli.ProductFlag(&cmd.inputs.Products),
+		flags.StringFlag{
+			Value: &cmd.inputs.Name,
+			Meta: flags.Meta{
+				Name:  "name",
+				Usage: flags.Usage{Description: "Specify the name of the function to describe, if not passed as an argument"},
+			},
+		},
+		flags.StringFlag{
+			Value: &cmd.inputs.DiffLocal,
+			Meta: flags.Meta{
+				Name: flagDiffLocal,
+				Usage: flags.Usage{
+					Description: "Compare the function to the function in the local filepath of a Realm app",
+					Note:        "Use '.' to compare to the Realm app in the current working directory",
+				},
+			},
+		},
+	}
+}
+
+// Args is the command args
+func (cmd *CommandDescribe) Args(args []string) error {
+	if len(args) == 0 {
+		return nil
+	}
+	if len(args) > 1 {
+		return fmt.Errorf("accepts at most 1 function name argument, received %d", len(args))
+	}
+	if cmd.inputs.Name != "" && cmd.inputs.Name != args[0] {
+		return errors.New(`cannot specify a function name argument along with a different "--name"`)
+	}
+	cmd.inputs.Name = args[0]
+	return nil
+}
+
+// Inputs is the command inputs
+func (cmd *CommandDescribe) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandDescribe) Handler(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	function, err := resolveFunction(ui, clients.Realm, app.GroupID, app.ID, cmd.inputs.Name)
+	if err != nil {
+		return err
+	}
+
+	definition, err := clients.Realm.Function(app.GroupID, app.ID, function.ID)
+	if err != nil {
+		return err
+	}
+
+	if cmd.inputs.DiffLocal == "" {
+		config := functionConfig(definition)
+		config["_id"] = definition.ID
+		config["last_modified"] = lastModifiedString(definition.Function)
+
+		ui.Print(
+			terminal.NewJSONLog("Config", config),
+			terminal.NewTextLog("Source\n%s", definition.Source),
+		)
+		return nil
+	}
+
+	localApp, err := cli.LoadApp(ui, profile, cmd.inputs.DiffLocal, false)
+	if err != nil {
+		return err
+	}
+
+	localFunction, err := findLocalFunction(localApp, definition.Name, "")
+	if err != nil {
+		return err
+	}
+
+	if !printFunctionDiffs(ui, definition, localFunction) {
+		ui.Print(terminal.NewTextLog("Deployed function '%s' matches the local function", definition.Name))
+	}
+	return nil
+}
+
+func (i *describeInputs) Resolve(profile *user.Profile, ui terminal.UI) error {
+	if err := i.ProjectInputs.Resolve(ui, profile.WorkingDirectory, false); err != nil {
+		return err
+	}
+
+	if i.DiffLocal != "" {
+		rootDir, err := resolveLocalAppDir(profile.WorkingDirectory, i.DiffLocal)
+		if err != nil {
+			return err
+		}
+		i.DiffLocal = rootDir
+	}
+
+	return nil
+}
+
+// functionConfig returns the deployed function config as it is stored in a local Realm app
+func functionConfig(definition realm.FunctionDefinition) map[string]interface{} {
+	config := map[string]interface{}{
+		"name":               definition.Name,
+		configPrivate:        definition.Private,
+		configDisableArgLogs: definition.DisableArgLogs,
+		configRunAsSystem:    definition.RunAsSystem,
+	}
+	if len(definition.CanEvaluate) > 0 {
+		config[configCanEvaluate] = definition.CanEvaluate
+	}
+	if definition.RunAsUserID != "" {
+		config[configRunAsUserID] = definition.RunAsUserID
+	}
+	if definition.RunAsUserIDScriptSource != "" {
+		config[configRunAsUserIDScriptSource] = definition.RunAsUserIDScriptSource
+	}
+	return config
+}
+
+// diffFunctionConfig describes each config field which differs between the deployed and local function
+func diffFunctionConfig(deployed, local map[string]interface{}) []interface{} {
+	var diffs []interface{}
+	for _, field := range configFields {
+		deployedValue, localValue := configValue(deployed[field]), configValue(local[field])
+		if toJSON(deployedValue) != toJSON(localValue) {
+			diffs = append(diffs, fmt.Sprintf("%s: %s -> %s", field, toJSON(deployedValue), toJSON(localValue)))
+		}
+	}
+	return diffs
+}
+
+// printFunctionDiffs displays the differences between the deployed and local function
+// and reports whether there were any
+func printFunctionDiffs(ui terminal.UI, deployed realm.FunctionDefinition, localFunction local.AppFunction) bool {
+	configDiffs := diffFunctionConfig(functionConfig(deployed), localFunction.Config)
+	sourceDiffs := diffLines(deployed.Source, localFunction.Source)
+
+	if len(configDiffs) > 0 {
+		ui.Print(terminal.NewListLog("Config differences (deployed -> local)", configDiffs...))
+	}
+	if len(sourceDiffs) > 0 {
+		ui.Print(terminal.NewTextLog("Source differences (deployed -> local)\n%s", strings.Join(sourceDiffs, "\n")))
+	}
+	return len(configDiffs) > 0 || len(sourceDiffs) > 0
+}
+
+// findLocalFunction finds the function in the local app by its name or, when set, the path to its source
+func findLocalFunction(app local.App, name, file string) (local.AppFunction, error) {
+	for _, fn := range local.AppFunctions(app.AppData) {
+		if file != "" {
+			if app.FunctionSourcePath(fn.Name) == file {
+				return fn, nil
+			}
+			continue
+		}
+		if fn.Name == name {
+			return fn, nil
+		}
+	}
+
+	if file != "" {
+		return local.AppFunction{}, fmt.Errorf("failed to find a function with the source %s in the local app at %s", file, app.RootDir)
+	}
+	return local.AppFunction{}, fmt.Errorf("failed to find function '%s' in the local app at %s", name, app.RootDir)
+}
+
+// configValue treats the unset and empty config values the same
+func configValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case bool:
+		if !v {
+			return nil
+		}
+	case string:
+		if v == "" {
+			return nil
+		}
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return nil
+		}
+	}
+	return value
+}
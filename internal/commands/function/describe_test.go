@@ -0,0 +1,229 @@
+package function
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+
+	"github.com/spf13/pflag"
+)
+
+func TestFunctionDescribeHandler(t *testing.T) {
+	app := realm.App{ID: "appID", GroupID: "groupID", Name: "test-app"}
+
+	greetSource, err := ioutil.ReadFile(filepath.Join("testdata", "local", "functions", "greet.js"))
+	assert.Nil(t, err)
+
+	newClient := func(definition realm.FunctionDefinition) mock.RealmClient {
+		rc := mock.RealmClient{}
+		rc.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{app}, nil
+		}
+		rc.FunctionsFn = func(groupID, appID string) ([]realm.Function, error) {
+			return []realm.Function{definition.Function}, nil
+		}
+		rc.FunctionFn = func(groupID, appID, functionID string) (realm.FunctionDefinition, error) {
+			return definition, nil
+		}
+		return rc
+	}
+
+	t.Run("should display the function config and source", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		var functionID string
+		rc := newClient(realm.FunctionDefinition{
+			Function:       realm.Function{ID: "fn1", Name: "greet", Private: true},
+			Source:         "exports = function() {};",
+			DisableArgLogs: true,
+			RunAsUserID:    "userID",
+		})
+		functionFn := rc.FunctionFn
+		rc.FunctionFn = func(groupID, appID, id string) (realm.FunctionDefinition, error) {
+			functionID = id
+			return functionFn(groupID, appID, id)
+		}
+
+		cmd := &CommandDescribe{describeInputs{
+			ProjectInputs: cli.ProjectInputs{Project: "groupID", App: "test-app"},
+			Name:          "greet",
+		}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: rc}))
+
+		assert.Equal(t, "fn1", functionID)
+		assert.Equal(t, `Config
+{
+  "_id": "fn1",
+  "disable_arg_logs": true,
+  "last_modified": "n/a",
+  "name": "greet",
+  "private": true,
+  "run_as_system": false,
+  "run_as_user_id": "userID"
+}
+Source
+exports = function() {};
+`, out.String())
+	})
+
+	t.Run("with diff local set", func(t *testing.T) {
+		wd, err := os.Getwd()
+		assert.Nil(t, err)
+
+		profile := mock.NewProfile(t)
+		profile.WorkingDirectory = filepath.Join(wd, "testdata", "local")
+
+		t.Run("should report when the local function matches", func(t *testing.T) {
+			out, ui := mock.NewUI()
+
+			rc := newClient(realm.FunctionDefinition{
+				Function: realm.Function{ID: "fn1", Name: "greet"},
+				Source:   string(greetSource),
+			})
+
+			cmd := &CommandDescribe{describeInputs{
+				ProjectInputs: cli.ProjectInputs{Project: "groupID", App: "test-app"},
+				Name:          "greet",
+				DiffLocal:     profile.WorkingDirectory,
+			}}
+			assert.Nil(t, cmd.Handler(profile, ui, cli.Clients{Realm: rc}))
+
+			assert.Equal(t, "Deployed function 'greet' matches the local function\n", out.String())
+		})
+
+		t.Run("should display the config and source differences", func(t *testing.T) {
+			out, ui := mock.NewUI()
+
+			rc := newClient(realm.FunctionDefinition{
+				Function: realm.Function{
+					ID:          "fn1",
+					Name:        "greet",
+					Private:     true,
+					CanEvaluate: map[string]interface{}{"%%true": true},
+				},
+				Source: `exports = function(name) {
+  return {
+    greeting: context.values.get("greeting") + ", " + name,
+    user: context.user.id,
+    email: context.user.data.email
+  };
+};
+`,
+			})
+
+			cmd := &CommandDescribe{describeInputs{
+				ProjectInputs: cli.ProjectInputs{Project: "groupID", App: "test-app"},
+				Name:          "greet",
+				DiffLocal:     profile.WorkingDirectory,
+			}}
+			assert.Nil(t, cmd.Handler(profile, ui, cli.Clients{Realm: rc}))
+
+			assert.Equal(t, `Config differences (deployed -> local)
+  private: true -> null
+  can_evaluate: {"%%true":true} -> null
+Source differences (deployed -> local)
+@@ -1,4 +1,5 @@
+ exports = function(name) {
++  console.log("greeting", name);
+   return {
+     greeting: context.values.get("greeting") + ", " + name,
+     user: context.user.id,
+`, out.String())
+		})
+
+		t.Run("should return an error when the local app does not have the function", func(t *testing.T) {
+			_, ui := mock.NewUI()
+
+			rc := newClient(realm.FunctionDefinition{Function: realm.Function{ID: "fn1", Name: "missing"}})
+
+			cmd := &CommandDescribe{describeInputs{
+				ProjectInputs: cli.ProjectInputs{Project: "groupID", App: "test-app"},
+				Name:          "missing",
+				DiffLocal:     profile.WorkingDirectory,
+			}}
+			assert.Equal(t,
+				errors.New("failed to find function 'missing' in the local app at "+profile.WorkingDirectory),
+				cmd.Handler(profile, ui, cli.Clients{Realm: rc}),
+			)
+		})
+	})
+
+	t.Run("should return an error when getting the function fails", func(t *testing.T) {
+		_, ui := mock.NewUI()
+
+		rc := newClient(realm.FunctionDefinition{Function: realm.Function{ID: "fn1", Name: "greet"}})
+		rc.FunctionFn = func(groupID, appID, functionID string) (realm.FunctionDefinition, error) {
+			return realm.FunctionDefinition{}, errors.New("something bad happened")
+		}
+
+		cmd := &CommandDescribe{describeInputs{
+			ProjectInputs: cli.ProjectInputs{Project: "groupID", App: "test-app"},
+			Name:          "greet",
+		}}
+		assert.Equal(t, errors.New("something bad happened"), cmd.Handler(nil, ui, cli.Clients{Realm: rc}))
+	})
+}
+
+func TestFunctionDescribeInputsResolve(t *testing.T) {
+	profile := mock.NewProfile(t)
+	wd, err := os.Getwd()
+	assert.Nil(t, err)
+	profile.WorkingDirectory = wd
+
+	t.Run("should resolve the local app directory to diff against", func(t *testing.T) {
+		i := describeInputs{ProjectInputs: cli.ProjectInputs{Project: "groupID", App: "test-app"}, DiffLocal: "testdata/local/values"}
+		assert.Nil(t, i.Resolve(profile, nil))
+		assert.Equal(t, filepath.Join(wd, "testdata/local"), i.DiffLocal)
+	})
+
+	t.Run("should return an error when the diff local path is not a realm app", func(t *testing.T) {
+		i := describeInputs{ProjectInputs: cli.ProjectInputs{Project: "groupID", App: "test-app"}, DiffLocal: "testdata"}
+		assert.Equal(t, errors.New("failed to find a Realm app at "+filepath.Join(wd, "testdata")), i.Resolve(profile, nil))
+	})
+}
+
+func TestFunctionDescribeFlags(t *testing.T) {
+	t.Run("should parse the diff local filepath provided as a separate value", func(t *testing.T) {
+		cmd := &CommandDescribe{}
+
+		fs := pflag.NewFlagSet("describe", pflag.ContinueOnError)
+		for _, flag := range cmd.Flags() {
+			flag.Register(fs)
+		}
+
+		assert.Nil(t, fs.Parse([]string{"--diff-local", "testdata/local", "--name", "greet"}))
+		assert.Equal(t, "testdata/local", cmd.inputs.DiffLocal)
+		assert.Equal(t, 0, fs.NArg())
+	})
+}
+
+func TestFunctionDescribeArgs(t *testing.T) {
+	t.Run("should leave the name unset without an arg", func(t *testing.T) {
+		cmd := &CommandDescribe{}
+		assert.Nil(t, cmd.Args(nil))
+		assert.Equal(t, "", cmd.inputs.Name)
+	})
+
+	t.Run("should set the name from the arg", func(t *testing.T) {
+		cmd := &CommandDescribe{}
+		assert.Nil(t, cmd.Args([]string{"greet"}))
+		assert.Equal(t, "greet", cmd.inputs.Name)
+	})
+
+	t.Run("should return an error with more than one arg", func(t *testing.T) {
+		cmd := &CommandDescribe{}
+		assert.Equal(t, errors.New("accepts at most 1 function name argument, received 2"), cmd.Args([]string{"greet", "sum"}))
+	})
+
+	t.Run("should return an error when the arg differs from the name flag", func(t *testing.T) {
+		cmd := &CommandDescribe{describeInputs{Name: "sum"}}
+		assert.Equal(t, errors.New(`cannot specify a function name argument along with a different "--name"`), cmd.Args([]string{"greet"}))
+	})
+}
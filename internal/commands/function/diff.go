@@ -0,0 +1,122 @@
+package function
+
+import (
+	"fmt"
+	"strings"
+)
+
+const diffContextLines = 3
+
+type diffOp struct {
+	kind    byte
+	line    string
+	oldLine int
+	newLine int
+}
+
+// diffLines returns a unified diff of the two sources, where lines only found in the
+// old source are prefixed with "-" and lines only found in the new source with "+".
+// An empty diff is returned when the sources match
+func diffLines(oldSrc, newSrc string) []string {
+	ops := diffOps(splitLines(oldSrc), splitLines(newSrc))
+
+	var changes []int
+	for i, op := range ops {
+		if op.kind != ' ' {
+			changes = append(changes, i)
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	var out []string
+	for start := 0; start < len(changes); {
+		end := start
+		for end+1 < len(changes) && changes[end+1]-changes[end] <= 2*diffContextLines {
+			end++
+		}
+
+		from := changes[start] - diffContextLines
+		if from < 0 {
+			from = 0
+		}
+		to := changes[end] + diffContextLines + 1
+		if to > len(ops) {
+			to = len(ops)
+		}
+
+		out = append(out, diffHunk(ops[from:to])...)
+		start = end + 1
+	}
+	return out
+}
+
+func diffHunk(ops []diffOp) []string {
+	var oldCount, newCount int
+	lines := make([]string, 0, len(ops)+1)
+	for _, op := range ops {
+		if op.kind != '+' {
+			oldCount++
+		}
+		if op.kind != '-' {
+			newCount++
+		}
+		lines = append(lines, string(op.kind)+op.line)
+	}
+
+	oldStart, newStart := ops[0].oldLine, ops[0].newLine
+	if oldCount > 0 {
+		oldStart++
+	}
+	if newCount > 0 {
+		newStart++
+	}
+
+	return append([]string{fmt.Sprintf("@@ -%d,%d +%d,%d @@", oldStart, oldCount, newStart, newCount)}, lines...)
+}
+
+// diffOps finds the operations which turn the old lines into the new lines
+// based on the longest common subsequence of the lines
+func diffOps(oldLines, newLines []string) []diffOp {
+	lcs := make([][]int, len(oldLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(newLines)+1)
+	}
+	for i := len(oldLines) - 1; i >= 0; i-- {
+		for j := len(newLines) - 1; j >= 0; j-- {
+			if oldLines[i] == newLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var ops []diffOp
+	var i, j int
+	for i < len(oldLines) || j < len(newLines) {
+		switch {
+		case i < len(oldLines) && j < len(newLines) && oldLines[i] == newLines[j]:
+			ops = append(ops, diffOp{' ', oldLines[i], i, j})
+			i++
+			j++
+		case j == len(newLines) || (i < len(oldLines) && lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, diffOp{'-', oldLines[i], i, j})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', newLines[j], i, j})
+			j++
+		}
+	}
+	return ops
+}
+
+func splitLines(src string) []string {
+	if src == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(strings.ReplaceAll(src, "\r\n", "\n"), "\n"), "\n")
+}
@@ -0,0 +1,46 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestDiffLines(t *testing.T) {
+	for _, tc := range []struct {
+		description string
+		oldSrc      string
+		newSrc      string
+		expected    []string
+	}{
+		{
+			description: "should return no diff for matching sources",
+			oldSrc:      "a\nb\nc\n",
+			newSrc:      "a\r\nb\r\nc",
+		},
+		{
+			description: "should diff a changed line with its surrounding context",
+			oldSrc:      "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			newSrc:      "1\n2\n3\n4\nfive\n6\n7\n8\n9\n",
+			expected:    []string{"@@ -2,7 +2,7 @@", " 2", " 3", " 4", "-5", "+five", " 6", " 7", " 8"},
+		},
+		{
+			description: "should split distant changes into separate hunks",
+			oldSrc:      "a\n1\n2\n3\n4\n5\n6\n7\n8\nb\n",
+			newSrc:      "A\n1\n2\n3\n4\n5\n6\n7\n8\nB\n",
+			expected: []string{
+				"@@ -1,4 +1,4 @@", "-a", "+A", " 1", " 2", " 3",
+				"@@ -7,4 +7,4 @@", " 6", " 7", " 8", "-b", "+B",
+			},
+		},
+		{
+			description: "should diff against an empty source",
+			newSrc:      "a\nb\n",
+			expected:    []string{"@@ -0,0 +1,2 @@", "+a", "+b"},
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, diffLines(tc.oldSrc, tc.newSrc))
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package function
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/terminal"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+)
+
+const (
+	headerName         = "Name"
+	headerID           = "ID"
+	headerPrivate      = "Private"
+	headerCanEvaluate  = "Can Evaluate"
+	headerRunAsSystem  = "Run As System"
+	headerLastModified = "Last Modified"
+)
+
+// CommandMetaList is the command meta for the `function list` command
+var CommandMetaList = cli.CommandMeta{
+	Use:         "list",
+	Aliases:     []string{"ls"},
+	Display:     "function list",
+	Description: "List the Functions in your Realm app",
+	HelpText: `This will display the Names and IDs of the Functions in your Realm app, along
+with whether each Function is private, its "can evaluate" expression, whether it
+runs as the System user and when it was last modified.`,
+}
+
+// CommandList is the `function list` command
+type CommandList struct {
+	inputs listInputs
+}
+
+type listInputs struct {
+	cli.ProjectInputs
+}
+
+// Flags is the command flags
+func (cmd *CommandList) Flags() []flags.Flag {
+	return []flags.Flag{
+		cli.AppFlagWithContext(&cmd.inputs.App, "to list its functions"),
+		cli.ProjectFlag(&cmd.inputs.Project),
+		cli.ProductFlag(&cmd.inputs.Products),
+	}
+}
+
+// Inputs is the command inputs
+func (cmd *CommandList) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandList) Handler(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	functions, err := clients.Realm.Functions(app.GroupID, app.ID)
+	if err != nil {
+		return err
+	}
+
+	if len(functions) == 0 {
+		ui.Print(terminal.NewTextLog("No available functions to show"))
+		return nil
+	}
+
+	rows := make([]map[string]interface{}, 0, len(functions))
+	for _, function := range functions {
+		rows = append(rows, map[string]interface{}{
+			headerName:         function.Name,
+			headerID:           function.ID,
+			headerPrivate:      function.Private,
+			headerCanEvaluate:  canEvaluateString(function.CanEvaluate),
+			headerRunAsSystem:  function.RunAsSystem,
+			headerLastModified: lastModifiedString(function),
+		})
+	}
+
+	ui.Print(terminal.NewTableLog(
+		fmt.Sprintf("Found %d functions", len(functions)),
+		[]string{headerName, headerID, headerPrivate, headerCanEvaluate, headerRunAsSystem, headerLastModified},
+		rows...,
+	))
+	return nil
+}
+
+func (i *listInputs) Resolve(profile *user.Profile, ui terminal.UI) error {
+	return i.ProjectInputs.Resolve(ui, profile.WorkingDirectory, false)
+}
+
+func canEvaluateString(canEvaluate map[string]interface{}) string {
+	if len(canEvaluate) == 0 {
+		return ""
+	}
+
+	data, err := json.Marshal(canEvaluate)
+	if err != nil {
+		return fmt.Sprintf("%v", canEvaluate)
+	}
+	return string(data)
+}
+
+func lastModifiedString(function realm.Function) string {
+	if function.LastModified == 0 {
+		return "n/a"
+	}
+	return time.Unix(function.LastModified, 0).UTC().String()
+}
@@ -0,0 +1,72 @@
+package function
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestFunctionListHandler(t *testing.T) {
+	app := realm.App{ID: "appID", GroupID: "groupID", Name: "test-app"}
+
+	t.Run("should display the app functions", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		rc := mock.RealmClient{}
+		rc.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{app}, nil
+		}
+		rc.FunctionsFn = func(groupID, appID string) ([]realm.Function, error) {
+			return []realm.Function{
+				{ID: "fn1", Name: "greet", CanEvaluate: map[string]interface{}{"%%true": true}},
+				{ID: "fn2", Name: "secret", Private: true, RunAsSystem: true, LastModified: 1615000000},
+			}, nil
+		}
+
+		cmd := &CommandList{listInputs{cli.ProjectInputs{Project: "groupID", App: "test-app"}}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: rc}))
+
+		assert.Equal(t, `Found 2 functions
+  Name    ID   Private  Can Evaluate     Run As System  Last Modified                
+  ------  ---  -------  ---------------  -------------  -----------------------------
+  greet   fn1  false    {"%%true":true}  false          n/a                          
+  secret  fn2  true                      true           2021-03-06 03:06:40 +0000 UTC
+`, out.String())
+	})
+
+	t.Run("should display a message when the app has no functions", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		rc := mock.RealmClient{}
+		rc.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{app}, nil
+		}
+		rc.FunctionsFn = func(groupID, appID string) ([]realm.Function, error) {
+			return nil, nil
+		}
+
+		cmd := &CommandList{listInputs{cli.ProjectInputs{Project: "groupID", App: "test-app"}}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: rc}))
+
+		assert.Equal(t, "No available functions to show\n", out.String())
+	})
+
+	t.Run("should return an error when listing the functions fails", func(t *testing.T) {
+		_, ui := mock.NewUI()
+
+		rc := mock.RealmClient{}
+		rc.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{app}, nil
+		}
+		rc.FunctionsFn = func(groupID, appID string) ([]realm.Function, error) {
+			return nil, errors.New("something bad happened")
+		}
+
+		cmd := &CommandList{listInputs{cli.ProjectInputs{Project: "groupID", App: "test-app"}}}
+		assert.Equal(t, errors.New("something bad happened"), cmd.Handler(nil, ui, cli.Clients{Realm: rc}))
+	})
+}
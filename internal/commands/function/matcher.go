@@ -0,0 +1,180 @@
+package function
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+const matcherRegex = "$regex"
+
+// matcher compares the expected values of a function test case to the values of a function execution.
+// Expected documents of the form {"$regex": "<pattern>"} match any string matching the pattern.
+// When partial, documents match if they contain the expected fields and arrays match
+// if they contain the expected elements in order
+type matcher struct {
+	partial bool
+}
+
+// Match returns a description of each mismatch found between the expected and actual values,
+// where path names the value being matched
+func (m matcher) Match(path string, expected, actual interface{}) []string {
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		if pattern, ok := regexPattern(e); ok {
+			return m.matchRegex(path, pattern, actual)
+		}
+
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			return []string{mismatch(path, expected, actual)}
+		}
+		return m.matchDocument(path, e, a)
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok {
+			return []string{mismatch(path, expected, actual)}
+		}
+		return m.matchArray(path, e, a)
+	}
+
+	if expectedNum, ok := toFloat(expected); ok {
+		if actualNum, ok := toFloat(actual); ok && expectedNum == actualNum {
+			return nil
+		}
+		return []string{mismatch(path, expected, actual)}
+	}
+
+	if expected != actual {
+		return []string{mismatch(path, expected, actual)}
+	}
+	return nil
+}
+
+func (m matcher) matchRegex(path, pattern string, actual interface{}) []string {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return []string{fmt.Sprintf("%s: invalid regex %q: %s", path, pattern, err)}
+	}
+
+	if s, ok := actual.(string); !ok || !re.MatchString(s) {
+		return []string{fmt.Sprintf("%s: expected a string matching %q but got %s", path, pattern, toJSON(actual))}
+	}
+	return nil
+}
+
+func (m matcher) matchDocument(path string, expected, actual map[string]interface{}) []string {
+	var mismatches []string
+
+	for _, key := range sortedKeys(expected) {
+		value, ok := actual[key]
+		if !ok {
+			mismatches = append(mismatches, fmt.Sprintf("%s: expected field to be present", joinPath(path, key)))
+			continue
+		}
+		mismatches = append(mismatches, m.Match(joinPath(path, key), expected[key], value)...)
+	}
+
+	if !m.partial {
+		for _, key := range sortedKeys(actual) {
+			if _, ok := expected[key]; !ok {
+				mismatches = append(mismatches, fmt.Sprintf("%s: unexpected field", joinPath(path, key)))
+			}
+		}
+	}
+
+	return mismatches
+}
+
+func (m matcher) matchArray(path string, expected, actual []interface{}) []string {
+	if !m.partial {
+		if len(expected) != len(actual) {
+			return []string{fmt.Sprintf("%s: expected %d elements but got %d", path, len(expected), len(actual))}
+		}
+
+		var mismatches []string
+		for i := range expected {
+			mismatches = append(mismatches, m.Match(fmt.Sprintf("%s[%d]", path, i), expected[i], actual[i])...)
+		}
+		return mismatches
+	}
+
+	var next int
+	for i, e := range expected {
+		found := false
+		for ; next < len(actual); next++ {
+			if len(m.Match("", e, actual[next])) == 0 {
+				found = true
+				next++
+				break
+			}
+		}
+		if !found {
+			return []string{fmt.Sprintf("%s[%d]: expected to contain %s", path, i, toJSON(e))}
+		}
+	}
+	return nil
+}
+
+// toRelaxedEJSON converts a value decoded from canonical Extended JSON into its
+// relaxed Extended JSON representation, so numbers can be compared as plain JSON
+func toRelaxedEJSON(value interface{}) interface{} {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+
+	relaxed, err := toEJSON(parseArgs([]string{string(data)}), false)
+	if err != nil {
+		return value
+	}
+	return relaxed[0]
+}
+
+func regexPattern(doc map[string]interface{}) (string, bool) {
+	if len(doc) != 1 {
+		return "", false
+	}
+	pattern, ok := doc[matcherRegex].(string)
+	return pattern, ok
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+func mismatch(path string, expected, actual interface{}) string {
+	return fmt.Sprintf("%s: expected %s but got %s", path, toJSON(expected), toJSON(actual))
+}
+
+func toJSON(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
+func joinPath(path, key string) string {
+	return path + "." + key
+}
+
+func sortedKeys(doc map[string]interface{}) []string {
+	keys := make([]string, 0, len(doc))
+	for key := range doc {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
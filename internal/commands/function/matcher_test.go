@@ -0,0 +1,97 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestMatcherMatch(t *testing.T) {
+	for _, tc := range []struct {
+		description        string
+		partial            bool
+		expected           interface{}
+		actual             interface{}
+		expectedMismatches []string
+	}{
+		{
+			description: "should match equal values",
+			expected:    map[string]interface{}{"a": "b", "c": []interface{}{true, nil}},
+			actual:      map[string]interface{}{"a": "b", "c": []interface{}{true, nil}},
+		},
+		{
+			description: "should match numbers regardless of their types",
+			expected:    []interface{}{1, 2.5},
+			actual:      []interface{}{float64(1), 2.5},
+		},
+		{
+			description:        "should report mismatched values",
+			expected:           map[string]interface{}{"a": 1, "b": []interface{}{"x"}},
+			actual:             map[string]interface{}{"a": float64(2), "b": "x"},
+			expectedMismatches: []string{"result.a: expected 1 but got 2", `result.b: expected ["x"] but got "x"`},
+		},
+		{
+			description:        "should report missing and unexpected fields",
+			expected:           map[string]interface{}{"a": 1},
+			actual:             map[string]interface{}{"b": float64(1)},
+			expectedMismatches: []string{"result.a: expected field to be present", "result.b: unexpected field"},
+		},
+		{
+			description:        "should report arrays of different lengths",
+			expected:           []interface{}{1},
+			actual:             []interface{}{float64(1), float64(2)},
+			expectedMismatches: []string{"result: expected 1 elements but got 2"},
+		},
+		{
+			description: "should match strings against a regex",
+			expected:    map[string]interface{}{"greeting": map[string]interface{}{"$regex": "^Hello, .+"}},
+			actual:      map[string]interface{}{"greeting": "Hello, eggcorn"},
+		},
+		{
+			description:        "should report strings which do not match a regex",
+			expected:           []interface{}{map[string]interface{}{"$regex": "^Hello"}, map[string]interface{}{"$regex": "^Hello"}},
+			actual:             []interface{}{"Goodbye", float64(1)},
+			expectedMismatches: []string{`result[0]: expected a string matching "^Hello" but got "Goodbye"`, `result[1]: expected a string matching "^Hello" but got 1`},
+		},
+		{
+			description: "should match documents with additional fields when partial",
+			partial:     true,
+			expected:    map[string]interface{}{"a": map[string]interface{}{"b": 1}},
+			actual:      map[string]interface{}{"a": map[string]interface{}{"b": float64(1), "c": float64(2)}, "d": "e"},
+		},
+		{
+			description: "should match arrays which contain the expected elements in order when partial",
+			partial:     true,
+			expected:    []interface{}{"b", map[string]interface{}{"$regex": "^d"}},
+			actual:      []interface{}{"a", "b", "c", "done"},
+		},
+		{
+			description:        "should report arrays which do not contain the expected elements in order when partial",
+			partial:            true,
+			expected:           []interface{}{"c", "b"},
+			actual:             []interface{}{"a", "b", "c"},
+			expectedMismatches: []string{`result[1]: expected to contain "b"`},
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			m := matcher{partial: tc.partial}
+			assert.Equal(t, tc.expectedMismatches, m.Match("result", tc.expected, tc.actual))
+		})
+	}
+}
+
+func TestToRelaxedEJSON(t *testing.T) {
+	assert.Equal(t, map[string]interface{}{
+		"int":    float64(1),
+		"long":   float64(2),
+		"double": 1.5,
+		"oid":    map[string]interface{}{"$oid": "5f5a4e5c1b2c3d4e5f6a7b8c"},
+		"date":   map[string]interface{}{"$date": "2021-01-01T00:00:00Z"},
+	}, toRelaxedEJSON(map[string]interface{}{
+		"int":    map[string]interface{}{"$numberInt": "1"},
+		"long":   map[string]interface{}{"$numberLong": "2"},
+		"double": map[string]interface{}{"$numberDouble": "1.5"},
+		"oid":    map[string]interface{}{"$oid": "5f5a4e5c1b2c3d4e5f6a7b8c"},
+		"date":   map[string]interface{}{"$date": map[string]interface{}{"$numberLong": "1609459200000"}},
+	}))
+}
@@ -0,0 +1,137 @@
+package function
+
+import (
+	"fmt"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/local"
+	"github.com/10gen/realm-cli/internal/terminal"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+)
+
+// CommandMetaPull is the command meta for the `function pull` command
+var CommandMetaPull = cli.CommandMeta{
+	Use:         "pull",
+	Display:     "function pull",
+	Description: "Update a single Function in your local directory from your Realm app",
+	HelpText: `Updates the config and source of one Function in your local Realm app to match
+the deployed Function, without exporting the rest of your app.`,
+}
+
+// CommandPull is the `function pull` command
+type CommandPull struct {
+	inputs pullInputs
+}
+
+type pullInputs struct {
+	cli.ProjectInputs
+	LocalPath string
+	Name      string
+}
+
+// Flags is the command flags
+func (cmd *CommandPull) Flags() []flags.Flag {
+	return []flags.Flag{
+		cli.AppFlagWithContext(&cmd.inputs.App, "to pull its function from"),
+		cli.ProjectFlag(&cmd.inputs.Project),
+		cli.ProductFlag(&cmd.inputs.Products),
+		flags.StringFlag{
+			Value: &cmd.inputs.LocalPath,
+			Meta: flags.Meta{
+				Name: flagLocal,
+				Usage: flags.Usage{
+					Description: "Specify the local filepath of a Realm app to pull the function to",
+					Note:        "Uses the Realm app in the current working directory by default",
+				},
+			},
+		},
+		flags.StringFlag{
+			Value: &cmd.inputs.Name,
+			Meta: flags.Meta{
+				Name:  flagName,
+				Usage: flags.Usage{Description: "Specify the name of the function to pull"},
+			},
+		},
+	}
+}
+
+// Inputs is the command inputs
+func (cmd *CommandPull) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandPull) Handler(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
+	localApp, err := cli.LoadApp(ui, profile, cmd.inputs.LocalPath, false)
+	if err != nil {
+		return err
+	}
+
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	function, err := resolveFunction(ui, clients.Realm, app.GroupID, app.ID, cmd.inputs.Name)
+	if err != nil {
+		return err
+	}
+
+	definition, err := clients.Realm.Function(app.GroupID, app.ID, function.ID)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := localApp.FunctionSourceMap(definition.Name); ok {
+		return fmt.Errorf("cannot pull function '%s' since it is bundled from the local sources at %s", definition.Name, localApp.FunctionSourcePath(definition.Name))
+	}
+
+	var localConfig map[string]interface{}
+	if localFunction, err := findLocalFunction(localApp, definition.Name, ""); err == nil {
+		if !printFunctionDiffs(ui, definition, localFunction) {
+			ui.Print(terminal.NewTextLog("Local function '%s' is identical to the deployed function, nothing to do", definition.Name))
+			return nil
+		}
+		localConfig = localFunction.Config
+	}
+
+	if err := localApp.WriteFunction(local.AppFunction{
+		Name:    definition.Name,
+		Private: definition.Private,
+		Source:  definition.Source,
+		Config:  pulledFunctionConfig(localConfig, definition),
+	}); err != nil {
+		return err
+	}
+
+	ui.Print(terminal.NewTextLog("Successfully pulled function '%s' to %s", definition.Name, localApp.FunctionSourcePath(definition.Name)))
+	return nil
+}
+
+func (i *pullInputs) Resolve(profile *user.Profile, ui terminal.UI) error {
+	localPath, err := resolveLocalFunctionApp(profile.WorkingDirectory, i.LocalPath)
+	if err != nil {
+		return err
+	}
+	i.LocalPath = localPath
+
+	return i.ProjectInputs.Resolve(ui, i.LocalPath, true)
+}
+
+// pulledFunctionConfig replaces the function config fields of the local config with the deployed ones,
+// any other fields of the local config are left as is
+func pulledFunctionConfig(localConfig map[string]interface{}, definition realm.FunctionDefinition) map[string]interface{} {
+	config := make(map[string]interface{}, len(localConfig))
+	for k, v := range localConfig {
+		config[k] = v
+	}
+	for _, field := range configFields {
+		delete(config, field)
+	}
+	for k, v := range functionConfig(definition) {
+		config[k] = v
+	}
+	return config
+}
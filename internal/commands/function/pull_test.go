@@ -0,0 +1,134 @@
+package function
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/local"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestFunctionPullHandler(t *testing.T) {
+	newClient := func(definitions ...realm.FunctionDefinition) mock.RealmClient {
+		rc := mock.RealmClient{}
+		rc.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{ID: "appID", GroupID: "groupID", Name: "test-app"}}, nil
+		}
+		rc.FunctionsFn = func(groupID, appID string) ([]realm.Function, error) {
+			functions := make([]realm.Function, 0, len(definitions))
+			for _, definition := range definitions {
+				functions = append(functions, definition.Function)
+			}
+			return functions, nil
+		}
+		rc.FunctionFn = func(groupID, appID, functionID string) (realm.FunctionDefinition, error) {
+			for _, definition := range definitions {
+				if definition.ID == functionID {
+					return definition, nil
+				}
+			}
+			return realm.FunctionDefinition{}, nil
+		}
+		return rc
+	}
+
+	t.Run("should update the local function to match the deployed function", func(t *testing.T) {
+		localApp, teardown := newLocalFunctionApp(t)
+		defer teardown()
+
+		out, ui := mock.NewUI()
+
+		rc := newClient(realm.FunctionDefinition{
+			Function: realm.Function{ID: "fn1", Name: "greet", Private: true},
+			Source:   "exports = function(name) {\n  return 'hi ' + name;\n};\n",
+		})
+
+		cmd := &CommandPull{pullInputs{
+			ProjectInputs: cli.ProjectInputs{Project: "groupID", App: "test-app"},
+			LocalPath:     localApp.RootDir,
+			Name:          "greet",
+		}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: rc}))
+
+		sourcePath := filepath.Join(localApp.RootDir, local.NameFunctions, "greet.js")
+		assert.Equal(t, `Config differences (deployed -> local)
+  private: true -> null
+  run_as_system: null -> true
+Source differences (deployed -> local)
+@@ -1,3 +1,3 @@
+ exports = function(name) {
+-  return 'hi ' + name;
++  return 'hello ' + name;
+ };
+Successfully pulled function 'greet' to `+sourcePath+"\n", out.String())
+
+		loaded, err := local.LoadApp(localApp.RootDir)
+		assert.Nil(t, err)
+		assert.Equal(t, []local.AppFunction{{
+			Name:    "greet",
+			Private: true,
+			Source:  "exports = function(name) {\n  return 'hi ' + name;\n};\n",
+			Config: map[string]interface{}{
+				"name":             "greet",
+				"private":          true,
+				"disable_arg_logs": false,
+				"run_as_system":    false,
+			},
+		}}, local.AppFunctions(loaded.AppData))
+	})
+
+	t.Run("should add a function which is missing from the local app", func(t *testing.T) {
+		localApp, teardown := newLocalFunctionApp(t)
+		defer teardown()
+
+		out, ui := mock.NewUI()
+
+		rc := newClient(realm.FunctionDefinition{
+			Function: realm.Function{ID: "fn2", Name: "farewell"},
+			Source:   "exports = () => 'bye';",
+		})
+
+		cmd := &CommandPull{pullInputs{
+			ProjectInputs: cli.ProjectInputs{Project: "groupID", App: "test-app"},
+			LocalPath:     localApp.RootDir,
+			Name:          "farewell",
+		}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: rc}))
+
+		sourcePath := filepath.Join(localApp.RootDir, local.NameFunctions, "farewell.js")
+		assert.Equal(t, "Successfully pulled function 'farewell' to "+sourcePath+"\n", out.String())
+
+		src, err := ioutil.ReadFile(sourcePath)
+		assert.Nil(t, err)
+		assert.Equal(t, "exports = () => 'bye';", string(src))
+
+		loaded, err := local.LoadApp(localApp.RootDir)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(local.AppFunctions(loaded.AppData)))
+	})
+
+	t.Run("should not write the local function when it matches the deployed function", func(t *testing.T) {
+		localApp, teardown := newLocalFunctionApp(t)
+		defer teardown()
+
+		out, ui := mock.NewUI()
+
+		rc := newClient(realm.FunctionDefinition{
+			Function: realm.Function{ID: "fn1", Name: "greet", RunAsSystem: true},
+			Source:   "exports = function(name) {\n  return 'hello ' + name;\n};\n",
+		})
+
+		cmd := &CommandPull{pullInputs{
+			ProjectInputs: cli.ProjectInputs{Project: "groupID", App: "test-app"},
+			LocalPath:     localApp.RootDir,
+			Name:          "greet",
+		}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: rc}))
+
+		assert.Equal(t, "Local function 'greet' is identical to the deployed function, nothing to do\n", out.String())
+	})
+}
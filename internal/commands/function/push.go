@@ -0,0 +1,200 @@
+package function
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/local"
+	"github.com/10gen/realm-cli/internal/terminal"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+)
+
+const (
+	flagName = "name"
+	flagFile = "file"
+)
+
+// CommandMetaPush is the command meta for the `function push` command
+var CommandMetaPush = cli.CommandMeta{
+	Use:         "push",
+	Display:     "function push",
+	Description: "Update a single Function of your Realm app from your local directory",
+	HelpText: `Updates the config and source of one deployed Function to match the Function
+in your local Realm app, without importing the rest of your app.
+
+Specify the Function either by its name with "--name" or by its source filepath
+with "--file". The differences between the deployed and local Function are
+displayed before the deployed Function is updated.
+
+Only existing Functions can be updated, use "push" to create new Functions.`,
+}
+
+// CommandPush is the `function push` command
+type CommandPush struct {
+	inputs pushInputs
+}
+
+type pushInputs struct {
+	cli.ProjectInputs
+	LocalPath string
+	Name      string
+	File      string
+}
+
+// Flags is the command flags
+func (cmd *CommandPush) Flags() []flags.Flag {
+	return []flags.Flag{
+		cli.AppFlagWithContext(&cmd.inputs.App, "to push its function to"),
+		cli.ProjectFlag(&cmd.inputs.Project),
+		cli.ProductFlag(&cmd.inputs.Products),
+		flags.StringFlag{
+			Value: &cmd.inputs.LocalPath,
+			Meta: flags.Meta{
+				Name: flagLocal,
+				Usage: flags.Usage{
+					Description: "Specify the local filepath of a Realm app to push the function from",
+					Note:        "Uses the Realm app in the current working directory by default",
+				},
+			},
+		},
+		flags.StringFlag{
+			Value: &cmd.inputs.Name,
+			Meta: flags.Meta{
+				Name:  flagName,
+				Usage: flags.Usage{Description: "Specify the name of the function to push"},
+			},
+		},
+		flags.StringFlag{
+			Value: &cmd.inputs.File,
+			Meta: flags.Meta{
+				Name:  flagFile,
+				Usage: flags.Usage{Description: "Specify the source filepath of the function to push"},
+			},
+		},
+	}
+}
+
+// Inputs is the command inputs
+func (cmd *CommandPush) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandPush) Handler(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
+	localApp, err := cli.LoadApp(ui, profile, cmd.inputs.LocalPath, false)
+	if err != nil {
+		return err
+	}
+
+	localFunction, err := findLocalFunction(localApp, cmd.inputs.Name, cmd.inputs.File)
+	if err != nil {
+		return err
+	}
+
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	functions, err := clients.Realm.Functions(app.GroupID, app.ID)
+	if err != nil {
+		return err
+	}
+
+	var functionID string
+	for _, function := range functions {
+		if function.Name == localFunction.Name {
+			functionID = function.ID
+			break
+		}
+	}
+	if functionID == "" {
+		return fmt.Errorf(`failed to find function '%s' in the deployed app, use "push" to create new functions`, localFunction.Name)
+	}
+
+	definition, err := clients.Realm.Function(app.GroupID, app.ID, functionID)
+	if err != nil {
+		return err
+	}
+
+	if !printFunctionDiffs(ui, definition, localFunction) {
+		ui.Print(terminal.NewTextLog("Deployed function '%s' is identical to the local function, nothing to do", localFunction.Name))
+		return nil
+	}
+
+	proceed, err := ui.Confirm("Would you like to update the deployed function '%s'?", localFunction.Name)
+	if err != nil {
+		return err
+	}
+	if !proceed {
+		return nil
+	}
+
+	update, err := localFunctionDefinition(functionID, localFunction)
+	if err != nil {
+		return err
+	}
+
+	if err := clients.Realm.UpdateFunction(app.GroupID, app.ID, functionID, update); err != nil {
+		return err
+	}
+
+	if err := localApp.WriteSourceMaps(); err != nil {
+		ui.Print(terminal.NewWarningLog("Failed to write the function source maps: %s", err))
+	}
+
+	ui.Print(terminal.NewTextLog("Successfully pushed function '%s'", localFunction.Name))
+	return nil
+}
+
+func (i *pushInputs) Resolve(profile *user.Profile, ui terminal.UI) error {
+	switch {
+	case i.Name != "" && i.File != "":
+		return fmt.Errorf(errDependencyFlagConflictTemplate, flagName, flagFile)
+	case i.Name == "" && i.File == "":
+		return fmt.Errorf(`must specify the function to push with either "%s" or "%s"`, flagName, flagFile)
+	}
+
+	localPath, err := resolveLocalFunctionApp(profile.WorkingDirectory, i.LocalPath)
+	if err != nil {
+		return err
+	}
+	i.LocalPath = localPath
+
+	if i.File != "" && !filepath.IsAbs(i.File) {
+		i.File = filepath.Join(profile.WorkingDirectory, i.File)
+	}
+
+	return i.ProjectInputs.Resolve(ui, i.LocalPath, true)
+}
+
+// resolveLocalFunctionApp finds the root directory of the local Realm app,
+// defaulting to the app in the working directory
+func resolveLocalFunctionApp(wd, path string) (string, error) {
+	if path == "" {
+		path = wd
+	}
+	return resolveLocalAppDir(wd, path)
+}
+
+// localFunctionDefinition converts the local function into the definition of the deployed function
+func localFunctionDefinition(functionID string, fn local.AppFunction) (realm.FunctionDefinition, error) {
+	data, err := json.Marshal(fn.Config)
+	if err != nil {
+		return realm.FunctionDefinition{}, err
+	}
+
+	var definition realm.FunctionDefinition
+	if err := json.Unmarshal(data, &definition); err != nil {
+		return realm.FunctionDefinition{}, fmt.Errorf("failed to parse the config of function '%s': %s", fn.Name, err)
+	}
+
+	definition.ID = functionID
+	definition.Name = fn.Name
+	definition.Source = fn.Source
+	return definition, nil
+}
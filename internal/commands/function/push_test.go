@@ -0,0 +1,198 @@
+package function
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/local"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+// newLocalFunctionApp writes a local Realm app with the greet function to a temporary directory
+func newLocalFunctionApp(t *testing.T) (local.App, func()) {
+	t.Helper()
+
+	tmpDir, teardown, err := u.NewTempDir("")
+	assert.Nil(t, err)
+
+	app := local.NewApp(tmpDir, "test-app-abcde", "test-app", realm.LocationVirginia, realm.DeploymentModelGlobal, realm.EnvironmentNone, realm.AppConfigVersion20210101)
+	assert.Nil(t, app.Write())
+	assert.Nil(t, app.WriteFunction(local.AppFunction{
+		Name:   "greet",
+		Source: "exports = function(name) {\n  return 'hello ' + name;\n};\n",
+		Config: map[string]interface{}{"private": false, "run_as_system": true},
+	}))
+
+	return app, teardown
+}
+
+func TestFunctionPushHandler(t *testing.T) {
+	localApp, teardown := newLocalFunctionApp(t)
+	defer teardown()
+
+	newClient := func(definition realm.FunctionDefinition) mock.RealmClient {
+		rc := mock.RealmClient{}
+		rc.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{ID: "appID", GroupID: "groupID", Name: "test-app"}}, nil
+		}
+		rc.FunctionsFn = func(groupID, appID string) ([]realm.Function, error) {
+			return []realm.Function{definition.Function}, nil
+		}
+		rc.FunctionFn = func(groupID, appID, functionID string) (realm.FunctionDefinition, error) {
+			return definition, nil
+		}
+		return rc
+	}
+
+	for _, tc := range []struct {
+		description string
+		inputs      pushInputs
+	}{
+		{
+			description: "should update the deployed function found by name",
+			inputs:      pushInputs{Name: "greet"},
+		},
+		{
+			description: "should update the deployed function found by source file",
+			inputs:      pushInputs{File: filepath.Join(localApp.RootDir, local.NameFunctions, "greet.js")},
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, out)
+
+			rc := newClient(realm.FunctionDefinition{
+				Function: realm.Function{ID: "fn1", Name: "greet", Private: true},
+				Source:   "exports = function(name) {\n  return 'hi ' + name;\n};\n",
+			})
+
+			var updatedID string
+			var updated realm.FunctionDefinition
+			rc.UpdateFunctionFn = func(groupID, appID, functionID string, function realm.FunctionDefinition) error {
+				updatedID = functionID
+				updated = function
+				return nil
+			}
+
+			tc.inputs.ProjectInputs = cli.ProjectInputs{Project: "groupID", App: "test-app"}
+			tc.inputs.LocalPath = localApp.RootDir
+
+			cmd := &CommandPush{tc.inputs}
+			assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: rc}))
+
+			assert.Equal(t, "fn1", updatedID)
+			assert.Equal(t, realm.FunctionDefinition{
+				Function: realm.Function{ID: "fn1", Name: "greet", RunAsSystem: true},
+				Source:   "exports = function(name) {\n  return 'hello ' + name;\n};\n",
+			}, updated)
+
+			assert.Equal(t, `Config differences (deployed -> local)
+  private: true -> null
+  run_as_system: null -> true
+Source differences (deployed -> local)
+@@ -1,3 +1,3 @@
+ exports = function(name) {
+-  return 'hi ' + name;
++  return 'hello ' + name;
+ };
+Successfully pushed function 'greet'
+`, out.String())
+		})
+	}
+
+	t.Run("should not update the deployed function when it matches the local function", func(t *testing.T) {
+		out := new(bytes.Buffer)
+		ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, out)
+
+		rc := newClient(realm.FunctionDefinition{
+			Function: realm.Function{ID: "fn1", Name: "greet", RunAsSystem: true},
+			Source:   "exports = function(name) {\n  return 'hello ' + name;\n};\n",
+		})
+		rc.UpdateFunctionFn = func(groupID, appID, functionID string, function realm.FunctionDefinition) error {
+			t.Fatal("expected the function to not be updated")
+			return nil
+		}
+
+		cmd := &CommandPush{pushInputs{
+			ProjectInputs: cli.ProjectInputs{Project: "groupID", App: "test-app"},
+			LocalPath:     localApp.RootDir,
+			Name:          "greet",
+		}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: rc}))
+
+		assert.Equal(t, "Deployed function 'greet' is identical to the local function, nothing to do\n", out.String())
+	})
+
+	t.Run("should return an error when the function is not deployed", func(t *testing.T) {
+		_, ui := mock.NewUI()
+
+		rc := newClient(realm.FunctionDefinition{Function: realm.Function{ID: "fn1", Name: "other"}})
+
+		cmd := &CommandPush{pushInputs{
+			ProjectInputs: cli.ProjectInputs{Project: "groupID", App: "test-app"},
+			LocalPath:     localApp.RootDir,
+			Name:          "greet",
+		}}
+		assert.Equal(t,
+			errors.New(`failed to find function 'greet' in the deployed app, use "push" to create new functions`),
+			cmd.Handler(nil, ui, cli.Clients{Realm: rc}),
+		)
+	})
+
+	t.Run("should return an error when the local app does not have the function", func(t *testing.T) {
+		_, ui := mock.NewUI()
+
+		cmd := &CommandPush{pushInputs{
+			ProjectInputs: cli.ProjectInputs{Project: "groupID", App: "test-app"},
+			LocalPath:     localApp.RootDir,
+			File:          filepath.Join(localApp.RootDir, "missing.js"),
+		}}
+		assert.Equal(t,
+			errors.New("failed to find a function with the source "+filepath.Join(localApp.RootDir, "missing.js")+" in the local app at "+localApp.RootDir),
+			cmd.Handler(nil, ui, cli.Clients{}),
+		)
+	})
+}
+
+func TestFunctionPushInputsResolve(t *testing.T) {
+	localApp, teardown := newLocalFunctionApp(t)
+	defer teardown()
+
+	profile := mock.NewProfile(t)
+	profile.WorkingDirectory = localApp.RootDir
+
+	t.Run("should resolve the local app and source file", func(t *testing.T) {
+		i := pushInputs{File: filepath.Join(local.NameFunctions, "greet.js")}
+		assert.Nil(t, i.Resolve(profile, nil))
+
+		assert.Equal(t, localApp.RootDir, i.LocalPath)
+		assert.Equal(t, filepath.Join(localApp.RootDir, local.NameFunctions, "greet.js"), i.File)
+		assert.Equal(t, "test-app-abcde", i.App)
+	})
+
+	for _, tc := range []struct {
+		description string
+		inputs      pushInputs
+		expectedErr error
+	}{
+		{
+			description: "should return an error when neither name nor file is set",
+			expectedErr: errors.New(`must specify the function to push with either "name" or "file"`),
+		},
+		{
+			description: "should return an error when both name and file are set",
+			inputs:      pushInputs{Name: "greet", File: "greet.js"},
+			expectedErr: errors.New(`cannot use both "name" and "file" at the same time`),
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expectedErr, tc.inputs.Resolve(profile, nil))
+		})
+	}
+}
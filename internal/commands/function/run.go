@@ -3,13 +3,18 @@ package function
 import (
 	"encoding/json"
 	"fmt"
-	"strconv"
+	"io/ioutil"
+	"math"
 
 	"github.com/10gen/realm-cli/internal/cli"
 	"github.com/10gen/realm-cli/internal/cli/user"
 	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/jsruntime"
+	"github.com/10gen/realm-cli/internal/local"
 	"github.com/10gen/realm-cli/internal/terminal"
 	"github.com/10gen/realm-cli/internal/utils/flags"
+
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 // CommandMetaRun is the command meta for the `function run` command
@@ -21,7 +26,25 @@ app. Once you select and run a Function for your Realm app, the following will
 be displayed:
   - A list of logs, if present
   - The function result as a document
-  - A list of error logs, if present`,
+  - A list of error logs, if present
+  - The locations in your local Realm app's source files of the error stack
+    traces, if run from within your local Realm app
+
+Args are parsed as Extended JSON, in either its canonical or relaxed format, so
+you can pass values such as ObjectIds, Dates, Longs and Decimal128s. Wrap an
+arg in double quotes to pass it as a string, and any arg which is not valid
+Extended JSON is passed as a string. The args are sent to your Realm app as
+relaxed Extended JSON, use "--args-canonical" to send them as canonical Extended
+JSON instead so numbers keep their types, such as Int32s, Longs and Doubles. The
+args can also be read as a JSON array from a file with "--args-file" or from
+stdin with "--args-stdin", which requires "--name" as the Function cannot be
+selected from a prompt. Use "--result-out" to save the function result as
+Extended JSON to a file.
+
+Use "--local" with the filepath of your local Realm app, such as "--local .",
+to run the Function from your local directory instead, without deploying it
+first. Local Functions can read your local values and execute other local
+Functions, but cannot use services.`,
 }
 
 // CommandRun is the `function run` command
@@ -45,13 +68,70 @@ func (cmd *CommandRun) Flags() []flags.Flag {
 		flags.StringArrayFlag{
 			Value: &cmd.inputs.Args,
 			Meta: flags.Meta{
-				Name: "args",
+				Name: flagArgs,
 				Usage: flags.Usage{
-					Description: "Specify the args to pass to your function",
+					Description: "Specify the args, as Extended JSON, to pass to your function",
 					DocsLink:    "https://docs.mongodb.com/realm/functions/call-a-function/#call-from-realm-cli",
 				},
 			},
 		},
+		flags.StringFlag{
+			Value: &cmd.inputs.ArgsFile,
+			Meta: flags.Meta{
+				Name: flagArgsFile,
+				Usage: flags.Usage{
+					Description: "Specify the filepath of a JSON array of args to pass to your function",
+				},
+			},
+		},
+		flags.BoolFlag{
+			Value: &cmd.inputs.ArgsStdin,
+			Meta: flags.Meta{
+				Name: flagArgsStdin,
+				Usage: flags.Usage{
+					Description: "Read a JSON array of args to pass to your function from stdin",
+					Note:        `Must be used with "--name"`,
+				},
+			},
+		},
+		flags.BoolFlag{
+			Value: &cmd.inputs.ArgsCanonical,
+			Meta: flags.Meta{
+				Name: flagArgsCanonical,
+				Usage: flags.Usage{
+					Description: "Send the args to your function as canonical Extended JSON rather than relaxed",
+				},
+			},
+		},
+		flags.StringFlag{
+			Value: &cmd.inputs.ResultOut,
+			Meta: flags.Meta{
+				Name: "result-out",
+				Usage: flags.Usage{
+					Description: "Save the function result as Extended JSON to the specified filepath",
+				},
+			},
+		},
+		flags.StringFlag{
+			Value: &cmd.inputs.LocalPath,
+			Meta: flags.Meta{
+				Name: flagLocal,
+				Usage: flags.Usage{
+					Description: "Run the function from the local filepath of a Realm app instead of the deployed app",
+					Note:        "Use '.' to run the function from the Realm app in the current working directory",
+				},
+			},
+		},
+		flags.StringFlag{
+			Value: &cmd.inputs.UserData,
+			Meta: flags.Meta{
+				Name: flagUserData,
+				Usage: flags.Usage{
+					Description: "Specify the user data, as a JSON document, of the user a local function runs as",
+					Note:        "Only used with the 'local' flag",
+				},
+			},
+		},
 		flags.StringFlag{
 			Value: &cmd.inputs.User,
 			Meta: flags.Meta{
@@ -74,45 +154,25 @@ func (cmd *CommandRun) Inputs() cli.InputResolver {
 
 // Handler is the command handler
 func (cmd *CommandRun) Handler(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
-	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	args := parseArgs(cmd.inputs.Args)
+
+	if cmd.inputs.LocalPath != "" {
+		return cmd.runLocal(profile, ui, args)
+	}
+
+	remoteArgs, err := toEJSON(args, cmd.inputs.ArgsCanonical)
 	if err != nil {
 		return err
 	}
 
-	function, err := cmd.inputs.resolveFunction(ui, clients.Realm, app.GroupID, app.ID)
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
 	if err != nil {
 		return err
 	}
 
-	args := make([]interface{}, 0, len(cmd.inputs.Args))
-	if cmd.inputs.Args != nil {
-		for _, arg := range cmd.inputs.Args {
-			if isJSON(arg) {
-				var argNew interface{}
-				if err := json.Unmarshal([]byte(arg), &argNew); err != nil {
-					return err
-				}
-				args = append(args, argNew)
-				continue
-			}
-			if isInt(arg) {
-				num, err := strconv.Atoi(arg)
-				if err != nil {
-					return err
-				}
-				args = append(args, num)
-				continue
-			}
-			if isFloat(arg) {
-				num, err := strconv.ParseFloat(arg, 64)
-				if err != nil {
-					return err
-				}
-				args = append(args, num)
-				continue
-			}
-			args = append(args, arg)
-		}
+	function, err := cmd.inputs.resolveFunction(ui, clients.Realm, app.GroupID, app.ID)
+	if err != nil {
+		return err
 	}
 
 	s := ui.Spinner(fmt.Sprintf("Running function %s with args %s...", cmd.inputs.Name, cmd.inputs.Args), terminal.SpinnerOptions{})
@@ -121,46 +181,173 @@ func (cmd *CommandRun) Handler(profile *user.Profile, ui terminal.UI, clients cl
 		s.Start()
 		defer s.Stop()
 
-		return clients.Realm.AppDebugExecuteFunction(app.GroupID, app.ID, cmd.inputs.User, function.Name, args)
+		return clients.Realm.AppDebugExecuteFunction(app.GroupID, app.ID, cmd.inputs.User, function.Name, remoteArgs)
 	}
 
 	response, err := runFunction()
 	if err != nil {
 		return err
 	}
+
+	var locations []interface{}
+	if len(response.ErrorLogs) > 0 {
+		// the error stack traces are mapped to the local app sources when run from within a local app,
+		// using the source maps written when the app was pushed as the deployed function was run
+		if localApp, ok := cli.LoadWorkingDirectoryApp(ui, profile); ok && localApp.ReadSourceMaps() == nil {
+			locations = sourceLocations(localApp, function.Name, response.ErrorLogs)
+		}
+	}
+
+	return cmd.printResults(ui, response, locations)
+}
+
+func (cmd *CommandRun) runLocal(profile *user.Profile, ui terminal.UI, args []interface{}) error {
+	app, err := cli.LoadApp(ui, profile, cmd.inputs.LocalPath, false)
+	if err != nil {
+		return err
+	}
+
+	runtimeUser := jsruntime.User{ID: cmd.inputs.User}
+	if cmd.inputs.UserData != "" {
+		if err := json.Unmarshal([]byte(cmd.inputs.UserData), &runtimeUser.Data); err != nil {
+			return fmt.Errorf("failed to parse user data: %s", err)
+		}
+	}
+
+	runtime := jsruntime.New(app, jsruntime.Options{User: runtimeUser})
+
+	name, err := cmd.inputs.resolveLocalFunction(ui, runtime)
+	if err != nil {
+		return err
+	}
+
+	// the local runtime has no Extended JSON types, so the args are passed as relaxed Extended JSON
+	localArgs, err := toEJSON(args, false)
+	if err != nil {
+		return err
+	}
+
+	response, err := runtime.Execute(name, localArgs)
+	if err != nil {
+		if execErr, ok := err.(jsruntime.ExecutionError); ok && execErr.Stack != "" {
+			response.ErrorLogs = append(response.ErrorLogs, execErr.Stack)
+		}
+		printLogs(ui, response, sourceLocations(app, name, response.ErrorLogs))
+		return err
+	}
+
+	result, err := toEJSON([]interface{}{narrowInts(response.Result)}, true)
+	if err != nil {
+		return err
+	}
+	response.Result = result[0]
+
+	return cmd.printResults(ui, response, sourceLocations(app, name, response.ErrorLogs))
+}
+
+func (cmd *CommandRun) printResults(ui terminal.UI, response realm.ExecutionResults, locations []interface{}) error {
+	printLogs(ui, response, locations)
+	ui.Print(terminal.NewJSONLog("Result", response.Result))
+
+	if cmd.inputs.ResultOut == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(response.Result, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(cmd.inputs.ResultOut, append(data, '\n'), 0666); err != nil {
+		return err
+	}
+	ui.Print(terminal.NewTextLog("Saved result to %s", cmd.inputs.ResultOut))
+	return nil
+}
+
+func printLogs(ui terminal.UI, response realm.ExecutionResults, locations []interface{}) {
 	if response.Logs != nil {
 		ui.Print(terminal.NewListLog("Logs", response.Logs))
 	}
 	if response.ErrorLogs != nil {
 		ui.Print(terminal.NewJSONLog("Error Logs", response.ErrorLogs))
 	}
-	ui.Print(terminal.NewJSONLog("Result", response.Result))
-
-	return nil
+	if len(locations) > 0 {
+		ui.Print(terminal.NewListLog("Local source locations", locations...))
+	}
 }
 
-func isJSON(data string) bool {
-	var obj map[string]interface{}
-	if err := json.Unmarshal([]byte(data), &obj); err == nil {
-		return true
+// sourceLocations maps the function error stack traces to the local app sources,
+// each location is followed by a snippet of its code
+func sourceLocations(app local.App, functionName string, errorLogs []string) []interface{} {
+	var lines []interface{}
+	for _, errorLog := range errorLogs {
+		for _, location := range app.MapStackTrace(functionName, errorLog) {
+			for _, line := range location.Lines() {
+				lines = append(lines, line)
+			}
+		}
 	}
-	var list []interface{}
-	if err := json.Unmarshal([]byte(data), &list); err == nil {
-		return true
+	return lines
+}
+
+// parseArgs parses each arg as Extended JSON, in either its canonical or relaxed format,
+// so that args can be passed as types such as ObjectIds, Dates, Longs and Decimal128s.
+// An arg which is not valid Extended JSON is passed to the function as a string
+func parseArgs(rawArgs []string) []interface{} {
+	args := make([]interface{}, 0, len(rawArgs))
+	for _, rawArg := range rawArgs {
+		var doc bson.D
+		if err := bson.UnmarshalExtJSON([]byte(`{"arg":`+rawArg+`}`), false, &doc); err != nil || len(doc) != 1 {
+			args = append(args, rawArg)
+			continue
+		}
+		args = append(args, doc[0].Value)
 	}
-	return false
+	return args
 }
 
-func isInt(data string) bool {
-	if _, err := strconv.Atoi(data); err != nil {
-		return false
+// toEJSON converts the values to their Extended JSON representation, the canonical format
+// keeps the value types when sent to the server while the relaxed format represents
+// the values as plain JSON where possible
+func toEJSON(values []interface{}, canonical bool) ([]interface{}, error) {
+	out := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		data, err := bson.MarshalExtJSON(bson.D{{Key: "value", Value: value}}, canonical, false)
+		if err != nil {
+			return nil, err
+		}
+
+		var doc struct {
+			Value interface{} `json:"value"`
+		}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+		out = append(out, doc.Value)
 	}
-	return true
+	return out, nil
 }
 
-func isFloat(data string) bool {
-	if _, err := strconv.ParseFloat(data, 64); err != nil {
-		return false
+// narrowInts converts the integers returned by the local runtime into 32-bit integers where they fit,
+// which matches how the server represents the numbers in function results
+func narrowInts(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int64:
+		if v >= math.MinInt32 && v <= math.MaxInt32 {
+			return int32(v)
+		}
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			out[key] = narrowInts(val)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, 0, len(v))
+		for _, val := range v {
+			out = append(out, narrowInts(val))
+		}
+		return out
 	}
-	return true
+	return value
 }
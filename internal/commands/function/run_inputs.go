@@ -1,29 +1,177 @@
 package function
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
 
 	"github.com/10gen/realm-cli/internal/cli"
 	"github.com/10gen/realm-cli/internal/cli/user"
 	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/jsruntime"
+	"github.com/10gen/realm-cli/internal/local"
 	"github.com/10gen/realm-cli/internal/terminal"
 
 	"github.com/AlecAivazis/survey/v2"
 )
 
+const (
+	flagArgs          = "args"
+	flagArgsFile      = "args-file"
+	flagArgsStdin     = "args-stdin"
+	flagArgsCanonical = "args-canonical"
+	flagLocal         = "local"
+	flagUserData      = "user-data"
+
+	errDependencyFlagConflictTemplate = `cannot use both "%s" and "%s" at the same time`
+)
+
 type runInputs struct {
 	cli.ProjectInputs
-	Name string
-	Args []string
-	User string
+	Name          string
+	Args          []string
+	ArgsFile      string
+	ArgsStdin     bool
+	ArgsCanonical bool
+	ResultOut     string
+	User          string
+	LocalPath     string
+	UserData      string
 }
 
 func (i *runInputs) Resolve(profile *user.Profile, ui terminal.UI) error {
-	return i.ProjectInputs.Resolve(ui, profile.WorkingDirectory, true)
+	if err := i.resolveArgs(profile, ui); err != nil {
+		return err
+	}
+
+	if i.ResultOut != "" && !filepath.IsAbs(i.ResultOut) {
+		i.ResultOut = filepath.Join(profile.WorkingDirectory, i.ResultOut)
+	}
+
+	if i.LocalPath == "" {
+		if i.UserData != "" {
+			return fmt.Errorf("cannot use %q without %q", flagUserData, flagLocal)
+		}
+		return i.ProjectInputs.Resolve(ui, profile.WorkingDirectory, true)
+	}
+
+	rootDir, err := resolveLocalAppDir(profile.WorkingDirectory, i.LocalPath)
+	if err != nil {
+		return err
+	}
+
+	i.LocalPath = rootDir
+	return nil
+}
+
+// resolveLocalAppDir returns the root directory of the local Realm app found at the filepath
+func resolveLocalAppDir(wd, path string) (string, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(wd, path)
+	}
+
+	app, _, err := local.FindApp(path)
+	if err != nil {
+		return "", err
+	}
+	if app.RootDir == "" {
+		return "", fmt.Errorf("failed to find a Realm app at %s", path)
+	}
+	return app.RootDir, nil
+}
+
+// resolveArgs reads the JSON array of args from a file or stdin,
+// each element of the array is then parsed like a single "args" flag value
+func (i *runInputs) resolveArgs(profile *user.Profile, ui terminal.UI) error {
+	switch {
+	case len(i.Args) > 0 && i.ArgsFile != "":
+		return fmt.Errorf(errDependencyFlagConflictTemplate, flagArgs, flagArgsFile)
+	case len(i.Args) > 0 && i.ArgsStdin:
+		return fmt.Errorf(errDependencyFlagConflictTemplate, flagArgs, flagArgsStdin)
+	case i.ArgsFile != "" && i.ArgsStdin:
+		return fmt.Errorf(errDependencyFlagConflictTemplate, flagArgsFile, flagArgsStdin)
+	case i.ArgsStdin && i.Name == "":
+		// the function cannot be selected from a prompt once stdin has been read
+		return fmt.Errorf(`must specify the function to run with "name" when using "%s"`, flagArgsStdin)
+	}
+
+	var data []byte
+	switch {
+	case i.ArgsFile != "":
+		path := i.ArgsFile
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(profile.WorkingDirectory, path)
+		}
+
+		fileData, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read args file: %s", err)
+		}
+		data = fileData
+	case i.ArgsStdin:
+		if ui.In() == nil {
+			return errors.New("failed to read args from stdin")
+		}
+
+		stdinData, err := ioutil.ReadAll(ui.In())
+		if err != nil {
+			return fmt.Errorf("failed to read args from stdin: %s", err)
+		}
+		data = stdinData
+	default:
+		return nil
+	}
+
+	var args []json.RawMessage
+	if err := json.Unmarshal(data, &args); err != nil {
+		return fmt.Errorf("args must be a JSON array: %s", err)
+	}
+
+	i.Args = make([]string, 0, len(args))
+	for _, arg := range args {
+		i.Args = append(i.Args, string(arg))
+	}
+	return nil
+}
+
+func (i *runInputs) resolveLocalFunction(ui terminal.UI, runtime *jsruntime.Runtime) (string, error) {
+	if i.Name != "" {
+		if !runtime.HasFunction(i.Name) {
+			return "", fmt.Errorf("failed to find function '%s'", i.Name)
+		}
+		return i.Name, nil
+	}
+
+	names := runtime.FunctionNames()
+	sort.Strings(names)
+
+	switch len(names) {
+	case 0:
+		return "", errors.New("no functions available to run")
+	case 1:
+		return names[0], nil
+	}
+
+	var selection string
+	if err := ui.AskOne(&selection, &survey.Select{
+		Message: "Select Function",
+		Options: names,
+	}); err != nil {
+		return "", fmt.Errorf("failed to select function: %s", err)
+	}
+	return selection, nil
 }
 
 func (i *runInputs) resolveFunction(ui terminal.UI, client realm.Client, groupID, appID string) (realm.Function, error) {
+	return resolveFunction(ui, client, groupID, appID, i.Name)
+}
+
+// resolveFunction finds the named function of the Realm app,
+// or prompts the user to select one if no name is provided
+func resolveFunction(ui terminal.UI, client realm.Client, groupID, appID, name string) (realm.Function, error) {
 	functions, err := client.Functions(groupID, appID)
 	if err != nil {
 		return realm.Function{}, err
@@ -33,13 +181,13 @@ func (i *runInputs) resolveFunction(ui terminal.UI, client realm.Client, groupID
 		return realm.Function{}, errors.New("no functions available to run")
 	}
 
-	if i.Name != "" {
+	if name != "" {
 		for _, function := range functions {
-			if function.Name == i.Name {
+			if function.Name == name {
 				return function, nil
 			}
 		}
-		return realm.Function{}, fmt.Errorf("failed to find function '%s'", i.Name)
+		return realm.Function{}, fmt.Errorf("failed to find function '%s'", name)
 	}
 
 	if len(functions) == 1 {
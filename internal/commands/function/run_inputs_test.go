@@ -1,10 +1,17 @@
 package function
 
 import (
+	"bytes"
 	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/jsruntime"
+	"github.com/10gen/realm-cli/internal/local"
+	u "github.com/10gen/realm-cli/internal/utils/test"
 	"github.com/10gen/realm-cli/internal/utils/test/assert"
 	"github.com/10gen/realm-cli/internal/utils/test/mock"
 	"github.com/AlecAivazis/survey/v2/terminal"
@@ -118,3 +125,145 @@ func TestFunctionRunInputsResolveFunction(t *testing.T) {
 		assert.Equal(t, realm.Function{Name: "func2"}, fn)
 	})
 }
+
+func TestFunctionRunInputsResolve(t *testing.T) {
+	t.Run("should resolve the local path to the app root directory", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		i := runInputs{LocalPath: "testdata/local/values"}
+		assert.Nil(t, i.Resolve(profile, nil))
+
+		assert.Equal(t, filepath.Join(profile.WorkingDirectory, "testdata/local"), i.LocalPath)
+	})
+
+	t.Run("should return an error when the local path is not within an app", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		i := runInputs{LocalPath: "testdata"}
+		assert.Equal(t, errors.New("failed to find a Realm app at "+filepath.Join(profile.WorkingDirectory, "testdata")), i.Resolve(profile, nil))
+	})
+
+	t.Run("should return an error when user data is set without a local path", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		i := runInputs{UserData: "{}"}
+		assert.Equal(t, errors.New(`cannot use "user-data" without "local"`), i.Resolve(profile, nil))
+	})
+}
+
+func TestFunctionRunInputsResolveArgs(t *testing.T) {
+	t.Run("should read the args from a file", func(t *testing.T) {
+		profile, teardown := mock.NewProfileFromTmpDir(t, "function_run_args_test")
+		defer teardown()
+
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(profile.WorkingDirectory, "args.json"), []byte(`[1, "2", {"$oid": "5f5a4e5c1b2c3d4e5f6a7b8c"}]`), 0666))
+
+		i := runInputs{ArgsFile: "args.json"}
+		assert.Nil(t, i.resolveArgs(profile, nil))
+		assert.Equal(t, []string{"1", `"2"`, `{"$oid": "5f5a4e5c1b2c3d4e5f6a7b8c"}`}, i.Args)
+	})
+
+	t.Run("should read the args from stdin", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		ui := mock.NewUIWithOptions(mock.UIOptions{In: strings.NewReader(`[{"$numberLong": "1"}, true]`)}, new(bytes.Buffer))
+
+		i := runInputs{Name: "test", ArgsStdin: true}
+		assert.Nil(t, i.resolveArgs(profile, ui))
+		assert.Equal(t, []string{`{"$numberLong": "1"}`, "true"}, i.Args)
+	})
+
+	t.Run("should return an error when the args are not a json array", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		ui := mock.NewUIWithOptions(mock.UIOptions{In: strings.NewReader(`{"a": 1}`)}, new(bytes.Buffer))
+
+		i := runInputs{Name: "test", ArgsStdin: true}
+		err := i.resolveArgs(profile, ui)
+		assert.True(t, strings.HasPrefix(err.Error(), "args must be a JSON array: "), "unexpected error: %s", err)
+	})
+
+	t.Run("should return an error when the args file cannot be read", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer teardown()
+
+		profile := mock.NewProfile(t)
+
+		i := runInputs{ArgsFile: filepath.Join(tmpDir, "missing.json")}
+		err = i.resolveArgs(profile, nil)
+		assert.True(t, strings.HasPrefix(err.Error(), "failed to read args file: "), "unexpected error: %s", err)
+	})
+
+	for _, tc := range []struct {
+		description string
+		inputs      runInputs
+		expectedErr error
+	}{
+		{
+			description: "args and args file",
+			inputs:      runInputs{Args: []string{"1"}, ArgsFile: "args.json"},
+			expectedErr: errors.New(`cannot use both "args" and "args-file" at the same time`),
+		},
+		{
+			description: "args and args stdin",
+			inputs:      runInputs{Args: []string{"1"}, ArgsStdin: true},
+			expectedErr: errors.New(`cannot use both "args" and "args-stdin" at the same time`),
+		},
+		{
+			description: "args file and args stdin",
+			inputs:      runInputs{ArgsFile: "args.json", ArgsStdin: true},
+			expectedErr: errors.New(`cannot use both "args-file" and "args-stdin" at the same time`),
+		},
+		{
+			description: "args stdin without a function name",
+			inputs:      runInputs{ArgsStdin: true},
+			expectedErr: errors.New(`must specify the function to run with "name" when using "args-stdin"`),
+		},
+	} {
+		t.Run("should return an error when using "+tc.description, func(t *testing.T) {
+			profile := mock.NewProfile(t)
+
+			assert.Equal(t, tc.expectedErr, tc.inputs.Resolve(profile, nil))
+		})
+	}
+}
+
+func TestFunctionRunInputsResolveLocalFunction(t *testing.T) {
+	app, err := local.LoadApp("testdata/local")
+	assert.Nil(t, err)
+
+	runtime := jsruntime.New(app, jsruntime.Options{})
+
+	t.Run("should return an error when no local functions of the specified name are found", func(t *testing.T) {
+		i := runInputs{Name: "missing"}
+
+		_, err := i.resolveLocalFunction(nil, runtime)
+		assert.Equal(t, errors.New("failed to find function 'missing'"), err)
+	})
+
+	t.Run("should prompt the user to select from the local functions if no name is set", func(t *testing.T) {
+		_, console, _, ui, consoleErr := mock.NewVT10XConsole()
+		assert.Nil(t, consoleErr)
+		defer console.Close()
+
+		doneCh := make(chan (struct{}))
+		go func() {
+			defer close(doneCh)
+
+			console.ExpectString("Select Function")
+			console.Send(string(terminal.KeyArrowDown))
+			console.SendLine("")
+			console.ExpectEOF()
+		}()
+
+		var i runInputs
+		name, err := i.resolveLocalFunction(ui, runtime)
+
+		console.Tty().Close() // flush the writers
+		<-doneCh              // wait for procedure to complete
+
+		assert.Nil(t, err)
+		assert.Equal(t, "greet", name)
+	})
+}
@@ -3,12 +3,19 @@ package function
 import (
 	"bytes"
 	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/10gen/realm-cli/internal/cli"
 	"github.com/10gen/realm-cli/internal/cloud/realm"
+	u "github.com/10gen/realm-cli/internal/utils/test"
 	"github.com/10gen/realm-cli/internal/utils/test/assert"
 	"github.com/10gen/realm-cli/internal/utils/test/mock"
+
+	"github.com/spf13/pflag"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 func TestFunctionHandler(t *testing.T) {
@@ -140,6 +147,126 @@ func TestFunctionHandler(t *testing.T) {
 		assert.Equal(t, display, out.String())
 	})
 
+	t.Run("should send the args as relaxed extended json by default", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		rc := mock.RealmClient{}
+		rc.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{ID: "appID", Name: "test-app"}}, nil
+		}
+		rc.FunctionsFn = func(groupID, appID string) ([]realm.Function, error) {
+			return []realm.Function{{Name: "test"}}, nil
+		}
+
+		var capturedArgs []interface{}
+		rc.AppDebugExecuteFunctionFn = func(groupID, appID, userID, name string, args []interface{}) (realm.ExecutionResults, error) {
+			capturedArgs = args
+			return realm.ExecutionResults{}, nil
+		}
+
+		_, ui := mock.NewUI()
+
+		cmd := &CommandRun{runInputs{
+			ProjectInputs: cli.ProjectInputs{
+				Project: "test-project",
+				App:     "test-app",
+			},
+			Name: "test",
+			Args: []string{
+				"1",
+				`{"$oid":"5f5a4e5c1b2c3d4e5f6a7b8c"}`,
+				`{"$date":"2021-01-01T00:00:00Z"}`,
+				`{"$numberLong":"4"}`,
+				`{"nested":[6.5,true]}`,
+			},
+		}}
+		assert.Nil(t, cmd.Handler(profile, ui, cli.Clients{Realm: rc}))
+
+		assert.Equal(t, []interface{}{
+			float64(1),
+			map[string]interface{}{"$oid": "5f5a4e5c1b2c3d4e5f6a7b8c"},
+			map[string]interface{}{"$date": "2021-01-01T00:00:00Z"},
+			float64(4),
+			map[string]interface{}{"nested": []interface{}{6.5, true}},
+		}, capturedArgs)
+	})
+
+	t.Run("should send the args as canonical extended json when specified and save the result to a file", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		tmpDir, teardown, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer teardown()
+
+		rc := mock.RealmClient{}
+		rc.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{ID: "appID", Name: "test-app"}}, nil
+		}
+		rc.FunctionsFn = func(groupID, appID string) ([]realm.Function, error) {
+			return []realm.Function{{Name: "test"}}, nil
+		}
+
+		var capturedArgs []interface{}
+		rc.AppDebugExecuteFunctionFn = func(groupID, appID, userID, name string, args []interface{}) (realm.ExecutionResults, error) {
+			capturedArgs = args
+			return realm.ExecutionResults{Result: map[string]interface{}{"_id": map[string]interface{}{"$oid": "5f5a4e5c1b2c3d4e5f6a7b8c"}}}, nil
+		}
+
+		out, ui := mock.NewUI()
+
+		resultOut := filepath.Join(tmpDir, "result.json")
+
+		cmd := &CommandRun{runInputs{
+			ProjectInputs: cli.ProjectInputs{
+				Project: "test-project",
+				App:     "test-app",
+			},
+			Name: "test",
+			Args: []string{
+				"1",
+				`"2"`,
+				"three",
+				`{"$oid":"5f5a4e5c1b2c3d4e5f6a7b8c"}`,
+				`{"$date":"2021-01-01T00:00:00Z"}`,
+				`{"$numberLong":"4"}`,
+				`{"$numberDecimal":"5.5"}`,
+				`{"nested":[6.5,true]}`,
+			},
+			ArgsCanonical: true,
+			ResultOut:     resultOut,
+		}}
+		assert.Nil(t, cmd.Handler(profile, ui, cli.Clients{Realm: rc}))
+
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"$numberInt": "1"},
+			"2",
+			"three",
+			map[string]interface{}{"$oid": "5f5a4e5c1b2c3d4e5f6a7b8c"},
+			map[string]interface{}{"$date": map[string]interface{}{"$numberLong": "1609459200000"}},
+			map[string]interface{}{"$numberLong": "4"},
+			map[string]interface{}{"$numberDecimal": "5.5"},
+			map[string]interface{}{"nested": []interface{}{map[string]interface{}{"$numberDouble": "6.5"}, true}},
+		}, capturedArgs)
+
+		assert.Equal(t, `Result
+{
+  "_id": {
+    "$oid": "5f5a4e5c1b2c3d4e5f6a7b8c"
+  }
+}
+Saved result to `+resultOut+`
+`, out.String())
+
+		result, err := ioutil.ReadFile(resultOut)
+		assert.Nil(t, err)
+		assert.Equal(t, `{
+  "_id": {
+    "$oid": "5f5a4e5c1b2c3d4e5f6a7b8c"
+  }
+}
+`, string(result))
+	})
+
 	for _, tc := range []struct {
 		description   string
 		realmClient   realm.Client
@@ -200,4 +327,163 @@ func TestFunctionHandler(t *testing.T) {
 			assert.Equal(t, tc.errorExpected, cmd.Handler(profile, ui, clients))
 		})
 	}
+
+	t.Run("should map the error logs to the sources of the local app in the working directory", func(t *testing.T) {
+		wd, err := filepath.Abs(filepath.Join("testdata", "local"))
+		assert.Nil(t, err)
+
+		profile := mock.NewProfile(t)
+		profile.WorkingDirectory = wd
+
+		rc := mock.RealmClient{}
+		rc.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{ID: "appID", Name: "test-app"}}, nil
+		}
+		rc.FunctionsFn = func(groupID, appID string) ([]realm.Function, error) {
+			return []realm.Function{{Name: "fail"}}, nil
+		}
+		rc.AppDebugExecuteFunctionFn = func(groupID, appID, userID, name string, args []interface{}) (realm.ExecutionResults, error) {
+			return realm.ExecutionResults{
+				ErrorLogs: []string{"TypeError: something bad happened\n\tat exports (function.js:3:30(10))\n\tat function_wrapper.js:5:30(18)"},
+			}, nil
+		}
+
+		out, ui := mock.NewUI()
+
+		cmd := &CommandRun{runInputs{
+			ProjectInputs: cli.ProjectInputs{Project: "test-project", App: "test-app"},
+			Name:          "fail",
+		}}
+		assert.Nil(t, cmd.Handler(profile, ui, cli.Clients{Realm: rc}))
+
+		assert.True(t, strings.Contains(out.String(), `Local source locations
+  functions/fail.js:3:30
+    2 |   console.log("about to fail");
+  > 3 |   return context.services.get("mongodb-atlas");
+      |                              ^
+    4 | };
+Result
+`), "expected source locations to be displayed:\n%s", out.String())
+	})
+}
+
+func TestFunctionHandlerLocal(t *testing.T) {
+	t.Run("should run the local function and display its results", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		out, ui := mock.NewUI()
+
+		cmd := &CommandRun{runInputs{
+			LocalPath: "testdata/local",
+			Name:      "greet",
+			Args:      []string{"eggcorn"},
+			User:      "user1",
+			UserData:  `{"email":"user1@example.com"}`,
+		}}
+		assert.Nil(t, cmd.Handler(profile, ui, cli.Clients{}))
+
+		assert.Equal(t, `Logs
+  [greeting eggcorn]
+Result
+{
+  "email": "user1@example.com",
+  "greeting": "Hello, eggcorn",
+  "user": "user1"
+}
+`, out.String())
+	})
+
+	t.Run("should pass the args as relaxed extended json and display the result as canonical extended json", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		out, ui := mock.NewUI()
+
+		cmd := &CommandRun{runInputs{
+			LocalPath: "testdata/local",
+			Name:      "sum",
+			Args:      []string{`{"$numberLong":"3"}`, "4"},
+		}}
+		assert.Nil(t, cmd.Handler(profile, ui, cli.Clients{}))
+
+		assert.Equal(t, `Result
+{
+  "big": {
+    "$numberLong": "30000000000"
+  },
+  "half": {
+    "$numberDouble": "1.5"
+  },
+  "sum": {
+    "$numberInt": "7"
+  }
+}
+`, out.String())
+	})
+
+	t.Run("should display the logs captured before the local function fails", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		out, ui := mock.NewUI()
+
+		cmd := &CommandRun{runInputs{LocalPath: "testdata/local", Name: "fail"}}
+
+		err := cmd.Handler(profile, ui, cli.Clients{})
+		assert.Equal(t, "service 'mongodb-atlas' is not supported when running functions locally", err.Error())
+		assert.True(t, strings.HasPrefix(out.String(), "Logs\n  [about to fail]\nError Logs\n"), "expected logs to be displayed:\n%s", out.String())
+		assert.True(t, strings.Contains(out.String(), "Local source locations\n  functions/fail.js:3:30\n"), "expected source locations to be displayed:\n%s", out.String())
+	})
+
+	t.Run("should return an error when the user data is not a json document", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		_, ui := mock.NewUI()
+
+		cmd := &CommandRun{runInputs{LocalPath: "testdata/local", Name: "greet", UserData: "email"}}
+
+		err := cmd.Handler(profile, ui, cli.Clients{})
+		assert.Equal(t, "failed to parse user data: invalid character 'e' looking for beginning of value", err.Error())
+	})
+}
+
+func TestFunctionRunFlags(t *testing.T) {
+	t.Run("should parse the local filepath provided as a separate value", func(t *testing.T) {
+		cmd := &CommandRun{}
+
+		fs := pflag.NewFlagSet("run", pflag.ContinueOnError)
+		for _, flag := range cmd.Flags() {
+			flag.Register(fs)
+		}
+
+		assert.Nil(t, fs.Parse([]string{"--local", "testdata/local", "--name", "greet"}))
+		assert.Equal(t, "testdata/local", cmd.inputs.LocalPath)
+		assert.Equal(t, 0, fs.NArg())
+	})
+}
+
+func TestFunctionParseArgs(t *testing.T) {
+	oid, err := primitive.ObjectIDFromHex("5f5a4e5c1b2c3d4e5f6a7b8c")
+	assert.Nil(t, err)
+
+	for _, tc := range []struct {
+		description string
+		arg         string
+		expected    interface{}
+	}{
+		{"should parse an int", "1", int32(1)},
+		{"should parse a long", "2147483648", int64(2147483648)},
+		{"should parse a double", "1.5", 1.5},
+		{"should parse a bool", "true", true},
+		{"should parse null", "null", nil},
+		{"should keep a quoted number as a string", `"123"`, "123"},
+		{"should keep invalid extended json as a string", "hello world", "hello world"},
+		{"should keep an arg which is not a single value as a string", `1,"arg":2`, `1,"arg":2`},
+		{"should parse a canonical object id", `{"$oid":"5f5a4e5c1b2c3d4e5f6a7b8c"}`, oid},
+		{"should parse a relaxed date", `{"$date":"2021-01-01T00:00:00Z"}`, primitive.DateTime(1609459200000)},
+		{"should parse a document", `{"a":{"$numberInt":"1"}}`, primitive.D{{Key: "a", Value: int32(1)}}},
+		{"should parse an array", `[1,"two"]`, primitive.A{int32(1), "two"}},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, []interface{}{tc.expected}, parseArgs([]string{tc.arg}))
+		})
+	}
 }
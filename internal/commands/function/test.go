@@ -0,0 +1,336 @@
+package function
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/terminal"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	numTestWorkers = 4
+
+	headerTest     = "Test"
+	headerFunction = "Function"
+	headerStatus   = "Status"
+	headerDetails  = "Details"
+
+	statusPassed = "passed"
+	statusFailed = "failed"
+)
+
+// CommandMetaTest is the command meta for the `function test` command
+var CommandMetaTest = cli.CommandMeta{
+	Use:         "test",
+	Display:     "function test",
+	Description: "Test the Functions of your Realm app against the test cases in your tests directory",
+	HelpText: `Runs each test case found in the JSON and YAML files of your tests directory
+against your Realm app, then displays a summary of the test results. A test file
+holds either a single test case or a list of test cases, such as:
+
+  name: adds two numbers
+  function: sum
+  args: [1, 2]
+  user: <userID>
+  expect:
+    result: {sum: 3}
+    logs: [{$regex: "^adding"}]
+    error_logs: []
+
+Each test case runs the function with the args, as Extended JSON, and as the
+user, or as the System user if no user is set. The args are sent to your Realm
+app as relaxed Extended JSON, the same as "function run" sends them. Only the
+expected "result", "logs" and "error_logs" which are set are checked. Results
+are compared as relaxed Extended JSON, and any expected value of
+{$regex: "<pattern>"} matches a string matching the pattern. Set "partial: true" in the expectation to allow
+documents to contain additional fields and arrays to contain additional elements.
+
+By default, the "tests" directory of your local Realm app is used. Use "--junit"
+to save the test results as a JUnit XML report.`,
+}
+
+// CommandTest is the `function test` command
+type CommandTest struct {
+	inputs testInputs
+}
+
+// Flags is the command flags
+func (cmd *CommandTest) Flags() []flags.Flag {
+	return []flags.Flag{
+		cli.AppFlagWithContext(&cmd.inputs.App, "to test its functions"),
+		cli.ProjectFlag(&cmd.inputs.Project),
+		cli.ProductFlag(&cmd.inputs.Products),
+		flags.StringFlag{
+			Value: &cmd.inputs.TestsDir,
+			Meta: flags.Meta{
+				Name: "tests",
+				Usage: flags.Usage{
+					Description: "Specify the filepath of the directory containing your test cases",
+					Note:        "Uses the tests directory of the Realm app in the current working directory if no filepath is provided",
+				},
+			},
+		},
+		flags.StringFlag{
+			Value: &cmd.inputs.JUnitPath,
+			Meta: flags.Meta{
+				Name: "junit",
+				Usage: flags.Usage{
+					Description: "Save the test results as a JUnit XML report at the specified filepath",
+				},
+			},
+		},
+	}
+}
+
+// Inputs is the command inputs
+func (cmd *CommandTest) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandTest) Handler(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
+	testCases, err := loadTestCases(cmd.inputs.TestsDir)
+	if err != nil {
+		return err
+	}
+
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	s := ui.Spinner(fmt.Sprintf("Running %d function tests...", len(testCases)), terminal.SpinnerOptions{})
+
+	runTests := func() []testResult {
+		s.Start()
+		defer s.Stop()
+
+		return runTestCases(clients.Realm, app.GroupID, app.ID, testCases)
+	}
+
+	results := runTests()
+
+	rows := make([]map[string]interface{}, 0, len(results))
+
+	var failed int
+	for _, result := range results {
+		row := map[string]interface{}{
+			headerTest:     result.testCase.Name,
+			headerFunction: result.testCase.Function,
+			headerStatus:   statusPassed,
+		}
+		if !result.passed() {
+			failed++
+			row[headerStatus] = statusFailed
+			row[headerDetails] = strings.Join(result.failures(), "; ")
+		}
+		rows = append(rows, row)
+	}
+
+	ui.Print(terminal.NewTableLog(
+		fmt.Sprintf("Passed %d of %d function tests", len(results)-failed, len(results)),
+		[]string{headerTest, headerFunction, headerStatus, headerDetails},
+		rows...,
+	))
+
+	if cmd.inputs.JUnitPath != "" {
+		data, err := junitReport(app.Name, results)
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(cmd.inputs.JUnitPath, data, 0666); err != nil {
+			return err
+		}
+		ui.Print(terminal.NewTextLog("Saved JUnit report to %s", cmd.inputs.JUnitPath))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed %d of %d function tests", failed, len(results))
+	}
+	return nil
+}
+
+type testResult struct {
+	testCase   testCase
+	err        error
+	mismatches []string
+	duration   time.Duration
+}
+
+func (r testResult) passed() bool {
+	return r.err == nil && len(r.mismatches) == 0
+}
+
+func (r testResult) failures() []string {
+	if r.err != nil {
+		return []string{r.err.Error()}
+	}
+	return r.mismatches
+}
+
+// runTestCases runs the test cases concurrently, the results are returned in the order of the test cases
+func runTestCases(client realm.Client, groupID, appID string, testCases []testCase) []testResult {
+	results := make([]testResult, len(testCases))
+
+	var wg sync.WaitGroup
+	jobCh := make(chan int)
+
+	for n := 0; n < numTestWorkers; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobCh {
+				results[i] = runTestCase(client, groupID, appID, testCases[i])
+			}
+		}()
+	}
+
+	for i := range testCases {
+		jobCh <- i
+	}
+	close(jobCh)
+
+	wg.Wait()
+	return results
+}
+
+func runTestCase(client realm.Client, groupID, appID string, tc testCase) testResult {
+	result := testResult{testCase: tc}
+
+	args, err := tc.functionArgs()
+	if err != nil {
+		result.err = err
+		return result
+	}
+
+	start := time.Now()
+	response, err := client.AppDebugExecuteFunction(groupID, appID, tc.User, tc.Function, args)
+	result.duration = time.Since(start)
+	if err != nil {
+		result.err = fmt.Errorf("failed to run function: %s", err)
+		return result
+	}
+
+	m := matcher{partial: tc.Expect.Partial}
+
+	for _, expectation := range []struct {
+		path     string
+		expected yaml.Node
+		actual   interface{}
+	}{
+		{"result", tc.Expect.Result, toRelaxedEJSON(response.Result)},
+		{"logs", tc.Expect.Logs, toInterfaces(response.Logs)},
+		{"error_logs", tc.Expect.ErrorLogs, toInterfaces(response.ErrorLogs)},
+	} {
+		if expectation.expected.Kind == 0 {
+			continue
+		}
+
+		var expected interface{}
+		if err := expectation.expected.Decode(&expected); err != nil {
+			result.err = fmt.Errorf("failed to parse expected %s: %s", expectation.path, err)
+			return result
+		}
+
+		result.mismatches = append(result.mismatches, m.Match(expectation.path, expected, expectation.actual)...)
+	}
+
+	return result
+}
+
+func toInterfaces(values []string) []interface{} {
+	out := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		out = append(out, value)
+	}
+	return out
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Error     *junitFailure `xml:"error,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Details string `xml:",chardata"`
+}
+
+// junitReport creates a JUnit XML report of the test results, where each function
+// is reported as a test class and execution errors are reported separately from failed expectations
+func junitReport(name string, results []testResult) ([]byte, error) {
+	suite := junitTestSuite{Name: name, Tests: len(results)}
+
+	var total time.Duration
+	for _, result := range results {
+		total += result.duration
+
+		testCase := junitTestCase{
+			Name:      result.testCase.Name,
+			ClassName: result.testCase.Function,
+			Time:      junitTime(result.duration),
+		}
+
+		switch {
+		case result.err != nil:
+			suite.Errors++
+			testCase.Error = &junitFailure{Message: result.err.Error()}
+		case len(result.mismatches) > 0:
+			suite.Failures++
+			testCase.Failure = &junitFailure{
+				Message: fmt.Sprintf("%d expectations failed", len(result.mismatches)),
+				Details: strings.Join(result.mismatches, "\n"),
+			}
+		}
+
+		suite.Cases = append(suite.Cases, testCase)
+	}
+	suite.Time = junitTime(total)
+
+	data, err := xml.MarshalIndent(junitTestSuites{
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Errors:   suite.Errors,
+		Time:     suite.Time,
+		Suites:   []junitTestSuite{suite},
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
+
+func junitTime(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,170 @@
+package function
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/local"
+	"github.com/10gen/realm-cli/internal/terminal"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	dirTests = "tests"
+)
+
+type testInputs struct {
+	cli.ProjectInputs
+	TestsDir  string
+	JUnitPath string
+}
+
+func (i *testInputs) Resolve(profile *user.Profile, ui terminal.UI) error {
+	if err := i.ProjectInputs.Resolve(ui, profile.WorkingDirectory, true); err != nil {
+		return err
+	}
+
+	if i.TestsDir == "" {
+		app, _, err := local.FindApp(profile.WorkingDirectory)
+		if err != nil {
+			return err
+		}
+
+		rootDir := profile.WorkingDirectory
+		if app.RootDir != "" {
+			rootDir = app.RootDir
+		}
+		i.TestsDir = filepath.Join(rootDir, dirTests)
+	} else if !filepath.IsAbs(i.TestsDir) {
+		i.TestsDir = filepath.Join(profile.WorkingDirectory, i.TestsDir)
+	}
+
+	if info, err := os.Stat(i.TestsDir); err != nil || !info.IsDir() {
+		return fmt.Errorf("failed to find tests directory at %s", i.TestsDir)
+	}
+
+	if i.JUnitPath != "" && !filepath.IsAbs(i.JUnitPath) {
+		i.JUnitPath = filepath.Join(profile.WorkingDirectory, i.JUnitPath)
+	}
+
+	return nil
+}
+
+// testCase is a function test case, where each file in the tests directory
+// holds either a single test case or a list of test cases
+type testCase struct {
+	Name     string        `yaml:"name"`
+	Function string        `yaml:"function"`
+	Args     []interface{} `yaml:"args"`
+	User     string        `yaml:"user"`
+	Expect   testExpect    `yaml:"expect"`
+}
+
+// testExpect is the expected outcome of a function test case,
+// only the values which are set are asserted on
+type testExpect struct {
+	Result    yaml.Node `yaml:"result"`
+	Logs      yaml.Node `yaml:"logs"`
+	ErrorLogs yaml.Node `yaml:"error_logs"`
+	Partial   bool      `yaml:"partial"`
+}
+
+// functionArgs returns the test case args as relaxed Extended JSON, the same as "function run" sends them
+func (tc testCase) functionArgs() ([]interface{}, error) {
+	rawArgs := make([]string, 0, len(tc.Args))
+	for _, arg := range tc.Args {
+		data, err := json.Marshal(arg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse args: %s", err)
+		}
+		rawArgs = append(rawArgs, string(data))
+	}
+	return toEJSON(parseArgs(rawArgs), false)
+}
+
+// loadTestCases reads the test cases from the JSON and YAML files in the tests directory
+func loadTestCases(dir string) ([]testCase, error) {
+	var testCases []testCase
+
+	if err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !local.IsConfigFile(path) {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		fileCases, err := readTestCases(path, filepath.ToSlash(relPath))
+		if err != nil {
+			return err
+		}
+
+		testCases = append(testCases, fileCases...)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if len(testCases) == 0 {
+		return nil, fmt.Errorf("no function tests found in %s", dir)
+	}
+	return testCases, nil
+}
+
+func readTestCases(path, relPath string) ([]testCase, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse test file %s: %s", relPath, err)
+	}
+
+	isList := len(doc.Content) > 0 && doc.Content[0].Kind == yaml.SequenceNode
+
+	var testCases []testCase
+	if isList {
+		err = decodeTestFile(data, &testCases)
+	} else {
+		testCases = make([]testCase, 1)
+		err = decodeTestFile(data, &testCases[0])
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse test file %s: %s", relPath, err)
+	}
+
+	defaultName := strings.TrimSuffix(relPath, filepath.Ext(relPath))
+	for i := range testCases {
+		if testCases[i].Name == "" {
+			testCases[i].Name = defaultName
+			if isList {
+				testCases[i].Name = fmt.Sprintf("%s[%d]", defaultName, i)
+			}
+		}
+		if testCases[i].Function == "" {
+			return nil, fmt.Errorf("test '%s' in %s must specify a function", testCases[i].Name, relPath)
+		}
+	}
+	return testCases, nil
+}
+
+func decodeTestFile(data []byte, out interface{}) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	return decoder.Decode(out)
+}
@@ -0,0 +1,45 @@
+package function
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestFunctionTestInputsResolve(t *testing.T) {
+	t.Run("should use the tests directory of the local app by default", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+		profile.WorkingDirectory = filepath.Join(profile.WorkingDirectory, "testdata", "local", "values")
+
+		i := testInputs{}
+		assert.Nil(t, i.Resolve(profile, nil))
+
+		assert.Equal(t, "local-abcde", i.App)
+		assert.Equal(t, filepath.Join(filepath.Dir(profile.WorkingDirectory), dirTests), i.TestsDir)
+	})
+
+	t.Run("should resolve the tests directory and junit report relative to the working directory", func(t *testing.T) {
+		profile, teardown := mock.NewProfileFromTmpDir(t, "function_test_inputs")
+		defer teardown()
+
+		assert.Nil(t, os.MkdirAll(filepath.Join(profile.WorkingDirectory, "checks"), os.ModePerm))
+
+		i := testInputs{TestsDir: "checks", JUnitPath: "report.xml"}
+		assert.Nil(t, i.Resolve(profile, nil))
+
+		assert.Equal(t, filepath.Join(profile.WorkingDirectory, "checks"), i.TestsDir)
+		assert.Equal(t, filepath.Join(profile.WorkingDirectory, "report.xml"), i.JUnitPath)
+	})
+
+	t.Run("should return an error when the tests directory does not exist", func(t *testing.T) {
+		profile, teardown := mock.NewProfileFromTmpDir(t, "function_test_inputs")
+		defer teardown()
+
+		i := testInputs{}
+		assert.Equal(t, errors.New("failed to find tests directory at "+filepath.Join(profile.WorkingDirectory, dirTests)), i.Resolve(profile, nil))
+	})
+}
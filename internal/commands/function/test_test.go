@@ -0,0 +1,192 @@
+package function
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestFunctionTestHandler(t *testing.T) {
+	setup := func(t *testing.T, files map[string]string) (string, func()) {
+		t.Helper()
+
+		profile, teardown := mock.NewProfileFromTmpDir(t, "function_test_handler")
+
+		testsDir := filepath.Join(profile.WorkingDirectory, dirTests)
+		for path, contents := range files {
+			assert.Nil(t, os.MkdirAll(filepath.Dir(filepath.Join(testsDir, path)), os.ModePerm))
+			assert.Nil(t, ioutil.WriteFile(filepath.Join(testsDir, path), []byte(contents), 0666))
+		}
+		return testsDir, teardown
+	}
+
+	newRealmClient := func() (*[]string, mock.RealmClient) {
+		var mu sync.Mutex
+		var calls []string
+
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{ID: "appID", GroupID: "groupID", Name: "test-app"}}, nil
+		}
+		realmClient.AppDebugExecuteFunctionFn = func(groupID, appID, userID, name string, args []interface{}) (realm.ExecutionResults, error) {
+			mu.Lock()
+			calls = append(calls, name+" "+userID+" "+toJSON(args))
+			mu.Unlock()
+
+			switch name {
+			case "sum":
+				return realm.ExecutionResults{
+					Result: map[string]interface{}{"sum": map[string]interface{}{"$numberInt": "3"}, "at": map[string]interface{}{"$numberLong": "1"}},
+					Logs:   []string{"adding 1 and 2"},
+				}, nil
+			case "greet":
+				return realm.ExecutionResults{Result: "Hello, eggcorn"}, nil
+			}
+			return realm.ExecutionResults{}, errors.New("function not found")
+		}
+		return &calls, realmClient
+	}
+
+	t.Run("should run the test cases and display a summary of the results", func(t *testing.T) {
+		testsDir, teardown := setup(t, map[string]string{
+			"sum.yaml": `name: adds two numbers
+function: sum
+args: [1, {$numberLong: "2"}]
+expect:
+  result: {sum: 3}
+  logs: [{$regex: "^adding"}]
+  partial: true
+`,
+			"nested/greet.json": `[
+  {"function": "greet", "user": "user1", "args": ["eggcorn"], "expect": {"result": {"$regex": "eggcorn$"}, "error_logs": []}}
+]`,
+			"README.md": "not a test",
+		})
+		defer teardown()
+
+		calls, realmClient := newRealmClient()
+
+		out, ui := mock.NewUI()
+
+		cmd := &CommandTest{testInputs{
+			ProjectInputs: cli.ProjectInputs{Project: "groupID", App: "test-app"},
+			TestsDir:      testsDir,
+		}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		sort.Strings(*calls)
+		assert.Equal(t, []string{
+			`greet user1 ["eggcorn"]`,
+			`sum  [1,2]`,
+		}, *calls)
+
+		assert.Equal(t, "Passed 2 of 2 function tests\n"+
+			"  Test              Function  Status  Details\n"+
+			"  ----------------  --------  ------  -------\n"+
+			"  nested/greet[0]   greet     passed         \n"+
+			"  adds two numbers  sum       passed         \n", out.String())
+	})
+
+	t.Run("should report the failed test cases and save a junit report", func(t *testing.T) {
+		testsDir, teardown := setup(t, map[string]string{
+			"tests.yml": `- name: wrong sum
+  function: sum
+  expect:
+    result: {sum: 4}
+    logs: []
+- name: missing
+  function: missing
+- name: greets
+  function: greet
+`,
+		})
+		defer teardown()
+
+		_, realmClient := newRealmClient()
+
+		out, ui := mock.NewUI()
+
+		junitPath := filepath.Join(filepath.Dir(testsDir), "report.xml")
+
+		cmd := &CommandTest{testInputs{
+			ProjectInputs: cli.ProjectInputs{Project: "groupID", App: "test-app"},
+			TestsDir:      testsDir,
+			JUnitPath:     junitPath,
+		}}
+		assert.Equal(t, errors.New("failed 2 of 3 function tests"), cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, "Passed 1 of 3 function tests\n"+
+			"  Test       Function  Status  Details                                                                                           \n"+
+			"  ---------  --------  ------  --------------------------------------------------------------------------------------------------\n"+
+			"  wrong sum  sum       failed  result.sum: expected 4 but got 3; result.at: unexpected field; logs: expected 0 elements but got 1\n"+
+			"  missing    missing   failed  failed to run function: function not found                                                        \n"+
+			"  greets     greet     passed                                                                                                    \n"+
+			"Saved JUnit report to "+junitPath+"\n", out.String())
+
+		report, err := ioutil.ReadFile(junitPath)
+		assert.Nil(t, err)
+
+		assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="3" failures="1" errors="1" time="">
+  <testsuite name="test-app" tests="3" failures="1" errors="1" time="">
+    <testcase name="wrong sum" classname="sum" time="">
+      <failure message="3 expectations failed">result.sum: expected 4 but got 3&#xA;result.at: unexpected field&#xA;logs: expected 0 elements but got 1</failure>
+    </testcase>
+    <testcase name="missing" classname="missing" time="">
+      <error message="failed to run function: function not found"></error>
+    </testcase>
+    <testcase name="greets" classname="greet" time=""></testcase>
+  </testsuite>
+</testsuites>
+`, regexp.MustCompile(`time="[0-9.]+"`).ReplaceAllString(string(report), `time=""`))
+	})
+
+	t.Run("should return an error when a test file is invalid", func(t *testing.T) {
+		for _, tc := range []struct {
+			description string
+			contents    string
+			expectedErr error
+		}{
+			{
+				description: "with an unknown field",
+				contents:    "function: sum\nexpected: {}\n",
+				expectedErr: errors.New("failed to parse test file sum.yaml: yaml: unmarshal errors:\n  line 2: field expected not found in type function.testCase"),
+			},
+			{
+				description: "without a function",
+				contents:    "name: adds two numbers\n",
+				expectedErr: errors.New("test 'adds two numbers' in sum.yaml must specify a function"),
+			},
+		} {
+			t.Run(tc.description, func(t *testing.T) {
+				testsDir, teardown := setup(t, map[string]string{"sum.yaml": tc.contents})
+				defer teardown()
+
+				_, ui := mock.NewUI()
+
+				cmd := &CommandTest{testInputs{TestsDir: testsDir}}
+				assert.Equal(t, tc.expectedErr, cmd.Handler(nil, ui, cli.Clients{}))
+			})
+		}
+	})
+
+	t.Run("should return an error when there are no test cases", func(t *testing.T) {
+		testsDir, teardown := setup(t, map[string]string{"README.md": "no tests here"})
+		defer teardown()
+
+		_, ui := mock.NewUI()
+
+		cmd := &CommandTest{testInputs{TestsDir: testsDir}}
+		assert.Equal(t, errors.New("no function tests found in "+testsDir), cmd.Handler(nil, ui, cli.Clients{}))
+	})
+}
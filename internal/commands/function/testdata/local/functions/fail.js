@@ -0,0 +1,4 @@
+exports = function() {
+  console.log("about to fail");
+  return context.services.get("mongodb-atlas");
+};
@@ -0,0 +1,8 @@
+exports = function(name) {
+  console.log("greeting", name);
+  return {
+    greeting: context.values.get("greeting") + ", " + name,
+    user: context.user.id,
+    email: context.user.data.email
+  };
+};
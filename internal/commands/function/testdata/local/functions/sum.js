@@ -0,0 +1,3 @@
+exports = function(a, b) {
+  return {sum: a + b, big: a * 1e10, half: a / 2};
+};
@@ -0,0 +1,259 @@
+package logs
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/terminal"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// set of supported log export formats
+const (
+	exportFormatNDJSON = "ndjson"
+	exportFormatCSV    = "csv"
+)
+
+// exportCSVHeaders are the columns of the exported CSV file, one per log field
+var exportCSVHeaders = []string{
+	"id",
+	"started",
+	"completed",
+	"type",
+	"messages",
+	"error",
+	"error_code",
+	"mem_time_usage",
+	"auth_event_failed",
+	"auth_event_type",
+	"auth_event_provider",
+	"event_subscription_id",
+	"event_subscription_name",
+	"function_id",
+	"function_name",
+	"incoming_webhook_id",
+	"incoming_webhook_name",
+}
+
+// CommandMetaExport is the command meta for the `logs export` command
+var CommandMetaExport = cli.CommandMeta{
+	Use:         "export",
+	Display:     "logs export",
+	Description: "Export the Logs in your Realm app to a file",
+	HelpText: `Writes every Log within the "--start" and "--end" dates to the file specified by
+"--out", with every field of each Log included. Logs are written one page at a
+time starting from the most recent page.
+
+Use "--format ndjson" to write one JSON document per line, or "--format csv" to
+write a CSV file with a header row where the Log messages are a JSON array.`,
+}
+
+// CommandExport is the `logs export` command
+type CommandExport struct {
+	inputs exportInputs
+}
+
+type exportInputs struct {
+	cli.ProjectInputs
+	Types  []string
+	Errors bool
+	Start  flags.Date
+	End    flags.Date
+	Format string
+	Out    string
+}
+
+// Flags is the command flags
+func (cmd *CommandExport) Flags() []flags.Flag {
+	return []flags.Flag{
+		cli.AppFlagWithContext(&cmd.inputs.App, "to export its logs"),
+		cli.ProjectFlag(&cmd.inputs.Project),
+		cli.ProductFlag(&cmd.inputs.Products),
+		logTypesFlag(&cmd.inputs.Types),
+		errorsFlag(&cmd.inputs.Errors),
+		startFlag(&cmd.inputs.Start, "Specify when to begin exporting logs"),
+		endFlag(&cmd.inputs.End, "Specify when to finish exporting logs"),
+		flags.StringFlag{
+			Value:        &cmd.inputs.Format,
+			DefaultValue: exportFormatNDJSON,
+			Meta: flags.Meta{
+				Name: "format",
+				Usage: flags.Usage{
+					Description:   "Specify the format of the exported logs",
+					AllowedValues: []string{exportFormatNDJSON, exportFormatCSV},
+				},
+			},
+		},
+		flags.StringFlag{
+			Value: &cmd.inputs.Out,
+			Meta: flags.Meta{
+				Name: "out",
+				Usage: flags.Usage{
+					Description: "Specify the filepath to write the logs to",
+				},
+			},
+		},
+	}
+}
+
+// Inputs is the command inputs
+func (cmd *CommandExport) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandExport) Handler(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(cmd.inputs.Out)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w := newLogsWriter(file, cmd.inputs.Format)
+
+	var count int
+	if _, err := clients.Realm.LogsPages(
+		app.GroupID,
+		app.ID,
+		realm.LogsOptions{
+			Types:      realmLogTypes(cmd.inputs.Types),
+			ErrorsOnly: cmd.inputs.Errors,
+			Start:      cmd.inputs.Start.Time,
+			End:        cmd.inputs.End.Time,
+		},
+		func(logs realm.Logs) error {
+			for _, log := range logs {
+				if err := w.write(log); err != nil {
+					return err
+				}
+				count++
+			}
+			return nil
+		},
+	); err != nil {
+		return err
+	}
+
+	if err := w.flush(); err != nil {
+		return err
+	}
+
+	ui.Print(terminal.NewTextLog("Successfully exported %d logs to %s", count, cmd.inputs.Out))
+	return nil
+}
+
+func (i *exportInputs) Resolve(profile *user.Profile, ui terminal.UI) error {
+	switch i.Format {
+	case exportFormatNDJSON, exportFormatCSV:
+	default:
+		return fmt.Errorf("unsupported format: %s", i.Format)
+	}
+
+	if i.Out == "" {
+		if err := ui.AskOne(&i.Out, &survey.Input{Message: "Logs filepath", Default: "logs." + i.Format}); err != nil {
+			return err
+		}
+	}
+
+	if !filepath.IsAbs(i.Out) {
+		i.Out = filepath.Join(profile.WorkingDirectory, i.Out)
+	}
+
+	return i.ProjectInputs.Resolve(ui, profile.WorkingDirectory, true)
+}
+
+// logsWriter writes each log to the exported file as it is fetched
+type logsWriter interface {
+	write(log realm.Log) error
+	flush() error
+}
+
+func newLogsWriter(w io.Writer, format string) logsWriter {
+	if format == exportFormatCSV {
+		return &csvLogsWriter{w: csv.NewWriter(w)}
+	}
+	buf := bufio.NewWriter(w)
+	return ndjsonLogsWriter{buf, json.NewEncoder(buf)}
+}
+
+type ndjsonLogsWriter struct {
+	buf     *bufio.Writer
+	encoder *json.Encoder
+}
+
+func (w ndjsonLogsWriter) write(log realm.Log) error {
+	return w.encoder.Encode(log)
+}
+
+func (w ndjsonLogsWriter) flush() error {
+	return w.buf.Flush()
+}
+
+type csvLogsWriter struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+func (w *csvLogsWriter) write(log realm.Log) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	messages, err := json.Marshal(log.Messages)
+	if err != nil {
+		return err
+	}
+
+	return w.w.Write([]string{
+		log.ID,
+		log.Started.Format(time.RFC3339Nano),
+		log.Completed.Format(time.RFC3339Nano),
+		log.Type,
+		string(messages),
+		log.Error,
+		log.ErrorCode,
+		strconv.FormatInt(log.MemTimeUsage, 10),
+		strconv.FormatBool(log.AuthEvent.Failed),
+		log.AuthEvent.Type,
+		log.AuthEvent.Provider,
+		log.EventSubscriptionID,
+		log.EventSubscriptionName,
+		log.FunctionID,
+		log.FunctionName,
+		log.IncomingWebhookID,
+		log.IncomingWebhookName,
+	})
+}
+
+func (w *csvLogsWriter) flush() error {
+	if err := w.writeHeader(); err != nil { // the header is still written when there are no logs
+		return err
+	}
+	w.w.Flush()
+	return w.w.Error()
+}
+
+func (w *csvLogsWriter) writeHeader() error {
+	if w.headerWritten {
+		return nil
+	}
+	w.headerWritten = true
+	return w.w.Write(exportCSVHeaders)
+}
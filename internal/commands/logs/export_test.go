@@ -0,0 +1,204 @@
+package logs
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestLogsExportHandler(t *testing.T) {
+	testPages := []realm.Logs{
+		{
+			{
+				ID:           "log0",
+				Type:         realm.LogTypeFunction,
+				Messages:     []interface{}{"a test log message", map[string]interface{}{"count": 1}},
+				Started:      time.Date(2021, time.June, 22, 7, 54, 43, 0, time.UTC),
+				Completed:    time.Date(2021, time.June, 22, 7, 54, 43, 234_000_000, time.UTC),
+				MemTimeUsage: 1024,
+				FunctionID:   "func-id",
+				FunctionName: "func0",
+			},
+		},
+		{
+			{
+				ID:        "log1",
+				Type:      realm.LogTypeAuth,
+				Error:     "something bad happened",
+				ErrorCode: "AuthError",
+				Started:   time.Date(2021, time.June, 22, 7, 54, 42, 0, time.UTC),
+				Completed: time.Date(2021, time.June, 22, 7, 54, 42, 5_000_000, time.UTC),
+				AuthEvent: realm.LogAuthEvent{Failed: true, Type: "login", Provider: "local-userpass"},
+			},
+			{
+				ID:                    "log2",
+				Type:                  realm.LogTypeDBTrigger,
+				Started:               time.Date(2021, time.June, 22, 7, 54, 41, 0, time.UTC),
+				Completed:             time.Date(2021, time.June, 22, 7, 54, 41, 5_000_000, time.UTC),
+				EventSubscriptionID:   "trigger-id",
+				EventSubscriptionName: "trigger0",
+				IncomingWebhookID:     "webhook-id",
+				IncomingWebhookName:   "webhook0",
+			},
+		},
+	}
+
+	setup := func() (mock.RealmClient, *realm.LogsOptions) {
+		var logsOpts realm.LogsOptions
+
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{GroupID: "groupID", ID: "appID"}}, nil
+		}
+		realmClient.LogsPagesFn = func(groupID, appID string, opts realm.LogsOptions, handlePage func(page realm.Logs) error) (bool, error) {
+			logsOpts = opts
+			for _, page := range testPages {
+				if err := handlePage(page); err != nil {
+					return false, err
+				}
+			}
+			return false, nil
+		}
+		return realmClient, &logsOpts
+	}
+
+	t.Run("should write every field of each log as ndjson", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer teardown()
+
+		realmClient, logsOpts := setup()
+
+		out, ui := mock.NewUI()
+
+		outPath := filepath.Join(tmpDir, "logs.ndjson")
+
+		cmd := &CommandExport{exportInputs{
+			ProjectInputs: cli.ProjectInputs{Project: "project", App: "test-app"},
+			Types:         []string{logTypeFunction},
+			Errors:        true,
+			Start:         flags.Date{Time: time.Date(2021, time.June, 22, 0, 0, 0, 0, time.UTC)},
+			Format:        exportFormatNDJSON,
+			Out:           outPath,
+		}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, realm.LogsOptions{
+			Types:      []string{realm.LogTypeFunction},
+			ErrorsOnly: true,
+			Start:      time.Date(2021, time.June, 22, 0, 0, 0, 0, time.UTC),
+		}, *logsOpts)
+		assert.Equal(t, "Successfully exported 3 logs to "+outPath+"\n", out.String())
+
+		data, err := ioutil.ReadFile(outPath)
+		assert.Nil(t, err)
+		assert.Equal(t, `{"_id":"log0","messages":["a test log message",{"count":1}],"type":"FUNCTION","started":"2021-06-22T07:54:43Z","completed":"2021-06-22T07:54:43.234Z","mem_time_usage":1024,"error":"","error_code":"","auth_event":{"failed":false,"type":"","provider":""},"event_subscription_id":"","event_subscription_name":"","function_id":"func-id","function_name":"func0","incoming_webhook_id":"","incoming_webhook_name":""}
+{"_id":"log1","messages":null,"type":"AUTH","started":"2021-06-22T07:54:42Z","completed":"2021-06-22T07:54:42.005Z","mem_time_usage":0,"error":"something bad happened","error_code":"AuthError","auth_event":{"failed":true,"type":"login","provider":"local-userpass"},"event_subscription_id":"","event_subscription_name":"","function_id":"","function_name":"","incoming_webhook_id":"","incoming_webhook_name":""}
+{"_id":"log2","messages":null,"type":"DB_TRIGGER","started":"2021-06-22T07:54:41Z","completed":"2021-06-22T07:54:41.005Z","mem_time_usage":0,"error":"","error_code":"","auth_event":{"failed":false,"type":"","provider":""},"event_subscription_id":"trigger-id","event_subscription_name":"trigger0","function_id":"","function_name":"","incoming_webhook_id":"webhook-id","incoming_webhook_name":"webhook0"}
+`, string(data))
+	})
+
+	t.Run("should write every field of each log as csv", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer teardown()
+
+		realmClient, _ := setup()
+
+		out, ui := mock.NewUI()
+
+		outPath := filepath.Join(tmpDir, "logs.csv")
+
+		cmd := &CommandExport{exportInputs{
+			ProjectInputs: cli.ProjectInputs{Project: "project", App: "test-app"},
+			Format:        exportFormatCSV,
+			Out:           outPath,
+		}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, "Successfully exported 3 logs to "+outPath+"\n", out.String())
+
+		data, err := ioutil.ReadFile(outPath)
+		assert.Nil(t, err)
+		assert.Equal(t, `id,started,completed,type,messages,error,error_code,mem_time_usage,auth_event_failed,auth_event_type,auth_event_provider,event_subscription_id,event_subscription_name,function_id,function_name,incoming_webhook_id,incoming_webhook_name
+log0,2021-06-22T07:54:43Z,2021-06-22T07:54:43.234Z,FUNCTION,"[""a test log message"",{""count"":1}]",,,1024,false,,,,,func-id,func0,,
+log1,2021-06-22T07:54:42Z,2021-06-22T07:54:42.005Z,AUTH,null,something bad happened,AuthError,0,true,login,local-userpass,,,,,,
+log2,2021-06-22T07:54:41Z,2021-06-22T07:54:41.005Z,DB_TRIGGER,null,,,0,false,,,trigger-id,trigger0,,,webhook-id,webhook0
+`, string(data))
+	})
+
+	t.Run("should write only the csv header when there are no logs", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer teardown()
+
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{}}, nil
+		}
+		realmClient.LogsPagesFn = func(groupID, appID string, opts realm.LogsOptions, handlePage func(page realm.Logs) error) (bool, error) {
+			return false, handlePage(realm.Logs{})
+		}
+
+		out, ui := mock.NewUI()
+
+		outPath := filepath.Join(tmpDir, "logs.csv")
+
+		cmd := &CommandExport{exportInputs{Format: exportFormatCSV, Out: outPath}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, "Successfully exported 0 logs to "+outPath+"\n", out.String())
+
+		data, err := ioutil.ReadFile(outPath)
+		assert.Nil(t, err)
+		assert.Equal(t, "id,started,completed,type,messages,error,error_code,mem_time_usage,auth_event_failed,auth_event_type,auth_event_provider,event_subscription_id,event_subscription_name,function_id,function_name,incoming_webhook_id,incoming_webhook_name\n", string(data))
+	})
+
+	t.Run("should return an error when the client fails to get logs", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer teardown()
+
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{}}, nil
+		}
+		realmClient.LogsPagesFn = func(groupID, appID string, opts realm.LogsOptions, handlePage func(page realm.Logs) error) (bool, error) {
+			return false, errors.New("something bad happened")
+		}
+
+		cmd := &CommandExport{exportInputs{Format: exportFormatNDJSON, Out: filepath.Join(tmpDir, "logs.ndjson")}}
+
+		err = cmd.Handler(nil, nil, cli.Clients{Realm: realmClient})
+		assert.Equal(t, errors.New("something bad happened"), err)
+	})
+}
+
+func TestLogsExportInputs(t *testing.T) {
+	projectInputs := cli.ProjectInputs{Project: "project", App: "test-app"}
+
+	t.Run("should return an error with an unsupported format", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		inputs := exportInputs{ProjectInputs: projectInputs, Format: "xml", Out: "logs.xml"}
+		assert.Equal(t, errors.New("unsupported format: xml"), inputs.Resolve(profile, nil))
+	})
+
+	t.Run("should resolve the out filepath relative to the working directory", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+		profile.WorkingDirectory = "/path/to/wd"
+
+		inputs := exportInputs{ProjectInputs: projectInputs, Format: exportFormatCSV, Out: "logs.csv"}
+		assert.Nil(t, inputs.Resolve(profile, nil))
+		assert.Equal(t, filepath.Join("/path/to/wd", "logs.csv"), inputs.Out)
+	})
+}
@@ -0,0 +1,78 @@
+package logs
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+)
+
+// logFilter filters logs by the criteria the Realm server is unable to filter by
+type logFilter struct {
+	function    string
+	trigger     string
+	webhook     string
+	errorCode   string
+	pattern     *regexp.Regexp
+	minDuration time.Duration
+}
+
+// isEmpty reports whether every log matches the filter
+func (f logFilter) isEmpty() bool {
+	return f.function == "" &&
+		f.trigger == "" &&
+		f.webhook == "" &&
+		f.errorCode == "" &&
+		f.pattern == nil &&
+		f.minDuration == 0
+}
+
+// apply returns the logs which match the filter
+func (f logFilter) apply(logs realm.Logs) realm.Logs {
+	if f.isEmpty() {
+		return logs
+	}
+
+	filtered := make(realm.Logs, 0, len(logs))
+	for _, log := range logs {
+		if f.matches(log) {
+			filtered = append(filtered, log)
+		}
+	}
+	return filtered
+}
+
+func (f logFilter) matches(log realm.Log) bool {
+	if f.function != "" && log.FunctionName != f.function && log.FunctionID != f.function {
+		return false
+	}
+	if f.trigger != "" && log.EventSubscriptionName != f.trigger && log.EventSubscriptionID != f.trigger {
+		return false
+	}
+	if f.webhook != "" && log.IncomingWebhookName != f.webhook && log.IncomingWebhookID != f.webhook {
+		return false
+	}
+	if f.errorCode != "" && log.ErrorCode != f.errorCode {
+		return false
+	}
+	if f.minDuration > 0 && log.Completed.Sub(log.Started) < f.minDuration {
+		return false
+	}
+	if f.pattern != nil && !f.matchesPattern(log) {
+		return false
+	}
+	return true
+}
+
+func (f logFilter) matchesPattern(log realm.Log) bool {
+	if log.Error != "" && f.pattern.MatchString(log.Error) {
+		return true
+	}
+	for _, message := range log.Messages {
+		if f.pattern.MatchString(fmt.Sprint(message)) {
+			return true
+		}
+	}
+	return false
+}
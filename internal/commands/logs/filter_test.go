@@ -0,0 +1,67 @@
+package logs
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestLogFilter(t *testing.T) {
+	started := time.Date(2021, time.June, 22, 7, 54, 42, 0, time.UTC)
+
+	functionLog := realm.Log{
+		Type:         realm.LogTypeFunction,
+		Messages:     []interface{}{"processing order", 42},
+		Started:      started,
+		Completed:    started.Add(250 * time.Millisecond),
+		FunctionID:   "func-id",
+		FunctionName: "processOrder",
+	}
+	triggerLog := realm.Log{
+		Type:                  realm.LogTypeDBTrigger,
+		Started:               started,
+		Completed:             started.Add(2 * time.Second),
+		Error:                 "connection timed out",
+		ErrorCode:             "FunctionExecutionError",
+		EventSubscriptionID:   "trigger-id",
+		EventSubscriptionName: "onOrder",
+		FunctionName:          "handleOrder",
+	}
+	webhookLog := realm.Log{
+		Type:                realm.LogTypeWebhook,
+		Started:             started,
+		Completed:           started.Add(time.Second),
+		IncomingWebhookID:   "webhook-id",
+		IncomingWebhookName: "orders",
+	}
+
+	logs := realm.Logs{functionLog, triggerLog, webhookLog}
+
+	for _, tc := range []struct {
+		description string
+		filter      logFilter
+		expected    realm.Logs
+	}{
+		{"no criteria", logFilter{}, logs},
+		{"a function name", logFilter{function: "processOrder"}, realm.Logs{functionLog}},
+		{"a function id", logFilter{function: "func-id"}, realm.Logs{functionLog}},
+		{"a trigger name", logFilter{trigger: "onOrder"}, realm.Logs{triggerLog}},
+		{"a trigger id", logFilter{trigger: "trigger-id"}, realm.Logs{triggerLog}},
+		{"a webhook name", logFilter{webhook: "orders"}, realm.Logs{webhookLog}},
+		{"a webhook id", logFilter{webhook: "webhook-id"}, realm.Logs{webhookLog}},
+		{"an error code", logFilter{errorCode: "FunctionExecutionError"}, realm.Logs{triggerLog}},
+		{"a pattern matching a message", logFilter{pattern: regexp.MustCompile("^processing")}, realm.Logs{functionLog}},
+		{"a pattern matching a non-string message", logFilter{pattern: regexp.MustCompile("^42$")}, realm.Logs{functionLog}},
+		{"a pattern matching an error", logFilter{pattern: regexp.MustCompile("timed? out")}, realm.Logs{triggerLog}},
+		{"a min duration", logFilter{minDuration: time.Second}, realm.Logs{triggerLog, webhookLog}},
+		{"multiple criteria", logFilter{minDuration: time.Second, errorCode: "FunctionExecutionError"}, realm.Logs{triggerLog}},
+		{"criteria no log matches", logFilter{function: "missing"}, realm.Logs{}},
+	} {
+		t.Run("should filter logs with "+tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.filter.apply(logs))
+		})
+	}
+}
@@ -0,0 +1,497 @@
+package logs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+)
+
+const (
+	forwardTargetFile      = "file://"
+	forwardTargetSyslog    = "syslog"
+	forwardTargetSyslogUDP = "syslog+udp"
+	forwardTargetSyslogTCP = "syslog+tcp"
+	forwardTargetHTTP      = "http"
+	forwardTargetHTTPS     = "https"
+
+	defaultForwardMaxSize = 100 // megabytes
+	defaultForwardMaxAge  = 24 * time.Hour
+
+	defaultForwardMaxBackups = 10
+
+	// forwardRotatedFormat is the time format appended to the name of a rotated file
+	forwardRotatedFormat = "20060102T150405.000"
+
+	defaultSyslogPort = "514"
+
+	// syslog priority is the facility times 8 plus the severity, logs are sent with the local0 facility
+	syslogFacilityLocal0 = 16
+	syslogSeverityError  = 3
+	syslogSeverityInfo   = 6
+
+	mediaTypeNDJSON = "application/x-ndjson"
+)
+
+var (
+	// forwardBatchSize is the max number of logs sent to the forward targets at once
+	forwardBatchSize = 100
+
+	// forwardBufferSize is the max number of logs waiting to be forwarded, any more logs are dropped
+	forwardBufferSize = 10000
+
+	// forwardFlushInterval is how often the logs waiting to be forwarded are sent when there is not a full batch
+	forwardFlushInterval = time.Second
+
+	// forwardMaxRetries is the number of times a batch is retried before it is given up on
+	forwardMaxRetries = 3
+
+	forwardRetryBackoff = time.Second
+	forwardTimeout      = 10 * time.Second
+)
+
+// forwardedLog is the structured record of a log sent to the forward targets
+type forwardedLog struct {
+	AppID       string `json:"app_id"`
+	ClientAppID string `json:"client_app_id"`
+	realm.Log
+}
+
+// logSink is a target logs are forwarded to
+type logSink interface {
+	// send returns how many of the records were sent, even when it fails,
+	// so a retry only sends the records which were not sent yet
+	send(records []forwardedLog) (int, error)
+	close() error
+	String() string
+}
+
+// parseLogSink parses the forward target, either a syslog, HTTP or file target
+func parseLogSink(workingDirectory, target string, maxSize int64, maxAge time.Duration, maxBackups int) (logSink, error) {
+	if strings.HasPrefix(target, forwardTargetFile) {
+		path := strings.TrimPrefix(target, forwardTargetFile)
+		if path == "" {
+			return nil, fmt.Errorf("invalid forward target: %s", target)
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(workingDirectory, path)
+		}
+		return &fileSink{path: path, maxSize: maxSize, maxAge: maxAge, maxBackups: maxBackups}, nil
+	}
+
+	u, err := url.Parse(target)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid forward target: %s", target)
+	}
+
+	switch u.Scheme {
+	case forwardTargetHTTP, forwardTargetHTTPS:
+		return &httpSink{url: target, client: &http.Client{Timeout: forwardTimeout}}, nil
+	case forwardTargetSyslog, forwardTargetSyslogUDP, forwardTargetSyslogTCP:
+		network := "udp"
+		if u.Scheme == forwardTargetSyslogTCP {
+			network = "tcp"
+		}
+
+		port := u.Port()
+		if port == "" {
+			port = defaultSyslogPort
+		}
+
+		hostname, err := os.Hostname()
+		if err != nil || hostname == "" {
+			hostname = "-"
+		}
+
+		return &syslogSink{
+			target:   target,
+			network:  network,
+			address:  net.JoinHostPort(u.Hostname(), port),
+			hostname: hostname,
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported forward target: %s", target)
+}
+
+// logForwarder sends the tailed logs to the forward targets in the background,
+// batching the logs and retrying the failed batches
+type logForwarder struct {
+	app     realm.App
+	sinks   []logSink
+	records chan forwardedLog
+	done    chan struct{}
+
+	mu       sync.Mutex
+	failures []string
+	dropped  int
+}
+
+func newLogForwarder(app realm.App, sinks []logSink) *logForwarder {
+	f := &logForwarder{
+		app:     app,
+		sinks:   sinks,
+		records: make(chan forwardedLog, forwardBufferSize),
+		done:    make(chan struct{}),
+	}
+	go f.run()
+	return f
+}
+
+// forward queues the logs to be forwarded, dropping the logs which do not fit in the buffer
+func (f *logForwarder) forward(logs realm.Logs) {
+	for _, log := range logs {
+		select {
+		case f.records <- forwardedLog{f.app.ID, f.app.ClientAppID, log}:
+		default:
+			f.mu.Lock()
+			f.dropped++
+			f.mu.Unlock()
+		}
+	}
+}
+
+// warnings returns the problems forwarding logs since the last call
+func (f *logForwarder) warnings() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	warnings := f.failures
+	if f.dropped > 0 {
+		warnings = append(warnings, fmt.Sprintf("Dropped %d logs as too many logs were waiting to be forwarded", f.dropped))
+	}
+
+	f.failures = nil
+	f.dropped = 0
+	return warnings
+}
+
+// close sends the logs waiting to be forwarded, then closes the forward targets
+func (f *logForwarder) close() []string {
+	close(f.records)
+	<-f.done
+
+	for _, sink := range f.sinks {
+		if err := sink.close(); err != nil {
+			f.fail("Failed to close forward target %s: %s", sink, err)
+		}
+	}
+	return f.warnings()
+}
+
+func (f *logForwarder) run() {
+	defer close(f.done)
+
+	ticker := time.NewTicker(forwardFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]forwardedLog, 0, forwardBatchSize)
+	for {
+		select {
+		case record, ok := <-f.records:
+			if !ok {
+				f.send(batch)
+				return
+			}
+			batch = append(batch, record)
+			if len(batch) < forwardBatchSize {
+				continue
+			}
+		case <-ticker.C:
+		}
+
+		f.send(batch)
+		batch = batch[:0]
+	}
+}
+
+func (f *logForwarder) send(batch []forwardedLog) {
+	if len(batch) == 0 {
+		return
+	}
+
+	for _, sink := range f.sinks {
+		unsent := batch
+
+		var err error
+		for attempt := 0; attempt <= forwardMaxRetries; attempt++ {
+			if attempt > 0 {
+				time.Sleep(forwardRetryBackoff * time.Duration(1<<(attempt-1)))
+			}
+
+			var n int
+			n, err = sink.send(unsent)
+			unsent = unsent[n:]
+			if err == nil {
+				break
+			}
+		}
+		if err != nil {
+			f.fail("Failed to forward %d logs to %s: %s", len(unsent), sink, err)
+		}
+	}
+}
+
+func (f *logForwarder) fail(format string, args ...interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failures = append(f.failures, fmt.Sprintf(format, args...))
+}
+
+// syslogSink sends each log as an RFC 5424 message, framed with its length over TCP,
+// a message only partially written before a failure is cut off by reconnecting and resent whole
+type syslogSink struct {
+	target   string
+	network  string
+	address  string
+	hostname string
+	conn     net.Conn
+}
+
+func (s *syslogSink) String() string { return s.target }
+
+func (s *syslogSink) send(records []forwardedLog) (int, error) {
+	if s.conn == nil {
+		conn, err := net.DialTimeout(s.network, s.address, forwardTimeout)
+		if err != nil {
+			return 0, err
+		}
+		s.conn = conn
+	}
+
+	if err := s.conn.SetWriteDeadline(time.Now().Add(forwardTimeout)); err != nil {
+		return 0, s.reset(err)
+	}
+
+	for i, record := range records {
+		msg, err := syslogMessage(s.hostname, record)
+		if err != nil {
+			return i, err
+		}
+		if s.network == "tcp" {
+			msg = append([]byte(fmt.Sprintf("%d ", len(msg))), msg...)
+		}
+		if _, err := s.conn.Write(msg); err != nil {
+			return i, s.reset(err)
+		}
+	}
+	return len(records), nil
+}
+
+// reset closes the connection after a failure so the next send reconnects
+func (s *syslogSink) reset(err error) error {
+	s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+func (s *syslogSink) close() error {
+	if s.conn == nil {
+		return nil
+	}
+	return s.conn.Close()
+}
+
+func syslogMessage(hostname string, record forwardedLog) ([]byte, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+
+	severity := syslogSeverityInfo
+	if record.Error != "" {
+		severity = syslogSeverityError
+	}
+
+	return []byte(fmt.Sprintf(
+		"<%d>1 %s %s %s - %s - %s",
+		syslogFacilityLocal0*8+severity,
+		record.Started.UTC().Format("2006-01-02T15:04:05.000000Z07:00"),
+		hostname,
+		syslogValue(record.ClientAppID),
+		syslogValue(record.Type),
+		data,
+	)), nil
+}
+
+func syslogValue(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// httpSink posts each batch of logs as NDJSON, a failed batch is resent whole
+// as the endpoint may have received it before failing to respond
+type httpSink struct {
+	url    string
+	client *http.Client
+}
+
+func (s *httpSink) String() string { return s.url }
+
+func (s *httpSink) send(records []forwardedLog) (int, error) {
+	data, err := ndjson(records)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := s.client.Post(s.url, mediaTypeNDJSON, bytes.NewReader(data))
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return 0, fmt.Errorf("unexpected status code %d", res.StatusCode)
+	}
+	return len(records), nil
+}
+
+func (s *httpSink) close() error { return nil }
+
+// fileSink appends the logs as NDJSON to a file, which is rotated once it
+// would grow past its max size or has been written to for longer than its max age,
+// keeping only the most recent max backups rotated files
+type fileSink struct {
+	path       string
+	maxSize    int64
+	maxAge     time.Duration
+	maxBackups int
+	file       *os.File
+	size       int64
+	opened     time.Time
+}
+
+func (s *fileSink) String() string { return forwardTargetFile + s.path }
+
+// send writes the whole batch or none of it, a partial write is truncated
+// so the retried batch does not duplicate the logs which were written
+func (s *fileSink) send(records []forwardedLog) (int, error) {
+	data, err := ndjson(records)
+	if err != nil {
+		return 0, err
+	}
+
+	if s.file == nil {
+		if err := s.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	now := logsClock.Now()
+	if s.size > 0 && (s.size+int64(len(data)) > s.maxSize || now.Sub(s.opened) >= s.maxAge) {
+		if err := s.rotate(now); err != nil {
+			return 0, err
+		}
+	}
+
+	if _, err := s.file.Write(data); err != nil {
+		if truncateErr := s.file.Truncate(s.size); truncateErr != nil {
+			// the file is reopened by the next send to find out how much was written
+			s.file.Close()
+			s.file = nil
+		}
+		return 0, err
+	}
+	s.size += int64(len(data))
+	return len(records), nil
+}
+
+func (s *fileSink) open() error {
+	file, err := os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0666)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	s.file = file
+	s.size = info.Size()
+	s.opened = logsClock.Now()
+	return nil
+}
+
+// rotate renames the file with the time it was rotated, removes the oldest rotated files
+// past the max backups and starts a new file
+func (s *fileSink) rotate(now time.Time) error {
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+	s.file = nil
+
+	if err := os.Rename(s.path, fmt.Sprintf("%s.%s", s.path, now.UTC().Format(forwardRotatedFormat))); err != nil {
+		return err
+	}
+	if err := s.prune(); err != nil {
+		return err
+	}
+	return s.open()
+}
+
+// prune removes the oldest rotated files, keeping the most recent max backups
+func (s *fileSink) prune() error {
+	dir, name := filepath.Split(s.path)
+
+	entries, err := ioutil.ReadDir(filepath.Clean(dir))
+	if err != nil {
+		return err
+	}
+
+	var rotated []string
+	for _, entry := range entries {
+		suffix := strings.TrimPrefix(entry.Name(), name+".")
+		if entry.IsDir() || suffix == entry.Name() {
+			continue
+		}
+		if _, err := time.Parse(forwardRotatedFormat, suffix); err != nil {
+			continue
+		}
+		rotated = append(rotated, entry.Name())
+	}
+
+	if len(rotated) <= s.maxBackups {
+		return nil
+	}
+
+	// the rotated time format sorts the oldest files first
+	sort.Strings(rotated)
+	for _, file := range rotated[:len(rotated)-s.maxBackups] {
+		if err := os.Remove(filepath.Join(dir, file)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *fileSink) close() error {
+	if s.file == nil {
+		return nil
+	}
+	return s.file.Close()
+}
+
+func ndjson(records []forwardedLog) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,509 @@
+package logs
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/api"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestParseLogSink(t *testing.T) {
+	hostname, err := os.Hostname()
+	assert.Nil(t, err)
+
+	for _, tc := range []struct {
+		target       string
+		expectedSink []interface{}
+	}{
+		{
+			target:       "file://logs.ndjson",
+			expectedSink: []interface{}{"file", filepath.Join("/working/dir", "logs.ndjson"), int64(1024), time.Hour, 3},
+		},
+		{
+			target:       "file:///var/log/realm.ndjson",
+			expectedSink: []interface{}{"file", "/var/log/realm.ndjson", int64(1024), time.Hour, 3},
+		},
+		{
+			target:       "https://example.com/logs",
+			expectedSink: []interface{}{"http", "https://example.com/logs", forwardTimeout},
+		},
+		{
+			target:       "syslog://localhost",
+			expectedSink: []interface{}{"syslog", "syslog://localhost", "udp", "localhost:514", hostname},
+		},
+		{
+			target:       "syslog+udp://localhost:1514",
+			expectedSink: []interface{}{"syslog", "syslog+udp://localhost:1514", "udp", "localhost:1514", hostname},
+		},
+		{
+			target:       "syslog+tcp://localhost:6514",
+			expectedSink: []interface{}{"syslog", "syslog+tcp://localhost:6514", "tcp", "localhost:6514", hostname},
+		},
+	} {
+		t.Run("should parse the target "+tc.target, func(t *testing.T) {
+			sink, err := parseLogSink("/working/dir", tc.target, 1024, time.Hour, 3)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedSink, logSinkFields(sink))
+		})
+	}
+
+	for _, tc := range []struct {
+		target string
+		err    error
+	}{
+		{"file://", errors.New("invalid forward target: file://")},
+		{"localhost:514", errors.New("invalid forward target: localhost:514")},
+		{"ftp://example.com", errors.New("unsupported forward target: ftp://example.com")},
+	} {
+		t.Run("should return an error with the target "+tc.target, func(t *testing.T) {
+			_, err := parseLogSink("/working/dir", tc.target, 1024, time.Hour, 3)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+// logSinkFields describes the sink by its fields, as they are unexported
+func logSinkFields(sink logSink) []interface{} {
+	switch s := sink.(type) {
+	case *fileSink:
+		return []interface{}{"file", s.path, s.maxSize, s.maxAge, s.maxBackups}
+	case *httpSink:
+		return []interface{}{"http", s.url, s.client.Timeout}
+	case *syslogSink:
+		return []interface{}{"syslog", s.target, s.network, s.address, s.hostname}
+	}
+	return nil
+}
+
+// sendRecords sends the records to the sink, expecting every record to be sent
+func sendRecords(t *testing.T, sink logSink, records []forwardedLog) {
+	t.Helper()
+	n, err := sink.send(records)
+	assert.Nil(t, err)
+	assert.Equal(t, len(records), n)
+}
+
+var testForwardedLogs = []forwardedLog{
+	{
+		AppID:       "app-id",
+		ClientAppID: "test-app-abcde",
+		Log: realm.Log{
+			ID:        "log0",
+			Type:      realm.LogTypeFunction,
+			Started:   time.Date(2021, time.June, 22, 7, 54, 42, 123000000, time.UTC),
+			Completed: time.Date(2021, time.June, 22, 7, 54, 42, 223000000, time.UTC),
+			Messages:  []interface{}{"hello"},
+		},
+	},
+	{
+		AppID:       "app-id",
+		ClientAppID: "test-app-abcde",
+		Log: realm.Log{
+			ID:        "log1",
+			Type:      realm.LogTypeAuth,
+			Started:   time.Date(2021, time.June, 22, 7, 54, 43, 0, time.UTC),
+			Completed: time.Date(2021, time.June, 22, 7, 54, 43, 0, time.UTC),
+			Error:     "denied",
+		},
+	},
+}
+
+func TestSyslogMessage(t *testing.T) {
+	msg, err := syslogMessage("host", testForwardedLogs[0])
+	assert.Nil(t, err)
+
+	data, err := json.Marshal(testForwardedLogs[0])
+	assert.Nil(t, err)
+
+	assert.Equal(t, "<134>1 2021-06-22T07:54:42.123000Z host test-app-abcde - FUNCTION - "+string(data), string(msg))
+
+	t.Run("should send errors with the error severity", func(t *testing.T) {
+		msg, err := syslogMessage("host", testForwardedLogs[1])
+		assert.Nil(t, err)
+		assert.True(t, strings.HasPrefix(string(msg), "<131>1 2021-06-22T07:54:43.000000Z host test-app-abcde - AUTH - "), "expected an error message but got: %s", msg)
+	})
+}
+
+func TestSyslogSink(t *testing.T) {
+	t.Run("should send each log in its own datagram over udp", func(t *testing.T) {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		assert.Nil(t, err)
+		defer conn.Close()
+
+		sink := &syslogSink{network: "udp", address: conn.LocalAddr().String(), hostname: "host"}
+		defer sink.close()
+
+		sendRecords(t, sink, testForwardedLogs)
+
+		for _, record := range testForwardedLogs {
+			expected, err := syslogMessage("host", record)
+			assert.Nil(t, err)
+
+			buf := make([]byte, 4096)
+			assert.Nil(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+			n, _, err := conn.ReadFrom(buf)
+			assert.Nil(t, err)
+			assert.Equal(t, string(expected), string(buf[:n]))
+		}
+	})
+
+	t.Run("should frame each log with its length over tcp", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.Nil(t, err)
+		defer listener.Close()
+
+		received := make(chan []string, 1)
+		go func() {
+			conn, err := listener.Accept()
+			if err != nil {
+				received <- nil
+				return
+			}
+			defer conn.Close()
+
+			r := bufio.NewReader(conn)
+
+			var msgs []string
+			for range testForwardedLogs {
+				length, err := r.ReadString(' ')
+				if err != nil {
+					break
+				}
+				n, err := strconv.Atoi(strings.TrimSpace(length))
+				if err != nil {
+					break
+				}
+				msg := make([]byte, n)
+				if _, err := io.ReadFull(r, msg); err != nil {
+					break
+				}
+				msgs = append(msgs, string(msg))
+			}
+			received <- msgs
+		}()
+
+		sink := &syslogSink{network: "tcp", address: listener.Addr().String(), hostname: "host"}
+		sendRecords(t, sink, testForwardedLogs)
+		assert.Nil(t, sink.close())
+
+		var expected []string
+		for _, record := range testForwardedLogs {
+			msg, err := syslogMessage("host", record)
+			assert.Nil(t, err)
+			expected = append(expected, string(msg))
+		}
+		assert.Equal(t, expected, <-received)
+	})
+}
+
+func TestHTTPSink(t *testing.T) {
+	t.Run("should post each batch as ndjson", func(t *testing.T) {
+		var contentType, body string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			data, err := ioutil.ReadAll(r.Body)
+			assert.Nil(t, err)
+
+			contentType = r.Header.Get(api.HeaderContentType)
+			body = string(data)
+		}))
+		defer server.Close()
+
+		sink := &httpSink{url: server.URL, client: &http.Client{}}
+		sendRecords(t, sink, testForwardedLogs)
+
+		expected, err := ndjson(testForwardedLogs)
+		assert.Nil(t, err)
+
+		assert.Equal(t, mediaTypeNDJSON, contentType)
+		assert.Equal(t, string(expected), body)
+		assert.Equal(t, 2, strings.Count(body, "\n"))
+	})
+
+	t.Run("should return an error when the endpoint does not succeed", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		sink := &httpSink{url: server.URL, client: &http.Client{}}
+		n, err := sink.send(testForwardedLogs)
+		assert.Equal(t, errors.New("unexpected status code 503"), err)
+		assert.Equal(t, 0, n)
+	})
+}
+
+func TestFileSink(t *testing.T) {
+	now := time.Date(2021, time.June, 22, 7, 54, 40, 0, time.UTC)
+
+	record0, err := ndjson(testForwardedLogs[:1])
+	assert.Nil(t, err)
+	record1, err := ndjson(testForwardedLogs[1:])
+	assert.Nil(t, err)
+
+	t.Run("should rotate the file once it would grow past its max size", func(t *testing.T) {
+		_, teardown := setupFakeClock(now)
+		defer teardown()
+
+		tmpDir, teardownTmpDir, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer teardownTmpDir()
+
+		path := filepath.Join(tmpDir, "logs.ndjson")
+
+		sink := &fileSink{path: path, maxSize: int64(len(record0) + 1), maxAge: time.Hour, maxBackups: 1}
+		sendRecords(t, sink, testForwardedLogs[:1])
+		sendRecords(t, sink, testForwardedLogs[1:])
+		assert.Nil(t, sink.close())
+
+		rotated, err := ioutil.ReadFile(path + ".20210622T075440.000")
+		assert.Nil(t, err)
+		assert.Equal(t, string(record0), string(rotated))
+
+		current, err := ioutil.ReadFile(path)
+		assert.Nil(t, err)
+		assert.Equal(t, string(record1), string(current))
+	})
+
+	t.Run("should rotate the file once it has been written to for longer than its max age", func(t *testing.T) {
+		clock, teardown := setupFakeClock(now)
+		defer teardown()
+
+		tmpDir, teardownTmpDir, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer teardownTmpDir()
+
+		path := filepath.Join(tmpDir, "logs.ndjson")
+
+		sink := &fileSink{path: path, maxSize: 1024 * 1024, maxAge: time.Hour, maxBackups: 1}
+		sendRecords(t, sink, testForwardedLogs[:1])
+
+		clock.Time = now.Add(30 * time.Minute)
+		sendRecords(t, sink, testForwardedLogs[1:])
+
+		clock.Time = now.Add(time.Hour)
+		sendRecords(t, sink, testForwardedLogs[:1])
+		assert.Nil(t, sink.close())
+
+		rotated, err := ioutil.ReadFile(path + ".20210622T085440.000")
+		assert.Nil(t, err)
+		assert.Equal(t, string(record0)+string(record1), string(rotated))
+
+		current, err := ioutil.ReadFile(path)
+		assert.Nil(t, err)
+		assert.Equal(t, string(record0), string(current))
+	})
+
+	t.Run("should only keep the most recent max backups rotated files", func(t *testing.T) {
+		clock, teardown := setupFakeClock(now)
+		defer teardown()
+
+		tmpDir, teardownTmpDir, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer teardownTmpDir()
+
+		path := filepath.Join(tmpDir, "logs.ndjson")
+		assert.Nil(t, ioutil.WriteFile(path+".bak", []byte("keep me"), 0666))
+
+		sink := &fileSink{path: path, maxSize: 1, maxAge: time.Hour, maxBackups: 2}
+		for i := 0; i < 4; i++ {
+			clock.Time = now.Add(time.Duration(i) * time.Second)
+			sendRecords(t, sink, testForwardedLogs[:1])
+		}
+		assert.Nil(t, sink.close())
+
+		files, err := ioutil.ReadDir(tmpDir)
+		assert.Nil(t, err)
+
+		var names []string
+		for _, file := range files {
+			names = append(names, file.Name())
+		}
+		assert.Equal(t, []string{
+			"logs.ndjson",
+			"logs.ndjson.20210622T075442.000",
+			"logs.ndjson.20210622T075443.000",
+			"logs.ndjson.bak",
+		}, names)
+	})
+}
+
+// partialSink sends only the first record of its first batch before failing
+type partialSink struct {
+	recordingSink
+	failed bool
+}
+
+func (s *partialSink) send(records []forwardedLog) (int, error) {
+	if !s.failed {
+		s.failed = true
+		s.batches = append(s.batches, append([]forwardedLog{}, records[:1]...))
+		return 1, errors.New("something bad happened")
+	}
+	return s.recordingSink.send(records)
+}
+
+type recordingSink struct {
+	batches [][]forwardedLog
+	errs    []error
+}
+
+func (s *recordingSink) String() string { return "recording" }
+
+func (s *recordingSink) send(records []forwardedLog) (int, error) {
+	if len(s.errs) > 0 {
+		err := s.errs[0]
+		s.errs = s.errs[1:]
+		return 0, err
+	}
+	s.batches = append(s.batches, append([]forwardedLog{}, records...))
+	return len(records), nil
+}
+
+func (s *recordingSink) close() error { return nil }
+
+func TestLogForwarder(t *testing.T) {
+	app := realm.App{ID: "app-id", ClientAppID: "test-app-abcde"}
+
+	origBatchSize, origRetryBackoff := forwardBatchSize, forwardRetryBackoff
+	defer func() { forwardBatchSize, forwardRetryBackoff = origBatchSize, origRetryBackoff }()
+
+	forwardBatchSize = 2
+	forwardRetryBackoff = 0
+
+	logs := realm.Logs{{ID: "log0"}, {ID: "log1"}, {ID: "log2"}}
+
+	t.Run("should forward the logs in batches with the app ids attached", func(t *testing.T) {
+		sink := &recordingSink{}
+
+		forwarder := newLogForwarder(app, []logSink{sink})
+		forwarder.forward(logs)
+		assert.Equal(t, 0, len(forwarder.close()))
+
+		var forwarded []forwardedLog
+		for _, batch := range sink.batches {
+			assert.True(t, len(batch) <= forwardBatchSize, "expected batches of at most %d logs", forwardBatchSize)
+			forwarded = append(forwarded, batch...)
+		}
+		assert.Equal(t, []forwardedLog{
+			{AppID: "app-id", ClientAppID: "test-app-abcde", Log: realm.Log{ID: "log0"}},
+			{AppID: "app-id", ClientAppID: "test-app-abcde", Log: realm.Log{ID: "log1"}},
+			{AppID: "app-id", ClientAppID: "test-app-abcde", Log: realm.Log{ID: "log2"}},
+		}, forwarded)
+	})
+
+	t.Run("should retry the failed batches", func(t *testing.T) {
+		sink := &recordingSink{errs: []error{errors.New("something bad happened"), errors.New("something bad happened")}}
+
+		forwarder := newLogForwarder(app, []logSink{sink})
+		forwarder.forward(logs[:1])
+		assert.Equal(t, 0, len(forwarder.close()))
+
+		assert.Equal(t, [][]forwardedLog{{{AppID: "app-id", ClientAppID: "test-app-abcde", Log: realm.Log{ID: "log0"}}}}, sink.batches)
+	})
+
+	t.Run("should only retry the logs which were not sent", func(t *testing.T) {
+		sink := &partialSink{}
+
+		forwarder := newLogForwarder(app, []logSink{sink})
+		forwarder.forward(logs[:2])
+		assert.Equal(t, 0, len(forwarder.close()))
+
+		assert.Equal(t, [][]forwardedLog{
+			{{AppID: "app-id", ClientAppID: "test-app-abcde", Log: realm.Log{ID: "log0"}}},
+			{{AppID: "app-id", ClientAppID: "test-app-abcde", Log: realm.Log{ID: "log1"}}},
+		}, sink.batches)
+	})
+
+	t.Run("should report the batches which failed too many times", func(t *testing.T) {
+		sink := &recordingSink{}
+		for i := 0; i <= forwardMaxRetries; i++ {
+			sink.errs = append(sink.errs, errors.New("something bad happened"))
+		}
+
+		forwarder := newLogForwarder(app, []logSink{sink})
+		forwarder.forward(logs[:1])
+		assert.Equal(t, []string{"Failed to forward 1 logs to recording: something bad happened"}, forwarder.close())
+		assert.Equal(t, 0, len(sink.batches))
+	})
+
+	t.Run("should drop the logs which do not fit in the buffer", func(t *testing.T) {
+		forwarder := &logForwarder{app: app, records: make(chan forwardedLog, 1)}
+		forwarder.forward(logs)
+
+		assert.Equal(t, []string{"Dropped 2 logs as too many logs were waiting to be forwarded"}, forwarder.warnings())
+		assert.Equal(t, 0, len(forwarder.warnings()))
+	})
+}
+
+func TestLogsTailForward(t *testing.T) {
+	cmdStart := time.Date(2021, time.June, 22, 7, 54, 40, 0, time.UTC)
+
+	t.Run("should forward the tailed logs to the file target", func(t *testing.T) {
+		_, teardown := setupFakeClock(cmdStart)
+		defer teardown()
+
+		tmpDir, teardownTmpDir, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer teardownTmpDir()
+
+		path := filepath.Join(tmpDir, "logs.ndjson")
+
+		testLogs := []realm.Logs{
+			{{ID: "log0", Type: realm.LogTypeFunction, Started: cmdStart.Add(-time.Minute)}},
+			{{ID: "log1", Type: realm.LogTypeFunction, Started: cmdStart.Add(time.Second), Error: "oops"}},
+		}
+
+		sigShutdown := make(chan os.Signal, 1)
+
+		var polls int
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{ID: "app-id", ClientAppID: "test-app-abcde"}}, nil
+		}
+		realmClient.LogsFn = func(groupID, appID string, opts realm.LogsOptions) (realm.Logs, error) {
+			polls++
+			if polls == len(testLogs) {
+				sigShutdown <- os.Interrupt
+			}
+			return testLogs[polls-1], nil
+		}
+
+		_, ui := mock.NewUI()
+
+		cmd := &CommandTail{tailInputs{listInputs{
+			Tail:        true,
+			Interval:    flags.Duration{Duration: 5 * time.Second},
+			sinks:       []logSink{&fileSink{path: path, maxSize: 1024 * 1024, maxAge: time.Hour, maxBackups: 1}},
+			sigShutdown: sigShutdown,
+		}}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		expected, err := ndjson([]forwardedLog{
+			{AppID: "app-id", ClientAppID: "test-app-abcde", Log: testLogs[0][0]},
+			{AppID: "app-id", ClientAppID: "test-app-abcde", Log: testLogs[1][0]},
+		})
+		assert.Nil(t, err)
+
+		data, err := ioutil.ReadFile(path)
+		assert.Nil(t, err)
+		assert.Equal(t, string(expected), string(data))
+	})
+}
@@ -1,13 +1,14 @@
 package logs
 
 import (
+	"errors"
 	"fmt"
 	"sort"
-	"time"
 
 	"github.com/10gen/realm-cli/internal/cli"
 	"github.com/10gen/realm-cli/internal/cli/user"
 	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/local"
 	"github.com/10gen/realm-cli/internal/terminal"
 	"github.com/10gen/realm-cli/internal/utils/flags"
 )
@@ -28,7 +29,22 @@ var CommandMetaList = cli.CommandMeta{
 	Description: "Lists the Logs in your Realm app",
 	HelpText: `Displays a list of your Realm app’s Logs sorted by recentness, with most recent
 Logs appearing towards the bottom. You can specify a "--tail" flag to monitor
-your Logs and follow any newly created Logs in real-time.`,
+your Logs and follow any newly created Logs in real-time. When tailing, new Logs
+are polled for every "--interval", failed polls are retried with backoff, and
+"--verbose" periodically displays a heartbeat.
+
+Without a "--start" date only the most recent page of Logs is listed, otherwise
+every Log within the "--start" and "--end" dates is listed. Use "--limit" to only
+list up to that many of the most recent Logs.
+
+Use the "--function", "--trigger", "--webhook", "--user-id", "--error-code",
+"--grep" and "--min-duration" flags to narrow down which Logs are listed, both
+when listing and tailing Logs.
+
+Use "logs tail" to also forward the tailed Logs to your own log stack.
+
+When run from within your local Realm app, the error stack traces of Function
+Logs are mapped to the locations in your local source files.`,
 }
 
 // CommandList is the `logs list` command
@@ -38,71 +54,197 @@ type CommandList struct {
 
 // Flags is the command flags
 func (cmd *CommandList) Flags() []flags.Flag {
-	return []flags.Flag{
+	fs := []flags.Flag{
 		cli.AppFlagWithContext(&cmd.inputs.App, "to list its logs"),
 		cli.ProjectFlag(&cmd.inputs.Project),
 		cli.ProductFlag(&cmd.inputs.Products),
-		flags.NewStringSetFlag(
-			&cmd.inputs.Types,
-			flags.StringSetOptions{
-				Meta: flags.Meta{
-					Name: "type",
-					Usage: flags.Usage{
-						Description: "Specify the type(s) of logs to list",
-					},
+		logTypesFlag(&cmd.inputs.Types),
+		errorsFlag(&cmd.inputs.Errors),
+		startFlag(&cmd.inputs.Start, "Specify when to begin listing logs"),
+		endFlag(&cmd.inputs.End, "Specify when to finish listing logs"),
+		flags.BoolFlag{
+			Value: &cmd.inputs.Tail,
+			Meta: flags.Meta{
+				Name: flagTail,
+				Usage: flags.Usage{
+					Description: "View your Realm app's logs in real-time",
+					Note:        `"--start" and "--end" flags do not apply here`,
 				},
-				ValidValues: []string{
-					logTypeAuth,
-					logTypeFunction,
-					logTypePush,
-					logTypeService,
-					logTypeTrigger,
-					logTypeGraphQL,
-					logTypeSync,
-					logTypeSchema,
+			},
+		},
+	}
+	fs = append(fs, tailFlags(&cmd.inputs)...)
+	fs = append(fs, logFilterFlags(&cmd.inputs)...)
+	return append(fs,
+		flags.IntFlag{
+			Value: &cmd.inputs.Limit,
+			Meta: flags.Meta{
+				Name: flagLimit,
+				Usage: flags.Usage{
+					Description: "Specify the max number of logs to list",
+					Note:        `Lists every log since "--start" when not set`,
+				},
+			},
+		},
+	)
+}
+
+// tailFlags are the flags which configure how logs are tailed
+func tailFlags(inputs *listInputs) []flags.Flag {
+	return []flags.Flag{
+		flags.CustomFlag{
+			Value: &inputs.Interval,
+			Meta: flags.Meta{
+				Name: flagInterval,
+				Usage: flags.Usage{
+					Description:   "Specify how often to poll for new logs when tailing",
+					DefaultValue:  defaultTailInterval.String(),
+					AllowedFormat: "<number><unit> (e.g. 500ms, 1.5s or 1m)",
 				},
 			},
-		),
+		},
 		flags.BoolFlag{
-			Value: &cmd.inputs.Errors,
+			Value: &inputs.Verbose,
 			Meta: flags.Meta{
-				Name: "errors",
+				Name: "verbose",
 				Usage: flags.Usage{
-					Description: "View your Realm app's error logs",
+					Description: "Periodically display a heartbeat when tailing logs",
 				},
 			},
 		},
-		flags.CustomFlag{
-			Value: &cmd.inputs.Start,
+	}
+}
+
+// logFilterFlags are the flags which narrow down the listed or tailed logs
+func logFilterFlags(inputs *listInputs) []flags.Flag {
+	return []flags.Flag{
+		flags.StringFlag{
+			Value: &inputs.Function,
+			Meta: flags.Meta{
+				Name: flagFunction,
+				Usage: flags.Usage{
+					Description: "Only list the logs of the function with the specified name or ID",
+				},
+			},
+		},
+		flags.StringFlag{
+			Value: &inputs.Trigger,
 			Meta: flags.Meta{
-				Name: "start",
+				Name: flagTrigger,
 				Usage: flags.Usage{
-					Description:   "Specify when to begin listing logs",
-					AllowedFormat: "2006-01-02[T15:04:05.000-0700]",
-					DocsLink:      "https://docs.mongodb.com/realm/logs/cli/#view-logs-for-a-date-range",
+					Description: "Only list the logs of the trigger with the specified name or ID",
+				},
+			},
+		},
+		flags.StringFlag{
+			Value: &inputs.Webhook,
+			Meta: flags.Meta{
+				Name: flagWebhook,
+				Usage: flags.Usage{
+					Description: "Only list the logs of the webhook with the specified name or ID",
+				},
+			},
+		},
+		flags.StringFlag{
+			Value: &inputs.UserID,
+			Meta: flags.Meta{
+				Name: flagUserID,
+				Usage: flags.Usage{
+					Description: "Only list the logs of the user with the specified ID",
+				},
+			},
+		},
+		flags.StringFlag{
+			Value: &inputs.ErrorCode,
+			Meta: flags.Meta{
+				Name: flagErrorCode,
+				Usage: flags.Usage{
+					Description: "Only list the logs with the specified error code",
+				},
+			},
+		},
+		flags.StringFlag{
+			Value: &inputs.Grep,
+			Meta: flags.Meta{
+				Name: flagGrep,
+				Usage: flags.Usage{
+					Description: "Only list the logs with a message or error matching the specified regular expression",
 				},
 			},
 		},
 		flags.CustomFlag{
-			Value: &cmd.inputs.End,
+			Value: &inputs.MinDuration,
 			Meta: flags.Meta{
-				Name: "end",
+				Name: flagMinDuration,
 				Usage: flags.Usage{
-					Description:   "Specify when to finish listing logs",
-					AllowedFormat: "2006-01-02[T15:04:05.000-0700]",
-					DocsLink:      "https://docs.mongodb.com/realm/logs/cli/#view-logs-for-a-date-range",
+					Description:   "Only list the logs which took at least the specified duration to complete",
+					AllowedFormat: "<number><unit> (e.g. 500ms, 1.5s or 1m)",
 				},
 			},
 		},
-		flags.BoolFlag{
-			Value: &cmd.inputs.Tail,
+	}
+}
+
+func logTypesFlag(value *[]string) flags.Flag {
+	return flags.NewStringSetFlag(
+		value,
+		flags.StringSetOptions{
 			Meta: flags.Meta{
-				Name: "tail",
+				Name: "type",
 				Usage: flags.Usage{
-					Description: "View your Realm app's logs in real-time",
-					Note:        `"--start" and "--end" flags do not apply here`,
+					Description: "Specify the type(s) of logs to list",
 				},
 			},
+			ValidValues: []string{
+				logTypeAuth,
+				logTypeFunction,
+				logTypePush,
+				logTypeService,
+				logTypeTrigger,
+				logTypeGraphQL,
+				logTypeSync,
+				logTypeSchema,
+			},
+		},
+	)
+}
+
+func errorsFlag(value *bool) flags.Flag {
+	return flags.BoolFlag{
+		Value: value,
+		Meta: flags.Meta{
+			Name: "errors",
+			Usage: flags.Usage{
+				Description: "View your Realm app's error logs",
+			},
+		},
+	}
+}
+
+func startFlag(value *flags.Date, description string) flags.Flag {
+	return flags.CustomFlag{
+		Value: value,
+		Meta: flags.Meta{
+			Name: "start",
+			Usage: flags.Usage{
+				Description:   description,
+				AllowedFormat: "2006-01-02[T15:04:05.000-0700]",
+				DocsLink:      "https://docs.mongodb.com/realm/logs/cli/#view-logs-for-a-date-range",
+			},
+		},
+	}
+}
+
+func endFlag(value *flags.Date, description string) flags.Flag {
+	return flags.CustomFlag{
+		Value: value,
+		Meta: flags.Meta{
+			Name: "end",
+			Usage: flags.Usage{
+				Description:   description,
+				AllowedFormat: "2006-01-02[T15:04:05.000-0700]",
+				DocsLink:      "https://docs.mongodb.com/realm/logs/cli/#view-logs-for-a-date-range",
+			},
 		},
 	}
 }
@@ -114,7 +256,7 @@ func (cmd *CommandList) Inputs() cli.InputResolver {
 
 // Handler is the command handler
 func (cmd *CommandList) Handler(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
-	cmdStart := time.Now() // for use with tail later
+	cmdStart := logsClock.Now() // for use with tail later
 
 	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
 	if err != nil {
@@ -124,77 +266,88 @@ func (cmd *CommandList) Handler(profile *user.Profile, ui terminal.UI, clients c
 	opts := realm.LogsOptions{
 		Types:      cmd.inputs.logTypes(),
 		ErrorsOnly: cmd.inputs.Errors,
+		UserID:     cmd.inputs.UserID,
 	}
+	filter := cmd.inputs.logFilter()
+
+	sourceMapper := newLogSourceMapper(ui, profile)
+
 	if !cmd.inputs.Tail {
 		opts.Start = cmd.inputs.Start.Time
 		opts.End = cmd.inputs.End.Time
-	}
 
-	logs, err := clients.Realm.Logs(app.GroupID, app.ID, opts)
-	if err != nil {
-		return err
-	}
+		logs, capped, err := listLogs(clients.Realm, app.GroupID, app.ID, opts, filter, cmd.inputs.Limit)
+		if err != nil {
+			return err
+		}
 
-	if cmd.inputs.Tail && len(logs) > tailLookBehind {
-		logs = logs[0:tailLookBehind]
+		printLogs(ui, logs, sourceMapper)
+		if capped {
+			ui.Print(terminal.NewWarningLog(
+				"Only listed the %d most recent logs, use %q to list more",
+				cmd.inputs.Limit,
+				"--"+flagLimit,
+			))
+		}
+		return nil // if not tailing, command stops here
 	}
 
-	printLogs(ui, logs)
-	if !cmd.inputs.Tail {
-		return nil // if not tailing, command stops here
+	return tailLogs(ui, clients.Realm, app, opts, filter, sourceMapper, cmdStart, &cmd.inputs)
+}
+
+func printForwardWarnings(ui terminal.UI, warnings []string) {
+	for _, warning := range warnings {
+		ui.Print(terminal.NewWarningLog("%s", warning))
 	}
+}
 
-	logsCh, errCh, closeCh := make(chan realm.Logs), make(chan error), make(chan struct{})
-	defer close(closeCh)
+// errLogsListed stops fetching logs once every log to list has been fetched
+var errLogsListed = errors.New("logs listed")
 
-	opts.Start = cmdStart
-	go pollForLogs(clients.Realm, app.GroupID, app.ID, opts, logsCh, errCh, closeCh)
+// listLogs fetches the logs which match the filter, up to the limit, and reports whether
+// there were more logs than the limit. Without a start date the range reaches back to
+// the very first log of the app, so only the most recent page is fetched
+func listLogs(realmClient realm.Client, groupID, appID string, opts realm.LogsOptions, filter logFilter, limit int) (realm.Logs, bool, error) {
+	var logs realm.Logs
 
-	for {
-		select {
-		case logs := <-logsCh:
-			printLogs(ui, logs)
-		case err := <-errCh:
-			return err
-		case <-cmd.inputs.sigShutdown:
+	singlePage := opts.Start.IsZero()
+	if filter.isEmpty() && !singlePage {
+		opts.Limit = limit
+		capped, err := realmClient.LogsPages(groupID, appID, opts, func(page realm.Logs) error {
+			logs = append(logs, page...)
 			return nil
-		}
+		})
+		return logs, capped, err
 	}
-}
 
-func pollForLogs(realmClient realm.Client, groupID, appID string, opts realm.LogsOptions, logsCh chan<- realm.Logs, errCh chan<- error, closeCh <-chan struct{}) {
-	ticker := time.NewTicker(5 * time.Second)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-closeCh:
-			return
-		case <-ticker.C:
-			startNext := time.Now()
-
-			logs, err := realmClient.Logs(groupID, appID, opts)
-			if err != nil {
-				select {
-				case <-closeCh: // if closed during API call
-				case errCh <- err:
-				}
-				return
-			}
-			select {
-			case <-closeCh:
-				return // if closed during API call
-			case logsCh <- logs:
-			}
-
-			opts.Start = startNext
+	var capped bool
+	if _, err := realmClient.LogsPages(groupID, appID, opts, func(page realm.Logs) error {
+		matched := filter.apply(page)
+		if limit > 0 && len(logs)+len(matched) > limit {
+			logs = append(logs, matched[:limit-len(logs)]...)
+			capped = true
+			return errLogsListed
+		}
+		logs = append(logs, matched...)
+		if singlePage {
+			return errLogsListed
 		}
+		return nil
+	}); err != nil && err != errLogsListed {
+		return nil, false, err
 	}
+	return logs, capped, nil
 }
 
-func printLogs(ui terminal.UI, logs realm.Logs) {
+// printLogs prints the logs sorted with the most recent logs last
+func printLogs(ui terminal.UI, logs realm.Logs, sourceMapper *logSourceMapper) {
 	sort.Sort(logs)
 	for _, log := range logs {
+		messages := log.Messages
+		if locations := sourceMapper.locations(log); len(locations) > 0 {
+			messages = append(append([]interface{}{}, log.Messages...), locations...)
+		}
+
 		ui.Print(terminal.NewListLog(
 			fmt.Sprintf(
 				"%s %9s %26s%s: %s",
@@ -204,11 +357,61 @@ func printLogs(ui terminal.UI, logs realm.Logs) {
 				logNameDisplay(log),
 				logStatusDisplay(log),
 			),
-			log.Messages...,
+			messages...,
 		))
 	}
 }
 
+// logSourceMapper maps the error stack traces of function logs to the sources of the local app
+// found at the working directory, which is only loaded once a function log with an error is printed
+type logSourceMapper struct {
+	load   func() (local.App, bool)
+	app    local.App
+	ok     bool
+	loaded bool
+}
+
+func newLogSourceMapper(ui terminal.UI, profile *user.Profile) *logSourceMapper {
+	if profile == nil {
+		return nil
+	}
+	return &logSourceMapper{load: func() (local.App, bool) {
+		app, ok := cli.LoadWorkingDirectoryApp(ui, profile)
+		if !ok {
+			return app, false
+		}
+
+		// the logs are of the deployed functions, so the source maps written when the app was pushed
+		// are used over those bundled from the local sources, which may have changed since
+		if err := app.ReadSourceMaps(); err != nil {
+			return local.App{}, false
+		}
+		return app, true
+	}}
+}
+
+func (m *logSourceMapper) locations(log realm.Log) []interface{} {
+	if m == nil || log.Error == "" || log.FunctionName == "" {
+		return nil
+	}
+
+	if !m.loaded {
+		m.app, m.ok = m.load()
+		m.loaded = true
+	}
+	if !m.ok {
+		return nil
+	}
+
+	var lines []interface{}
+	for _, location := range m.app.MapStackTrace(log.FunctionName, log.Error) {
+		for _, line := range location.Lines() {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
 func logNameDisplay(log realm.Log) string {
 	var name, prefix string
 
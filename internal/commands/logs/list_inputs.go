@@ -1,8 +1,11 @@
 package logs
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"os/signal"
+	"regexp"
 	"syscall"
 
 	"github.com/10gen/realm-cli/internal/cli"
@@ -12,6 +15,25 @@ import (
 	"github.com/10gen/realm-cli/internal/utils/flags"
 )
 
+const (
+	flagFunction          = "function"
+	flagTrigger           = "trigger"
+	flagWebhook           = "webhook"
+	flagUserID            = "user-id"
+	flagErrorCode         = "error-code"
+	flagGrep              = "grep"
+	flagMinDuration       = "min-duration"
+	flagLimit             = "limit"
+	flagTail              = "tail"
+	flagInterval          = "interval"
+	flagForward           = "forward"
+	flagForwardMaxSize    = "forward-max-size"
+	flagForwardMaxAge     = "forward-max-age"
+	flagForwardMaxBackups = "forward-max-backups"
+
+	errDependencyFlagConflictTemplate = `cannot use both "%s" and "%s" at the same time`
+)
+
 // set of supported log type flags
 const (
 	logTypeAuth     = "auth"
@@ -26,24 +48,119 @@ const (
 
 type listInputs struct {
 	cli.ProjectInputs
-	Types       []string
-	Errors      bool
-	Start       flags.Date
-	End         flags.Date
-	Tail        bool
-	sigShutdown chan os.Signal
+	Types             []string
+	Errors            bool
+	Start             flags.Date
+	End               flags.Date
+	Tail              bool
+	Interval          flags.Duration
+	Verbose           bool
+	Limit             int
+	Function          string
+	Trigger           string
+	Webhook           string
+	UserID            string
+	ErrorCode         string
+	Grep              string
+	MinDuration       flags.Duration
+	Forward           []string
+	ForwardMaxSize    int
+	ForwardMaxAge     flags.Duration
+	ForwardMaxBackups int
+	grepPattern       *regexp.Regexp
+	sinks             []logSink
+	sigShutdown       chan os.Signal
 }
 
 func (i *listInputs) Resolve(profile *user.Profile, ui terminal.UI) error {
-	i.sigShutdown = make(chan os.Signal, 1)
-	signal.Notify(i.sigShutdown, syscall.SIGTERM, syscall.SIGINT)
+	if i.Limit < 0 {
+		return errors.New(`"limit" must not be negative`)
+	}
+	if i.Tail && i.Limit > 0 {
+		return fmt.Errorf(errDependencyFlagConflictTemplate, flagTail, flagLimit)
+	}
+	if i.Interval.Duration < 0 {
+		return errors.New(`"interval" must not be negative`)
+	}
+	if i.Interval.Duration == 0 {
+		i.Interval.Duration = defaultTailInterval
+	}
+	if i.MinDuration.Duration < 0 {
+		return errors.New(`"min-duration" must not be negative`)
+	}
+	if i.Grep != "" {
+		pattern, err := regexp.Compile(i.Grep)
+		if err != nil {
+			return fmt.Errorf(`invalid "grep" pattern: %s`, err)
+		}
+		i.grepPattern = pattern
+	}
+
+	if len(i.Forward) > 0 {
+		if err := i.resolveSinks(profile.WorkingDirectory); err != nil {
+			return err
+		}
+	}
+
+	if i.Tail {
+		i.sigShutdown = make(chan os.Signal, 1)
+		signal.Notify(i.sigShutdown, syscall.SIGTERM, syscall.SIGINT)
+	}
 
 	return i.ProjectInputs.Resolve(ui, profile.WorkingDirectory, true)
 }
 
+func (i *listInputs) resolveSinks(workingDirectory string) error {
+	if i.ForwardMaxSize < 0 {
+		return fmt.Errorf(`"%s" must not be negative`, flagForwardMaxSize)
+	}
+	if i.ForwardMaxSize == 0 {
+		i.ForwardMaxSize = defaultForwardMaxSize
+	}
+	if i.ForwardMaxAge.Duration < 0 {
+		return fmt.Errorf(`"%s" must not be negative`, flagForwardMaxAge)
+	}
+	if i.ForwardMaxAge.Duration == 0 {
+		i.ForwardMaxAge.Duration = defaultForwardMaxAge
+	}
+
+	if i.ForwardMaxBackups < 0 {
+		return fmt.Errorf(`"%s" must not be negative`, flagForwardMaxBackups)
+	}
+	if i.ForwardMaxBackups == 0 {
+		i.ForwardMaxBackups = defaultForwardMaxBackups
+	}
+
+	i.sinks = make([]logSink, 0, len(i.Forward))
+	for _, target := range i.Forward {
+		sink, err := parseLogSink(workingDirectory, target, int64(i.ForwardMaxSize)*1024*1024, i.ForwardMaxAge.Duration, i.ForwardMaxBackups)
+		if err != nil {
+			return err
+		}
+		i.sinks = append(i.sinks, sink)
+	}
+	return nil
+}
+
+func (i *listInputs) logFilter() logFilter {
+	return logFilter{
+		function:    i.Function,
+		trigger:     i.Trigger,
+		webhook:     i.Webhook,
+		errorCode:   i.ErrorCode,
+		pattern:     i.grepPattern,
+		minDuration: i.MinDuration.Duration,
+	}
+}
+
 func (i *listInputs) logTypes() []string {
+	return realmLogTypes(i.Types)
+}
+
+// realmLogTypes maps the log type flag values to the Realm app log types
+func realmLogTypes(logTypes []string) []string {
 	var types []string
-	for _, lt := range i.Types {
+	for _, lt := range logTypes {
 		switch lt {
 		case logTypeAuth:
 			types = append(types, realm.LogTypeAuth, realm.LogTypeAPIKey)
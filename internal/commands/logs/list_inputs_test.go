@@ -1,12 +1,125 @@
 package logs
 
 import (
+	"errors"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/10gen/realm-cli/internal/cli"
 	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/flags"
 	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
 )
 
+func TestLogsListInputsResolve(t *testing.T) {
+	projectInputs := cli.ProjectInputs{Project: "project", App: "test-app"}
+
+	for _, tc := range []struct {
+		description string
+		inputs      listInputs
+		err         error
+	}{
+		{
+			description: "with a negative limit",
+			inputs:      listInputs{ProjectInputs: projectInputs, Limit: -1},
+			err:         errors.New(`"limit" must not be negative`),
+		},
+		{
+			description: "with both tail and limit set",
+			inputs:      listInputs{ProjectInputs: projectInputs, Tail: true, Limit: 10},
+			err:         errors.New(`cannot use both "tail" and "limit" at the same time`),
+		},
+		{
+			description: "with a negative interval",
+			inputs:      listInputs{ProjectInputs: projectInputs, Tail: true, Interval: flags.Duration{Duration: -time.Second}},
+			err:         errors.New(`"interval" must not be negative`),
+		},
+		{
+			description: "with a negative min duration",
+			inputs:      listInputs{ProjectInputs: projectInputs, MinDuration: flags.Duration{Duration: -time.Second}},
+			err:         errors.New(`"min-duration" must not be negative`),
+		},
+		{
+			description: "with an invalid grep pattern",
+			inputs:      listInputs{ProjectInputs: projectInputs, Grep: "("},
+			err:         errors.New(`invalid "grep" pattern: error parsing regexp: missing closing ): ` + "`(`"),
+		},
+		{
+			description: "with a negative forward max size",
+			inputs:      listInputs{ProjectInputs: projectInputs, Tail: true, Forward: []string{"file://logs.ndjson"}, ForwardMaxSize: -1},
+			err:         errors.New(`"forward-max-size" must not be negative`),
+		},
+		{
+			description: "with a negative forward max backups",
+			inputs:      listInputs{ProjectInputs: projectInputs, Tail: true, Forward: []string{"file://logs.ndjson"}, ForwardMaxBackups: -1},
+			err:         errors.New(`"forward-max-backups" must not be negative`),
+		},
+		{
+			description: "with an unsupported forward target",
+			inputs:      listInputs{ProjectInputs: projectInputs, Tail: true, Forward: []string{"ftp://example.com"}},
+			err:         errors.New("unsupported forward target: ftp://example.com"),
+		},
+	} {
+		t.Run("should return an error "+tc.description, func(t *testing.T) {
+			profile := mock.NewProfile(t)
+
+			assert.Equal(t, tc.err, tc.inputs.Resolve(profile, nil))
+		})
+	}
+
+	t.Run("should resolve with a limit", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		inputs := listInputs{ProjectInputs: projectInputs, Limit: 10}
+		assert.Nil(t, inputs.Resolve(profile, nil))
+		assert.Equal(t, 10, inputs.Limit)
+	})
+
+	t.Run("should default the tail interval", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		inputs := listInputs{ProjectInputs: projectInputs, Tail: true}
+		assert.Nil(t, inputs.Resolve(profile, nil))
+		assert.Equal(t, defaultTailInterval, inputs.Interval.Duration)
+		assert.True(t, inputs.sigShutdown != nil, "expected a shutdown signal to be listened for")
+	})
+
+	t.Run("should not listen for a shutdown signal when not tailing", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		inputs := listInputs{ProjectInputs: projectInputs}
+		assert.Nil(t, inputs.Resolve(profile, nil))
+		assert.True(t, inputs.sigShutdown == nil, "expected no shutdown signal to be listened for")
+	})
+
+	t.Run("should compile the grep pattern into the log filter", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		inputs := listInputs{ProjectInputs: projectInputs, Grep: "timed? out"}
+		assert.Nil(t, inputs.Resolve(profile, nil))
+		assert.Equal(t, "timed? out", inputs.logFilter().pattern.String())
+	})
+
+	t.Run("should resolve the forward targets with the default file limits", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+		profile.WorkingDirectory = "/working/dir"
+
+		inputs := listInputs{ProjectInputs: projectInputs, Tail: true, Forward: []string{"file://logs.ndjson"}}
+		assert.Nil(t, inputs.Resolve(profile, nil))
+
+		assert.Equal(t, defaultForwardMaxSize, inputs.ForwardMaxSize)
+		assert.Equal(t, defaultForwardMaxAge, inputs.ForwardMaxAge.Duration)
+		assert.Equal(t, defaultForwardMaxBackups, inputs.ForwardMaxBackups)
+		assert.Equal(t, 1, len(inputs.sinks))
+		assert.Equal(t,
+			[]interface{}{"file", filepath.Join("/working/dir", "logs.ndjson"), int64(defaultForwardMaxSize * 1024 * 1024), defaultForwardMaxAge, defaultForwardMaxBackups},
+			logSinkFields(inputs.sinks[0]),
+		)
+	})
+}
+
 func TestLogTypes(t *testing.T) {
 	for _, tc := range []struct {
 		logType  string
@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"os"
 	"strings"
-	"sync"
 	"testing"
 	"time"
 
 	"github.com/10gen/realm-cli/internal/cli"
 	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/local"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+	u "github.com/10gen/realm-cli/internal/utils/test"
 	"github.com/10gen/realm-cli/internal/utils/test/assert"
 	"github.com/10gen/realm-cli/internal/utils/test/mock"
 )
@@ -37,8 +39,8 @@ func TestLogsList(t *testing.T) {
 		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
 			return []realm.App{{}}, nil
 		}
-		realmClient.LogsFn = func(groupID, appID string, opts realm.LogsOptions) (realm.Logs, error) {
-			return nil, errors.New("something bad happened")
+		realmClient.LogsPagesFn = func(groupID, appID string, opts realm.LogsOptions, handlePage func(page realm.Logs) error) (bool, error) {
+			return false, errors.New("something bad happened")
 		}
 
 		cmd := &CommandList{}
@@ -52,8 +54,8 @@ func TestLogsList(t *testing.T) {
 		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
 			return []realm.App{{}}, nil
 		}
-		realmClient.LogsFn = func(groupID, appID string, opts realm.LogsOptions) (realm.Logs, error) {
-			return realm.Logs{
+		realmClient.LogsPagesFn = func(groupID, appID string, opts realm.LogsOptions, handlePage func(page realm.Logs) error) (bool, error) {
+			return false, handlePage(realm.Logs{
 				{
 					Type:         realm.LogTypeServiceStreamFunction,
 					Messages:     []interface{}{"a test log message"},
@@ -75,7 +77,7 @@ func TestLogsList(t *testing.T) {
 					Started:   time.Date(2019, time.June, 22, 7, 54, 42, 0, time.UTC),
 					Completed: time.Date(2019, time.June, 22, 7, 54, 42, 5_000_000, time.UTC),
 				},
-			}, nil
+			})
 		}
 
 		out, ui := mock.NewUI()
@@ -95,118 +97,340 @@ func TestLogsList(t *testing.T) {
   a test log message
 `, out.String())
 	})
-}
 
-func TestLogsListTail(t *testing.T) {
-	t.Run("should poll for logs until a shutdown signal is received", func(t *testing.T) {
-		var logIdx int
-		testLogs := []realm.Logs{
-			{{
-				Type:      realm.LogTypeAuth,
-				Started:   time.Date(2019, time.June, 22, 7, 54, 42, 0, time.UTC),
-				Completed: time.Date(2019, time.June, 22, 7, 54, 42, 5_000_000, time.UTC),
-				Messages:  []interface{}{"initial log"},
-			}},
-			{{
-				Type:      realm.LogTypeAuth,
-				Started:   time.Date(2020, time.June, 22, 7, 54, 42, 0, time.UTC),
-				Completed: time.Date(2020, time.June, 22, 7, 54, 42, 5_000_000, time.UTC),
-				Messages:  []interface{}{"second log"},
-			}},
-			{{
-				Type:      realm.LogTypeAuth,
-				Started:   time.Date(2021, time.June, 22, 7, 54, 42, 0, time.UTC),
-				Completed: time.Date(2021, time.June, 22, 7, 54, 42, 5_000_000, time.UTC),
-				Messages:  []interface{}{"third log"},
-			}},
-		}
-		startDates := make([]time.Time, len(testLogs))
-
-		var wg sync.WaitGroup
-		wg.Add(len(testLogs))
+	t.Run("should map function errors to the sources of the local app in the working directory", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer teardown()
+
+		app := local.NewApp(tmpDir, "test-app-abcde", "test-app", realm.LocationVirginia, realm.DeploymentModelGlobal, realm.EnvironmentNone, realm.AppConfigVersion20210101)
+		assert.Nil(t, app.Write())
+		assert.Nil(t, app.WriteFunction(local.AppFunction{
+			Name:   "func0",
+			Source: "exports = function() {\n  throw new Error('oops');\n};\n",
+		}))
+
+		profile := mock.NewProfile(t)
+		profile.WorkingDirectory = tmpDir
 
 		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{}}, nil
+		}
+		realmClient.LogsPagesFn = func(groupID, appID string, opts realm.LogsOptions, handlePage func(page realm.Logs) error) (bool, error) {
+			return false, handlePage(realm.Logs{
+				{
+					Type:         realm.LogTypeFunction,
+					Messages:     []interface{}{"a test log message"},
+					Error:        "oops\n\tat exports (function.js:2:9(3))",
+					Started:      time.Date(2021, time.June, 22, 7, 54, 42, 0, time.UTC),
+					Completed:    time.Date(2021, time.June, 22, 7, 54, 43, 234_000_000, time.UTC),
+					FunctionName: "func0",
+				},
+			})
+		}
 
+		out, ui := mock.NewUI()
+
+		cmd := &CommandList{listInputs{ProjectInputs: cli.ProjectInputs{Project: "project", App: "test-app"}}}
+		assert.Nil(t, cmd.Handler(profile, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, `2021-06-22T07:54:42.000+0000  [1.234s]                   Function func0: Error - oops
+	at exports (function.js:2:9(3))
+  a test log message
+  functions/func0.js:2:9
+    1 | exports = function() {
+  > 2 |   throw new Error('oops');
+      |         ^
+    3 | };
+`, out.String())
+	})
+	t.Run("should only list the most recent page of logs without a start date", func(t *testing.T) {
+		realmClient := mock.RealmClient{}
 		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
 			return []realm.App{{}}, nil
 		}
 
-		realmClient.LogsFn = func(groupID, appID string, opts realm.LogsOptions) (realm.Logs, error) {
-			logs := testLogs[logIdx]
-			startDates[logIdx] = opts.Start
+		var pages int
+		realmClient.LogsPagesFn = func(groupID, appID string, opts realm.LogsOptions, handlePage func(page realm.Logs) error) (bool, error) {
+			for _, page := range []realm.Logs{
+				{
+					{Type: realm.LogTypeFunction, FunctionName: "func2", Started: time.Date(2021, time.June, 22, 7, 54, 44, 0, time.UTC), Completed: time.Date(2021, time.June, 22, 7, 54, 44, 0, time.UTC)},
+					{Type: realm.LogTypeFunction, FunctionName: "func1", Started: time.Date(2021, time.June, 22, 7, 54, 43, 0, time.UTC), Completed: time.Date(2021, time.June, 22, 7, 54, 43, 0, time.UTC)},
+				},
+				{
+					{Type: realm.LogTypeFunction, FunctionName: "func0", Started: time.Date(2021, time.June, 22, 7, 54, 42, 0, time.UTC), Completed: time.Date(2021, time.June, 22, 7, 54, 42, 0, time.UTC)},
+				},
+			} {
+				pages++
+				if err := handlePage(page); err != nil {
+					return false, err
+				}
+			}
+			return false, nil
+		}
 
-			wg.Done()
-			logIdx++
+		out, ui := mock.NewUI()
 
-			return logs, nil
+		cmd := &CommandList{listInputs{ProjectInputs: cli.ProjectInputs{Project: "project", App: "test-app"}, Grep: "func"}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, 1, pages)
+		assert.Equal(t, `2021-06-22T07:54:43.000+0000      [0s]                   Function func1: OK
+2021-06-22T07:54:44.000+0000      [0s]                   Function func2: OK
+`, out.String())
+	})
+
+	t.Run("should list the logs of every page since the start date oldest first and indicate when logs were capped", func(t *testing.T) {
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{}}, nil
+		}
+
+		var limit int
+		realmClient.LogsPagesFn = func(groupID, appID string, opts realm.LogsOptions, handlePage func(page realm.Logs) error) (bool, error) {
+			limit = opts.Limit
+			for _, page := range []realm.Logs{
+				{
+					{Type: realm.LogTypeFunction, FunctionName: "func2", Started: time.Date(2021, time.June, 22, 7, 54, 44, 0, time.UTC), Completed: time.Date(2021, time.June, 22, 7, 54, 44, 0, time.UTC)},
+					{Type: realm.LogTypeFunction, FunctionName: "func1", Started: time.Date(2021, time.June, 22, 7, 54, 43, 0, time.UTC), Completed: time.Date(2021, time.June, 22, 7, 54, 43, 0, time.UTC)},
+				},
+				{
+					{Type: realm.LogTypeFunction, FunctionName: "func0", Started: time.Date(2021, time.June, 22, 7, 54, 42, 0, time.UTC), Completed: time.Date(2021, time.June, 22, 7, 54, 42, 0, time.UTC)},
+				},
+			} {
+				if err := handlePage(page); err != nil {
+					return false, err
+				}
+			}
+			return true, nil
 		}
 
 		out, ui := mock.NewUI()
 
+		cmd := &CommandList{listInputs{
+			ProjectInputs: cli.ProjectInputs{Project: "project", App: "test-app"},
+			Start:         flags.Date{Time: time.Date(2021, time.June, 22, 0, 0, 0, 0, time.UTC)},
+			Limit:         3,
+		}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, 3, limit)
+		assert.Equal(t, `2021-06-22T07:54:42.000+0000      [0s]                   Function func0: OK
+2021-06-22T07:54:43.000+0000      [0s]                   Function func1: OK
+2021-06-22T07:54:44.000+0000      [0s]                   Function func2: OK
+Only listed the 3 most recent logs, use "--limit" to list more
+`, out.String())
+	})
+	t.Run("should filter the logs by the criteria not supported by the server and stop at the limit", func(t *testing.T) {
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{}}, nil
+		}
+
+		var logsOpts realm.LogsOptions
+		var pages int
+		realmClient.LogsPagesFn = func(groupID, appID string, opts realm.LogsOptions, handlePage func(page realm.Logs) error) (bool, error) {
+			logsOpts = opts
+			for _, page := range []realm.Logs{
+				{
+					{Type: realm.LogTypeFunction, FunctionName: "func1", ErrorCode: "FunctionExecutionError", Error: "oops", Started: time.Date(2021, time.June, 22, 7, 54, 45, 0, time.UTC), Completed: time.Date(2021, time.June, 22, 7, 54, 46, 0, time.UTC)},
+					{Type: realm.LogTypeFunction, FunctionName: "func0", ErrorCode: "FunctionExecutionError", Error: "oops", Started: time.Date(2021, time.June, 22, 7, 54, 44, 0, time.UTC), Completed: time.Date(2021, time.June, 22, 7, 54, 45, 0, time.UTC)},
+				},
+				{
+					{Type: realm.LogTypeFunction, FunctionName: "func1", ErrorCode: "FunctionExecutionError", Error: "oops", Started: time.Date(2021, time.June, 22, 7, 54, 43, 0, time.UTC), Completed: time.Date(2021, time.June, 22, 7, 54, 44, 0, time.UTC)},
+					{Type: realm.LogTypeFunction, FunctionName: "func1", Started: time.Date(2021, time.June, 22, 7, 54, 42, 0, time.UTC), Completed: time.Date(2021, time.June, 22, 7, 54, 43, 0, time.UTC)},
+				},
+				{
+					{Type: realm.LogTypeFunction, FunctionName: "func1", ErrorCode: "FunctionExecutionError", Error: "oops", Started: time.Date(2021, time.June, 22, 7, 54, 41, 0, time.UTC), Completed: time.Date(2021, time.June, 22, 7, 54, 42, 0, time.UTC)},
+				},
+			} {
+				pages++
+				if err := handlePage(page); err != nil {
+					return false, err
+				}
+			}
+			return false, nil
+		}
+
+		out, ui := mock.NewUI()
+
+		cmd := &CommandList{listInputs{
+			ProjectInputs: cli.ProjectInputs{Project: "project", App: "test-app"},
+			Start:         flags.Date{Time: time.Date(2021, time.June, 22, 0, 0, 0, 0, time.UTC)},
+			UserID:        "user-id",
+			Function:      "func1",
+			ErrorCode:     "FunctionExecutionError",
+			Limit:         2,
+		}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, "user-id", logsOpts.UserID)
+		assert.Equal(t, 0, logsOpts.Limit)
+		assert.Equal(t, 3, pages)
+		assert.Equal(t, `2021-06-22T07:54:43.000+0000      [1s]                   Function func1: FunctionExecutionErrorError - oops
+2021-06-22T07:54:45.000+0000      [1s]                   Function func1: FunctionExecutionErrorError - oops
+Only listed the 2 most recent logs, use "--limit" to list more
+`, out.String())
+	})
+}
+
+func TestLogsListTail(t *testing.T) {
+	cmdStart := time.Date(2021, time.June, 22, 7, 54, 40, 0, time.UTC)
+
+	authLog := func(id string, started time.Time, message string) realm.Log {
+		return realm.Log{
+			ID:        id,
+			Type:      realm.LogTypeAuth,
+			Started:   started,
+			Completed: started.Add(5 * time.Millisecond),
+			Messages:  []interface{}{message},
+		}
+	}
+
+	t.Run("should poll for logs at the interval until a shutdown signal is received", func(t *testing.T) {
+		clock, teardown := setupFakeClock(cmdStart)
+		defer teardown()
+
+		testLogs := []realm.Logs{
+			{authLog("log0", cmdStart.Add(-time.Minute), "initial log")},
+			{authLog("log1", cmdStart.Add(5*time.Second), "second log")},
+			{authLog("log2", cmdStart.Add(9*time.Second), "third log"), authLog("log1", cmdStart.Add(5*time.Second), "second log")},
+		}
+
 		sigShutdown := make(chan os.Signal, 1)
-		go func() {
-			wg.Wait()
-			sigShutdown <- os.Interrupt
-		}()
 
-		cmd := &CommandList{listInputs{sigShutdown: sigShutdown, Tail: true}}
+		var startDates []time.Time
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{}}, nil
+		}
+		realmClient.LogsFn = func(groupID, appID string, opts realm.LogsOptions) (realm.Logs, error) {
+			startDates = append(startDates, opts.Start)
+
+			logs := testLogs[len(startDates)-1]
+			if len(startDates) == len(testLogs) {
+				sigShutdown <- os.Interrupt
+			}
+			return logs, nil
+		}
+
+		out, ui := mock.NewUI()
 
-		cmdStart := time.Now()
+		cmd := &CommandList{listInputs{sigShutdown: sigShutdown, Tail: true, Interval: flags.Duration{Duration: 5 * time.Second}}}
 		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
 
-		assert.Equal(t, `2019-06-22T07:54:42.000+0000     [5ms]             Authentication: OK
+		assert.Equal(t, `2021-06-22T07:53:40.000+0000     [5ms]             Authentication: OK
   initial log
-2020-06-22T07:54:42.000+0000     [5ms]             Authentication: OK
+2021-06-22T07:54:45.000+0000     [5ms]             Authentication: OK
   second log
-2021-06-22T07:54:42.000+0000     [5ms]             Authentication: OK
+2021-06-22T07:54:49.000+0000     [5ms]             Authentication: OK
   third log
 `, out.String())
 
-		assert.Equal(t, time.Time{}, startDates[0])
-		for i := 0; i < len(startDates)-1; i++ {
-			expectedStart := cmdStart.Add(time.Duration(5*i) * time.Second).Format(testDateFormat)
-			actualStart := startDates[i+1].Format(testDateFormat)
+		assert.Equal(t, []time.Time{
+			{},
+			cmdStart.Add(-tailDedupeWindow),
+			cmdStart.Add(5 * time.Second).Add(-tailDedupeWindow),
+		}, startDates)
+		assert.Equal(t, []time.Duration{5 * time.Second, 5 * time.Second}, clock.Waits)
+	})
+
+	t.Run("should display the logs which arrive late without displaying any log twice", func(t *testing.T) {
+		_, teardown := setupFakeClock(cmdStart)
+		defer teardown()
+
+		testLogs := []realm.Logs{
+			{},
+			{authLog("log1", cmdStart.Add(5*time.Second), "first log")},
+			{authLog("log1", cmdStart.Add(5*time.Second), "first log"), authLog("log0", cmdStart.Add(2*time.Second), "late log")},
+		}
+
+		sigShutdown := make(chan os.Signal, 1)
+
+		var calls int
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{}}, nil
+		}
+		realmClient.LogsFn = func(groupID, appID string, opts realm.LogsOptions) (realm.Logs, error) {
+			calls++
+			if calls == len(testLogs) {
+				sigShutdown <- os.Interrupt
+			}
+			return testLogs[calls-1], nil
+		}
+
+		out, ui := mock.NewUI()
+
+		cmd := &CommandList{listInputs{sigShutdown: sigShutdown, Tail: true}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, `2021-06-22T07:54:45.000+0000     [5ms]             Authentication: OK
+  first log
+2021-06-22T07:54:42.000+0000     [5ms]             Authentication: OK
+  late log
+`, out.String())
+	})
+
+	t.Run("should retry failed polls with backoff", func(t *testing.T) {
+		clock, teardown := setupFakeClock(cmdStart)
+		defer teardown()
+
+		sigShutdown := make(chan os.Signal, 1)
 
-			assert.Equal(t, expectedStart, actualStart)
+		var calls int
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{}}, nil
+		}
+		realmClient.LogsFn = func(groupID, appID string, opts realm.LogsOptions) (realm.Logs, error) {
+			calls++
+			switch calls {
+			case 1:
+				return nil, nil
+			case 2, 3:
+				return nil, errors.New("something bad happened")
+			}
+			sigShutdown <- os.Interrupt
+			return realm.Logs{authLog("log0", cmdStart.Add(time.Second), "tailed log")}, nil
 		}
+
+		out, ui := mock.NewUI()
+
+		cmd := &CommandList{listInputs{sigShutdown: sigShutdown, Tail: true, Interval: flags.Duration{Duration: 5 * time.Second}}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, `Failed to get logs, retrying in 1s: something bad happened
+Failed to get logs, retrying in 2s: something bad happened
+2021-06-22T07:54:41.000+0000     [5ms]             Authentication: OK
+  tailed log
+`, out.String())
+		assert.Equal(t, []time.Duration{5 * time.Second, time.Second, 2 * time.Second}, clock.Waits)
 	})
 
-	t.Run("should poll for logs until an api call returns an error", func(t *testing.T) {
+	t.Run("should stop tailing once too many polls fail in a row", func(t *testing.T) {
 		origTailLookBehind := tailLookBehind
 		defer func() { tailLookBehind = origTailLookBehind }()
 		tailLookBehind = 2
 
+		origTailMaxRetries := tailMaxRetries
+		defer func() { tailMaxRetries = origTailMaxRetries }()
+		tailMaxRetries = 2
+
+		clock, teardown := setupFakeClock(cmdStart)
+		defer teardown()
+
 		testLogs := []realm.Logs{
 			{
-				{
-					Type:      realm.LogTypeAuth,
-					Started:   time.Date(2021, time.June, 22, 7, 54, 42, 0, time.UTC),
-					Completed: time.Date(2021, time.June, 22, 7, 54, 42, 5_000_000, time.UTC),
-					Messages:  []interface{}{"lower log"},
-				},
-				{
-					Type:      realm.LogTypeAuth,
-					Started:   time.Date(2020, time.June, 22, 7, 54, 42, 0, time.UTC),
-					Completed: time.Date(2020, time.June, 22, 7, 54, 42, 5_000_000, time.UTC),
-					Messages:  []interface{}{"upper log"},
-				},
-				{
-					Type:      realm.LogTypeAuth,
-					Started:   time.Date(2019, time.June, 22, 7, 54, 42, 0, time.UTC),
-					Completed: time.Date(2019, time.June, 22, 7, 54, 42, 5_000_000, time.UTC),
-					Messages:  []interface{}{"skipped log"},
-				},
+				authLog("", time.Date(2021, time.June, 22, 7, 54, 39, 0, time.UTC), "lower log"),
+				authLog("", time.Date(2020, time.June, 22, 7, 54, 42, 0, time.UTC), "upper log"),
+				authLog("", time.Date(2019, time.June, 22, 7, 54, 42, 0, time.UTC), "skipped log"),
 			},
-			{{
-				Type:      realm.LogTypeAuth,
-				Started:   time.Date(2022, time.June, 22, 7, 54, 42, 0, time.UTC),
-				Completed: time.Date(2022, time.June, 22, 7, 54, 42, 5_000_000, time.UTC),
-				Messages:  []interface{}{"tailed log"},
-			}},
+			{authLog("", time.Date(2021, time.June, 22, 7, 54, 42, 0, time.UTC), "tailed log")},
 		}
 
 		realmClient := mock.RealmClient{}
-
 		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
 			return []realm.App{{}}, nil
 		}
@@ -222,17 +446,94 @@ func TestLogsListTail(t *testing.T) {
 
 		out, ui := mock.NewUI()
 
-		cmd := &CommandList{listInputs{Tail: true}}
+		cmd := &CommandList{listInputs{sigShutdown: make(chan os.Signal, 1), Tail: true, Interval: flags.Duration{Duration: 5 * time.Second}}}
 
 		err := cmd.Handler(nil, ui, cli.Clients{Realm: realmClient})
 		assert.Equal(t, errors.New("something bad happened"), err)
 
 		assert.Equal(t, `2020-06-22T07:54:42.000+0000     [5ms]             Authentication: OK
   upper log
-2021-06-22T07:54:42.000+0000     [5ms]             Authentication: OK
+2021-06-22T07:54:39.000+0000     [5ms]             Authentication: OK
   lower log
-2022-06-22T07:54:42.000+0000     [5ms]             Authentication: OK
+2021-06-22T07:54:42.000+0000     [5ms]             Authentication: OK
+  tailed log
+Failed to get logs, retrying in 1s: something bad happened
+Failed to get logs, retrying in 2s: something bad happened
+`, out.String())
+		assert.Equal(t, []time.Duration{5 * time.Second, 5 * time.Second, time.Second, 2 * time.Second}, clock.Waits)
+	})
+
+	t.Run("should periodically display a heartbeat when verbose", func(t *testing.T) {
+		_, teardown := setupFakeClock(cmdStart)
+		defer teardown()
+
+		sigShutdown := make(chan os.Signal, 1)
+
+		var calls int
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{}}, nil
+		}
+		realmClient.LogsFn = func(groupID, appID string, opts realm.LogsOptions) (realm.Logs, error) {
+			calls++
+			if calls == 5 {
+				sigShutdown <- os.Interrupt
+			}
+			if calls == 2 {
+				return realm.Logs{authLog("log0", cmdStart.Add(10*time.Second), "tailed log")}, nil
+			}
+			return nil, nil
+		}
+
+		out, ui := mock.NewUI()
+
+		cmd := &CommandList{listInputs{sigShutdown: sigShutdown, Tail: true, Verbose: true, Interval: flags.Duration{Duration: 30 * time.Second}}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, `2021-06-22T07:54:50.000+0000     [5ms]             Authentication: OK
   tailed log
+Still tailing logs, the most recent log started at 2021-06-22T07:54:50.000+0000
+Still tailing logs, the most recent log started at 2021-06-22T07:54:50.000+0000
+`, out.String())
+	})
+
+	t.Run("should filter both the initial and polled logs", func(t *testing.T) {
+		_, teardown := setupFakeClock(cmdStart)
+		defer teardown()
+
+		testLogs := []realm.Logs{
+			{
+				{Type: realm.LogTypeFunction, FunctionName: "func0", Started: time.Date(2019, time.June, 22, 7, 54, 42, 0, time.UTC), Completed: time.Date(2019, time.June, 22, 7, 54, 42, 5_000_000, time.UTC)},
+				{Type: realm.LogTypeFunction, FunctionName: "func1", Started: time.Date(2019, time.June, 22, 7, 54, 43, 0, time.UTC), Completed: time.Date(2019, time.June, 22, 7, 54, 43, 5_000_000, time.UTC)},
+			},
+			{
+				{Type: realm.LogTypeFunction, FunctionName: "func1", Started: time.Date(2021, time.June, 22, 7, 54, 42, 0, time.UTC), Completed: time.Date(2021, time.June, 22, 7, 54, 42, 5_000_000, time.UTC)},
+				{Type: realm.LogTypeFunction, FunctionName: "func0", Started: time.Date(2021, time.June, 22, 7, 54, 43, 0, time.UTC), Completed: time.Date(2021, time.June, 22, 7, 54, 43, 5_000_000, time.UTC)},
+			},
+		}
+
+		sigShutdown := make(chan os.Signal, 1)
+
+		var calls int
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{}}, nil
+		}
+		realmClient.LogsFn = func(groupID, appID string, opts realm.LogsOptions) (realm.Logs, error) {
+			calls++
+			if calls == len(testLogs) {
+				sigShutdown <- os.Interrupt
+			}
+			return testLogs[calls-1], nil
+		}
+
+		out, ui := mock.NewUI()
+
+		cmd := &CommandList{listInputs{sigShutdown: sigShutdown, Tail: true, Function: "func0"}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, `2019-06-22T07:54:42.000+0000     [5ms]                   Function func0: OK
+2021-06-22T07:54:43.000+0000     [5ms]                   Function func0: OK
 `, out.String())
 	})
 }
@@ -394,9 +695,9 @@ func TestLogTypeFormat(t *testing.T) {
 			return []realm.App{{}}, nil
 		}
 		var logsOpts realm.LogsOptions
-		realmClient.LogsFn = func(groupID, appID string, opts realm.LogsOptions) (realm.Logs, error) {
+		realmClient.LogsPagesFn = func(groupID, appID string, opts realm.LogsOptions, handlePage func(page realm.Logs) error) (bool, error) {
 			logsOpts = opts
-			return realm.Logs{}, nil
+			return false, handlePage(realm.Logs{})
 		}
 
 		typeInputs := listInputs{Types: []string{logTypeSchema}}
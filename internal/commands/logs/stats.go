@@ -0,0 +1,324 @@
+package logs
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/terminal"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+	"github.com/10gen/realm-cli/internal/utils/stats"
+)
+
+const (
+	defaultStatsSince = 24 * time.Hour
+
+	statsTopErrorCodes = 3
+)
+
+// set of supported log stats dimensions
+const (
+	statsGroupByType     = "type"
+	statsGroupByFunction = "function"
+	statsGroupByTrigger  = "trigger"
+	statsGroupByWebhook  = "webhook"
+)
+
+const (
+	headerType          = "Type"
+	headerFunction      = "Function"
+	headerTrigger       = "Trigger"
+	headerWebhook       = "Webhook"
+	headerCount         = "Count"
+	headerErrors        = "Errors"
+	headerErrorRate     = "Error Rate"
+	headerTopErrorCodes = "Top Error Codes"
+	headerP50           = "P50"
+	headerP95           = "P95"
+	headerP99           = "P99"
+	headerMemTimeUsage  = "Mem Time Usage"
+)
+
+var statsGroupByHeaders = map[string]string{
+	statsGroupByType:     headerType,
+	statsGroupByFunction: headerFunction,
+	statsGroupByTrigger:  headerTrigger,
+	statsGroupByWebhook:  headerWebhook,
+}
+
+// CommandMetaStats is the command meta for the `logs stats` command
+var CommandMetaStats = cli.CommandMeta{
+	Use:         "stats",
+	Display:     "logs stats",
+	Description: "Display statistics of the Logs in your Realm app",
+	HelpText: `Aggregates the Logs created within the "--since" duration, grouped by the
+dimensions specified with "--group-by". For each group the following is displayed:
+  - The number of Logs, the number of errors and the error rate
+  - The most frequent error codes
+  - The p50, p95 and p99 of the Log durations
+  - The total memory time usage
+
+Use "--output-format json" to display the statistics as JSON.`,
+}
+
+// CommandStats is the `logs stats` command
+type CommandStats struct {
+	inputs statsInputs
+}
+
+type statsInputs struct {
+	cli.ProjectInputs
+	Types   []string
+	Since   flags.Duration
+	GroupBy []string
+}
+
+// Flags is the command flags
+func (cmd *CommandStats) Flags() []flags.Flag {
+	return []flags.Flag{
+		cli.AppFlagWithContext(&cmd.inputs.App, "to display its log statistics"),
+		cli.ProjectFlag(&cmd.inputs.Project),
+		cli.ProductFlag(&cmd.inputs.Products),
+		logTypesFlag(&cmd.inputs.Types),
+		flags.CustomFlag{
+			Value: &cmd.inputs.Since,
+			Meta: flags.Meta{
+				Name: "since",
+				Usage: flags.Usage{
+					Description:   "Specify how far back to aggregate logs",
+					DefaultValue:  "24h",
+					AllowedFormat: "<number><unit> (e.g. 30m, 1h or 72h)",
+				},
+			},
+		},
+		flags.NewStringSetFlag(
+			&cmd.inputs.GroupBy,
+			flags.StringSetOptions{
+				Meta: flags.Meta{
+					Name: "group-by",
+					Usage: flags.Usage{
+						Description:  "Specify the dimension(s) to group logs by",
+						DefaultValue: statsGroupByType,
+					},
+				},
+				ValidValues: []string{
+					statsGroupByType,
+					statsGroupByFunction,
+					statsGroupByTrigger,
+					statsGroupByWebhook,
+				},
+			},
+		),
+	}
+}
+
+// Inputs is the command inputs
+func (cmd *CommandStats) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandStats) Handler(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	since := logsClock.Now().Add(-cmd.inputs.Since.Duration)
+
+	logStats := newLogStats(cmd.inputs.GroupBy)
+	if _, err := clients.Realm.LogsPages(
+		app.GroupID,
+		app.ID,
+		realm.LogsOptions{Types: realmLogTypes(cmd.inputs.Types), Start: since},
+		func(logs realm.Logs) error {
+			for _, log := range logs {
+				logStats.add(log)
+			}
+			return nil
+		},
+	); err != nil {
+		return err
+	}
+
+	if logStats.count == 0 {
+		ui.Print(terminal.NewTextLog("No logs found in the last %s", cmd.inputs.Since.Duration))
+		return nil
+	}
+
+	ui.Print(terminal.NewTableLog(
+		fmt.Sprintf("Aggregated %d logs from the last %s", logStats.count, cmd.inputs.Since.Duration),
+		logStats.headers(),
+		logStats.rows()...,
+	))
+	return nil
+}
+
+func (i *statsInputs) Resolve(profile *user.Profile, ui terminal.UI) error {
+	if i.Since.Duration < 0 {
+		return errors.New(`"since" must not be negative`)
+	}
+	if i.Since.Duration == 0 {
+		i.Since.Duration = defaultStatsSince
+	}
+	if len(i.GroupBy) == 0 {
+		i.GroupBy = []string{statsGroupByType}
+	}
+
+	return i.ProjectInputs.Resolve(ui, profile.WorkingDirectory, true)
+}
+
+// logStats aggregates logs by the group by dimensions
+type logStats struct {
+	groupBy []string
+	count   int
+	groups  map[string]*logGroupStats
+}
+
+type logGroupStats struct {
+	values       []string
+	count        int
+	errors       int
+	errorCodes   map[string]int
+	durations    []time.Duration
+	memTimeUsage int64
+}
+
+func newLogStats(groupBy []string) *logStats {
+	return &logStats{groupBy: groupBy, groups: map[string]*logGroupStats{}}
+}
+
+func (s *logStats) add(log realm.Log) {
+	values := make([]string, 0, len(s.groupBy))
+	for _, dimension := range s.groupBy {
+		values = append(values, logDimensionValue(log, dimension))
+	}
+
+	key := strings.Join(values, "\x00")
+	group, ok := s.groups[key]
+	if !ok {
+		group = &logGroupStats{values: values, errorCodes: map[string]int{}}
+		s.groups[key] = group
+	}
+
+	s.count++
+	group.count++
+	if log.Error != "" {
+		group.errors++
+		if log.ErrorCode != "" {
+			group.errorCodes[log.ErrorCode]++
+		}
+	}
+	group.durations = append(group.durations, log.Completed.Sub(log.Started))
+	group.memTimeUsage += log.MemTimeUsage
+}
+
+func (s *logStats) headers() []string {
+	headers := make([]string, 0, len(s.groupBy)+8)
+	for _, dimension := range s.groupBy {
+		headers = append(headers, statsGroupByHeaders[dimension])
+	}
+	return append(
+		headers,
+		headerCount,
+		headerErrors,
+		headerErrorRate,
+		headerTopErrorCodes,
+		headerP50,
+		headerP95,
+		headerP99,
+		headerMemTimeUsage,
+	)
+}
+
+// rows returns the stats of each group, the groups with the most logs first
+func (s *logStats) rows() []map[string]interface{} {
+	groups := make([]*logGroupStats, 0, len(s.groups))
+	for _, group := range s.groups {
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].count != groups[j].count {
+			return groups[i].count > groups[j].count
+		}
+		return strings.Join(groups[i].values, "\x00") < strings.Join(groups[j].values, "\x00")
+	})
+
+	rows := make([]map[string]interface{}, 0, len(groups))
+	for _, group := range groups {
+		sort.Slice(group.durations, func(i, j int) bool { return group.durations[i] < group.durations[j] })
+
+		row := map[string]interface{}{
+			headerCount:         group.count,
+			headerErrors:        group.errors,
+			headerErrorRate:     fmt.Sprintf("%.2f%%", float64(group.errors)/float64(group.count)*100),
+			headerTopErrorCodes: group.topErrorCodes(),
+			headerP50:           stats.Percentile(group.durations, 50),
+			headerP95:           stats.Percentile(group.durations, 95),
+			headerP99:           stats.Percentile(group.durations, 99),
+			headerMemTimeUsage:  group.memTimeUsage,
+		}
+		for i, dimension := range s.groupBy {
+			value := group.values[i]
+			if value == "" {
+				value = "-"
+			}
+			row[statsGroupByHeaders[dimension]] = value
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func (g *logGroupStats) topErrorCodes() string {
+	codes := make([]string, 0, len(g.errorCodes))
+	for code := range g.errorCodes {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		if g.errorCodes[codes[i]] != g.errorCodes[codes[j]] {
+			return g.errorCodes[codes[i]] > g.errorCodes[codes[j]]
+		}
+		return codes[i] < codes[j]
+	})
+	if len(codes) > statsTopErrorCodes {
+		codes = codes[:statsTopErrorCodes]
+	}
+
+	top := make([]string, 0, len(codes))
+	for _, code := range codes {
+		top = append(top, fmt.Sprintf("%s (%d)", code, g.errorCodes[code]))
+	}
+	return strings.Join(top, ", ")
+}
+
+func logDimensionValue(log realm.Log, dimension string) string {
+	switch dimension {
+	case statsGroupByType:
+		if display := logTypeDisplay(log); display != "" {
+			return display
+		}
+		return log.Type
+	case statsGroupByFunction:
+		if log.FunctionName != "" {
+			return log.FunctionName
+		}
+		return log.FunctionID
+	case statsGroupByTrigger:
+		if log.EventSubscriptionName != "" {
+			return log.EventSubscriptionName
+		}
+		return log.EventSubscriptionID
+	case statsGroupByWebhook:
+		if log.IncomingWebhookName != "" {
+			return log.IncomingWebhookName
+		}
+		return log.IncomingWebhookID
+	}
+	return ""
+}
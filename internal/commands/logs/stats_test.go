@@ -0,0 +1,172 @@
+package logs
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestLogsStatsHandler(t *testing.T) {
+	started := time.Date(2021, time.June, 22, 7, 54, 42, 0, time.UTC)
+
+	functionLog := func(name string, duration time.Duration, errorCode string) realm.Log {
+		log := realm.Log{
+			Type:         realm.LogTypeFunction,
+			Started:      started,
+			Completed:    started.Add(duration),
+			MemTimeUsage: 100,
+			FunctionName: name,
+		}
+		if errorCode != "" {
+			log.Error = "something bad happened"
+			log.ErrorCode = errorCode
+		}
+		return log
+	}
+
+	testPages := []realm.Logs{
+		{
+			functionLog("func0", 10*time.Millisecond, ""),
+			functionLog("func0", 20*time.Millisecond, "FunctionExecutionError"),
+			functionLog("func1", 5*time.Millisecond, ""),
+		},
+		{
+			functionLog("func0", 30*time.Millisecond, "ExecutionTimeLimitExceeded"),
+			functionLog("func0", 40*time.Millisecond, "FunctionExecutionError"),
+			{
+				Type:                realm.LogTypeWebhook,
+				Started:             started,
+				Completed:           started.Add(time.Second),
+				IncomingWebhookName: "webhook0",
+			},
+		},
+	}
+
+	setup := func() (mock.RealmClient, *realm.LogsOptions) {
+		var logsOpts realm.LogsOptions
+
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{}}, nil
+		}
+		realmClient.LogsPagesFn = func(groupID, appID string, opts realm.LogsOptions, handlePage func(page realm.Logs) error) (bool, error) {
+			logsOpts = opts
+			for _, page := range testPages {
+				if err := handlePage(page); err != nil {
+					return false, err
+				}
+			}
+			return false, nil
+		}
+		return realmClient, &logsOpts
+	}
+
+	t.Run("should aggregate the logs since the duration by type", func(t *testing.T) {
+		realmClient, logsOpts := setup()
+
+		out, ui := mock.NewUI()
+
+		cmd := &CommandStats{statsInputs{
+			ProjectInputs: cli.ProjectInputs{Project: "project", App: "test-app"},
+			Types:         []string{logTypeFunction, logTypeService},
+			Since:         flags.Duration{Duration: time.Hour},
+			GroupBy:       []string{statsGroupByType},
+		}}
+
+		now := time.Date(2021, time.June, 22, 7, 54, 40, 0, time.UTC)
+		_, teardown := setupFakeClock(now)
+		defer teardown()
+
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, realmLogTypes([]string{logTypeFunction, logTypeService}), logsOpts.Types)
+		assert.Equal(t, now.Add(-time.Hour), logsOpts.Start)
+
+		assert.Equal(t, `Aggregated 6 logs from the last 1h0m0s
+  Type      Count  Errors  Error Rate  Top Error Codes                                             P50   P95   P99   Mem Time Usage
+  --------  -----  ------  ----------  ----------------------------------------------------------  ----  ----  ----  --------------
+  Function  5      3       60.00%      FunctionExecutionError (2), ExecutionTimeLimitExceeded (1)  20ms  40ms  40ms  500           
+  Webhook   1      0       0.00%                                                                   1s    1s    1s    0             
+`, out.String())
+	})
+
+	t.Run("should aggregate the logs by multiple dimensions", func(t *testing.T) {
+		realmClient, _ := setup()
+
+		out, ui := mock.NewUI()
+
+		cmd := &CommandStats{statsInputs{
+			ProjectInputs: cli.ProjectInputs{Project: "project", App: "test-app"},
+			Since:         flags.Duration{Duration: time.Hour},
+			GroupBy:       []string{statsGroupByFunction, statsGroupByWebhook},
+		}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, `Aggregated 6 logs from the last 1h0m0s
+  Function  Webhook   Count  Errors  Error Rate  Top Error Codes                                             P50   P95   P99   Mem Time Usage
+  --------  --------  -----  ------  ----------  ----------------------------------------------------------  ----  ----  ----  --------------
+  func0     -         4      3       75.00%      FunctionExecutionError (2), ExecutionTimeLimitExceeded (1)  20ms  40ms  40ms  400           
+  -         webhook0  1      0       0.00%                                                                   1s    1s    1s    0             
+  func1     -         1      0       0.00%                                                                   5ms   5ms   5ms   100           
+`, out.String())
+	})
+
+	t.Run("should display a message when there are no logs", func(t *testing.T) {
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{}}, nil
+		}
+		realmClient.LogsPagesFn = func(groupID, appID string, opts realm.LogsOptions, handlePage func(page realm.Logs) error) (bool, error) {
+			return false, handlePage(realm.Logs{})
+		}
+
+		out, ui := mock.NewUI()
+
+		cmd := &CommandStats{statsInputs{Since: flags.Duration{Duration: 24 * time.Hour}, GroupBy: []string{statsGroupByType}}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, "No logs found in the last 24h0m0s\n", out.String())
+	})
+
+	t.Run("should return an error when the client fails to get logs", func(t *testing.T) {
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{}}, nil
+		}
+		realmClient.LogsPagesFn = func(groupID, appID string, opts realm.LogsOptions, handlePage func(page realm.Logs) error) (bool, error) {
+			return false, errors.New("something bad happened")
+		}
+
+		cmd := &CommandStats{statsInputs{GroupBy: []string{statsGroupByType}}}
+
+		err := cmd.Handler(nil, nil, cli.Clients{Realm: realmClient})
+		assert.Equal(t, errors.New("something bad happened"), err)
+	})
+}
+
+func TestLogsStatsInputs(t *testing.T) {
+	projectInputs := cli.ProjectInputs{Project: "project", App: "test-app"}
+
+	t.Run("should default the since duration and group by dimension", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		inputs := statsInputs{ProjectInputs: projectInputs}
+		assert.Nil(t, inputs.Resolve(profile, nil))
+
+		assert.Equal(t, defaultStatsSince, inputs.Since.Duration)
+		assert.Equal(t, []string{statsGroupByType}, inputs.GroupBy)
+	})
+
+	t.Run("should return an error with a negative since duration", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		inputs := statsInputs{ProjectInputs: projectInputs, Since: flags.Duration{Duration: -time.Hour}}
+		assert.Equal(t, errors.New(`"since" must not be negative`), inputs.Resolve(profile, nil))
+	})
+}
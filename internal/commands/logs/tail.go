@@ -0,0 +1,321 @@
+package logs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/terminal"
+	"github.com/10gen/realm-cli/internal/utils/clock"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+)
+
+const (
+	defaultTailInterval = 5 * time.Second
+)
+
+var (
+	// tailDedupeWindow is how far back each poll looks for logs which arrived late
+	tailDedupeWindow = 30 * time.Second
+
+	// tailHeartbeatInterval is how often a heartbeat is displayed when tailing verbosely
+	tailHeartbeatInterval = time.Minute
+
+	// tailMaxRetries is the number of consecutive failed polls retried before tailing gives up
+	tailMaxRetries = 5
+
+	tailBackoffMin = time.Second
+	tailBackoffMax = 30 * time.Second
+)
+
+// CommandMetaTail is the command meta for the `logs tail` command
+var CommandMetaTail = cli.CommandMeta{
+	Use:         "tail",
+	Display:     "logs tail",
+	Description: "Tail the Logs in your Realm app and optionally forward them",
+	HelpText: `Follows your Realm app's newly created Logs in real-time, the same as "logs list
+--tail". New Logs are polled for every "--interval", failed polls are retried with
+backoff, and "--verbose" periodically displays a heartbeat.
+
+Use "--forward" to also send the tailed Logs to syslog over UDP or TCP, to an HTTP
+endpoint as batches of NDJSON, or to a local file which is rotated once it reaches
+"--forward-max-size" or "--forward-max-age", keeping the most recent
+"--forward-max-backups" rotated files. Each forwarded Log includes the app ID and
+client app ID of your Realm app. Logs are forwarded in batches in the background,
+and failed batches are retried before being dropped.
+
+Logs are forwarded at least once. A retry only resends the Logs which were not yet
+written to a syslog or file target, but resends the whole batch to an HTTP endpoint,
+which may have received the batch before failing to respond.`,
+}
+
+// CommandTail is the `logs tail` command
+type CommandTail struct {
+	inputs tailInputs
+}
+
+type tailInputs struct {
+	listInputs
+}
+
+func (i *tailInputs) Resolve(profile *user.Profile, ui terminal.UI) error {
+	i.Tail = true
+	return i.listInputs.Resolve(profile, ui)
+}
+
+// Flags is the command flags
+func (cmd *CommandTail) Flags() []flags.Flag {
+	fs := []flags.Flag{
+		cli.AppFlagWithContext(&cmd.inputs.App, "to tail its logs"),
+		cli.ProjectFlag(&cmd.inputs.Project),
+		cli.ProductFlag(&cmd.inputs.Products),
+		logTypesFlag(&cmd.inputs.Types),
+		errorsFlag(&cmd.inputs.Errors),
+	}
+	fs = append(fs, tailFlags(&cmd.inputs.listInputs)...)
+	fs = append(fs, logFilterFlags(&cmd.inputs.listInputs)...)
+	return append(fs,
+		flags.StringArrayFlag{
+			Value: &cmd.inputs.Forward,
+			Meta: flags.Meta{
+				Name: flagForward,
+				Usage: flags.Usage{
+					Description:   "Forward the tailed logs to the specified target(s)",
+					AllowedFormat: "syslog+udp://<host>[:<port>], syslog+tcp://<host>[:<port>], http[s]://<url> or file://<path>",
+				},
+			},
+		},
+		flags.IntFlag{
+			Value: &cmd.inputs.ForwardMaxSize,
+			Meta: flags.Meta{
+				Name: flagForwardMaxSize,
+				Usage: flags.Usage{
+					Description:  "Specify the size in megabytes a forwarded logs file grows to before it is rotated",
+					DefaultValue: fmt.Sprint(defaultForwardMaxSize),
+				},
+			},
+		},
+		flags.CustomFlag{
+			Value: &cmd.inputs.ForwardMaxAge,
+			Meta: flags.Meta{
+				Name: flagForwardMaxAge,
+				Usage: flags.Usage{
+					Description:   "Specify how long a forwarded logs file is written to before it is rotated",
+					DefaultValue:  "24h",
+					AllowedFormat: "<number><unit> (e.g. 30m, 1h or 72h)",
+				},
+			},
+		},
+		flags.IntFlag{
+			Value: &cmd.inputs.ForwardMaxBackups,
+			Meta: flags.Meta{
+				Name: flagForwardMaxBackups,
+				Usage: flags.Usage{
+					Description:  "Specify the number of rotated forwarded logs files to keep",
+					DefaultValue: fmt.Sprint(defaultForwardMaxBackups),
+				},
+			},
+		},
+	)
+}
+
+// Inputs is the command inputs
+func (cmd *CommandTail) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandTail) Handler(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
+	cmdStart := logsClock.Now()
+
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	opts := realm.LogsOptions{
+		Types:      cmd.inputs.logTypes(),
+		ErrorsOnly: cmd.inputs.Errors,
+		UserID:     cmd.inputs.UserID,
+	}
+
+	return tailLogs(ui, clients.Realm, app, opts, cmd.inputs.logFilter(), newLogSourceMapper(ui, profile), cmdStart, &cmd.inputs.listInputs)
+}
+
+// tailLogs prints the most recent logs then follows the logs created since the command started,
+// forwarding the logs to the forward targets when there are any
+func tailLogs(ui terminal.UI, realmClient realm.Client, app realm.App, opts realm.LogsOptions, filter logFilter, sourceMapper *logSourceMapper, cmdStart time.Time, inputs *listInputs) error {
+	opts.Limit = tailLookBehind
+
+	logs, err := realmClient.Logs(app.GroupID, app.ID, opts)
+	if err != nil {
+		return err
+	}
+
+	if len(logs) > tailLookBehind {
+		logs = logs[0:tailLookBehind]
+	}
+
+	var forwarder *logForwarder
+	if len(inputs.sinks) > 0 {
+		forwarder = newLogForwarder(app, inputs.sinks)
+		defer func() {
+			printForwardWarnings(ui, forwarder.close())
+		}()
+	}
+
+	handleLogs := func(logs realm.Logs) {
+		logs = filter.apply(logs)
+		printLogs(ui, logs, sourceMapper)
+		if forwarder != nil {
+			forwarder.forward(logs)
+			printForwardWarnings(ui, forwarder.warnings())
+		}
+	}
+
+	handleLogs(logs)
+
+	tail := newLogsTail(cmdStart)
+	tail.add(logs)
+
+	opts.Limit = 0
+	poller := logsPoller{
+		realmClient: realmClient,
+		groupID:     app.GroupID,
+		appID:       app.ID,
+		interval:    inputs.Interval.Duration,
+		verbose:     inputs.Verbose,
+		sigShutdown: inputs.sigShutdown,
+	}
+	return poller.tail(ui, opts, tail, handleLogs)
+}
+
+// logsClock is the clock used by the logs commands
+var logsClock clock.Clock = clock.System{}
+
+// logsTail tracks the logs seen while tailing, so each poll can look back far enough
+// to catch the logs which arrived late without displaying any log twice
+type logsTail struct {
+	since time.Time
+	seen  map[string]time.Time
+}
+
+func newLogsTail(since time.Time) *logsTail {
+	return &logsTail{since: since, seen: map[string]time.Time{}}
+}
+
+// start returns the start date of the next poll
+func (t *logsTail) start() time.Time {
+	return t.since.Add(-tailDedupeWindow)
+}
+
+// add records the logs and returns the ones not seen before
+func (t *logsTail) add(logs realm.Logs) realm.Logs {
+	unseen := make(realm.Logs, 0, len(logs))
+	for _, log := range logs {
+		key := logKey(log)
+		if _, ok := t.seen[key]; ok {
+			continue
+		}
+		t.seen[key] = log.Started
+		unseen = append(unseen, log)
+
+		if log.Started.After(t.since) {
+			t.since = log.Started
+		}
+	}
+
+	// forget the logs which are too old to be returned by the next poll
+	for key, started := range t.seen {
+		if started.Before(t.start()) {
+			delete(t.seen, key)
+		}
+	}
+
+	return unseen
+}
+
+// logKey identifies the log by its id, or by its contents when the id is unavailable
+func logKey(log realm.Log) string {
+	if log.ID != "" {
+		return log.ID
+	}
+	data, err := json.Marshal(log)
+	if err != nil {
+		return log.Type + log.Started.String() + log.Completed.String()
+	}
+	return string(data)
+}
+
+// tailBackoff returns how long to wait before retrying after the number of consecutive failed polls
+func tailBackoff(failures int) time.Duration {
+	backoff := tailBackoffMin
+	for i := 1; i < failures && backoff < tailBackoffMax; i++ {
+		backoff *= 2
+	}
+	if backoff > tailBackoffMax {
+		return tailBackoffMax
+	}
+	return backoff
+}
+
+// logsPoller polls for the logs of a Realm app
+type logsPoller struct {
+	realmClient realm.Client
+	groupID     string
+	appID       string
+	interval    time.Duration
+	verbose     bool
+	sigShutdown <-chan os.Signal
+}
+
+// tail polls for the logs created since the most recent log seen until a shutdown signal is received,
+// retrying failed polls with backoff and giving up once they have failed too many times in a row
+func (p logsPoller) tail(ui terminal.UI, opts realm.LogsOptions, tail *logsTail, handleLogs func(logs realm.Logs)) error {
+	lastHeartbeat := logsClock.Now()
+
+	var failures int
+	for {
+		wait := p.interval
+		if failures > 0 {
+			wait = tailBackoff(failures)
+		}
+
+		// a pending shutdown takes priority over the next poll
+		select {
+		case <-p.sigShutdown:
+			return nil
+		default:
+		}
+
+		select {
+		case <-p.sigShutdown:
+			return nil
+		case <-logsClock.After(wait):
+		}
+
+		opts.Start = tail.start()
+
+		logs, err := p.realmClient.Logs(p.groupID, p.appID, opts)
+		if err != nil {
+			failures++
+			if failures > tailMaxRetries {
+				return err
+			}
+			ui.Print(terminal.NewWarningLog("Failed to get logs, retrying in %s: %s", tailBackoff(failures), err))
+			continue
+		}
+		failures = 0
+
+		handleLogs(tail.add(logs))
+
+		if now := logsClock.Now(); p.verbose && now.Sub(lastHeartbeat) >= tailHeartbeatInterval {
+			ui.Print(terminal.NewDebugLog("Still tailing logs, the most recent log started at %s", tail.since.Format(dateFormat)))
+			lastHeartbeat = now
+		}
+	}
+}
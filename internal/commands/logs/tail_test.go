@@ -0,0 +1,96 @@
+package logs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func setupFakeClock(now time.Time) (*mock.Clock, func()) {
+	origLogsClock := logsClock
+
+	clock := &mock.Clock{Time: now}
+	logsClock = clock
+
+	return clock, func() { logsClock = origLogsClock }
+}
+
+func TestLogsTail(t *testing.T) {
+	since := time.Date(2021, time.June, 22, 7, 54, 40, 0, time.UTC)
+
+	t.Run("should start the next poll the dedupe window before the most recent log seen", func(t *testing.T) {
+		tail := newLogsTail(since)
+		assert.Equal(t, since.Add(-tailDedupeWindow), tail.start())
+
+		tail.add(realm.Logs{{ID: "log0", Started: since.Add(time.Minute)}, {ID: "log1", Started: since.Add(time.Second)}})
+		assert.Equal(t, since.Add(time.Minute).Add(-tailDedupeWindow), tail.start())
+	})
+
+	t.Run("should only return the logs not seen before", func(t *testing.T) {
+		tail := newLogsTail(since)
+
+		assert.Equal(t, realm.Logs{{ID: "log0", Started: since}}, tail.add(realm.Logs{{ID: "log0", Started: since}}))
+		assert.Equal(t, realm.Logs{{ID: "log1", Started: since}}, tail.add(realm.Logs{{ID: "log0", Started: since}, {ID: "log1", Started: since}}))
+		assert.Equal(t, realm.Logs{}, tail.add(realm.Logs{{ID: "log1", Started: since}}))
+	})
+
+	t.Run("should identify the logs without an id by their contents", func(t *testing.T) {
+		tail := newLogsTail(since)
+
+		log0 := realm.Log{Type: realm.LogTypeAuth, Started: since, Messages: []interface{}{"log0"}}
+		log1 := realm.Log{Type: realm.LogTypeAuth, Started: since, Messages: []interface{}{"log1"}}
+
+		assert.Equal(t, realm.Logs{log0}, tail.add(realm.Logs{log0}))
+		assert.Equal(t, realm.Logs{log1}, tail.add(realm.Logs{log0, log1}))
+	})
+
+	t.Run("should forget the logs from before the dedupe window", func(t *testing.T) {
+		tail := newLogsTail(since)
+
+		tail.add(realm.Logs{{ID: "log0", Started: since}})
+		tail.add(realm.Logs{{ID: "log1", Started: since.Add(tailDedupeWindow + time.Second)}})
+
+		assert.Equal(t, 1, len(tail.seen))
+		_, ok := tail.seen["log1"]
+		assert.True(t, ok, "expected log1 to be remembered")
+	})
+}
+
+func TestLogsTailBackoff(t *testing.T) {
+	for _, tc := range []struct {
+		failures int
+		backoff  time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{5, 16 * time.Second},
+		{6, 30 * time.Second},
+		{100, 30 * time.Second},
+	} {
+		assert.Equal(t, tc.backoff, tailBackoff(tc.failures))
+	}
+}
+
+func TestLogsTailInputsResolve(t *testing.T) {
+	t.Run("should always tail the logs", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		inputs := tailInputs{listInputs{ProjectInputs: cli.ProjectInputs{Project: "project", App: "test-app"}}}
+		assert.Nil(t, inputs.Resolve(profile, nil))
+		assert.True(t, inputs.Tail, "expected the inputs to tail")
+		assert.Equal(t, defaultTailInterval, inputs.Interval.Duration)
+	})
+
+	t.Run("should resolve the forward targets", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		inputs := tailInputs{listInputs{ProjectInputs: cli.ProjectInputs{Project: "project", App: "test-app"}, Forward: []string{"https://example.com/logs"}}}
+		assert.Nil(t, inputs.Resolve(profile, nil))
+		assert.Equal(t, 1, len(inputs.sinks))
+	})
+}
@@ -0,0 +1,179 @@
+package logs
+
+import (
+	"errors"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/terminal"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+)
+
+// CommandMetaWatch is the command meta for the `logs watch` command
+var CommandMetaWatch = cli.CommandMeta{
+	Use:         "watch",
+	Display:     "logs watch",
+	Description: "Watch the Logs in your Realm app and alert when rules fire",
+	HelpText: `Tails your Realm app's Logs and evaluates the rules found in the JSON file
+specified by "--config". A rule fires once more than its "threshold" of Logs
+matching it started within its "window" (default: 1m), and will not fire again
+until its "cooldown" (default: 5m) has passed. Only the Logs which started after
+the watch began are evaluated. For example:
+
+  {
+    "rules": [
+      {
+        "name": "function errors",
+        "match": { "types": ["FUNCTION"], "errors_only": true },
+        "threshold": 10,
+        "window": "5m",
+        "webhook": "https://example.com/alerts"
+      },
+      {
+        "name": "auth failures",
+        "match": { "types": ["AUTH"], "auth_provider": "local-userpass", "auth_failed": true },
+        "command": "notify-send \"Realm auth failure\""
+      }
+    ]
+  }
+
+Logs can be matched by "types", "errors_only", "function", "trigger", "webhook",
+"error_code", "grep", "auth_provider" and "auth_failed". When a rule fires, its
+"command" is run with the alert JSON passed through stdin and its "webhook" is
+sent the alert JSON with a POST request. A command which runs for longer than 30
+seconds is given up on, so that watching can continue.`,
+}
+
+// CommandWatch is the `logs watch` command
+type CommandWatch struct {
+	inputs watchInputs
+}
+
+type watchInputs struct {
+	cli.ProjectInputs
+	Config      string
+	Interval    flags.Duration
+	Verbose     bool
+	config      watchConfig
+	sigShutdown chan os.Signal
+}
+
+// Flags is the command flags
+func (cmd *CommandWatch) Flags() []flags.Flag {
+	return []flags.Flag{
+		cli.AppFlagWithContext(&cmd.inputs.App, "to watch its logs"),
+		cli.ProjectFlag(&cmd.inputs.Project),
+		cli.ProductFlag(&cmd.inputs.Products),
+		flags.StringFlag{
+			Value: &cmd.inputs.Config,
+			Meta: flags.Meta{
+				Name: "config",
+				Usage: flags.Usage{
+					Description: "Specify the filepath of the JSON file with the rules to evaluate",
+				},
+			},
+		},
+		flags.CustomFlag{
+			Value: &cmd.inputs.Interval,
+			Meta: flags.Meta{
+				Name: flagInterval,
+				Usage: flags.Usage{
+					Description:   "Specify how often to poll for new logs",
+					DefaultValue:  defaultTailInterval.String(),
+					AllowedFormat: "<number><unit> (e.g. 500ms, 1.5s or 1m)",
+				},
+			},
+		},
+		flags.BoolFlag{
+			Value: &cmd.inputs.Verbose,
+			Meta: flags.Meta{
+				Name: "verbose",
+				Usage: flags.Usage{
+					Description: "Periodically display a heartbeat when watching logs",
+				},
+			},
+		},
+	}
+}
+
+// Inputs is the command inputs
+func (cmd *CommandWatch) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandWatch) Handler(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	rules := cmd.inputs.config.Rules
+
+	ui.Print(terminal.NewTextLog("Watching the logs of %s with %d rules", app.Name, len(rules)))
+
+	poller := logsPoller{
+		realmClient: clients.Realm,
+		groupID:     app.GroupID,
+		appID:       app.ID,
+		interval:    cmd.inputs.Interval.Duration,
+		verbose:     cmd.inputs.Verbose,
+		sigShutdown: cmd.inputs.sigShutdown,
+	}
+	watchStart := logsClock.Now()
+	return poller.tail(ui, realm.LogsOptions{}, newLogsTail(watchStart), func(logs realm.Logs) {
+		// the first poll looks back for late logs, which must not count towards the rules
+		logs = logsStartedSince(logs, watchStart)
+
+		now := logsClock.Now()
+		for _, rule := range rules {
+			if alert, ok := rule.evaluate(logs, now); ok {
+				sendWatchAlert(ui, rule, alert)
+			}
+		}
+	})
+}
+
+// logsStartedSince returns the logs which started at or after the time
+func logsStartedSince(logs realm.Logs, since time.Time) realm.Logs {
+	started := make(realm.Logs, 0, len(logs))
+	for _, log := range logs {
+		if !log.Started.Before(since) {
+			started = append(started, log)
+		}
+	}
+	return started
+}
+
+func (i *watchInputs) Resolve(profile *user.Profile, ui terminal.UI) error {
+	if i.Config == "" {
+		return errors.New(`must specify the rules to evaluate with "config"`)
+	}
+	if !filepath.IsAbs(i.Config) {
+		i.Config = filepath.Join(profile.WorkingDirectory, i.Config)
+	}
+
+	config, err := loadWatchConfig(i.Config)
+	if err != nil {
+		return err
+	}
+	i.config = config
+
+	if i.Interval.Duration < 0 {
+		return errors.New(`"interval" must not be negative`)
+	}
+	if i.Interval.Duration == 0 {
+		i.Interval.Duration = defaultTailInterval
+	}
+
+	i.sigShutdown = make(chan os.Signal, 1)
+	signal.Notify(i.sigShutdown, syscall.SIGTERM, syscall.SIGINT)
+
+	return i.ProjectInputs.Resolve(ui, profile.WorkingDirectory, true)
+}
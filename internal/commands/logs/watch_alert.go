@@ -0,0 +1,113 @@
+package logs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/terminal"
+	"github.com/10gen/realm-cli/internal/utils/api"
+)
+
+const (
+	envAlertRule = "REALM_ALERT_RULE"
+
+	watchWebhookTimeout = 10 * time.Second
+)
+
+// watchCommandTimeout is how long the command of a fired rule may run before it is killed,
+// so a hung command cannot stop the logs from being watched
+var watchCommandTimeout = 30 * time.Second
+
+// sendWatchAlert runs the command and posts to the webhook of the fired rule,
+// any failures are displayed as warnings so watching can continue
+func sendWatchAlert(ui terminal.UI, rule *watchRule, alert watchAlert) {
+	ui.Print(terminal.NewWarningLog(
+		"Rule '%s' fired: %d matching logs in the last %s",
+		rule.Name,
+		alert.Count,
+		alert.Window,
+	))
+
+	payload, err := json.Marshal(alert)
+	if err != nil {
+		ui.Print(terminal.NewWarningLog("Failed to create the alert of rule '%s': %s", rule.Name, err))
+		return
+	}
+
+	if rule.Command != "" {
+		if err := runWatchAlertCommand(rule.Command, rule.Name, payload); err != nil {
+			ui.Print(terminal.NewWarningLog("Failed to run the command of rule '%s': %s", rule.Name, err))
+		}
+	}
+
+	if rule.Webhook != "" {
+		if err := postWatchAlert(rule.Webhook, payload); err != nil {
+			ui.Print(terminal.NewWarningLog("Failed to post the alert of rule '%s' to %s: %s", rule.Name, rule.Webhook, err))
+		}
+	}
+}
+
+// runWatchAlertCommand runs the command with the shell, passing the alert JSON through stdin,
+// and gives up on the command once it runs for longer than the timeout
+func runWatchAlertCommand(command, ruleName string, payload []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), watchCommandTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = append(os.Environ(), envAlertRule+"="+ruleName)
+
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	// the shell is killed once the context is done, but any process it started
+	// may keep its output open, so waiting for the command is not relied on to return
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("timed out after %s", watchCommandTimeout)
+	case err := <-done:
+		if err != nil {
+			if out := strings.TrimSpace(output.String()); out != "" {
+				return fmt.Errorf("%s: %s", err, out)
+			}
+			return err
+		}
+		return nil
+	}
+}
+
+func postWatchAlert(url string, payload []byte) error {
+	client := &http.Client{Timeout: watchWebhookTimeout}
+
+	res, err := client.Post(url, api.MediaTypeJSON, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("unexpected status code %d", res.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+package logs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+)
+
+const (
+	defaultWatchRuleWindow   = time.Minute
+	defaultWatchRuleCooldown = 5 * time.Minute
+
+	watchAlertMaxLogs = 10
+)
+
+// watchConfig is the config of the rules evaluated by `logs watch`
+type watchConfig struct {
+	Rules []*watchRule `json:"rules"`
+}
+
+// watchRule fires once more than the threshold of logs matching it started within its window
+type watchRule struct {
+	Name      string         `json:"name"`
+	Match     watchRuleMatch `json:"match"`
+	Threshold int            `json:"threshold"`
+	Window    string         `json:"window,omitempty"`
+	Cooldown  string         `json:"cooldown,omitempty"`
+	Command   string         `json:"command,omitempty"`
+	Webhook   string         `json:"webhook,omitempty"`
+
+	filter    logFilter
+	window    time.Duration
+	cooldown  time.Duration
+	matched   realm.Logs
+	lastFired time.Time
+}
+
+// watchRuleMatch are the criteria a log must meet to match a rule
+type watchRuleMatch struct {
+	Types        []string `json:"types,omitempty"`
+	ErrorsOnly   bool     `json:"errors_only,omitempty"`
+	Function     string   `json:"function,omitempty"`
+	Trigger      string   `json:"trigger,omitempty"`
+	Webhook      string   `json:"webhook,omitempty"`
+	ErrorCode    string   `json:"error_code,omitempty"`
+	Grep         string   `json:"grep,omitempty"`
+	AuthProvider string   `json:"auth_provider,omitempty"`
+	AuthFailed   bool     `json:"auth_failed,omitempty"`
+}
+
+// watchAlert is the alert sent when a rule fires
+type watchAlert struct {
+	Rule      string      `json:"rule"`
+	Count     int         `json:"count"`
+	Window    string      `json:"window"`
+	Threshold int         `json:"threshold"`
+	FiredAt   time.Time   `json:"fired_at"`
+	Logs      []realm.Log `json:"logs"`
+}
+
+func loadWatchConfig(path string) (watchConfig, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return watchConfig{}, err
+	}
+
+	var config watchConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return watchConfig{}, fmt.Errorf("failed to parse the watch config at %s: %s", path, err)
+	}
+
+	if len(config.Rules) == 0 {
+		return watchConfig{}, fmt.Errorf("the watch config at %s must have at least one rule", path)
+	}
+	for i, rule := range config.Rules {
+		if err := rule.init(); err != nil {
+			return watchConfig{}, fmt.Errorf("invalid rule %d in the watch config at %s: %s", i+1, path, err)
+		}
+	}
+	return config, nil
+}
+
+// init validates the rule and prepares it for evaluation
+func (r *watchRule) init() error {
+	if r.Name == "" {
+		return errors.New("rule must have a name")
+	}
+	if r.Command == "" && r.Webhook == "" {
+		return fmt.Errorf("rule '%s' must have either a command or a webhook", r.Name)
+	}
+	if r.Threshold < 0 {
+		return fmt.Errorf("rule '%s' must not have a negative threshold", r.Name)
+	}
+
+	r.window = defaultWatchRuleWindow
+	if r.Window != "" {
+		window, err := time.ParseDuration(r.Window)
+		if err != nil || window <= 0 {
+			return fmt.Errorf("rule '%s' has an invalid window: %s", r.Name, r.Window)
+		}
+		r.window = window
+	}
+
+	r.cooldown = defaultWatchRuleCooldown
+	if r.Cooldown != "" {
+		cooldown, err := time.ParseDuration(r.Cooldown)
+		if err != nil || cooldown < 0 {
+			return fmt.Errorf("rule '%s' has an invalid cooldown: %s", r.Name, r.Cooldown)
+		}
+		r.cooldown = cooldown
+	}
+
+	r.filter = logFilter{
+		function:  r.Match.Function,
+		trigger:   r.Match.Trigger,
+		webhook:   r.Match.Webhook,
+		errorCode: r.Match.ErrorCode,
+	}
+	if r.Match.Grep != "" {
+		pattern, err := regexp.Compile(r.Match.Grep)
+		if err != nil {
+			return fmt.Errorf("rule '%s' has an invalid grep pattern: %s", r.Name, err)
+		}
+		r.filter.pattern = pattern
+	}
+
+	return nil
+}
+
+func (r *watchRule) matches(log realm.Log) bool {
+	if len(r.Match.Types) > 0 && !containsString(r.Match.Types, log.Type) {
+		return false
+	}
+	if r.Match.ErrorsOnly && log.Error == "" {
+		return false
+	}
+	if r.Match.AuthProvider != "" && log.AuthEvent.Provider != r.Match.AuthProvider {
+		return false
+	}
+	if r.Match.AuthFailed && !log.AuthEvent.Failed {
+		return false
+	}
+	return r.filter.matches(log)
+}
+
+// evaluate records the logs which match the rule and returns the alert to send if the rule fires,
+// the window is measured back from now against when each log started
+func (r *watchRule) evaluate(logs realm.Logs, now time.Time) (watchAlert, bool) {
+	for _, log := range logs {
+		if r.matches(log) {
+			r.matched = append(r.matched, log)
+		}
+	}
+
+	// forget the matches which have fallen out of the window, logs can arrive late and out of order
+	matched := r.matched[:0]
+	for _, log := range r.matched {
+		if now.Sub(log.Started) <= r.window {
+			matched = append(matched, log)
+		}
+	}
+	r.matched = matched
+
+	if len(r.matched) <= r.Threshold {
+		return watchAlert{}, false
+	}
+	if !r.lastFired.IsZero() && now.Sub(r.lastFired) < r.cooldown {
+		return watchAlert{}, false
+	}
+
+	alert := watchAlert{
+		Rule:      r.Name,
+		Count:     len(r.matched),
+		Window:    r.window.String(),
+		Threshold: r.Threshold,
+		FiredAt:   now,
+	}
+
+	sort.Stable(r.matched)
+
+	alertLogs := r.matched
+	if len(alertLogs) > watchAlertMaxLogs {
+		alertLogs = alertLogs[len(alertLogs)-watchAlertMaxLogs:]
+	}
+	alert.Logs = append(alert.Logs, alertLogs...)
+
+	r.matched = nil
+	r.lastFired = now
+
+	return alert, true
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,180 @@
+package logs
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestLoadWatchConfig(t *testing.T) {
+	tmpDir, teardown, err := u.NewTempDir("")
+	assert.Nil(t, err)
+	defer teardown()
+
+	writeConfig := func(t *testing.T, name, data string) string {
+		t.Helper()
+		path := filepath.Join(tmpDir, name)
+		assert.Nil(t, ioutil.WriteFile(path, []byte(data), 0666))
+		return path
+	}
+
+	t.Run("should load the rules with their defaults", func(t *testing.T) {
+		path := writeConfig(t, "valid.json", `{
+  "rules": [
+    {"name": "function errors", "match": {"types": ["FUNCTION"], "errors_only": true}, "threshold": 10, "window": "5m", "cooldown": "1h", "webhook": "https://example.com"},
+    {"name": "auth failures", "match": {"auth_provider": "local-userpass", "auth_failed": true, "grep": "denied"}, "command": "true"}
+  ]
+}`)
+
+		config, err := loadWatchConfig(path)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(config.Rules))
+
+		assert.Equal(t, "function errors", config.Rules[0].Name)
+		assert.Equal(t, 10, config.Rules[0].Threshold)
+		assert.Equal(t, 5*time.Minute, config.Rules[0].window)
+		assert.Equal(t, time.Hour, config.Rules[0].cooldown)
+
+		assert.Equal(t, "auth failures", config.Rules[1].Name)
+		assert.Equal(t, defaultWatchRuleWindow, config.Rules[1].window)
+		assert.Equal(t, defaultWatchRuleCooldown, config.Rules[1].cooldown)
+		assert.Equal(t, "denied", config.Rules[1].filter.pattern.String())
+	})
+
+	for _, tc := range []struct {
+		description string
+		data        string
+		err         string
+	}{
+		{"invalid json", `{"rules": [`, "failed to parse the watch config at %s: unexpected end of JSON input"},
+		{"no rules", `{"rules": []}`, "the watch config at %s must have at least one rule"},
+		{"a rule without a name", `{"rules": [{"command": "true"}]}`, "invalid rule 1 in the watch config at %s: rule must have a name"},
+		{"a rule without an action", `{"rules": [{"name": "rule"}]}`, "invalid rule 1 in the watch config at %s: rule 'rule' must have either a command or a webhook"},
+		{"a negative threshold", `{"rules": [{"name": "rule", "command": "true", "threshold": -1}]}`, "invalid rule 1 in the watch config at %s: rule 'rule' must not have a negative threshold"},
+		{"an invalid window", `{"rules": [{"name": "rule", "command": "true", "window": "5"}]}`, "invalid rule 1 in the watch config at %s: rule 'rule' has an invalid window: 5"},
+		{"an invalid cooldown", `{"rules": [{"name": "rule", "command": "true", "cooldown": "-1m"}]}`, "invalid rule 1 in the watch config at %s: rule 'rule' has an invalid cooldown: -1m"},
+		{"an invalid grep pattern", `{"rules": [{"name": "rule", "command": "true", "match": {"grep": "("}}]}`, "invalid rule 1 in the watch config at %s: rule 'rule' has an invalid grep pattern: error parsing regexp: missing closing ): `(`"},
+	} {
+		t.Run("should return an error with "+tc.description, func(t *testing.T) {
+			path := writeConfig(t, "invalid.json", tc.data)
+
+			_, err := loadWatchConfig(path)
+			assert.Equal(t, errors.New(fmt.Sprintf(tc.err, path)), err)
+		})
+	}
+}
+
+func TestWatchRuleEvaluate(t *testing.T) {
+	now := time.Date(2021, time.June, 22, 7, 54, 42, 0, time.UTC)
+
+	functionError := realm.Log{Type: realm.LogTypeFunction, Error: "oops", FunctionName: "func0"}
+	functionOK := realm.Log{Type: realm.LogTypeFunction, FunctionName: "func0"}
+	authFailure := realm.Log{Type: realm.LogTypeAuth, Error: "denied", AuthEvent: realm.LogAuthEvent{Failed: true, Provider: "local-userpass"}}
+	otherAuthFailure := realm.Log{Type: realm.LogTypeAuth, Error: "denied", AuthEvent: realm.LogAuthEvent{Failed: true, Provider: "anon-user"}}
+
+	at := func(log realm.Log, started time.Time) realm.Log {
+		log.Started = started
+		return log
+	}
+
+	newRule := func(t *testing.T, rule watchRule) *watchRule {
+		t.Helper()
+		rule.Name = "rule"
+		rule.Command = "true"
+		assert.Nil(t, rule.init())
+		return &rule
+	}
+
+	t.Run("should match logs by type, errors and auth event", func(t *testing.T) {
+		rule := newRule(t, watchRule{Match: watchRuleMatch{Types: []string{realm.LogTypeAuth}, AuthProvider: "local-userpass", AuthFailed: true}})
+
+		assert.True(t, rule.matches(authFailure), "expected the auth failure to match")
+		assert.False(t, rule.matches(otherAuthFailure), "expected the other provider's auth failure to not match")
+		assert.False(t, rule.matches(functionError), "expected the function error to not match")
+
+		rule = newRule(t, watchRule{Match: watchRuleMatch{Types: []string{realm.LogTypeFunction}, ErrorsOnly: true, Function: "func0"}})
+
+		assert.True(t, rule.matches(functionError), "expected the function error to match")
+		assert.False(t, rule.matches(functionOK), "expected the function log without an error to not match")
+	})
+
+	t.Run("should fire once more than the threshold of matching logs started within the window", func(t *testing.T) {
+		rule := newRule(t, watchRule{Match: watchRuleMatch{ErrorsOnly: true}, Threshold: 2, Window: "5m", Cooldown: "0s"})
+
+		_, ok := rule.evaluate(realm.Logs{at(functionError, now), at(functionOK, now)}, now)
+		assert.False(t, ok, "expected the rule to not fire")
+
+		// the first match falls out of the window
+		_, ok = rule.evaluate(realm.Logs{at(functionError, now.Add(6*time.Minute)), at(functionError, now.Add(6*time.Minute))}, now.Add(6*time.Minute))
+		assert.False(t, ok, "expected the rule to not fire")
+
+		alert, ok := rule.evaluate(realm.Logs{at(authFailure, now.Add(7*time.Minute))}, now.Add(7*time.Minute))
+		assert.True(t, ok, "expected the rule to fire")
+		assert.Equal(t, watchAlert{
+			Rule:      "rule",
+			Count:     3,
+			Window:    "5m0s",
+			Threshold: 2,
+			FiredAt:   now.Add(7 * time.Minute),
+			Logs: []realm.Log{
+				at(functionError, now.Add(6*time.Minute)),
+				at(functionError, now.Add(6*time.Minute)),
+				at(authFailure, now.Add(7*time.Minute)),
+			},
+		}, alert)
+
+		// the matches which fired are not counted again
+		_, ok = rule.evaluate(realm.Logs{at(functionError, now.Add(8*time.Minute))}, now.Add(8*time.Minute))
+		assert.False(t, ok, "expected the rule to not fire")
+	})
+
+	t.Run("should measure the window against when the logs started rather than when they were seen", func(t *testing.T) {
+		rule := newRule(t, watchRule{Match: watchRuleMatch{ErrorsOnly: true}, Threshold: 1, Window: "5m"})
+
+		// the late log started outside the window even though it was just seen
+		_, ok := rule.evaluate(realm.Logs{at(functionError, now.Add(-6*time.Minute)), at(functionError, now.Add(-time.Minute))}, now)
+		assert.False(t, ok, "expected the rule to not fire")
+
+		alert, ok := rule.evaluate(realm.Logs{at(functionError, now.Add(-2*time.Minute))}, now)
+		assert.True(t, ok, "expected the rule to fire")
+		assert.Equal(t, []realm.Log{
+			at(functionError, now.Add(-2*time.Minute)),
+			at(functionError, now.Add(-time.Minute)),
+		}, alert.Logs)
+	})
+
+	t.Run("should not fire again until the cooldown has passed", func(t *testing.T) {
+		rule := newRule(t, watchRule{Match: watchRuleMatch{ErrorsOnly: true}, Window: "10m", Cooldown: "5m"})
+
+		_, ok := rule.evaluate(realm.Logs{at(functionError, now)}, now)
+		assert.True(t, ok, "expected the rule to fire")
+
+		_, ok = rule.evaluate(realm.Logs{at(functionError, now.Add(time.Minute))}, now.Add(time.Minute))
+		assert.False(t, ok, "expected the rule to be cooling down")
+
+		alert, ok := rule.evaluate(realm.Logs{at(functionError, now.Add(5*time.Minute))}, now.Add(5*time.Minute))
+		assert.True(t, ok, "expected the rule to fire")
+		assert.Equal(t, 2, alert.Count)
+	})
+
+	t.Run("should only include the most recent matching logs in the alert", func(t *testing.T) {
+		rule := newRule(t, watchRule{})
+
+		logs := make(realm.Logs, watchAlertMaxLogs+5)
+		for i := range logs {
+			logs[i] = realm.Log{Type: realm.LogTypeFunction, FunctionName: fmt.Sprintf("func%d", i), Started: now.Add(time.Duration(i) * time.Millisecond)}
+		}
+
+		alert, ok := rule.evaluate(logs, now.Add(time.Second))
+		assert.True(t, ok, "expected the rule to fire")
+		assert.Equal(t, len(logs), alert.Count)
+		assert.Equal(t, []realm.Log(logs[5:]), alert.Logs)
+	})
+}
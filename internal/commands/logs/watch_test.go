@@ -0,0 +1,260 @@
+package logs
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/api"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestLogsWatchInputs(t *testing.T) {
+	t.Run("should return an error when the config is not specified", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		var i watchInputs
+		assert.Equal(t, errors.New(`must specify the rules to evaluate with "config"`), i.Resolve(profile, nil))
+	})
+
+	t.Run("should load the config relative to the working directory and default the interval", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+		tmpDir, teardown, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer teardown()
+		profile.WorkingDirectory = tmpDir
+
+		assert.Nil(t, ioutil.WriteFile(
+			filepath.Join(profile.WorkingDirectory, "rules.json"),
+			[]byte(`{"rules": [{"name": "rule", "command": "true"}]}`),
+			0666,
+		))
+
+		i := watchInputs{ProjectInputs: cli.ProjectInputs{Project: "project", App: "app"}, Config: "rules.json"}
+		assert.Nil(t, i.Resolve(profile, nil))
+
+		assert.Equal(t, filepath.Join(profile.WorkingDirectory, "rules.json"), i.Config)
+		assert.Equal(t, 1, len(i.config.Rules))
+		assert.Equal(t, defaultTailInterval, i.Interval.Duration)
+	})
+
+	t.Run("should return an error when the interval is negative", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+		tmpDir, teardown, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer teardown()
+		profile.WorkingDirectory = tmpDir
+
+		assert.Nil(t, ioutil.WriteFile(
+			filepath.Join(profile.WorkingDirectory, "rules.json"),
+			[]byte(`{"rules": [{"name": "rule", "command": "true"}]}`),
+			0666,
+		))
+
+		i := watchInputs{Config: "rules.json", Interval: flags.Duration{Duration: -time.Second}}
+		assert.Equal(t, errors.New(`"interval" must not be negative`), i.Resolve(profile, nil))
+	})
+}
+
+func TestLogsWatchHandler(t *testing.T) {
+	cmdStart := time.Date(2021, time.June, 22, 7, 54, 40, 0, time.UTC)
+
+	functionError := func(id string, started time.Time) realm.Log {
+		return realm.Log{
+			ID:           id,
+			Type:         realm.LogTypeFunction,
+			Started:      started,
+			Completed:    started.Add(5 * time.Millisecond),
+			FunctionName: "func0",
+			Error:        "oops",
+		}
+	}
+
+	setupRealmClient := func(testLogs []realm.Logs, sigShutdown chan os.Signal) mock.RealmClient {
+		var polls int
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{Name: "test-app"}}, nil
+		}
+		realmClient.LogsFn = func(groupID, appID string, opts realm.LogsOptions) (realm.Logs, error) {
+			polls++
+			if polls == len(testLogs) {
+				sigShutdown <- os.Interrupt
+			}
+			return testLogs[polls-1], nil
+		}
+		return realmClient
+	}
+
+	t.Run("should post the alert to the webhook of a rule once it fires", func(t *testing.T) {
+		_, teardown := setupFakeClock(cmdStart)
+		defer teardown()
+
+		var alerts []watchAlert
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, api.MediaTypeJSON, r.Header.Get(api.HeaderContentType))
+
+			var alert watchAlert
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&alert))
+			alerts = append(alerts, alert)
+		}))
+		defer server.Close()
+
+		rule := &watchRule{Name: "function errors", Match: watchRuleMatch{ErrorsOnly: true}, Threshold: 1, Webhook: server.URL}
+		assert.Nil(t, rule.init())
+
+		sigShutdown := make(chan os.Signal, 1)
+		realmClient := setupRealmClient([]realm.Logs{
+			// the first poll looks back past when the watch started
+			{functionError("old", cmdStart.Add(-5*time.Second)), functionError("log0", cmdStart.Add(time.Second))},
+			{functionError("log0", cmdStart.Add(time.Second)), functionError("log1", cmdStart.Add(6*time.Second))},
+		}, sigShutdown)
+
+		out, ui := mock.NewUI()
+
+		cmd := &CommandWatch{watchInputs{
+			config:      watchConfig{Rules: []*watchRule{rule}},
+			Interval:    flags.Duration{Duration: 5 * time.Second},
+			sigShutdown: sigShutdown,
+		}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, `Watching the logs of test-app with 1 rules
+Rule 'function errors' fired: 2 matching logs in the last 1m0s
+`, out.String())
+
+		assert.Equal(t, 1, len(alerts))
+		assert.Equal(t, "function errors", alerts[0].Rule)
+		assert.Equal(t, 2, alerts[0].Count)
+		assert.Equal(t, 1, alerts[0].Threshold)
+		assert.Equal(t, []string{"log0", "log1"}, []string{alerts[0].Logs[0].ID, alerts[0].Logs[1].ID})
+	})
+
+	t.Run("should run the command of a rule with the alert passed through stdin", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("the command is run with sh")
+		}
+
+		_, teardown := setupFakeClock(cmdStart)
+		defer teardown()
+
+		tmpDir, teardownTmpDir, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer teardownTmpDir()
+
+		alertPath := filepath.Join(tmpDir, "alert.json")
+
+		rule := &watchRule{Name: "function errors", Command: `echo "$REALM_ALERT_RULE" > ` + alertPath + `.rule && cat > ` + alertPath}
+		assert.Nil(t, rule.init())
+
+		sigShutdown := make(chan os.Signal, 1)
+		realmClient := setupRealmClient([]realm.Logs{{functionError("log0", cmdStart.Add(time.Second))}}, sigShutdown)
+
+		_, ui := mock.NewUI()
+
+		cmd := &CommandWatch{watchInputs{
+			config:      watchConfig{Rules: []*watchRule{rule}},
+			Interval:    flags.Duration{Duration: 5 * time.Second},
+			sigShutdown: sigShutdown,
+		}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		data, err := ioutil.ReadFile(alertPath)
+		assert.Nil(t, err)
+
+		var alert watchAlert
+		assert.Nil(t, json.Unmarshal(data, &alert))
+		assert.Equal(t, "function errors", alert.Rule)
+		assert.Equal(t, 1, alert.Count)
+
+		ruleName, err := ioutil.ReadFile(alertPath + ".rule")
+		assert.Nil(t, err)
+		assert.Equal(t, "function errors\n", string(ruleName))
+	})
+
+	t.Run("should display a warning and keep watching when the command of a rule times out", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("the command is run with sh")
+		}
+
+		_, teardown := setupFakeClock(cmdStart)
+		defer teardown()
+
+		origWatchCommandTimeout := watchCommandTimeout
+		watchCommandTimeout = 100 * time.Millisecond
+		defer func() { watchCommandTimeout = origWatchCommandTimeout }()
+
+		rule := &watchRule{Name: "function errors", Command: "sleep 10", Cooldown: "0s"}
+		assert.Nil(t, rule.init())
+
+		sigShutdown := make(chan os.Signal, 1)
+		realmClient := setupRealmClient([]realm.Logs{
+			{functionError("log0", cmdStart.Add(time.Second))},
+			{functionError("log1", cmdStart.Add(6*time.Second))},
+		}, sigShutdown)
+
+		out, ui := mock.NewUI()
+
+		cmd := &CommandWatch{watchInputs{
+			config:      watchConfig{Rules: []*watchRule{rule}},
+			Interval:    flags.Duration{Duration: 5 * time.Second},
+			sigShutdown: sigShutdown,
+		}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, `Watching the logs of test-app with 1 rules
+Rule 'function errors' fired: 1 matching logs in the last 1m0s
+Failed to run the command of rule 'function errors': timed out after 100ms
+Rule 'function errors' fired: 1 matching logs in the last 1m0s
+Failed to run the command of rule 'function errors': timed out after 100ms
+`, out.String())
+	})
+
+	t.Run("should display a warning and keep watching when an alert fails to send", func(t *testing.T) {
+		_, teardown := setupFakeClock(cmdStart)
+		defer teardown()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		rule := &watchRule{Name: "function errors", Webhook: server.URL, Cooldown: "0s"}
+		assert.Nil(t, rule.init())
+
+		sigShutdown := make(chan os.Signal, 1)
+		realmClient := setupRealmClient([]realm.Logs{
+			{functionError("log0", cmdStart.Add(time.Second))},
+			{functionError("log1", cmdStart.Add(6*time.Second))},
+		}, sigShutdown)
+
+		out, ui := mock.NewUI()
+
+		cmd := &CommandWatch{watchInputs{
+			config:      watchConfig{Rules: []*watchRule{rule}},
+			Interval:    flags.Duration{Duration: 5 * time.Second},
+			sigShutdown: sigShutdown,
+		}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, `Watching the logs of test-app with 1 rules
+Rule 'function errors' fired: 1 matching logs in the last 1m0s
+Failed to post the alert of rule 'function errors' to `+server.URL+`: unexpected status code 500
+Rule 'function errors' fired: 1 matching logs in the last 1m0s
+Failed to post the alert of rule 'function errors' to `+server.URL+`: unexpected status code 500
+`, out.String())
+	})
+}
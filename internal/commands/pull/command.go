@@ -122,6 +122,18 @@ func (cmd *Command) Flags() []flags.Flag {
 				},
 			},
 		},
+		flags.CustomFlag{
+			Value: &cmd.inputs.Format,
+			Meta: flags.Meta{
+				Name: "format",
+				Usage: flags.Usage{
+					Description:   "Specify the file format to write the app configuration files as",
+					Note:          "Defaults to the format of the local app configuration file, if one exists. Existing YAML files keep their format",
+					DefaultValue:  "<none>",
+					AllowedValues: local.FileFormatValues,
+				},
+			},
+		},
 		cli.ProjectFlag(&cmd.inputs.Project),
 		cli.ConfigVersionFlag(&cmd.inputs.AppVersion, "Specify the app config version to export as"),
 	}
@@ -192,6 +204,9 @@ func (cmd *Command) Handler(profile *user.Profile, ui terminal.UI, clients cli.C
 	if err := local.WriteZip(pathBackend, zipPkg); err != nil {
 		return fmt.Errorf("unable to write app to disk: %s", err)
 	}
+	if err := local.ConvertFormat(pathBackend, cmd.inputs.Format); err != nil {
+		return fmt.Errorf("unable to write app to disk: %s", err)
+	}
 	ui.Print(terminal.NewTextLog("Saved app to disk"))
 
 	if cmd.inputs.IncludeNodeModules || cmd.inputs.IncludePackageJSON || cmd.inputs.IncludeDependencies {
@@ -157,6 +157,25 @@ Successfully pulled app down: app
 			assert.Equal(t, `{"egg":"corn"}
 `, string(testData))
 		})
+
+		t.Run("should write the received zip package to the destination as yaml when the format is set", func(t *testing.T) {
+			profile, teardown := mock.NewProfileFromTmpDir(t, "pull_handler_test")
+			defer teardown()
+
+			_, ui := mock.NewUI()
+
+			cmd := &Command{inputs{Project: "elsewhere", LocalPath: "app", Format: local.FileFormatYAML}}
+
+			assert.Nil(t, cmd.Handler(profile, ui, cli.Clients{Realm: realmClient}))
+			destination := filepath.Join(profile.WorkingDirectory, "app")
+
+			testData, readErr := ioutil.ReadFile(filepath.Join(destination, "test.yaml"))
+			assert.Nil(t, readErr)
+			assert.Equal(t, "egg: corn\n", string(testData))
+
+			_, err := os.Stat(filepath.Join(destination, "test.json"))
+			assert.True(t, os.IsNotExist(err), "expected test.json to not exist, but instead: %s", err)
+		})
 	})
 
 	t.Run("with a realm client that fails to export dependencies", func(t *testing.T) {
@@ -27,6 +27,7 @@ type inputs struct {
 	RemoteApp           string
 	LocalPath           string
 	AppVersion          realm.AppConfigVersion
+	Format              local.FileFormat
 	IncludeDependencies bool
 	IncludeNodeModules  bool
 	IncludePackageJSON  bool
@@ -77,6 +78,10 @@ func (i *inputs) Resolve(profile *user.Profile, ui terminal.UI) error {
 		if i.RemoteApp == "" {
 			i.RemoteApp = app.Option()
 		}
+
+		if i.Format == local.FileFormatEmpty {
+			i.Format = app.FileFormat()
+		}
 	}
 
 	return nil
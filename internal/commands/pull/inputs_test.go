@@ -42,6 +42,14 @@ func TestPullInputsResolve(t *testing.T) {
 			assert.Equal(t, profile.WorkingDirectory, i.LocalPath)
 			assert.Equal(t, "eggcorn-abcde", i.RemoteApp)
 			assert.Equal(t, realm.AppConfigVersion20210101, i.AppVersion)
+			assert.Equal(t, local.FileFormatJSON, i.Format)
+		})
+
+		t.Run("should not override the format flag if set", func(t *testing.T) {
+			i := inputs{Format: local.FileFormatYAML}
+			assert.Nil(t, i.Resolve(profile, nil))
+
+			assert.Equal(t, local.FileFormatYAML, i.Format)
 		})
 
 		t.Run("should return an error if app version flag is different from the project value", func(t *testing.T) {
@@ -62,6 +70,23 @@ func TestPullInputsResolve(t *testing.T) {
 		})
 	})
 
+	t.Run("should default the format to yaml when run inside a project directory with a yaml config", func(t *testing.T) {
+		profile, teardown := mock.NewProfileFromTmpDir(t, "pull_input_test")
+		defer teardown()
+
+		assert.Nil(t, ioutil.WriteFile(
+			filepath.Join(profile.WorkingDirectory, local.FileRealmConfigYAML.String()),
+			[]byte("config_version: 20210101\napp_id: eggcorn-abcde\nname: eggcorn\n"),
+			0666,
+		))
+
+		var i inputs
+		assert.Nil(t, i.Resolve(profile, nil))
+
+		assert.Equal(t, "eggcorn-abcde", i.RemoteApp)
+		assert.Equal(t, local.FileFormatYAML, i.Format)
+	})
+
 	t.Run("resolving the to flag should work", func(t *testing.T) {
 		homeDir, teardown := u.SetupHomeDir("")
 		defer teardown()
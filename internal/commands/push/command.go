@@ -2,6 +2,7 @@ package push
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 
 const (
 	flagLocalPath           = "local"
+	flagBundle              = "bundle"
 	flagRemote              = "remote"
 	flagIncludeDependencies = "include-dependencies"
 	flagIncludeNodeModules  = "include-node-modules"
@@ -24,6 +26,17 @@ const (
 	flagIncludeHosting      = "include-hosting"
 	flagResetCDNCache       = "reset-cdn-cache"
 	flagDryRun              = "dry-run"
+	flagAllLocal            = "all-local"
+)
+
+const (
+	headerApp    = "App"
+	headerPath   = "Path"
+	headerStatus = "Status"
+	headerError  = "Error"
+
+	statusSucceeded = "succeeded"
+	statusFailed    = "failed"
 )
 
 var (
@@ -59,6 +72,16 @@ func (cmd *Command) Flags() []flags.Flag {
 				},
 			},
 		},
+		flags.StringFlag{
+			Value: &cmd.inputs.Bundle,
+			Meta: flags.Meta{
+				Name: flagBundle,
+				Usage: flags.Usage{
+					Description: "Specify the filepath of a Realm app bundle to be imported",
+					Note:        "Bundles can be created with the 'app bundle' command",
+				},
+			},
+		},
 		flags.StringFlag{
 			Value: &cmd.inputs.RemoteApp,
 			Meta: flags.Meta{
@@ -130,6 +153,17 @@ func (cmd *Command) Flags() []flags.Flag {
 				},
 			},
 		},
+		flags.BoolFlag{
+			Value: &cmd.inputs.AllLocal,
+			Meta: flags.Meta{
+				Name: flagAllLocal,
+				Usage: flags.Usage{
+					Description: "Push every Realm app found within the local filepath",
+					Note:        "Each app is pushed to the remote Realm app matching its ID, a failed push does not stop the remaining apps from being pushed",
+				},
+			},
+		},
+		cli.ProfileLoadFlag(&cmd.inputs.ProfileLoad),
 		cli.ProjectFlag(&cmd.inputs.Project),
 	}
 }
@@ -141,10 +175,56 @@ func (cmd *Command) Inputs() cli.InputResolver {
 
 // Handler is the command handler
 func (cmd *Command) Handler(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
-	app, err := local.LoadApp(cmd.inputs.LocalPath)
+	if cmd.inputs.AllLocal {
+		return cmd.pushAll(profile, ui, clients)
+	}
+	return cmd.push(profile, ui, clients)
+}
+
+func (cmd *Command) pushAll(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
+	rows := make([]map[string]interface{}, 0, len(cmd.inputs.localApps))
+
+	var failed int
+	for _, app := range cmd.inputs.localApps {
+		path := app.RootDir
+		if rel, err := filepath.Rel(profile.WorkingDirectory, app.RootDir); err == nil {
+			path = rel
+		}
+		ui.Print(terminal.NewTextLog("Pushing app: %s", path))
+
+		appCmd := Command{cmd.inputs}
+		appCmd.inputs.AllLocal = false
+		appCmd.inputs.LocalPath = app.RootDir
+		appCmd.inputs.RemoteApp = app.ID()
+
+		row := map[string]interface{}{headerApp: app.Option(), headerPath: path, headerStatus: statusSucceeded}
+		if err := appCmd.push(profile, ui, clients); err != nil {
+			failed++
+			row[headerStatus] = statusFailed
+			row[headerError] = err.Error()
+			ui.Print(terminal.NewErrorLog(err))
+		}
+		rows = append(rows, row)
+	}
+
+	ui.Print(terminal.NewTableLog(
+		fmt.Sprintf("Pushed %d of %d local apps", len(rows)-failed, len(rows)),
+		[]string{headerApp, headerPath, headerStatus, headerError},
+		rows...,
+	))
+
+	if failed > 0 {
+		return fmt.Errorf("failed to push %d of %d local apps", failed, len(rows))
+	}
+	return nil
+}
+
+func (cmd *Command) push(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, cleanupApp, err := cmd.inputs.loadApp(profile, ui)
 	if err != nil {
 		return err
 	}
+	defer cleanupApp()
 
 	appRemote, err := cmd.inputs.resolveRemoteApp(ui, clients.Realm)
 	if err != nil {
@@ -169,7 +249,12 @@ func (cmd *Command) Handler(profile *user.Profile, ui terminal.UI, clients cli.C
 			return nil
 		}
 
-		newApp, proceed, err := createNewApp(ui, clients.Realm, cmd.inputs.LocalPath, appRemote.GroupID, app.AppData)
+		appDirectory := cmd.inputs.LocalPath
+		if cmd.inputs.Bundle != "" {
+			appDirectory = app.RootDir
+		}
+
+		newApp, proceed, err := createNewApp(ui, clients.Realm, appDirectory, appRemote.GroupID, app.AppData)
 		if err != nil {
 			return err
 		}
@@ -292,6 +377,10 @@ func (cmd *Command) Handler(profile *user.Profile, ui terminal.UI, clients cli.C
 			}
 			return err
 		}
+
+		if err := app.WriteSourceMaps(); err != nil {
+			ui.Print(terminal.NewWarningLog("Failed to write the function source maps: %s", err))
+		}
 	}
 
 	if cmd.inputs.IncludePackageJSON || cmd.inputs.IncludeNodeModules || cmd.inputs.IncludeDependencies {
@@ -475,7 +564,15 @@ func createNewApp(ui terminal.UI, realmClient realm.Client, appDirectory, groupI
 		return realm.App{}, false, err
 	}
 
-	if err := local.AsApp(appDirectory, app, realm.DefaultAppConfigVersion).WriteConfig(); err != nil {
+	newApp := local.AsApp(appDirectory, app, realm.DefaultAppConfigVersion)
+
+	// keep the format of an existing config file so it is updated in place
+	if localApp, ok, err := local.FindApp(appDirectory); err == nil && ok &&
+		localApp.RootDir == newApp.RootDir && localApp.Config.Name == newApp.Config.Name {
+		newApp.Config = localApp.Config
+	}
+
+	if err := newApp.WriteConfig(); err != nil {
 		return realm.App{}, false, err
 	}
 	return app, true, nil
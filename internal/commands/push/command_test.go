@@ -914,6 +914,52 @@ Deployed app is identical to proposed version, nothing to do
 `, out.String())
 	})
 
+	t.Run("with all local set should push every local app and summarize the results", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+		profile.WorkingDirectory = filepath.Join(profile.WorkingDirectory, "testdata")
+
+		var realmClient mock.RealmClient
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{ID: filter.App, GroupID: "groupID", ClientAppID: filter.App}}, nil
+		}
+		realmClient.DiffFn = func(groupID, appID string, appData interface{}) ([]string, error) {
+			if appID == "eggcorn-edcba" {
+				return nil, errors.New("something bad happened")
+			}
+			return []string{}, nil
+		}
+
+		i := inputs{AllLocal: true, DryRun: true}
+		assert.Nil(t, i.Resolve(profile, nil))
+
+		out, ui := mock.NewUI()
+
+		cmd := &Command{i}
+
+		err := cmd.Handler(profile, ui, cli.Clients{Realm: realmClient})
+		assert.Equal(t, errors.New("failed to push 1 of 4 local apps"), err)
+		assert.Equal(t, `Pushing app: dependencies
+Determining changes
+Deployed app is identical to proposed version, nothing to do
+Pushing app: hosting
+Determining changes
+Deployed app is identical to proposed version, nothing to do
+Pushing app: project
+Determining changes
+Deployed app is identical to proposed version, nothing to do
+Pushing app: project-alt
+Determining changes
+something bad happened
+Pushed 3 of 4 local apps
+  App            Path          Status     Error                 
+  -------------  ------------  ---------  ----------------------
+  eggcorn-abcde  dependencies  succeeded                        
+  eggcorn-abcde  hosting       succeeded                        
+  eggcorn-abcde  project       succeeded                        
+  eggcorn-edcba  project-alt   failed     something bad happened
+`, out.String())
+	})
+
 	t.Run("with diffs generated from the app but is a dry run", func(t *testing.T) {
 		var realmClient mock.RealmClient
 		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
@@ -1218,6 +1264,34 @@ func TestPushCommandCreateNewApp(t *testing.T) {
 			}
 		})
 
+		t.Run("and a local app with a yaml config file should update the config file in place", func(t *testing.T) {
+			tmpDir, teardown, tmpDirErr := u.NewTempDir("push_handler")
+			assert.Nil(t, tmpDirErr)
+			defer teardown()
+
+			configPath := filepath.Join(tmpDir, local.FileRealmConfigYAML.String())
+			assert.Nil(t, ioutil.WriteFile(configPath, []byte("# my app\nconfig_version: 20210101\nname: name\n"), 0666))
+
+			ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, new(bytes.Buffer))
+
+			_, proceed, err := createNewApp(ui, realmClient, tmpDir, "groupID", fullPkg)
+			assert.Nil(t, err)
+			assert.True(t, proceed, "should proceed")
+
+			configData, readErr := ioutil.ReadFile(configPath)
+			assert.Nil(t, readErr)
+			assert.Equal(t, `# my app
+config_version: 20210101
+name: name
+location: location
+deployment_model: deployment_model
+environment: environment
+`, string(configData))
+
+			_, err = os.Stat(filepath.Join(tmpDir, local.FileRealmConfig.String()))
+			assert.True(t, os.IsNotExist(err), "expected %s to not exist", local.FileRealmConfig.String())
+		})
+
 		t.Run("and an interactive ui that is set to auto confirm", func(t *testing.T) {
 			for _, tc := range []struct {
 				description     string
@@ -2,6 +2,7 @@ package push
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 
@@ -25,6 +26,7 @@ type appRemote struct {
 
 type inputs struct {
 	LocalPath           string
+	Bundle              string
 	RemoteApp           string
 	Project             string
 	IncludeNodeModules  bool
@@ -33,6 +35,10 @@ type inputs struct {
 	IncludeHosting      bool
 	ResetCDNCache       bool
 	DryRun              bool
+	ProfileLoad         bool
+	AllLocal            bool
+
+	localApps []local.App
 }
 
 func (i *inputs) Resolve(profile *user.Profile, ui terminal.UI) error {
@@ -45,6 +51,14 @@ func (i *inputs) Resolve(profile *user.Profile, ui terminal.UI) error {
 		}
 	}
 
+	if i.AllLocal {
+		return i.resolveLocalApps(profile)
+	}
+
+	if i.Bundle != "" {
+		return i.resolveBundle(profile)
+	}
+
 	searchPath := i.LocalPath
 	if searchPath == "" {
 		searchPath = profile.WorkingDirectory
@@ -79,6 +93,106 @@ func (i *inputs) Resolve(profile *user.Profile, ui terminal.UI) error {
 	return nil
 }
 
+func (i *inputs) resolveBundle(profile *user.Profile) error {
+	for _, flag := range []struct {
+		name string
+		set  bool
+	}{
+		{flagLocalPath, i.LocalPath != ""},
+		{flagIncludeNodeModules, i.IncludeNodeModules},
+		{flagIncludePackageJSON, i.IncludePackageJSON},
+		{flagIncludeDependencies, i.IncludeDependencies},
+	} {
+		if flag.set {
+			return fmt.Errorf(errDependencyFlagConflictTemplate, flagBundle, flag.name)
+		}
+	}
+
+	if !filepath.IsAbs(i.Bundle) {
+		i.Bundle = filepath.Join(profile.WorkingDirectory, i.Bundle)
+	}
+
+	bundle, err := local.LoadBundle(i.Bundle)
+	if err != nil {
+		return err
+	}
+
+	if i.RemoteApp == "" {
+		i.RemoteApp = bundle.AppData.ID()
+	}
+
+	return nil
+}
+
+func (i *inputs) resolveLocalApps(profile *user.Profile) error {
+	for _, flag := range []struct {
+		name string
+		set  bool
+	}{
+		{flagBundle, i.Bundle != ""},
+		{flagRemote, i.RemoteApp != ""},
+	} {
+		if flag.set {
+			return fmt.Errorf(errDependencyFlagConflictTemplate, flagAllLocal, flag.name)
+		}
+	}
+
+	searchPath := i.LocalPath
+	if searchPath == "" {
+		searchPath = profile.WorkingDirectory
+	}
+
+	if _, err := os.Stat(searchPath); os.IsNotExist(err) {
+		return errProjectInvalid(searchPath, false)
+	}
+
+	apps, err := local.FindApps(searchPath)
+	if err != nil {
+		return err
+	}
+	if len(apps) == 0 {
+		return errProjectInvalid(searchPath, true)
+	}
+
+	i.localApps = apps
+	return nil
+}
+
+// loadApp loads the local app, unbundling it into a temporary directory first
+// if a bundle is specified; the returned callback performs any cleanup required
+func (i inputs) loadApp(profile *user.Profile, ui terminal.UI) (local.App, func(), error) {
+	if i.Bundle == "" {
+		app, err := cli.LoadApp(ui, profile, i.LocalPath, i.ProfileLoad)
+		return app, func() {}, err
+	}
+
+	bundle, err := local.LoadBundle(i.Bundle)
+	if err != nil {
+		return local.App{}, func() {}, err
+	}
+
+	tmpDir, err := ioutil.TempDir("", "") // uses os.TempDir and guarantees existence and proper permissions
+	if err != nil {
+		return local.App{}, func() {}, err
+	}
+	cleanup := func() {
+		// TODO(REALMC-8369): remove the nolint directive once errcheck is fixed
+		os.RemoveAll(tmpDir) //nolint: errcheck
+	}
+
+	if _, err := bundle.Unbundle(tmpDir, realm.AppConfigVersionZero); err != nil {
+		cleanup()
+		return local.App{}, func() {}, err
+	}
+
+	app, err := cli.LoadApp(ui, nil, tmpDir, i.ProfileLoad) // temporary files are not cached
+	if err != nil {
+		cleanup()
+		return local.App{}, func() {}, err
+	}
+	return app, cleanup, nil
+}
+
 func (i inputs) resolveRemoteApp(ui terminal.UI, client realm.Client) (appRemote, error) {
 	r := appRemote{GroupID: i.Project}
 
@@ -101,13 +215,16 @@ func (i inputs) resolveRemoteApp(ui terminal.UI, client realm.Client) (appRemote
 }
 
 func (i inputs) args(omitDryRun bool) []flags.Arg {
-	args := make([]flags.Arg, 0, 7)
+	args := make([]flags.Arg, 0, 8)
 	if i.Project != "" {
 		args = append(args, flags.Arg{cli.ProjectFlagName, i.Project})
 	}
 	if i.LocalPath != "" {
 		args = append(args, flags.Arg{flagLocalPath, i.LocalPath})
 	}
+	if i.Bundle != "" {
+		args = append(args, flags.Arg{flagBundle, i.Bundle})
+	}
 	if i.RemoteApp != "" {
 		args = append(args, flags.Arg{flagRemote, i.RemoteApp})
 	}
@@ -82,6 +82,75 @@ func TestPushInputsResolve(t *testing.T) {
 		assert.Equal(t, profile.WorkingDirectory, i.LocalPath)
 		assert.Equal(t, "eggcorn-abcde", i.RemoteApp)
 	})
+
+	t.Run("should return an error when bundle and local are both set", func(t *testing.T) {
+		i := inputs{Bundle: "eggcorn.json", LocalPath: "eggcorn"}
+		assert.Equal(t, errors.New(`cannot use both "bundle" and "local" at the same time`), i.Resolve(nil, nil))
+	})
+
+	t.Run("should return an error when bundle and a dependencies flag are both set", func(t *testing.T) {
+		i := inputs{Bundle: "eggcorn.json", IncludePackageJSON: true}
+		assert.Equal(t, errors.New(`cannot use both "bundle" and "include-package-json" at the same time`), i.Resolve(nil, nil))
+	})
+
+	t.Run("should return an error when all local and bundle are both set", func(t *testing.T) {
+		i := inputs{AllLocal: true, Bundle: "eggcorn.json"}
+		assert.Equal(t, errors.New(`cannot use both "all-local" and "bundle" at the same time`), i.Resolve(nil, nil))
+	})
+
+	t.Run("should return an error when all local and remote are both set", func(t *testing.T) {
+		i := inputs{AllLocal: true, RemoteApp: "eggcorn"}
+		assert.Equal(t, errors.New(`cannot use both "all-local" and "remote" at the same time`), i.Resolve(nil, nil))
+	})
+
+	t.Run("should find every app within the local path when all local is set", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		i := inputs{AllLocal: true, LocalPath: "testdata"}
+		assert.Nil(t, i.Resolve(profile, nil))
+
+		rootDirs := make([]string, 0, len(i.localApps))
+		for _, app := range i.localApps {
+			rootDirs = append(rootDirs, app.RootDir)
+		}
+		assert.Equal(t, []string{
+			filepath.Join(profile.WorkingDirectory, "testdata", "dependencies"),
+			filepath.Join(profile.WorkingDirectory, "testdata", "hosting"),
+			filepath.Join(profile.WorkingDirectory, "testdata", "project"),
+			filepath.Join(profile.WorkingDirectory, "testdata", "project-alt"),
+		}, rootDirs)
+	})
+
+	t.Run("should return an error when all local is set and no apps are found", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		i := inputs{AllLocal: true, LocalPath: "testdata/project/functions"}
+		err := i.Resolve(profile, nil)
+		assert.Equal(t, "directory 'testdata/project/functions' is not a supported Realm app project", err.Error())
+	})
+
+	t.Run("should set the remote app from the bundle if a bundle is specified", func(t *testing.T) {
+		profile, teardown := mock.NewProfileFromTmpDir(t, "app_init_input_test")
+		defer teardown()
+
+		assert.Nil(t, ioutil.WriteFile(
+			filepath.Join(profile.WorkingDirectory, "eggcorn.json"),
+			[]byte(fmt.Sprintf(`{"app":{"config_version": %d, "app_id": "eggcorn-abcde", "name":"eggcorn"}}`, realm.DefaultAppConfigVersion)),
+			0666,
+		))
+
+		i := inputs{Bundle: "eggcorn.json"}
+		assert.Nil(t, i.Resolve(profile, nil))
+
+		assert.Equal(t, filepath.Join(profile.WorkingDirectory, "eggcorn.json"), i.Bundle)
+		assert.Equal(t, "", i.LocalPath)
+		assert.Equal(t, "eggcorn-abcde", i.RemoteApp)
+
+		app, cleanup, err := i.loadApp(profile, nil)
+		defer cleanup()
+		assert.Nil(t, err)
+		assert.Equal(t, "eggcorn", app.Name())
+	})
 }
 
 func TestPushInputsResolveTo(t *testing.T) {
@@ -0,0 +1,397 @@
+package user
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/terminal"
+	"github.com/10gen/realm-cli/internal/utils/clock"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// set of supported user cleanup actions
+const (
+	cleanupActionDisable = "disable"
+	cleanupActionDelete  = "delete"
+	cleanupActionRevoke  = "revoke"
+)
+
+const (
+	flagInactiveSince = "inactive-since"
+
+	defaultCleanupConcurrency = 5
+	defaultCleanupFailures    = "user-cleanup.failures.csv"
+
+	headerProviderType = "Provider Type"
+	headerInactive     = "Inactive"
+	headerExcluded     = "Excluded"
+)
+
+// userClock is the clock users are considered inactive relative to
+var userClock clock.Clock = clock.System{}
+
+var cleanupActionsPastTense = map[string]string{
+	cleanupActionDisable: "disabled",
+	cleanupActionDelete:  "deleted",
+	cleanupActionRevoke:  "revoked the sessions of",
+}
+
+// CommandMetaCleanup is the command meta for the `user cleanup` command
+var CommandMetaCleanup = cli.CommandMeta{
+	Use:         "cleanup",
+	Display:     "user cleanup",
+	Description: "Disable, delete or revoke the sessions of the inactive users of your Realm app",
+	HelpText: `Finds the Users of your Realm app across all providers who have not logged in
+within the duration specified by "--inactive-since", or who have never logged in
+and were created before then. For example, "--inactive-since 180d" finds the Users
+who have been inactive for the last 180 days.
+
+A summary of the inactive Users is displayed before the "--action" is taken on
+each of them. Use "--dry-run" to display the Users without taking any action.
+Users can be left alone by their ID with "--exclude-user" or by the domain of
+their email with "--exclude-domain".
+
+The Users which could not be cleaned up are written to the CSV file specified
+by "--failures".`,
+}
+
+// CommandCleanup is the `user cleanup` command
+type CommandCleanup struct {
+	inputs cleanupInputs
+}
+
+type cleanupInputs struct {
+	cli.ProjectInputs
+	InactiveSince  flags.Duration
+	Action         string
+	DryRun         bool
+	ExcludeUsers   []string
+	ExcludeDomains []string
+	Concurrency    int
+	Failures       string
+}
+
+// Flags is the command flags
+func (cmd *CommandCleanup) Flags() []flags.Flag {
+	return []flags.Flag{
+		cli.AppFlagWithContext(&cmd.inputs.App, "to clean up its users"),
+		cli.ProjectFlag(&cmd.inputs.Project),
+		cli.ProductFlag(&cmd.inputs.Products),
+		flags.CustomFlag{
+			Value: &cmd.inputs.InactiveSince,
+			Meta: flags.Meta{
+				Name: flagInactiveSince,
+				Usage: flags.Usage{
+					Description:   "Specify how long users must not have logged in for to be cleaned up",
+					AllowedFormat: "<number><unit> (e.g. 90d or 720h)",
+				},
+			},
+		},
+		flags.StringFlag{
+			Value: &cmd.inputs.Action,
+			Meta: flags.Meta{
+				Name: "action",
+				Usage: flags.Usage{
+					Description:   "Specify the action to take on the inactive users",
+					AllowedValues: []string{cleanupActionDisable, cleanupActionDelete, cleanupActionRevoke},
+				},
+			},
+		},
+		flags.BoolFlag{
+			Value: &cmd.inputs.DryRun,
+			Meta: flags.Meta{
+				Name: "dry-run",
+				Usage: flags.Usage{
+					Description: "Display the inactive users without taking any action",
+				},
+			},
+		},
+		flags.StringSliceFlag{
+			Value: &cmd.inputs.ExcludeUsers,
+			Meta: flags.Meta{
+				Name: "exclude-user",
+				Usage: flags.Usage{
+					Description: "Specify the ID(s) of the users to leave alone",
+				},
+			},
+		},
+		flags.StringSliceFlag{
+			Value: &cmd.inputs.ExcludeDomains,
+			Meta: flags.Meta{
+				Name: "exclude-domain",
+				Usage: flags.Usage{
+					Description: "Specify the email domain(s) of the users to leave alone",
+				},
+			},
+		},
+		flags.IntFlag{
+			Value:        &cmd.inputs.Concurrency,
+			DefaultValue: defaultCleanupConcurrency,
+			Meta: flags.Meta{
+				Name: "concurrency",
+				Usage: flags.Usage{
+					Description: "Specify the max number of users being cleaned up at once",
+				},
+			},
+		},
+		flags.StringFlag{
+			Value: &cmd.inputs.Failures,
+			Meta: flags.Meta{
+				Name: "failures",
+				Usage: flags.Usage{
+					Description:  "Specify the filepath to write the users which failed to be cleaned up to",
+					DefaultValue: defaultCleanupFailures,
+				},
+			},
+		},
+	}
+}
+
+// Inputs is the command inputs
+func (cmd *CommandCleanup) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandCleanup) Handler(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	cutoff := userClock.Now().Add(-cmd.inputs.InactiveSince.Duration)
+
+	var inactive, excluded []realm.User
+	if err := clients.Realm.UsersPages(app.GroupID, app.ID, realm.UserFilter{}, func(page []realm.User) error {
+		for _, user := range page {
+			if !isInactiveUser(user, cutoff) {
+				continue
+			}
+			// there is nothing to do for the users which are already disabled
+			if cmd.inputs.Action == cleanupActionDisable && user.Disabled {
+				continue
+			}
+			if cmd.inputs.excludes(user) {
+				excluded = append(excluded, user)
+				continue
+			}
+			inactive = append(inactive, user)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if len(inactive) == 0 && len(excluded) == 0 {
+		ui.Print(terminal.NewTextLog("No users have been inactive since %s", cutoff.UTC()))
+		return nil
+	}
+
+	ui.Print(cleanupSummaryLog(cutoff, inactive, excluded))
+
+	if len(inactive) == 0 {
+		ui.Print(terminal.NewTextLog("No users to %s", cmd.inputs.Action))
+		return nil
+	}
+
+	if cmd.inputs.DryRun {
+		ui.Print(userListLogs(inactive, getUserComparerByLastAuthentication)...)
+		ui.Print(terminal.NewTextLog("Dry run: would have %s %d users", cleanupActionsPastTense[cmd.inputs.Action], len(inactive)))
+		return nil
+	}
+
+	proceed, err := ui.Confirm("Are you sure you want to %s %d users?", cmd.inputs.Action, len(inactive))
+	if err != nil {
+		return err
+	}
+	if !proceed {
+		return nil
+	}
+
+	var action func(groupID, appID, userID string) error
+	switch cmd.inputs.Action {
+	case cleanupActionDelete:
+		action = clients.Realm.DeleteUser
+	case cleanupActionRevoke:
+		action = clients.Realm.RevokeUserSessions
+	default:
+		action = clients.Realm.DisableUser
+	}
+
+	s := ui.Spinner(cleanupProgress(cmd.inputs.Action, 0, len(inactive)), terminal.SpinnerOptions{})
+	s.Start()
+
+	outputs := runCleanup(inactive, cmd.inputs.Concurrency, func(user realm.User) error {
+		return action(app.GroupID, app.ID, user.ID)
+	}, func(done int) {
+		s.SetMessage(cleanupProgress(cmd.inputs.Action, done, len(inactive)))
+	})
+
+	s.Stop()
+
+	var failures userOutputs
+	for _, output := range outputs {
+		if output.err != nil {
+			failures = append(failures, output)
+		}
+	}
+
+	ui.Print(terminal.NewTextLog("Successfully %s %d of %d users", cleanupActionsPastTense[cmd.inputs.Action], len(outputs)-len(failures), len(outputs)))
+
+	if len(failures) == 0 {
+		return nil
+	}
+
+	if err := writeCleanupFailures(cmd.inputs.Failures, failures); err != nil {
+		return fmt.Errorf("failed to write the users which failed to be cleaned up: %s", err)
+	}
+	ui.Print(terminal.NewWarningLog("Failed to %s %d users, the failures were written to %s", cmd.inputs.Action, len(failures), cmd.inputs.Failures))
+	return nil
+}
+
+func (i *cleanupInputs) Resolve(profile *user.Profile, ui terminal.UI) error {
+	if i.InactiveSince.Duration == 0 {
+		return fmt.Errorf(`must specify how long users must have been inactive for with "%s"`, flagInactiveSince)
+	}
+	if i.InactiveSince.Duration < 0 {
+		return fmt.Errorf(`"%s" must be positive`, flagInactiveSince)
+	}
+
+	if i.Action == "" {
+		if err := ui.AskOne(&i.Action, &survey.Select{
+			Message: "Which action would you like to take on the inactive users?",
+			Options: []string{cleanupActionDisable, cleanupActionDelete, cleanupActionRevoke},
+		}); err != nil {
+			return err
+		}
+	}
+	if _, ok := cleanupActionsPastTense[i.Action]; !ok {
+		return fmt.Errorf("unsupported action: %s", i.Action)
+	}
+
+	if i.Concurrency < 1 {
+		return errors.New(`"concurrency" must be positive`)
+	}
+
+	for idx, domain := range i.ExcludeDomains {
+		i.ExcludeDomains[idx] = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(domain), "@"))
+	}
+
+	if i.Failures == "" {
+		i.Failures = defaultCleanupFailures
+	}
+	if !filepath.IsAbs(i.Failures) {
+		i.Failures = filepath.Join(profile.WorkingDirectory, i.Failures)
+	}
+
+	return i.ProjectInputs.Resolve(ui, profile.WorkingDirectory, false)
+}
+
+// excludes reports whether the user has been left alone by either its ID or email domain
+func (i cleanupInputs) excludes(user realm.User) bool {
+	for _, id := range i.ExcludeUsers {
+		if user.ID == id {
+			return true
+		}
+	}
+
+	email, ok := user.Data[userDataEmail].(string)
+	if !ok {
+		return false
+	}
+	email = strings.ToLower(email)
+	for _, domain := range i.ExcludeDomains {
+		if strings.HasSuffix(email, "@"+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// isInactiveUser reports whether the user last logged in before the cutoff,
+// or was created before the cutoff when the user has never logged in
+func isInactiveUser(user realm.User, cutoff time.Time) bool {
+	if user.LastAuthenticationDate != 0 {
+		return time.Unix(user.LastAuthenticationDate, 0).Before(cutoff)
+	}
+	return user.CreationDate != 0 && time.Unix(user.CreationDate, 0).Before(cutoff)
+}
+
+func cleanupSummaryLog(cutoff time.Time, inactive, excluded []realm.User) terminal.Log {
+	inactiveByProviderType := toUserOutputs(inactive).byProviderType()
+	excludedByProviderType := toUserOutputs(excluded).byProviderType()
+
+	var rows []map[string]interface{}
+	for _, providerType := range realm.ValidAuthProviderTypes {
+		i, e := len(inactiveByProviderType[providerType]), len(excludedByProviderType[providerType])
+		if i == 0 && e == 0 {
+			continue
+		}
+		rows = append(rows, map[string]interface{}{
+			headerProviderType: providerType.Display(),
+			headerInactive:     i,
+			headerExcluded:     e,
+		})
+	}
+
+	return terminal.NewTableLog(
+		fmt.Sprintf("Found %d users inactive since %s", len(inactive)+len(excluded), cutoff.UTC()),
+		[]string{headerProviderType, headerInactive, headerExcluded},
+		rows...,
+	)
+}
+
+func cleanupProgress(action string, done, total int) string {
+	return fmt.Sprintf("Cleaning up users (%s): %d/%d...", action, done, total)
+}
+
+// runCleanup takes the action on each user with up to the concurrency in progress at once,
+// reporting the number of users done as they complete, the outputs are returned in order
+func runCleanup(users []realm.User, concurrency int, action func(user realm.User) error, progress func(done int)) userOutputs {
+	outputs := make(userOutputs, len(users))
+
+	runWorkers(len(users), concurrency, func(i int) {
+		outputs[i] = userOutput{users[i], action(users[i])}
+	}, progress)
+
+	return outputs
+}
+
+func writeCleanupFailures(path string, failures userOutputs) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+	if err := w.Write([]string{"id", "provider_type", "user", "error"}); err != nil {
+		return err
+	}
+	for _, failure := range failures {
+		var providerType realm.AuthProviderType
+		if len(failure.user.Identities) > 0 {
+			providerType = failure.user.Identities[0].ProviderType
+		}
+		if err := w.Write([]string{
+			failure.user.ID,
+			providerType.String(),
+			displayUserData(providerType, failure.user),
+			failure.err.Error(),
+		}); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,289 @@
+package user
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestUserCleanupInputsResolve(t *testing.T) {
+	days := func(n int) flags.Duration { return flags.Duration{Duration: time.Duration(n) * 24 * time.Hour} }
+
+	for _, tc := range []struct {
+		description string
+		inputs      cleanupInputs
+		expectedErr error
+	}{
+		{
+			description: "should return an error without an inactive since duration",
+			inputs:      cleanupInputs{Action: cleanupActionDisable, Concurrency: 1},
+			expectedErr: errors.New(`must specify how long users must have been inactive for with "inactive-since"`),
+		},
+		{
+			description: "should return an error with a negative inactive since duration",
+			inputs:      cleanupInputs{InactiveSince: days(-1), Action: cleanupActionDisable, Concurrency: 1},
+			expectedErr: errors.New(`"inactive-since" must be positive`),
+		},
+		{
+			description: "should return an error with an unsupported action",
+			inputs:      cleanupInputs{InactiveSince: days(1), Action: "archive", Concurrency: 1},
+			expectedErr: errors.New("unsupported action: archive"),
+		},
+		{
+			description: "should return an error with a concurrency less than one",
+			inputs:      cleanupInputs{InactiveSince: days(1), Action: cleanupActionDisable},
+			expectedErr: errors.New(`"concurrency" must be positive`),
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			profile := mock.NewProfile(t)
+
+			assert.Equal(t, tc.expectedErr, tc.inputs.Resolve(profile, nil))
+		})
+	}
+
+	t.Run("should normalize the excluded domains and default the failures filepath", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+		profile.WorkingDirectory = "/working/dir"
+
+		inputs := cleanupInputs{
+			ProjectInputs:  cli.ProjectInputs{Project: "project", App: "app"},
+			InactiveSince:  days(180),
+			Action:         cleanupActionDelete,
+			ExcludeDomains: []string{"@Example.com", " domain.com"},
+			Concurrency:    1,
+		}
+		assert.Nil(t, inputs.Resolve(profile, nil))
+
+		assert.Equal(t, []string{"example.com", "domain.com"}, inputs.ExcludeDomains)
+		assert.Equal(t, filepath.Join("/working/dir", defaultCleanupFailures), inputs.Failures)
+	})
+}
+
+func TestUserCleanupHandler(t *testing.T) {
+	now := time.Date(2021, 6, 22, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(n int) int64 { return now.AddDate(0, 0, -n).Unix() }
+
+	origUserClock := userClock
+	userClock = &mock.Clock{Time: now}
+	defer func() { userClock = origUserClock }()
+
+	app := realm.App{ID: "app-id", GroupID: "group-id", Name: "eggcorn"}
+
+	emailUser := func(id, email string, lastAuth int64) realm.User {
+		return realm.User{
+			ID:                     id,
+			Type:                   "normal",
+			Identities:             []realm.UserIdentity{{ProviderType: realm.AuthProviderTypeUserPassword}},
+			Data:                   map[string]interface{}{"email": email},
+			CreationDate:           daysAgo(365),
+			LastAuthenticationDate: lastAuth,
+		}
+	}
+
+	disabledUser := emailUser("user-6", "user-6@domain.com", daysAgo(60))
+	disabledUser.Disabled = true
+
+	users := []realm.User{
+		emailUser("user-1", "user-1@domain.com", daysAgo(60)),
+		emailUser("user-2", "user-2@domain.com", daysAgo(1)),
+		{
+			ID:           "user-3",
+			Type:         "server",
+			Identities:   []realm.UserIdentity{{ProviderType: realm.AuthProviderTypeAPIKey}},
+			Data:         map[string]interface{}{"name": "server-key"},
+			CreationDate: daysAgo(90),
+		},
+		emailUser("user-4", "user-4@Example.com", daysAgo(100)),
+		emailUser("user-5", "user-5@domain.com", daysAgo(100)),
+		disabledUser,
+	}
+
+	newRealmClient := func() mock.RealmClient {
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{app}, nil
+		}
+		realmClient.UsersPagesFn = func(groupID, appID string, filter realm.UserFilter, handlePage func(page []realm.User) error) error {
+			if err := handlePage(users[:3]); err != nil {
+				return err
+			}
+			return handlePage(users[3:])
+		}
+		return realmClient
+	}
+
+	newInputs := func(action string) cleanupInputs {
+		return cleanupInputs{
+			InactiveSince:  flags.Duration{Duration: 30 * 24 * time.Hour},
+			Action:         action,
+			ExcludeUsers:   []string{"user-5"},
+			ExcludeDomains: []string{"example.com"},
+			Concurrency:    2,
+		}
+	}
+
+	t.Run("should display the inactive users without taking any action during a dry run", func(t *testing.T) {
+		realmClient := newRealmClient()
+		realmClient.DisableUserFn = func(groupID, appID, userID string) error {
+			return errors.New("should not be called")
+		}
+
+		out, ui := mock.NewUI()
+
+		inputs := newInputs(cleanupActionDisable)
+		inputs.DryRun = true
+
+		cmd := &CommandCleanup{inputs}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, `Found 4 users inactive since 2021-05-23 00:00:00 +0000 UTC
+  Provider Type  Inactive  Excluded
+  -------------  --------  --------
+  User/Password  1         2       
+  ApiKey         1         0       
+Provider type: User/Password
+  Email              ID      Type    Enabled  Last Authenticated           
+  -----------------  ------  ------  -------  -----------------------------
+  user-1@domain.com  user-1  normal  true     2021-04-23 00:00:00 +0000 UTC
+Provider type: ApiKey
+  Name        ID      Type    Enabled  Last Authenticated
+  ----------  ------  ------  -------  ------------------
+  server-key  user-3  server  true     n/a               
+Dry run: would have disabled 2 users
+`, out.String())
+	})
+
+	t.Run("should take the action on the inactive users and report the results", func(t *testing.T) {
+		for _, tc := range []struct {
+			action string
+			setup  func(realmClient *mock.RealmClient, fn func(groupID, appID, userID string) error)
+			output string
+		}{
+			{
+				action: cleanupActionDisable,
+				setup: func(realmClient *mock.RealmClient, fn func(groupID, appID, userID string) error) {
+					realmClient.DisableUserFn = fn
+				},
+				output: "Successfully disabled 2 of 2 users\n",
+			},
+			{
+				action: cleanupActionDelete,
+				setup: func(realmClient *mock.RealmClient, fn func(groupID, appID, userID string) error) {
+					realmClient.DeleteUserFn = fn
+				},
+				output: "Successfully deleted 3 of 3 users\n",
+			},
+			{
+				action: cleanupActionRevoke,
+				setup: func(realmClient *mock.RealmClient, fn func(groupID, appID, userID string) error) {
+					realmClient.RevokeUserSessionFn = fn
+				},
+				output: "Successfully revoked the sessions of 3 of 3 users\n",
+			},
+		} {
+			t.Run("with the "+tc.action+" action", func(t *testing.T) {
+				var mu sync.Mutex
+				var userIDs []string
+
+				realmClient := newRealmClient()
+				tc.setup(&realmClient, func(groupID, appID, userID string) error {
+					assert.Equal(t, "group-id", groupID)
+					assert.Equal(t, "app-id", appID)
+
+					mu.Lock()
+					defer mu.Unlock()
+					userIDs = append(userIDs, userID)
+					return nil
+				})
+
+				out := new(bytes.Buffer)
+				ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, out)
+
+				cmd := &CommandCleanup{newInputs(tc.action)}
+				assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+				expectedUserIDs := []string{"user-1", "user-3"}
+				if tc.action != cleanupActionDisable {
+					expectedUserIDs = append(expectedUserIDs, "user-6")
+				}
+				sort.Strings(userIDs)
+				assert.Equal(t, expectedUserIDs, userIDs)
+
+				assert.True(t, bytes.HasSuffix(out.Bytes(), []byte(tc.output)), "expected output to end with %q, but got: %s", tc.output, out.String())
+			})
+		}
+	})
+
+	t.Run("should write the users which failed to be cleaned up to the failures file", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer teardown()
+
+		failuresPath := filepath.Join(tmpDir, defaultCleanupFailures)
+
+		realmClient := newRealmClient()
+		realmClient.DisableUserFn = func(groupID, appID, userID string) error {
+			if userID == "user-3" {
+				return errors.New("something bad happened")
+			}
+			return nil
+		}
+
+		out := new(bytes.Buffer)
+		ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, out)
+
+		inputs := newInputs(cleanupActionDisable)
+		inputs.Failures = failuresPath
+
+		cmd := &CommandCleanup{inputs}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		expectedOutput := "Successfully disabled 1 of 2 users\nFailed to disable 1 users, the failures were written to " + failuresPath + "\n"
+		assert.True(t, bytes.HasSuffix(out.Bytes(), []byte(expectedOutput)), "expected output to end with %q, but got: %s", expectedOutput, out.String())
+
+		data, err := ioutil.ReadFile(failuresPath)
+		assert.Nil(t, err)
+		assert.Equal(t, `id,provider_type,user,error
+user-3,api-key,server-key,something bad happened
+`, string(data))
+	})
+
+	t.Run("should not take any action when there are no inactive users", func(t *testing.T) {
+		realmClient := newRealmClient()
+		realmClient.UsersPagesFn = func(groupID, appID string, filter realm.UserFilter, handlePage func(page []realm.User) error) error {
+			return handlePage(users[1:2])
+		}
+
+		out, ui := mock.NewUI()
+
+		cmd := &CommandCleanup{newInputs(cleanupActionDelete)}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, "No users have been inactive since 2021-05-23 00:00:00 +0000 UTC\n", out.String())
+	})
+
+	t.Run("should return an error when the users cannot be found", func(t *testing.T) {
+		realmClient := newRealmClient()
+		realmClient.UsersPagesFn = func(groupID, appID string, filter realm.UserFilter, handlePage func(page []realm.User) error) error {
+			return errors.New("something bad happened")
+		}
+
+		_, ui := mock.NewUI()
+
+		cmd := &CommandCleanup{newInputs(cleanupActionDelete)}
+		assert.Equal(t, errors.New("something bad happened"), cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+	})
+}
@@ -0,0 +1,220 @@
+package user
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/terminal"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// set of supported user export formats
+const (
+	exportFormatJSON = "json"
+	exportFormatCSV  = "csv"
+)
+
+// exportCSVHeaders are the columns of the exported CSV file
+var exportCSVHeaders = []string{
+	"id",
+	"type",
+	"disabled",
+	"creation_date",
+	"last_authentication_date",
+	"email",
+	"name",
+	"provider_types",
+	"identities",
+	"data",
+}
+
+// CommandMetaExport is the command meta for the `user export` command
+var CommandMetaExport = cli.CommandMeta{
+	Use:         "export",
+	Display:     "user export",
+	Description: "Export the application users of your Realm app to a file",
+	HelpText: `Writes your Realm app's Users to the file specified by "--out", including their
+identities and metadata. Use the same filters as "user list" to narrow down which
+Users are exported.
+
+Use "--format json" to write a JSON array of Users, or "--format csv" to write a
+CSV file with a header row where the identities and data of each User are JSON.`,
+}
+
+// CommandExport is the `user export` command
+type CommandExport struct {
+	inputs exportInputs
+}
+
+type exportInputs struct {
+	cli.ProjectInputs
+	multiUserInputs
+	Format string
+	Out    string
+}
+
+// Flags is the command flags
+func (cmd *CommandExport) Flags() []flags.Flag {
+	return []flags.Flag{
+		cli.AppFlagWithContext(&cmd.inputs.App, "to export its users"),
+		cli.ProjectFlag(&cmd.inputs.Project),
+		cli.ProductFlag(&cmd.inputs.Products),
+		usersFlag(&cmd.inputs.Users, "Filter the Realm app's users by ID(s)"),
+		pendingFlag(&cmd.inputs.Pending),
+		stateFlag(&cmd.inputs.State),
+		providersFlag(&cmd.inputs.ProviderTypes),
+		flags.StringFlag{
+			Value:        &cmd.inputs.Format,
+			DefaultValue: exportFormatJSON,
+			Meta: flags.Meta{
+				Name: "format",
+				Usage: flags.Usage{
+					Description:   "Specify the format of the exported users",
+					AllowedValues: []string{exportFormatJSON, exportFormatCSV},
+				},
+			},
+		},
+		flags.StringFlag{
+			Value: &cmd.inputs.Out,
+			Meta: flags.Meta{
+				Name: "out",
+				Usage: flags.Usage{
+					Description: "Specify the filepath to write the users to",
+				},
+			},
+		},
+	}
+}
+
+// Inputs is the command inputs
+func (cmd *CommandExport) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandExport) Handler(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	users, err := cmd.inputs.findUsers(clients.Realm, app.GroupID, app.ID)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(cmd.inputs.Out)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if cmd.inputs.Format == exportFormatCSV {
+		err = writeUsersCSV(file, users)
+	} else {
+		err = writeUsersJSON(file, users)
+	}
+	if err != nil {
+		return err
+	}
+
+	ui.Print(terminal.NewTextLog("Successfully exported %d users to %s", len(users), cmd.inputs.Out))
+	return nil
+}
+
+func (i *exportInputs) Resolve(profile *user.Profile, ui terminal.UI) error {
+	switch i.Format {
+	case exportFormatJSON, exportFormatCSV:
+	default:
+		return fmt.Errorf("unsupported format: %s", i.Format)
+	}
+
+	if i.Out == "" {
+		if err := ui.AskOne(&i.Out, &survey.Input{Message: "Users filepath", Default: "users." + i.Format}); err != nil {
+			return err
+		}
+	}
+
+	if !filepath.IsAbs(i.Out) {
+		i.Out = filepath.Join(profile.WorkingDirectory, i.Out)
+	}
+
+	return i.ProjectInputs.Resolve(ui, profile.WorkingDirectory, false)
+}
+
+func writeUsersJSON(w io.Writer, users []realm.User) error {
+	if users == nil {
+		users = []realm.User{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(users)
+}
+
+func writeUsersCSV(w io.Writer, users []realm.User) error {
+	csvWriter := csv.NewWriter(w)
+	if err := csvWriter.Write(exportCSVHeaders); err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		identities, err := json.Marshal(user.Identities)
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(user.Data)
+		if err != nil {
+			return err
+		}
+
+		providerTypes := make([]string, 0, len(user.Identities))
+		for _, identity := range user.Identities {
+			providerTypes = append(providerTypes, identity.ProviderType.String())
+		}
+
+		if err := csvWriter.Write([]string{
+			user.ID,
+			user.Type,
+			strconv.FormatBool(user.Disabled),
+			exportDate(user.CreationDate),
+			exportDate(user.LastAuthenticationDate),
+			exportUserData(user, userDataEmail),
+			exportUserData(user, userDataName),
+			strings.Join(providerTypes, ","),
+			string(identities),
+			string(data),
+		}); err != nil {
+			return err
+		}
+	}
+
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// exportDate formats the unix timestamp, leaving the unset dates empty
+func exportDate(unix int64) string {
+	if unix == 0 {
+		return ""
+	}
+	return time.Unix(unix, 0).UTC().Format(time.RFC3339)
+}
+
+func exportUserData(user realm.User, field string) string {
+	if val, ok := user.Data[field]; ok {
+		return fmt.Sprint(val)
+	}
+	return ""
+}
@@ -0,0 +1,171 @@
+package user
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestUserExportInputsResolve(t *testing.T) {
+	t.Run("should return an error with an unsupported format", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		inputs := exportInputs{Format: "xml"}
+		assert.Equal(t, errors.New("unsupported format: xml"), inputs.Resolve(profile, nil))
+	})
+
+	t.Run("should resolve the out filepath relative to the working directory", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+		profile.WorkingDirectory = "/working/dir"
+
+		inputs := exportInputs{ProjectInputs: cli.ProjectInputs{Project: "project", App: "app"}, Format: exportFormatCSV, Out: "users.csv"}
+		assert.Nil(t, inputs.Resolve(profile, nil))
+		assert.Equal(t, filepath.Join("/working/dir", "users.csv"), inputs.Out)
+	})
+}
+
+func TestUserExportHandler(t *testing.T) {
+	app := realm.App{ID: "app-id", GroupID: "group-id", Name: "eggcorn"}
+
+	providerID, err := primitive.ObjectIDFromHex("60d18b6c7c1fbb0bc9e0d8f1")
+	assert.Nil(t, err)
+
+	users := []realm.User{
+		{
+			ID:                     "user-1",
+			Type:                   "normal",
+			Identities:             []realm.UserIdentity{{UID: "uid-1", ProviderType: realm.AuthProviderTypeUserPassword, ProviderID: providerID}},
+			Data:                   map[string]interface{}{"email": "user-1@domain.com"},
+			CreationDate:           1624345200,
+			LastAuthenticationDate: 1624348800,
+		},
+		{
+			ID:         "user-2",
+			Type:       "server",
+			Disabled:   true,
+			Identities: []realm.UserIdentity{{UID: "uid-2", ProviderType: realm.AuthProviderTypeAPIKey, ProviderID: providerID}},
+			Data:       map[string]interface{}{"name": "server-key"},
+		},
+	}
+
+	for _, tc := range []struct {
+		format   string
+		expected string
+	}{
+		{
+			format: exportFormatJSON,
+			expected: `[
+  {
+    "_id": "user-1",
+    "identities": [
+      {
+        "id": "uid-1",
+        "provider_type": "local-userpass",
+        "provider_id": "60d18b6c7c1fbb0bc9e0d8f1"
+      }
+    ],
+    "type": "normal",
+    "disabled": false,
+    "data": {
+      "email": "user-1@domain.com"
+    },
+    "creation_date": 1624345200,
+    "last_authentication_date": 1624348800
+  },
+  {
+    "_id": "user-2",
+    "identities": [
+      {
+        "id": "uid-2",
+        "provider_type": "api-key",
+        "provider_id": "60d18b6c7c1fbb0bc9e0d8f1"
+      }
+    ],
+    "type": "server",
+    "disabled": true,
+    "data": {
+      "name": "server-key"
+    },
+    "creation_date": 0,
+    "last_authentication_date": 0
+  }
+]
+`,
+		},
+		{
+			format: exportFormatCSV,
+			expected: `id,type,disabled,creation_date,last_authentication_date,email,name,provider_types,identities,data
+user-1,normal,false,2021-06-22T07:00:00Z,2021-06-22T08:00:00Z,user-1@domain.com,,local-userpass,"[{""id"":""uid-1"",""provider_type"":""local-userpass"",""provider_id"":""60d18b6c7c1fbb0bc9e0d8f1""}]","{""email"":""user-1@domain.com""}"
+user-2,server,true,,,,server-key,api-key,"[{""id"":""uid-2"",""provider_type"":""api-key"",""provider_id"":""60d18b6c7c1fbb0bc9e0d8f1""}]","{""name"":""server-key""}"
+`,
+		},
+	} {
+		t.Run("should export the users as "+tc.format, func(t *testing.T) {
+			tmpDir, teardown, err := u.NewTempDir("")
+			assert.Nil(t, err)
+			defer teardown()
+
+			out := filepath.Join(tmpDir, "users."+tc.format)
+
+			var capturedFilter realm.UserFilter
+			realmClient := mock.RealmClient{}
+			realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+				return []realm.App{app}, nil
+			}
+			realmClient.FindUsersFn = func(groupID, appID string, filter realm.UserFilter) ([]realm.User, error) {
+				capturedFilter = filter
+				return users, nil
+			}
+
+			output, ui := mock.NewUI()
+
+			cmd := &CommandExport{exportInputs{
+				multiUserInputs: multiUserInputs{State: realm.UserStateEnabled},
+				Format:          tc.format,
+				Out:             out,
+			}}
+			assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+			assert.Equal(t, "Successfully exported 2 users to "+out+"\n", output.String())
+			assert.Equal(t, realm.UserFilter{State: realm.UserStateEnabled, Providers: realm.AuthProviderTypes{}}, capturedFilter)
+
+			data, err := ioutil.ReadFile(out)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, string(data))
+		})
+	}
+
+	t.Run("should write an empty json array when there are no users", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer teardown()
+
+		out := filepath.Join(tmpDir, "users.json")
+
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{app}, nil
+		}
+		realmClient.FindUsersFn = func(groupID, appID string, filter realm.UserFilter) ([]realm.User, error) {
+			return nil, nil
+		}
+
+		_, ui := mock.NewUI()
+
+		cmd := &CommandExport{exportInputs{Format: exportFormatJSON, Out: out}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		data, err := ioutil.ReadFile(out)
+		assert.Nil(t, err)
+		assert.Equal(t, "[]\n", string(data))
+	})
+}
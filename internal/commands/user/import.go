@@ -0,0 +1,402 @@
+package user
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/terminal"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+)
+
+const (
+	defaultImportConcurrency = 5
+	defaultImportRate        = 10
+
+	importFormatCSV  = ".csv"
+	importFormatJSON = ".json"
+
+	importFieldType     = "type"
+	importFieldEmail    = "email"
+	importFieldPassword = "password"
+	importFieldName     = "name"
+	importFieldError    = "error"
+
+	headerRow      = "Row"
+	headerUser     = "User"
+	headerKey      = "Key"
+	headerImported = "Imported"
+)
+
+// CommandMetaImport is the command meta for the `user import` command
+var CommandMetaImport = cli.CommandMeta{
+	Use:         "import",
+	Display:     "user import",
+	Description: "Import application users into your Realm app from a file",
+	HelpText: `Creates a User in your Realm app for each row of the CSV or JSON file specified
+by "--file". Each row has a "type" of either "email" or "api-key", along with
+the "email" and "password" of an Email/Password user or the "name" of an API Key.
+When the "type" is omitted, it is inferred from the other fields. For example:
+
+  type,email,password,name
+  email,user@example.com,P@ssw0rd,
+  api-key,,,server-key
+
+Users are created with up to "--concurrency" requests in progress at once and
+no more than "--rate" requests per second. The number of rows imported so far is
+displayed while the import runs, and the result of each row is displayed once the
+import is complete. The rows which failed are written to the file specified by
+"--failures" in the same format as the imported file, so they can be fixed and
+imported again. Since the failed rows include their passwords, the file is only
+readable by you.`,
+}
+
+// CommandImport is the `user import` command
+type CommandImport struct {
+	inputs importInputs
+}
+
+type importInputs struct {
+	cli.ProjectInputs
+	File        string
+	Concurrency int
+	Rate        int
+	Failures    string
+}
+
+// Flags is the command flags
+func (cmd *CommandImport) Flags() []flags.Flag {
+	return []flags.Flag{
+		cli.AppFlagWithContext(&cmd.inputs.App, "to import its users"),
+		cli.ProjectFlag(&cmd.inputs.Project),
+		cli.ProductFlag(&cmd.inputs.Products),
+		flags.StringFlag{
+			Value: &cmd.inputs.File,
+			Meta: flags.Meta{
+				Name: "file",
+				Usage: flags.Usage{
+					Description:   "Specify the filepath of the users to import",
+					AllowedFormat: "<path>.csv or <path>.json",
+				},
+			},
+		},
+		flags.IntFlag{
+			Value:        &cmd.inputs.Concurrency,
+			DefaultValue: defaultImportConcurrency,
+			Meta: flags.Meta{
+				Name: "concurrency",
+				Usage: flags.Usage{
+					Description: "Specify the max number of users being created at once",
+				},
+			},
+		},
+		flags.IntFlag{
+			Value:        &cmd.inputs.Rate,
+			DefaultValue: defaultImportRate,
+			Meta: flags.Meta{
+				Name: "rate",
+				Usage: flags.Usage{
+					Description: "Specify the max number of users created per second",
+				},
+			},
+		},
+		flags.StringFlag{
+			Value: &cmd.inputs.Failures,
+			Meta: flags.Meta{
+				Name: "failures",
+				Usage: flags.Usage{
+					Description:  "Specify the filepath to write the users which failed to import to",
+					DefaultValue: "<file>.failures.<ext>",
+				},
+			},
+		},
+	}
+}
+
+// Inputs is the command inputs
+func (cmd *CommandImport) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandImport) Handler(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
+	users, err := readImportUsers(cmd.inputs.File)
+	if err != nil {
+		return err
+	}
+
+	if len(users) == 0 {
+		ui.Print(terminal.NewTextLog("No users to import"))
+		return nil
+	}
+
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	s := ui.Spinner(importProgress(0, len(users)), terminal.SpinnerOptions{})
+	s.Start()
+
+	results := runImport(users, cmd.inputs.Concurrency, cmd.inputs.Rate, func(u importUser) importResult {
+		switch u.Type {
+		case userTypeAPIKey:
+			apiKey, err := clients.Realm.CreateAPIKey(app.GroupID, app.ID, u.Name)
+			return importResult{user: u, id: apiKey.ID, key: apiKey.Key, err: err}
+		default:
+			user, err := clients.Realm.CreateUser(app.GroupID, app.ID, u.Email, u.Password)
+			return importResult{user: u, id: user.ID, err: err}
+		}
+	}, func(done int) {
+		s.SetMessage(importProgress(done, len(users)))
+	})
+
+	s.Stop()
+
+	var failures []importUser
+	rows := make([]map[string]interface{}, 0, len(results))
+	for i, result := range results {
+		row := map[string]interface{}{
+			headerRow:      i + 1,
+			headerType:     result.user.Type,
+			headerUser:     result.user.display(),
+			headerID:       result.id,
+			headerKey:      result.key,
+			headerImported: result.err == nil,
+			headerDetails:  "",
+		}
+		if result.err != nil {
+			row[headerDetails] = result.err.Error()
+
+			failure := result.user
+			failure.Error = result.err.Error()
+			failures = append(failures, failure)
+		}
+		rows = append(rows, row)
+	}
+
+	ui.Print(terminal.NewTableLog(
+		fmt.Sprintf("Imported %d of %d users", len(results)-len(failures), len(results)),
+		[]string{headerRow, headerType, headerUser, headerID, headerKey, headerImported, headerDetails},
+		rows...,
+	))
+
+	if len(failures) == 0 {
+		return nil
+	}
+
+	if err := writeImportUsers(cmd.inputs.Failures, failures); err != nil {
+		return fmt.Errorf("failed to write the users which failed to import: %s", err)
+	}
+	ui.Print(terminal.NewWarningLog("Failed to import %d users, the failures were written to %s", len(failures), cmd.inputs.Failures))
+	return nil
+}
+
+func (i *importInputs) Resolve(profile *user.Profile, ui terminal.UI) error {
+	if i.File == "" {
+		return errors.New(`must specify the users to import with "file"`)
+	}
+	if !filepath.IsAbs(i.File) {
+		i.File = filepath.Join(profile.WorkingDirectory, i.File)
+	}
+
+	ext := strings.ToLower(filepath.Ext(i.File))
+	if ext != importFormatCSV && ext != importFormatJSON {
+		return fmt.Errorf("unsupported file format: %s", filepath.Base(i.File))
+	}
+
+	if i.Concurrency < 1 {
+		return errors.New(`"concurrency" must be positive`)
+	}
+	if i.Rate < 1 {
+		return errors.New(`"rate" must be positive`)
+	}
+
+	if i.Failures == "" {
+		i.Failures = strings.TrimSuffix(i.File, filepath.Ext(i.File)) + ".failures" + filepath.Ext(i.File)
+	} else if !filepath.IsAbs(i.Failures) {
+		i.Failures = filepath.Join(profile.WorkingDirectory, i.Failures)
+	}
+	if ext := strings.ToLower(filepath.Ext(i.Failures)); ext != importFormatCSV && ext != importFormatJSON {
+		return fmt.Errorf("unsupported file format: %s", filepath.Base(i.Failures))
+	}
+
+	return i.ProjectInputs.Resolve(ui, profile.WorkingDirectory, false)
+}
+
+// importUser is a row of the imported file
+type importUser struct {
+	Type     userType `json:"type"`
+	Email    string   `json:"email,omitempty"`
+	Password string   `json:"password,omitempty"`
+	Name     string   `json:"name,omitempty"`
+	Error    string   `json:"error,omitempty"`
+}
+
+func (u importUser) display() string {
+	if u.Type == userTypeAPIKey {
+		return u.Name
+	}
+	return u.Email
+}
+
+// validate infers the type of the user when it is omitted and checks the user can be created
+func (u *importUser) validate() error {
+	if u.Type == userTypeNil {
+		if u.Name != "" {
+			u.Type = userTypeAPIKey
+		} else {
+			u.Type = userTypeEmailPassword
+		}
+	}
+
+	switch u.Type {
+	case userTypeAPIKey:
+		if u.Name == "" {
+			return errors.New("api key must have a name")
+		}
+	case userTypeEmailPassword:
+		if u.Email == "" || u.Password == "" {
+			return errors.New("user must have an email and password")
+		}
+	default:
+		return fmt.Errorf("unsupported user type: %s", u.Type)
+	}
+	return nil
+}
+
+type importResult struct {
+	user importUser
+	id   string
+	key  string
+	err  error
+}
+
+func importProgress(done, total int) string {
+	return fmt.Sprintf("Importing users: %d/%d...", done, total)
+}
+
+// runImport creates each user with up to the concurrency in progress at once
+// and no more than the rate started per second, reporting the number of rows
+// done as they complete, the results are returned in row order
+func runImport(users []importUser, concurrency, rate int, create func(u importUser) importResult, progress func(done int)) []importResult {
+	results := make([]importResult, len(users))
+
+	limiter := time.NewTicker(time.Second / time.Duration(rate))
+	defer limiter.Stop()
+
+	runWorkers(len(users), concurrency, func(i int) {
+		// the rows which cannot be created fail without a request
+		if err := users[i].validate(); err != nil {
+			results[i] = importResult{user: users[i], err: err}
+			return
+		}
+
+		<-limiter.C
+		results[i] = create(users[i])
+	}, progress)
+
+	return results
+}
+
+func readImportUsers(path string) ([]importUser, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if strings.ToLower(filepath.Ext(path)) == importFormatJSON {
+		var users []importUser
+		if err := json.NewDecoder(file).Decode(&users); err != nil {
+			return nil, fmt.Errorf("failed to parse the users at %s: %s", path, err)
+		}
+		return users, nil
+	}
+
+	users, err := readImportUsersCSV(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the users at %s: %s", path, err)
+	}
+	return users, nil
+}
+
+// readImportUsersCSV reads the users by the columns named in the header row, ignoring any other columns
+func readImportUsersCSV(r io.Reader) ([]importUser, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	columns := map[string]int{}
+	for i, header := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(header))] = i
+	}
+	if _, ok := columns[importFieldEmail]; !ok {
+		if _, ok := columns[importFieldName]; !ok {
+			return nil, fmt.Errorf(`header row must have an "%s" or "%s" column`, importFieldEmail, importFieldName)
+		}
+	}
+
+	value := func(record []string, field string) string {
+		if i, ok := columns[field]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	users := make([]importUser, 0, len(records)-1)
+	for _, record := range records[1:] {
+		users = append(users, importUser{
+			Type:     userType(value(record, importFieldType)),
+			Email:    value(record, importFieldEmail),
+			Password: value(record, importFieldPassword),
+			Name:     value(record, importFieldName),
+		})
+	}
+	return users, nil
+}
+
+// writeImportUsers writes the users to a file only readable by the current user,
+// since the users include their passwords
+func writeImportUsers(path string, users []importUser) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// an existing file keeps its permissions when it is opened
+	if err := file.Chmod(0600); err != nil {
+		return err
+	}
+
+	if strings.ToLower(filepath.Ext(path)) == importFormatJSON {
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(users)
+	}
+
+	w := csv.NewWriter(file)
+	if err := w.Write([]string{importFieldType, importFieldEmail, importFieldPassword, importFieldName, importFieldError}); err != nil {
+		return err
+	}
+	for _, u := range users {
+		if err := w.Write([]string{u.Type.String(), u.Email, u.Password, u.Name, u.Error}); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
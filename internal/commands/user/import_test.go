@@ -0,0 +1,226 @@
+package user
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestUserImportInputsResolve(t *testing.T) {
+	for _, tc := range []struct {
+		description string
+		inputs      importInputs
+		err         error
+	}{
+		{
+			description: "without a file",
+			inputs:      importInputs{Concurrency: 1, Rate: 1},
+			err:         errors.New(`must specify the users to import with "file"`),
+		},
+		{
+			description: "with an unsupported file format",
+			inputs:      importInputs{File: "users.txt", Concurrency: 1, Rate: 1},
+			err:         errors.New("unsupported file format: users.txt"),
+		},
+		{
+			description: "with an unsupported failures file format",
+			inputs:      importInputs{File: "users.csv", Failures: "failures.txt", Concurrency: 1, Rate: 1},
+			err:         errors.New("unsupported file format: failures.txt"),
+		},
+		{
+			description: "without a positive concurrency",
+			inputs:      importInputs{File: "users.csv", Rate: 1},
+			err:         errors.New(`"concurrency" must be positive`),
+		},
+		{
+			description: "without a positive rate",
+			inputs:      importInputs{File: "users.csv", Concurrency: 1},
+			err:         errors.New(`"rate" must be positive`),
+		},
+	} {
+		t.Run("should return an error "+tc.description, func(t *testing.T) {
+			profile := mock.NewProfile(t)
+
+			assert.Equal(t, tc.err, tc.inputs.Resolve(profile, nil))
+		})
+	}
+
+	t.Run("should resolve the files relative to the working directory", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+		profile.WorkingDirectory = "/working/dir"
+
+		inputs := importInputs{ProjectInputs: cli.ProjectInputs{Project: "project", App: "app"}, File: "users.json", Concurrency: 1, Rate: 1}
+		assert.Nil(t, inputs.Resolve(profile, nil))
+
+		assert.Equal(t, filepath.Join("/working/dir", "users.json"), inputs.File)
+		assert.Equal(t, filepath.Join("/working/dir", "users.failures.json"), inputs.Failures)
+	})
+}
+
+func TestReadImportUsers(t *testing.T) {
+	tmpDir, teardown, err := u.NewTempDir("")
+	assert.Nil(t, err)
+	defer teardown()
+
+	t.Run("should read the users from a csv file by its header columns", func(t *testing.T) {
+		path := filepath.Join(tmpDir, "users.csv")
+		assert.Nil(t, ioutil.WriteFile(path, []byte(`Name,Email,Password,Type,Error
+,user@domain.com,password,email,
+server-key,,,,some previous error
+`), 0666))
+
+		users, err := readImportUsers(path)
+		assert.Nil(t, err)
+		assert.Equal(t, []importUser{
+			{Type: userTypeEmailPassword, Email: "user@domain.com", Password: "password"},
+			{Name: "server-key"},
+		}, users)
+	})
+
+	t.Run("should return an error when the csv file has neither an email or name column", func(t *testing.T) {
+		path := filepath.Join(tmpDir, "invalid.csv")
+		assert.Nil(t, ioutil.WriteFile(path, []byte("type,password\nemail,password\n"), 0666))
+
+		_, err := readImportUsers(path)
+		assert.Equal(t, errors.New(`failed to parse the users at `+path+`: header row must have an "email" or "name" column`), err)
+	})
+
+	t.Run("should read the users from a json file", func(t *testing.T) {
+		path := filepath.Join(tmpDir, "users.json")
+		assert.Nil(t, ioutil.WriteFile(path, []byte(`[
+  {"email": "user@domain.com", "password": "password"},
+  {"type": "api-key", "name": "server-key"}
+]`), 0666))
+
+		users, err := readImportUsers(path)
+		assert.Nil(t, err)
+		assert.Equal(t, []importUser{
+			{Email: "user@domain.com", Password: "password"},
+			{Type: userTypeAPIKey, Name: "server-key"},
+		}, users)
+	})
+}
+
+func TestImportUserValidate(t *testing.T) {
+	for _, tc := range []struct {
+		description  string
+		user         importUser
+		expectedType userType
+		err          error
+	}{
+		{"infer an email user", importUser{Email: "user@domain.com", Password: "password"}, userTypeEmailPassword, nil},
+		{"infer an api key", importUser{Name: "server-key"}, userTypeAPIKey, nil},
+		{"reject an email user without a password", importUser{Email: "user@domain.com"}, userTypeEmailPassword, errors.New("user must have an email and password")},
+		{"reject an api key without a name", importUser{Type: userTypeAPIKey}, userTypeAPIKey, errors.New("api key must have a name")},
+		{"reject an unsupported type", importUser{Type: "anon-user"}, "anon-user", errors.New("unsupported user type: anon-user")},
+	} {
+		t.Run("should "+tc.description, func(t *testing.T) {
+			user := tc.user
+			assert.Equal(t, tc.err, user.validate())
+			assert.Equal(t, tc.expectedType, user.Type)
+		})
+	}
+}
+
+func TestUserImportHandler(t *testing.T) {
+	app := realm.App{ID: "app-id", GroupID: "group-id", Name: "eggcorn"}
+
+	t.Run("should create each user and write the failures to a file", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer teardown()
+
+		usersPath := filepath.Join(tmpDir, "users.csv")
+		failuresPath := filepath.Join(tmpDir, "users.failures.csv")
+
+		assert.Nil(t, ioutil.WriteFile(usersPath, []byte(`type,email,password,name
+email,user-1@domain.com,password,
+email,user-2@domain.com,password,
+api-key,,,server-key
+email,user-3@domain.com,,
+`), 0666))
+
+		var mu sync.Mutex
+		var createdUsers, createdAPIKeys []string
+
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{app}, nil
+		}
+		realmClient.CreateUserFn = func(groupID, appID, email, password string) (realm.User, error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if email == "user-2@domain.com" {
+				return realm.User{}, errors.New("name already in use")
+			}
+			createdUsers = append(createdUsers, email)
+			return realm.User{ID: "id-" + email}, nil
+		}
+		realmClient.CreateAPIKeyFn = func(groupID, appID, apiKeyName string) (realm.APIKey, error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			createdAPIKeys = append(createdAPIKeys, apiKeyName)
+			return realm.APIKey{ID: "id-" + apiKeyName, Key: "key"}, nil
+		}
+
+		out, ui := mock.NewUI()
+
+		cmd := &CommandImport{importInputs{File: usersPath, Failures: failuresPath, Concurrency: 2, Rate: 1000}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, []string{"user-1@domain.com"}, createdUsers)
+		assert.Equal(t, []string{"server-key"}, createdAPIKeys)
+
+		assert.Equal(t, strings.Join([]string{
+			"Imported 2 of 4 users",
+			"  Row  Type     User               ID                    Key  Imported  Details                             ",
+			"  ---  -------  -----------------  --------------------  ---  --------  ------------------------------------",
+			"  1    email    user-1@domain.com  id-user-1@domain.com       true                                          ",
+			"  2    email    user-2@domain.com                             false     name already in use                 ",
+			"  3    api-key  server-key         id-server-key         key  true                                          ",
+			"  4    email    user-3@domain.com                             false     user must have an email and password",
+			"Failed to import 2 users, the failures were written to " + failuresPath,
+			"",
+		}, "\n"), out.String())
+
+		failures, err := ioutil.ReadFile(failuresPath)
+		assert.Nil(t, err)
+		assert.Equal(t, `type,email,password,name,error
+email,user-2@domain.com,password,,name already in use
+email,user-3@domain.com,,,user must have an email and password
+`, string(failures))
+		if runtime.GOOS != "windows" {
+			info, err := os.Stat(failuresPath)
+			assert.Nil(t, err)
+			assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+		}
+	})
+
+	t.Run("should not import anything when the file has no users", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer teardown()
+
+		usersPath := filepath.Join(tmpDir, "users.json")
+		assert.Nil(t, ioutil.WriteFile(usersPath, []byte(`[]`), 0666))
+
+		out, ui := mock.NewUI()
+
+		cmd := &CommandImport{importInputs{File: usersPath, Concurrency: 1, Rate: 1}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: mock.RealmClient{}}))
+		assert.Equal(t, "No users to import\n", out.String())
+	})
+}
@@ -14,6 +14,8 @@ import (
 const (
 	userDataEmail = "email"
 	userDataName  = "name"
+
+	loginIDTypeEmail = "email"
 )
 
 type multiUserInputs struct {
@@ -41,6 +43,9 @@ func displayUserData(apt realm.AuthProviderType, user realm.User) string {
 	switch apt {
 	case realm.AuthProviderTypeUserPassword:
 		val, ok = user.Data["email"]
+		if !ok {
+			val, ok = pendingUserEmail(user)
+		}
 	case realm.AuthProviderTypeAPIKey:
 		val, ok = user.Data["name"]
 	}
@@ -50,6 +55,28 @@ func displayUserData(apt realm.AuthProviderType, user realm.User) string {
 	return ""
 }
 
+// userProviderType returns the provider type of the user's first identity,
+// pending users have no identities yet and are always Email/Password users
+func userProviderType(user realm.User) realm.AuthProviderType {
+	if len(user.Identities) > 0 {
+		return user.Identities[0].ProviderType
+	}
+	if len(user.LoginIDs) > 0 {
+		return realm.AuthProviderTypeUserPassword
+	}
+	return realm.AuthProviderTypeEmpty
+}
+
+// pendingUserEmail returns the email a pending user registered with
+func pendingUserEmail(user realm.User) (string, bool) {
+	for _, loginID := range user.LoginIDs {
+		if loginID.IDType == loginIDTypeEmail {
+			return loginID.ID, true
+		}
+	}
+	return "", false
+}
+
 func (i multiUserInputs) filter() realm.UserFilter {
 	return realm.UserFilter{
 		IDs:       i.Users,
@@ -77,10 +104,7 @@ func (i multiUserInputs) selectUsers(ui terminal.UI, resolvedUsers []realm.User,
 	selectableUsers := map[string]realm.User{}
 	selectableUserOptions := make([]string, len(resolvedUsers))
 	for idx, user := range resolvedUsers {
-		var apt realm.AuthProviderType
-		if len(user.Identities) > 0 {
-			apt = user.Identities[0].ProviderType
-		}
+		apt := userProviderType(user)
 		opt := displayUser(apt, user)
 		selectableUserOptions[idx] = opt
 		selectableUsers[opt] = user
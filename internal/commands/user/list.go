@@ -1,8 +1,10 @@
 package user
 
 import (
+	"errors"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/10gen/realm-cli/internal/cli"
@@ -12,13 +14,37 @@ import (
 	"github.com/10gen/realm-cli/internal/utils/flags"
 )
 
+const (
+	flagLimit          = "limit"
+	flagCreatedBefore  = "created-before"
+	flagCreatedAfter   = "created-after"
+	flagLastAuthBefore = "last-auth-before"
+	flagLastAuthAfter  = "last-auth-after"
+
+	sortCreated  = "created"
+	sortLastAuth = "last-auth"
+)
+
+// errUsersLimitReached stops fetching users once enough users have matched the filter
+var errUsersLimitReached = errors.New("users limit reached")
+
 // CommandMetaList is the command meta for the `user list` command
 var CommandMetaList = cli.CommandMeta{
 	Use:         "list",
 	Aliases:     []string{"ls"},
 	Description: "List the application users of your Realm app",
-	HelpText: `Displays a list of your Realm app's Users' details. The list is grouped by Auth
-Provider type and sorted by Last Authentication Date.`,
+	HelpText: `Displays a list of your Realm app's Users' details, one page at a time as the
+pages are fetched. Each page is grouped by Auth Provider type and sorted by Last
+Authentication Date on its own, so when more than one page is listed the tables of
+each Auth Provider type repeat and the Users are only in order within each page.
+
+Use "--sort" to instead list every User in one table per Auth Provider type,
+sorted by their Creation or Last Authentication Date, which waits for every page
+to be fetched.
+
+Use the "--created-before", "--created-after", "--last-auth-before",
+"--last-auth-after" and "--email-contains" flags to narrow down which Users are
+listed, and "--limit" to only list up to that many Users.`,
 }
 
 // CommandList is the `user list` command
@@ -29,6 +55,13 @@ type CommandList struct {
 type listInputs struct {
 	cli.ProjectInputs
 	multiUserInputs
+	Limit          int
+	Sort           string
+	CreatedBefore  flags.Date
+	CreatedAfter   flags.Date
+	LastAuthBefore flags.Date
+	LastAuthAfter  flags.Date
+	EmailContains  string
 }
 
 // Flags is the command flags
@@ -41,6 +74,52 @@ func (cmd *CommandList) Flags() []flags.Flag {
 		pendingFlag(&cmd.inputs.Pending),
 		stateFlag(&cmd.inputs.State),
 		providersFlag(&cmd.inputs.ProviderTypes),
+		flags.IntFlag{
+			Value: &cmd.inputs.Limit,
+			Meta: flags.Meta{
+				Name: flagLimit,
+				Usage: flags.Usage{
+					Description: "Specify the max number of users to list",
+					Note:        "Lists every user when not set",
+				},
+			},
+		},
+		flags.StringFlag{
+			Value: &cmd.inputs.Sort,
+			Meta: flags.Meta{
+				Name: "sort",
+				Usage: flags.Usage{
+					Description:   "Sort every user by the specified date, most recent first",
+					AllowedValues: []string{sortCreated, sortLastAuth},
+				},
+			},
+		},
+		userDateFlag(&cmd.inputs.CreatedBefore, flagCreatedBefore, "Only list the users created before the specified date"),
+		userDateFlag(&cmd.inputs.CreatedAfter, flagCreatedAfter, "Only list the users created after the specified date"),
+		userDateFlag(&cmd.inputs.LastAuthBefore, flagLastAuthBefore, "Only list the users last authenticated before the specified date"),
+		userDateFlag(&cmd.inputs.LastAuthAfter, flagLastAuthAfter, "Only list the users last authenticated after the specified date"),
+		flags.StringFlag{
+			Value: &cmd.inputs.EmailContains,
+			Meta: flags.Meta{
+				Name: "email-contains",
+				Usage: flags.Usage{
+					Description: "Only list the users with an email containing the specified text",
+				},
+			},
+		},
+	}
+}
+
+func userDateFlag(value *flags.Date, name, description string) flags.Flag {
+	return flags.CustomFlag{
+		Value: value,
+		Meta: flags.Meta{
+			Name: name,
+			Usage: flags.Usage{
+				Description:   description,
+				AllowedFormat: "2006-01-02[T15:04:05.000-0700]",
+			},
+		},
 	}
 }
 
@@ -56,22 +135,79 @@ func (cmd *CommandList) Handler(profile *user.Profile, ui terminal.UI, clients c
 		return err
 	}
 
-	users, err := cmd.inputs.findUsers(clients.Realm, app.GroupID, app.ID)
-	if err != nil {
+	filter := cmd.inputs.listFilter()
+	limit := cmd.inputs.Limit
+
+	var fetched, count int
+	var capped bool
+	var sorted []realm.User
+
+	err = clients.Realm.UsersPages(app.GroupID, app.ID, cmd.inputs.filter(), func(page []realm.User) error {
+		fetched += len(page)
+		users := filter.apply(page)
+
+		if cmd.inputs.Sort != "" {
+			sorted = append(sorted, users...)
+			return nil
+		}
+
+		// without a sort each page is displayed as it arrives, grouped and sorted on its own
+		if limit > 0 && count+len(users) > limit {
+			users = users[:limit-count]
+			capped = true
+		}
+		count += len(users)
+
+		ui.Print(userListLogs(users, getUserComparerByLastAuthentication)...)
+		if capped {
+			return errUsersLimitReached
+		}
+		return nil
+	})
+	if err != nil && err != errUsersLimitReached {
 		return err
 	}
 
-	outputs := make(userOutputs, 0, len(users))
-	for _, user := range users {
-		outputs = append(outputs, userOutput{user, err})
+	if cmd.inputs.Sort != "" {
+		comparer := getUserComparerByLastAuthentication
+		if cmd.inputs.Sort == sortCreated {
+			comparer = getUserComparerByCreation
+		}
+
+		// sort every user before applying the limit, the users are sorted again once grouped by provider type
+		outputs := toUserOutputs(sorted)
+		sort.SliceStable(outputs, comparer(outputs))
+		for i, output := range outputs {
+			sorted[i] = output.user
+		}
+
+		if limit > 0 && len(sorted) > limit {
+			sorted = sorted[:limit]
+			capped = true
+		}
+		count = len(sorted)
+
+		ui.Print(userListLogs(sorted, comparer)...)
 	}
 
-	if len(outputs) == 0 {
+	if len(cmd.inputs.Users) > 0 && fetched == 0 {
+		return errors.New("no users found")
+	}
+
+	if count == 0 {
 		ui.Print(terminal.NewTextLog("No available users to show"))
 		return nil
 	}
 
-	outputsByProviderType := outputs.byProviderType()
+	if capped {
+		ui.Print(terminal.NewWarningLog("Only listed %d users, use %q to list more", limit, "--"+flagLimit))
+	}
+	return nil
+}
+
+// userListLogs displays the users in a table for each provider type, sorted by the comparer
+func userListLogs(users []realm.User, comparer func(outputs []userOutput) func(i, j int) bool) []terminal.Log {
+	outputsByProviderType := toUserOutputs(users).byProviderType()
 
 	logs := make([]terminal.Log, 0, len(outputsByProviderType))
 	for _, providerType := range realm.ValidAuthProviderTypes {
@@ -80,7 +216,7 @@ func (cmd *CommandList) Handler(profile *user.Profile, ui terminal.UI, clients c
 			continue
 		}
 
-		sort.Slice(o, getUserComparerByLastAuthentication(o))
+		sort.SliceStable(o, comparer(o))
 
 		logs = append(logs, terminal.NewTableLog(
 			fmt.Sprintf("Provider type: %s", providerType.Display()),
@@ -88,9 +224,15 @@ func (cmd *CommandList) Handler(profile *user.Profile, ui terminal.UI, clients c
 			tableRows(providerType, o, tableRowList)...,
 		))
 	}
+	return logs
+}
 
-	ui.Print(logs...)
-	return nil
+func toUserOutputs(users []realm.User) userOutputs {
+	outputs := make(userOutputs, 0, len(users))
+	for _, user := range users {
+		outputs = append(outputs, userOutput{user, nil})
+	}
+	return outputs
 }
 
 func getUserComparerByLastAuthentication(outputs []userOutput) func(i, j int) bool {
@@ -99,10 +241,98 @@ func getUserComparerByLastAuthentication(outputs []userOutput) func(i, j int) bo
 	}
 }
 
+func getUserComparerByCreation(outputs []userOutput) func(i, j int) bool {
+	return func(i, j int) bool {
+		return outputs[i].user.CreationDate > outputs[j].user.CreationDate
+	}
+}
+
 func (i *listInputs) Resolve(profile *user.Profile, ui terminal.UI) error {
+	if i.Limit < 0 {
+		return fmt.Errorf(`"%s" must not be negative`, flagLimit)
+	}
+
+	switch i.Sort {
+	case "", sortCreated, sortLastAuth:
+	default:
+		return fmt.Errorf("unsupported sort: %s", i.Sort)
+	}
+
+	if !i.CreatedAfter.Time.IsZero() && !i.CreatedBefore.Time.IsZero() && !i.CreatedAfter.Time.Before(i.CreatedBefore.Time) {
+		return fmt.Errorf(`"%s" must be before "%s"`, flagCreatedAfter, flagCreatedBefore)
+	}
+	if !i.LastAuthAfter.Time.IsZero() && !i.LastAuthBefore.Time.IsZero() && !i.LastAuthAfter.Time.Before(i.LastAuthBefore.Time) {
+		return fmt.Errorf(`"%s" must be before "%s"`, flagLastAuthAfter, flagLastAuthBefore)
+	}
+
 	return i.ProjectInputs.Resolve(ui, profile.WorkingDirectory, false)
 }
 
+func (i *listInputs) listFilter() userListFilter {
+	return userListFilter{
+		createdBefore:  i.CreatedBefore.Time,
+		createdAfter:   i.CreatedAfter.Time,
+		lastAuthBefore: i.LastAuthBefore.Time,
+		lastAuthAfter:  i.LastAuthAfter.Time,
+		emailContains:  strings.ToLower(i.EmailContains),
+	}
+}
+
+// userListFilter narrows down the listed users by their dates and email
+type userListFilter struct {
+	createdBefore  time.Time
+	createdAfter   time.Time
+	lastAuthBefore time.Time
+	lastAuthAfter  time.Time
+	emailContains  string
+}
+
+func (f userListFilter) apply(users []realm.User) []realm.User {
+	filtered := make([]realm.User, 0, len(users))
+	for _, user := range users {
+		if f.matches(user) {
+			filtered = append(filtered, user)
+		}
+	}
+	return filtered
+}
+
+func (f userListFilter) matches(user realm.User) bool {
+	if !matchesDateRange(user.CreationDate, f.createdAfter, f.createdBefore) {
+		return false
+	}
+	if !matchesDateRange(user.LastAuthenticationDate, f.lastAuthAfter, f.lastAuthBefore) {
+		return false
+	}
+	if f.emailContains != "" {
+		email, _ := user.Data[userDataEmail].(string)
+		if !strings.Contains(strings.ToLower(email), f.emailContains) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesDateRange checks the unix timestamp is within the range, where an unset date never
+// matches a range and a zero after or before leaves that side of the range open
+func matchesDateRange(unix int64, after, before time.Time) bool {
+	if after.IsZero() && before.IsZero() {
+		return true
+	}
+	if unix == 0 {
+		return false
+	}
+
+	date := time.Unix(unix, 0)
+	if !after.IsZero() && !date.After(after) {
+		return false
+	}
+	if !before.IsZero() && !date.Before(before) {
+		return false
+	}
+	return true
+}
+
 func tableRowList(output userOutput, row map[string]interface{}) {
 	timeString := "n/a"
 	if output.user.LastAuthenticationDate != 0 {
@@ -4,9 +4,11 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/10gen/realm-cli/internal/cli"
 	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/flags"
 	"github.com/10gen/realm-cli/internal/utils/test/assert"
 	"github.com/10gen/realm-cli/internal/utils/test/mock"
 )
@@ -28,8 +30,8 @@ func TestUserListHandler(t *testing.T) {
 		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
 			return []realm.App{app}, nil
 		}
-		realmClient.FindUsersFn = func(groupID, appID string, filter realm.UserFilter) ([]realm.User, error) {
-			return nil, nil
+		realmClient.UsersPagesFn = func(groupID, appID string, filter realm.UserFilter, handlePage func(page []realm.User) error) error {
+			return nil
 		}
 		realmClient.DeleteUserFn = func(groupID, appID, userID string) error {
 			return nil
@@ -51,8 +53,8 @@ func TestUserListHandler(t *testing.T) {
 		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
 			return []realm.App{app}, nil
 		}
-		realmClient.FindUsersFn = func(groupID, appID string, filter realm.UserFilter) ([]realm.User, error) {
-			return testUsers, nil
+		realmClient.UsersPagesFn = func(groupID, appID string, filter realm.UserFilter, handlePage func(page []realm.User) error) error {
+			return handlePage(testUsers)
 		}
 
 		cmd := &CommandList{listInputs{
@@ -100,10 +102,10 @@ func TestUserListHandler(t *testing.T) {
 			return []realm.App{app}, nil
 		}
 
-		realmClient.FindUsersFn = func(groupID, appID string, filter realm.UserFilter) ([]realm.User, error) {
+		realmClient.UsersPagesFn = func(groupID, appID string, filter realm.UserFilter, handlePage func(page []realm.User) error) error {
 			capturedProjectID = groupID
 			capturedAppID = appID
-			return testUsers[:1], nil
+			return handlePage(testUsers[:1])
 		}
 
 		cmd := &CommandList{listInputs{
@@ -151,8 +153,8 @@ func TestUserListHandler(t *testing.T) {
 					realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
 						return []realm.App{app}, nil
 					}
-					realmClient.FindUsersFn = func(groupID, appID string, filter realm.UserFilter) ([]realm.User, error) {
-						return nil, errors.New("something bad happened")
+					realmClient.UsersPagesFn = func(groupID, appID string, filter realm.UserFilter, handlePage func(page []realm.User) error) error {
+						return errors.New("something bad happened")
 					}
 					return realmClient
 				},
@@ -169,6 +171,195 @@ func TestUserListHandler(t *testing.T) {
 	})
 }
 
+func TestUserListPages(t *testing.T) {
+	app := realm.App{ID: "appID", GroupID: "projectID", Name: "eggcorn"}
+
+	newUser := func(id string, created, lastAuth int64) realm.User {
+		return realm.User{
+			ID:                     id,
+			Identities:             []realm.UserIdentity{{ProviderType: realm.AuthProviderTypeUserPassword}},
+			Data:                   map[string]interface{}{"email": id + "@test.com"},
+			CreationDate:           created,
+			LastAuthenticationDate: lastAuth,
+		}
+	}
+
+	pages := [][]realm.User{
+		{newUser("user-1", 1000, 4000), newUser("user-2", 3000, 5000)},
+		{newUser("user-3", 2000, 6000), newUser("admin-4", 4000, 0)},
+	}
+
+	setupClient := func(fetchedPages *int) mock.RealmClient {
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{app}, nil
+		}
+		realmClient.UsersPagesFn = func(groupID, appID string, filter realm.UserFilter, handlePage func(page []realm.User) error) error {
+			for _, page := range pages {
+				*fetchedPages++
+				if err := handlePage(page); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		return realmClient
+	}
+
+	t.Run("should display each page of users as it is fetched", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		var fetchedPages int
+		cmd := &CommandList{}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: setupClient(&fetchedPages)}))
+
+		assert.Equal(t, strings.Join([]string{
+			"Provider type: User/Password",
+			"  Email            ID      Type  Enabled  Last Authenticated           ",
+			"  ---------------  ------  ----  -------  -----------------------------",
+			"  user-2@test.com  user-2        true     1970-01-01 01:23:20 +0000 UTC",
+			"  user-1@test.com  user-1        true     1970-01-01 01:06:40 +0000 UTC",
+			"Provider type: User/Password",
+			"  Email             ID       Type  Enabled  Last Authenticated           ",
+			"  ----------------  -------  ----  -------  -----------------------------",
+			"  user-3@test.com   user-3         true     1970-01-01 01:40:00 +0000 UTC",
+			"  admin-4@test.com  admin-4        true     n/a                          ",
+			"",
+		}, "\n"), out.String())
+		assert.Equal(t, 2, fetchedPages)
+	})
+
+	t.Run("should stop fetching users once more than the limit have matched", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		var fetchedPages int
+		cmd := &CommandList{listInputs{Limit: 1}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: setupClient(&fetchedPages)}))
+
+		assert.Equal(t, strings.Join([]string{
+			"Provider type: User/Password",
+			"  Email            ID      Type  Enabled  Last Authenticated           ",
+			"  ---------------  ------  ----  -------  -----------------------------",
+			"  user-1@test.com  user-1        true     1970-01-01 01:06:40 +0000 UTC",
+			`Only listed 1 users, use "--limit" to list more`,
+			"",
+		}, "\n"), out.String())
+		assert.Equal(t, 1, fetchedPages)
+	})
+
+	t.Run("should sort every user by creation date before applying the limit", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		var fetchedPages int
+		cmd := &CommandList{listInputs{Sort: sortCreated, Limit: 3}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: setupClient(&fetchedPages)}))
+
+		assert.Equal(t, strings.Join([]string{
+			"Provider type: User/Password",
+			"  Email             ID       Type  Enabled  Last Authenticated           ",
+			"  ----------------  -------  ----  -------  -----------------------------",
+			"  admin-4@test.com  admin-4        true     n/a                          ",
+			"  user-2@test.com   user-2         true     1970-01-01 01:23:20 +0000 UTC",
+			"  user-3@test.com   user-3         true     1970-01-01 01:40:00 +0000 UTC",
+			`Only listed 3 users, use "--limit" to list more`,
+			"",
+		}, "\n"), out.String())
+		assert.Equal(t, 2, fetchedPages)
+	})
+
+	t.Run("should only display the users matching the date and email filters", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		var fetchedPages int
+		cmd := &CommandList{listInputs{
+			CreatedAfter:  flags.Date{Time: time.Unix(1500, 0)},
+			LastAuthAfter: flags.Date{Time: time.Unix(1, 0)},
+			EmailContains: "USER",
+		}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: setupClient(&fetchedPages)}))
+
+		assert.Equal(t, strings.Join([]string{
+			"Provider type: User/Password",
+			"  Email            ID      Type  Enabled  Last Authenticated           ",
+			"  ---------------  ------  ----  -------  -----------------------------",
+			"  user-2@test.com  user-2        true     1970-01-01 01:23:20 +0000 UTC",
+			"Provider type: User/Password",
+			"  Email            ID      Type  Enabled  Last Authenticated           ",
+			"  ---------------  ------  ----  -------  -----------------------------",
+			"  user-3@test.com  user-3        true     1970-01-01 01:40:00 +0000 UTC",
+			"",
+		}, "\n"), out.String())
+	})
+
+	t.Run("should display the empty state message when no users match the filters", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		var fetchedPages int
+		cmd := &CommandList{listInputs{EmailContains: "nobody"}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: setupClient(&fetchedPages)}))
+
+		assert.Equal(t, "No available users to show\n", out.String())
+	})
+}
+
+func TestUserListInputsResolve(t *testing.T) {
+	for _, tc := range []struct {
+		description string
+		inputs      listInputs
+		err         error
+	}{
+		{
+			description: "with a negative limit",
+			inputs:      listInputs{Limit: -1},
+			err:         errors.New(`"limit" must not be negative`),
+		},
+		{
+			description: "with an unsupported sort",
+			inputs:      listInputs{Sort: "email"},
+			err:         errors.New("unsupported sort: email"),
+		},
+		{
+			description: "with the created dates out of order",
+			inputs:      listInputs{CreatedAfter: flags.Date{Time: time.Unix(2000, 0)}, CreatedBefore: flags.Date{Time: time.Unix(1000, 0)}},
+			err:         errors.New(`"created-after" must be before "created-before"`),
+		},
+		{
+			description: "with the last auth dates out of order",
+			inputs:      listInputs{LastAuthAfter: flags.Date{Time: time.Unix(1000, 0)}, LastAuthBefore: flags.Date{Time: time.Unix(1000, 0)}},
+			err:         errors.New(`"last-auth-after" must be before "last-auth-before"`),
+		},
+	} {
+		t.Run("should return an error "+tc.description, func(t *testing.T) {
+			profile := mock.NewProfile(t)
+
+			assert.Equal(t, tc.err, tc.inputs.Resolve(profile, nil))
+		})
+	}
+}
+
+func TestMatchesDateRange(t *testing.T) {
+	after, before := time.Unix(1000, 0), time.Unix(2000, 0)
+
+	for _, tc := range []struct {
+		description string
+		unix        int64
+		after       time.Time
+		before      time.Time
+		matches     bool
+	}{
+		{"match any date without a range", 0, time.Time{}, time.Time{}, true},
+		{"not match an unset date with a range", 0, after, time.Time{}, false},
+		{"match a date within the range", 1500, after, before, true},
+		{"not match a date before the range", 1000, after, before, false},
+		{"not match a date after the range", 2000, after, before, false},
+		{"match a date after an open ended range", 5000, after, time.Time{}, true},
+	} {
+		t.Run("should "+tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.matches, matchesDateRange(tc.unix, tc.after, tc.before))
+		})
+	}
+}
+
 func TestTableRowList(t *testing.T) {
 	t.Run("should show successful list user row", func(t *testing.T) {
 		output := userOutput{
@@ -0,0 +1,142 @@
+package user
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/terminal"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+)
+
+const (
+	flagEmail = "email"
+
+	headerConfirmed        = "Confirmed"
+	headerConfirmationSent = "Confirmation Sent"
+	headerResetSent        = "Reset Sent"
+	headerReset            = "Reset"
+)
+
+// emailUserInputs are the inputs of the commands which act on Email/Password users by their email
+type emailUserInputs struct {
+	cli.ProjectInputs
+	Emails []string
+}
+
+func (i *emailUserInputs) Resolve(profile *user.Profile, ui terminal.UI) error {
+	return i.ProjectInputs.Resolve(ui, profile.WorkingDirectory, false)
+}
+
+// addEmails adds the emails specified as args to the ones specified with "--email" flags
+func (i *emailUserInputs) addEmails(args []string) {
+	i.Emails = append(i.Emails, args...)
+}
+
+func emailsFlag(value *[]string, description string) flags.StringSliceFlag {
+	return flags.StringSliceFlag{
+		Value: value,
+		Meta: flags.Meta{
+			Name: flagEmail,
+			Usage: flags.Usage{
+				Description: description,
+			},
+		},
+	}
+}
+
+// selectUsers finds the users specified by their email,
+// otherwise the users are selected from the found users
+func (i emailUserInputs) selectUsers(ui terminal.UI, found []realm.User, action string) ([]realm.User, error) {
+	if len(i.Emails) == 0 {
+		return multiUserInputs{}.selectUsers(ui, found, action)
+	}
+
+	usersByEmail := make(map[string]realm.User, len(found))
+	for _, user := range found {
+		if email := userEmail(user); email != "" {
+			usersByEmail[strings.ToLower(email)] = user
+		}
+	}
+
+	selected := make([]realm.User, 0, len(i.Emails))
+	for _, email := range i.Emails {
+		user, ok := usersByEmail[strings.ToLower(email)]
+		if !ok {
+			return nil, fmt.Errorf("unable to find user: %s", email)
+		}
+		selected = append(selected, user)
+	}
+	return selected, nil
+}
+
+// userEmail returns the email of an Email/Password user, whether or not the user is still pending
+func userEmail(user realm.User) string {
+	if email, ok := user.Data[userDataEmail].(string); ok {
+		return email
+	}
+	email, _ := pendingUserEmail(user)
+	return email
+}
+
+// emailUserAction takes an action on each of the selected users and displays the results
+type emailUserAction struct {
+	noun   string
+	action string
+	title  string
+	header string
+	run    func(email string) error
+}
+
+func (a emailUserAction) handle(ui terminal.UI, inputs emailUserInputs, found []realm.User) error {
+	users, err := inputs.selectUsers(ui, found, a.action)
+	if err != nil {
+		return err
+	}
+
+	if len(users) == 0 {
+		ui.Print(terminal.NewTextLog("No %s to %s", a.noun, a.action))
+		return nil
+	}
+
+	outputs := make(userOutputs, 0, len(users))
+	for _, user := range users {
+		outputs = append(outputs, userOutput{user, a.run(userEmail(user))})
+	}
+
+	sort.SliceStable(outputs, getUserOutputComparerBySuccess(outputs))
+
+	rows := make([]map[string]interface{}, 0, len(outputs))
+	for _, output := range outputs {
+		var details string
+		if output.err != nil {
+			details = output.err.Error()
+		}
+		rows = append(rows, map[string]interface{}{
+			headerEmail:   userEmail(output.user),
+			headerID:      output.user.ID,
+			a.header:      output.err == nil,
+			headerDetails: details,
+		})
+	}
+
+	ui.Print(terminal.NewTableLog(
+		fmt.Sprintf(a.title, len(outputs)),
+		[]string{headerEmail, headerID, a.header, headerDetails},
+		rows...,
+	))
+	return nil
+}
+
+func findPendingUsers(realmClient realm.Client, app realm.App) ([]realm.User, error) {
+	return realmClient.FindUsers(app.GroupID, app.ID, realm.UserFilter{Pending: true})
+}
+
+func findEmailPasswordUsers(realmClient realm.Client, app realm.App) ([]realm.User, error) {
+	return realmClient.FindUsers(app.GroupID, app.ID, realm.UserFilter{
+		Providers: []realm.AuthProviderType{realm.AuthProviderTypeUserPassword},
+	})
+}
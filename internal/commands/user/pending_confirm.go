@@ -0,0 +1,68 @@
+package user
+
+import (
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/terminal"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+)
+
+// CommandMetaPendingConfirm is the command meta for the `user pending confirm` command
+var CommandMetaPendingConfirm = cli.CommandMeta{
+	Use:         "confirm",
+	Display:     "user pending confirm",
+	Description: "Confirm pending Email/Password users of your Realm app",
+	HelpText: `Confirms the pending Email/Password users specified by their email, either as
+arguments or with "--email" flags, so they can log in without confirming their
+email themselves. You will be prompted to select the pending users to confirm if
+none are specified.`,
+}
+
+// CommandPendingConfirm is the `user pending confirm` command
+type CommandPendingConfirm struct {
+	inputs emailUserInputs
+}
+
+// Flags is the command flags
+func (cmd *CommandPendingConfirm) Flags() []flags.Flag {
+	return []flags.Flag{
+		cli.AppFlagWithContext(&cmd.inputs.App, "to confirm its pending users"),
+		cli.ProjectFlag(&cmd.inputs.Project),
+		cli.ProductFlag(&cmd.inputs.Products),
+		emailsFlag(&cmd.inputs.Emails, "Specify the email(s) of the pending users to confirm"),
+	}
+}
+
+// Inputs is the command inputs
+func (cmd *CommandPendingConfirm) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Args is the command args
+func (cmd *CommandPendingConfirm) Args(args []string) error {
+	cmd.inputs.addEmails(args)
+	return nil
+}
+
+// Handler is the command handler
+func (cmd *CommandPendingConfirm) Handler(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	found, err := findPendingUsers(clients.Realm, app)
+	if err != nil {
+		return err
+	}
+
+	return emailUserAction{
+		noun:   "pending users",
+		action: "confirm",
+		title:  "Confirmed %d pending user(s)",
+		header: headerConfirmed,
+		run: func(email string) error {
+			return clients.Realm.ConfirmPendingUser(app.GroupID, app.ID, email)
+		},
+	}.handle(ui, cmd.inputs, found)
+}
@@ -0,0 +1,67 @@
+package user
+
+import (
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/terminal"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+)
+
+// CommandMetaPendingDelete is the command meta for the `user pending delete` command
+var CommandMetaPendingDelete = cli.CommandMeta{
+	Use:         "delete",
+	Display:     "user pending delete",
+	Description: "Delete pending Email/Password users from your Realm app",
+	HelpText: `Removes the pending Email/Password users specified by their email, either as
+arguments or with "--email" flags, so their emails can be registered again. You
+will be prompted to select the pending users to delete if none are specified.`,
+}
+
+// CommandPendingDelete is the `user pending delete` command
+type CommandPendingDelete struct {
+	inputs emailUserInputs
+}
+
+// Flags is the command flags
+func (cmd *CommandPendingDelete) Flags() []flags.Flag {
+	return []flags.Flag{
+		cli.AppFlagWithContext(&cmd.inputs.App, "to delete its pending users"),
+		cli.ProjectFlag(&cmd.inputs.Project),
+		cli.ProductFlag(&cmd.inputs.Products),
+		emailsFlag(&cmd.inputs.Emails, "Specify the email(s) of the pending users to delete"),
+	}
+}
+
+// Inputs is the command inputs
+func (cmd *CommandPendingDelete) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Args is the command args
+func (cmd *CommandPendingDelete) Args(args []string) error {
+	cmd.inputs.addEmails(args)
+	return nil
+}
+
+// Handler is the command handler
+func (cmd *CommandPendingDelete) Handler(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	found, err := findPendingUsers(clients.Realm, app)
+	if err != nil {
+		return err
+	}
+
+	return emailUserAction{
+		noun:   "pending users",
+		action: "delete",
+		title:  "Deleted %d pending user(s)",
+		header: headerDeleted,
+		run: func(email string) error {
+			return clients.Realm.DeletePendingUser(app.GroupID, app.ID, email)
+		},
+	}.handle(ui, cmd.inputs, found)
+}
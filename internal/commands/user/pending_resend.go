@@ -0,0 +1,67 @@
+package user
+
+import (
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/terminal"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+)
+
+// CommandMetaPendingResend is the command meta for the `user pending resend` command
+var CommandMetaPendingResend = cli.CommandMeta{
+	Use:         "resend",
+	Display:     "user pending resend",
+	Description: "Resend the confirmation email to pending Email/Password users of your Realm app",
+	HelpText: `Sends the confirmation email again to the pending Email/Password users specified
+by their email, either as arguments or with "--email" flags. You will be prompted
+to select the pending users if none are specified.`,
+}
+
+// CommandPendingResend is the `user pending resend` command
+type CommandPendingResend struct {
+	inputs emailUserInputs
+}
+
+// Flags is the command flags
+func (cmd *CommandPendingResend) Flags() []flags.Flag {
+	return []flags.Flag{
+		cli.AppFlagWithContext(&cmd.inputs.App, "to resend the confirmation email to its pending users"),
+		cli.ProjectFlag(&cmd.inputs.Project),
+		cli.ProductFlag(&cmd.inputs.Products),
+		emailsFlag(&cmd.inputs.Emails, "Specify the email(s) of the pending users to resend the confirmation email to"),
+	}
+}
+
+// Inputs is the command inputs
+func (cmd *CommandPendingResend) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Args is the command args
+func (cmd *CommandPendingResend) Args(args []string) error {
+	cmd.inputs.addEmails(args)
+	return nil
+}
+
+// Handler is the command handler
+func (cmd *CommandPendingResend) Handler(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	found, err := findPendingUsers(clients.Realm, app)
+	if err != nil {
+		return err
+	}
+
+	return emailUserAction{
+		noun:   "pending users",
+		action: "resend the confirmation email to",
+		title:  "Resent the confirmation email to %d pending user(s)",
+		header: headerConfirmationSent,
+		run: func(email string) error {
+			return clients.Realm.ResendPendingUserConfirmation(app.GroupID, app.ID, email)
+		},
+	}.handle(ui, cmd.inputs, found)
+}
@@ -0,0 +1,175 @@
+package user
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+var testPendingUsers = []realm.User{
+	{ID: "pending-1", LoginIDs: []realm.UserLoginID{{IDType: "email", ID: "one@domain.com"}}},
+	{ID: "pending-2", LoginIDs: []realm.UserLoginID{{IDType: "email", ID: "two@domain.com"}}},
+}
+
+func newPendingRealmClient(t *testing.T) mock.RealmClient {
+	realmClient := mock.RealmClient{}
+	realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+		return []realm.App{{ID: "app-id", GroupID: "group-id", ClientAppID: "eggcorn-abcde", Name: "eggcorn"}}, nil
+	}
+	realmClient.FindUsersFn = func(groupID, appID string, filter realm.UserFilter) ([]realm.User, error) {
+		assert.Equal(t, realm.UserFilter{Pending: true}, filter)
+		return testPendingUsers, nil
+	}
+	return realmClient
+}
+
+func TestUserPendingHandlers(t *testing.T) {
+	for _, tc := range []struct {
+		description string
+		cmd         func(inputs emailUserInputs) cli.Command
+		setup       func(realmClient *mock.RealmClient, fn func(groupID, appID, email string) error)
+		title       string
+		table       []string
+	}{
+		{
+			description: "confirm",
+			cmd:         func(inputs emailUserInputs) cli.Command { return &CommandPendingConfirm{inputs} },
+			setup: func(realmClient *mock.RealmClient, fn func(groupID, appID, email string) error) {
+				realmClient.ConfirmPendingUserFn = fn
+			},
+			title: "Confirmed 2 pending user(s)",
+			table: []string{
+				"  Email           ID         Confirmed  Details               ",
+				"  --------------  ---------  ---------  ----------------------",
+				"  two@domain.com  pending-2  false      something bad happened",
+				"  one@domain.com  pending-1  true                             ",
+			},
+		},
+		{
+			description: "resend",
+			cmd:         func(inputs emailUserInputs) cli.Command { return &CommandPendingResend{inputs} },
+			setup: func(realmClient *mock.RealmClient, fn func(groupID, appID, email string) error) {
+				realmClient.ResendPendingUserConfirmationFn = fn
+			},
+			title: "Resent the confirmation email to 2 pending user(s)",
+			table: []string{
+				"  Email           ID         Confirmation Sent  Details               ",
+				"  --------------  ---------  -----------------  ----------------------",
+				"  two@domain.com  pending-2  false              something bad happened",
+				"  one@domain.com  pending-1  true                                     ",
+			},
+		},
+		{
+			description: "delete",
+			cmd:         func(inputs emailUserInputs) cli.Command { return &CommandPendingDelete{inputs} },
+			setup: func(realmClient *mock.RealmClient, fn func(groupID, appID, email string) error) {
+				realmClient.DeletePendingUserFn = fn
+			},
+			title: "Deleted 2 pending user(s)",
+			table: []string{
+				"  Email           ID         Deleted  Details               ",
+				"  --------------  ---------  -------  ----------------------",
+				"  two@domain.com  pending-2  false    something bad happened",
+				"  one@domain.com  pending-1  true                           ",
+			},
+		},
+	} {
+		t.Run("should "+tc.description+" the pending users specified by email", func(t *testing.T) {
+			out, ui := mock.NewUI()
+
+			var emails []string
+			realmClient := newPendingRealmClient(t)
+			tc.setup(&realmClient, func(groupID, appID, email string) error {
+				assert.Equal(t, "group-id", groupID)
+				assert.Equal(t, "app-id", appID)
+				emails = append(emails, email)
+				if email == "two@domain.com" {
+					return errors.New("something bad happened")
+				}
+				return nil
+			})
+
+			cmd := tc.cmd(emailUserInputs{Emails: []string{"One@domain.com", "two@domain.com"}})
+			assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+			assert.Equal(t, []string{"one@domain.com", "two@domain.com"}, emails)
+
+			assert.Equal(t, strings.Join(append([]string{tc.title}, tc.table...), "\n")+"\n", out.String())
+		})
+	}
+
+	t.Run("should accept the emails as args along with the email flags", func(t *testing.T) {
+		_, ui := mock.NewUI()
+
+		var emails []string
+		realmClient := newPendingRealmClient(t)
+		realmClient.ResendPendingUserConfirmationFn = func(groupID, appID, email string) error {
+			emails = append(emails, email)
+			return nil
+		}
+
+		cmd := &CommandPendingResend{emailUserInputs{Emails: []string{"one@domain.com"}}}
+		assert.Nil(t, cmd.Args([]string{"two@domain.com"}))
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, []string{"one@domain.com", "two@domain.com"}, emails)
+	})
+
+	t.Run("should return an error when a pending user cannot be found", func(t *testing.T) {
+		_, ui := mock.NewUI()
+
+		cmd := &CommandPendingConfirm{emailUserInputs{Emails: []string{"three@domain.com"}}}
+		assert.Equal(t, errors.New("unable to find user: three@domain.com"), cmd.Handler(nil, ui, cli.Clients{Realm: newPendingRealmClient(t)}))
+	})
+
+	t.Run("should display a message when there are no pending users", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		realmClient := newPendingRealmClient(t)
+		realmClient.FindUsersFn = func(groupID, appID string, filter realm.UserFilter) ([]realm.User, error) {
+			return nil, nil
+		}
+
+		cmd := &CommandPendingDelete{}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, "No pending users to delete\n", out.String())
+	})
+
+	t.Run("should prompt for the pending users when none are specified", func(t *testing.T) {
+		_, console, _, ui, consoleErr := mock.NewVT10XConsole()
+		assert.Nil(t, consoleErr)
+		defer console.Close()
+
+		doneCh := make(chan struct{})
+		go func() {
+			defer close(doneCh)
+			console.ExpectString("Which user(s) would you like to confirm?")
+			console.Send("two@domain.com")
+			console.Send(" ")
+			console.SendLine("")
+			console.ExpectEOF()
+		}()
+
+		var emails []string
+		realmClient := newPendingRealmClient(t)
+		realmClient.ConfirmPendingUserFn = func(groupID, appID, email string) error {
+			emails = append(emails, email)
+			return nil
+		}
+
+		cmd := &CommandPendingConfirm{}
+		err := cmd.Handler(nil, ui, cli.Clients{Realm: realmClient})
+
+		console.Tty().Close()
+		<-doneCh
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"two@domain.com"}, emails)
+	})
+}
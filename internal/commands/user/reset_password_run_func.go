@@ -0,0 +1,212 @@
+package user
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/terminal"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+const flagPasswordStdin = "password-stdin"
+
+// CommandMetaResetPasswordRunFunc is the command meta for the `user reset-password run-func` command
+var CommandMetaResetPasswordRunFunc = cli.CommandMeta{
+	Use:         "run-func",
+	Display:     "user reset-password run-func",
+	Description: "Run the password reset function for an Email/Password user of your Realm app",
+	HelpText: `Runs the password reset function configured for your Realm app's Email/Password
+provider for the user specified by their email, either as an argument or with
+"--email". You will be prompted to select the user if none is specified.
+
+You will be prompted for the new password of the user, or use "--password-stdin"
+to read it from stdin instead, which requires the user's email as the user cannot
+be selected from a prompt.
+
+The function is called through your Realm app's client API, the same as your users
+calling it themselves, so the Email/Password provider must be enabled and
+configured to run a password reset function.
+
+Each "--arg" is passed along to the password reset function, and is parsed as JSON
+when possible, otherwise it is passed as a string.`,
+}
+
+// CommandResetPasswordRunFunc is the `user reset-password run-func` command
+type CommandResetPasswordRunFunc struct {
+	inputs resetPasswordRunFuncInputs
+}
+
+type resetPasswordRunFuncInputs struct {
+	cli.ProjectInputs
+	Email         string
+	PasswordStdin bool
+	Args          []string
+	password      string
+}
+
+// Flags is the command flags
+func (cmd *CommandResetPasswordRunFunc) Flags() []flags.Flag {
+	return []flags.Flag{
+		cli.AppFlagWithContext(&cmd.inputs.App, "to reset its user's password"),
+		cli.ProjectFlag(&cmd.inputs.Project),
+		cli.ProductFlag(&cmd.inputs.Products),
+		flags.StringFlag{
+			Value: &cmd.inputs.Email,
+			Meta: flags.Meta{
+				Name: flagEmail,
+				Usage: flags.Usage{
+					Description: "Specify the email of the user to reset the password of",
+				},
+			},
+		},
+		flags.BoolFlag{
+			Value: &cmd.inputs.PasswordStdin,
+			Meta: flags.Meta{
+				Name: flagPasswordStdin,
+				Usage: flags.Usage{
+					Description: "Read the new password of the user from stdin",
+				},
+			},
+		},
+		flags.StringArrayFlag{
+			Value: &cmd.inputs.Args,
+			Meta: flags.Meta{
+				Name: "arg",
+				Usage: flags.Usage{
+					Description: "Specify the argument(s) to pass to the password reset function",
+				},
+			},
+		},
+	}
+}
+
+// Inputs is the command inputs
+func (cmd *CommandResetPasswordRunFunc) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Args is the command args
+func (cmd *CommandResetPasswordRunFunc) Args(args []string) error {
+	if len(args) == 0 {
+		return nil
+	}
+	if len(args) > 1 {
+		return fmt.Errorf("accepts at most 1 email argument, received %d", len(args))
+	}
+	if cmd.inputs.Email != "" && !strings.EqualFold(cmd.inputs.Email, args[0]) {
+		return fmt.Errorf(`cannot specify an email argument along with a different "--%s"`, flagEmail)
+	}
+	cmd.inputs.Email = args[0]
+	return nil
+}
+
+// Handler is the command handler
+func (cmd *CommandResetPasswordRunFunc) Handler(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	found, err := findEmailPasswordUsers(clients.Realm, app)
+	if err != nil {
+		return err
+	}
+
+	email, err := cmd.inputs.resolveEmail(ui, found)
+	if err != nil {
+		return err
+	}
+	if email == "" {
+		ui.Print(terminal.NewTextLog("No users to reset the password of"))
+		return nil
+	}
+
+	args := parseResetPasswordArgs(cmd.inputs.Args)
+
+	return emailUserAction{
+		noun:   "users",
+		action: "reset the password of",
+		title:  "Ran the password reset function for %d user(s)",
+		header: headerReset,
+		run: func(email string) error {
+			return clients.Realm.CallResetPasswordFunction(app.ClientAppID, email, cmd.inputs.password, args)
+		},
+	}.handle(ui, emailUserInputs{Emails: []string{email}}, found)
+}
+
+func (i *resetPasswordRunFuncInputs) Resolve(profile *user.Profile, ui terminal.UI) error {
+	if err := i.ProjectInputs.Resolve(ui, profile.WorkingDirectory, false); err != nil {
+		return err
+	}
+
+	if !i.PasswordStdin {
+		return ui.AskOne(&i.password, &survey.Password{Message: "New Password"})
+	}
+
+	// the user cannot be selected from a prompt once stdin has been read
+	if i.Email == "" {
+		return fmt.Errorf(`must specify the user with "%s" when using "%s"`, flagEmail, flagPasswordStdin)
+	}
+	if ui.In() == nil {
+		return errors.New("failed to read the password from stdin")
+	}
+
+	data, err := ioutil.ReadAll(ui.In())
+	if err != nil {
+		return fmt.Errorf("failed to read the password from stdin: %s", err)
+	}
+
+	i.password = strings.TrimRight(string(data), "\r\n")
+	if i.password == "" {
+		return errors.New("the password read from stdin must not be empty")
+	}
+	return nil
+}
+
+// resolveEmail returns the email of the user to reset the password of,
+// otherwise the user is selected from the found users
+func (i resetPasswordRunFuncInputs) resolveEmail(ui terminal.UI, found []realm.User) (string, error) {
+	if i.Email != "" {
+		return i.Email, nil
+	}
+
+	options := make([]string, 0, len(found))
+	for _, user := range found {
+		if email := userEmail(user); email != "" {
+			options = append(options, email)
+		}
+	}
+	if len(options) == 0 {
+		return "", nil
+	}
+
+	var email string
+	if err := ui.AskOne(&email, &survey.Select{
+		Message: "Which user would you like to reset the password of?",
+		Options: options,
+	}); err != nil {
+		return "", err
+	}
+	return email, nil
+}
+
+// parseResetPasswordArgs parses each arg as JSON, leaving the args which are not JSON as strings
+func parseResetPasswordArgs(rawArgs []string) []interface{} {
+	args := make([]interface{}, 0, len(rawArgs))
+	for _, rawArg := range rawArgs {
+		var arg interface{}
+		if err := json.Unmarshal([]byte(rawArg), &arg); err != nil {
+			arg = rawArg
+		}
+		args = append(args, arg)
+	}
+	return args
+}
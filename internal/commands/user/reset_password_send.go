@@ -0,0 +1,72 @@
+package user
+
+import (
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/terminal"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+)
+
+// CommandMetaResetPasswordSend is the command meta for the `user reset-password send` command
+var CommandMetaResetPasswordSend = cli.CommandMeta{
+	Use:         "send",
+	Display:     "user reset-password send",
+	Description: "Send a password reset email to Email/Password users of your Realm app",
+	HelpText: `Sends a password reset email to the Email/Password users specified by their
+email, either as arguments or with "--email" flags, using the reset password URL
+configured for your Realm app's Email/Password provider. You will be prompted to
+select the users if none are specified.
+
+The email is requested through your Realm app's client API, the same as your
+users requesting it themselves, so the Email/Password provider must be enabled and
+configured to send a password reset email.`,
+}
+
+// CommandResetPasswordSend is the `user reset-password send` command
+type CommandResetPasswordSend struct {
+	inputs emailUserInputs
+}
+
+// Flags is the command flags
+func (cmd *CommandResetPasswordSend) Flags() []flags.Flag {
+	return []flags.Flag{
+		cli.AppFlagWithContext(&cmd.inputs.App, "to reset its users' passwords"),
+		cli.ProjectFlag(&cmd.inputs.Project),
+		cli.ProductFlag(&cmd.inputs.Products),
+		emailsFlag(&cmd.inputs.Emails, "Specify the email(s) of the users to send a password reset email to"),
+	}
+}
+
+// Inputs is the command inputs
+func (cmd *CommandResetPasswordSend) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Args is the command args
+func (cmd *CommandResetPasswordSend) Args(args []string) error {
+	cmd.inputs.addEmails(args)
+	return nil
+}
+
+// Handler is the command handler
+func (cmd *CommandResetPasswordSend) Handler(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	found, err := findEmailPasswordUsers(clients.Realm, app)
+	if err != nil {
+		return err
+	}
+
+	return emailUserAction{
+		noun:   "users",
+		action: "send a password reset email to",
+		title:  "Sent a password reset email to %d user(s)",
+		header: headerResetSent,
+		run: func(email string) error {
+			return clients.Realm.SendResetPasswordEmail(app.ClientAppID, email)
+		},
+	}.handle(ui, cmd.inputs, found)
+}
@@ -0,0 +1,187 @@
+package user
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func newResetPasswordRealmClient(t *testing.T) mock.RealmClient {
+	realmClient := mock.RealmClient{}
+	realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+		return []realm.App{{ID: "app-id", GroupID: "group-id", ClientAppID: "eggcorn-abcde", Name: "eggcorn"}}, nil
+	}
+	realmClient.FindUsersFn = func(groupID, appID string, filter realm.UserFilter) ([]realm.User, error) {
+		assert.Equal(t, realm.UserFilter{Providers: []realm.AuthProviderType{realm.AuthProviderTypeUserPassword}}, filter)
+		return []realm.User{
+			{
+				ID:         "user-1",
+				Identities: []realm.UserIdentity{{ProviderType: realm.AuthProviderTypeUserPassword}},
+				Data:       map[string]interface{}{"email": "one@domain.com"},
+			},
+			{
+				ID:         "user-2",
+				Identities: []realm.UserIdentity{{ProviderType: realm.AuthProviderTypeUserPassword}},
+				Data:       map[string]interface{}{"email": "two@domain.com"},
+			},
+		}, nil
+	}
+	return realmClient
+}
+
+func TestUserResetPasswordSendHandler(t *testing.T) {
+	out, ui := mock.NewUI()
+
+	var emails []string
+	realmClient := newResetPasswordRealmClient(t)
+	realmClient.SendResetPasswordEmailFn = func(clientAppID, email string) error {
+		assert.Equal(t, "eggcorn-abcde", clientAppID)
+		emails = append(emails, email)
+		return nil
+	}
+
+	cmd := &CommandResetPasswordSend{emailUserInputs{Emails: []string{"two@domain.com", "one@domain.com"}}}
+	assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+	assert.Equal(t, []string{"two@domain.com", "one@domain.com"}, emails)
+	assert.Equal(t, strings.Join([]string{
+		"Sent a password reset email to 2 user(s)",
+		"  Email           ID      Reset Sent  Details",
+		"  --------------  ------  ----------  -------",
+		"  two@domain.com  user-2  true               ",
+		"  one@domain.com  user-1  true               ",
+		"",
+	}, "\n"), out.String())
+}
+
+func TestUserResetPasswordRunFuncHandler(t *testing.T) {
+	type call struct {
+		Email    string
+		Password string
+		Args     []interface{}
+	}
+
+	t.Run("should run the password reset function for the user", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		var calls []call
+		realmClient := newResetPasswordRealmClient(t)
+		realmClient.CallResetPasswordFunctionFn = func(clientAppID, email, password string, args []interface{}) error {
+			assert.Equal(t, "eggcorn-abcde", clientAppID)
+			calls = append(calls, call{email, password, args})
+			return nil
+		}
+
+		cmd := &CommandResetPasswordRunFunc{resetPasswordRunFuncInputs{
+			Email:    "two@domain.com",
+			Args:     []string{`{"token":"abc"}`, "plain"},
+			password: "newPassword",
+		}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		args := []interface{}{map[string]interface{}{"token": "abc"}, "plain"}
+		assert.Equal(t, []call{{"two@domain.com", "newPassword", args}}, calls)
+		assert.Equal(t, strings.Join([]string{
+			"Ran the password reset function for 1 user(s)",
+			"  Email           ID      Reset  Details",
+			"  --------------  ------  -----  -------",
+			"  two@domain.com  user-2  true          ",
+			"",
+		}, "\n"), out.String())
+	})
+
+	t.Run("should display the failure to run the password reset function", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		realmClient := newResetPasswordRealmClient(t)
+		realmClient.CallResetPasswordFunctionFn = func(clientAppID, email, password string, args []interface{}) error {
+			return errors.New("something bad happened")
+		}
+
+		cmd := &CommandResetPasswordRunFunc{resetPasswordRunFuncInputs{Email: "one@domain.com", password: "newPassword"}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, strings.Join([]string{
+			"Ran the password reset function for 1 user(s)",
+			"  Email           ID      Reset  Details               ",
+			"  --------------  ------  -----  ----------------------",
+			"  one@domain.com  user-1  false  something bad happened",
+			"",
+		}, "\n"), out.String())
+	})
+
+	t.Run("should return an error when the user is not found", func(t *testing.T) {
+		_, ui := mock.NewUI()
+
+		cmd := &CommandResetPasswordRunFunc{resetPasswordRunFuncInputs{Email: "three@domain.com", password: "newPassword"}}
+		assert.Equal(t, errors.New("unable to find user: three@domain.com"), cmd.Handler(nil, ui, cli.Clients{Realm: newResetPasswordRealmClient(t)}))
+	})
+}
+
+func TestUserResetPasswordRunFuncInputs(t *testing.T) {
+	profile := mock.NewProfile(t)
+
+	t.Run("should read the password from stdin", func(t *testing.T) {
+		ui := mock.NewUIWithOptions(mock.UIOptions{In: strings.NewReader("newPassword\n")}, new(bytes.Buffer))
+
+		inputs := resetPasswordRunFuncInputs{
+			ProjectInputs: cli.ProjectInputs{Project: "project", App: "eggcorn"},
+			Email:         "one@domain.com",
+			PasswordStdin: true,
+		}
+		assert.Nil(t, inputs.Resolve(profile, ui))
+		assert.Equal(t, "newPassword", inputs.password)
+	})
+
+	t.Run("should return an error when reading the password from stdin without an email", func(t *testing.T) {
+		ui := mock.NewUIWithOptions(mock.UIOptions{In: strings.NewReader("newPassword\n")}, new(bytes.Buffer))
+
+		inputs := resetPasswordRunFuncInputs{
+			ProjectInputs: cli.ProjectInputs{Project: "project", App: "eggcorn"},
+			PasswordStdin: true,
+		}
+		assert.Equal(t, errors.New(`must specify the user with "email" when using "password-stdin"`), inputs.Resolve(profile, ui))
+	})
+
+	t.Run("should return an error when the password read from stdin is empty", func(t *testing.T) {
+		ui := mock.NewUIWithOptions(mock.UIOptions{In: strings.NewReader("\n")}, new(bytes.Buffer))
+
+		inputs := resetPasswordRunFuncInputs{
+			ProjectInputs: cli.ProjectInputs{Project: "project", App: "eggcorn"},
+			Email:         "one@domain.com",
+			PasswordStdin: true,
+		}
+		assert.Equal(t, errors.New("the password read from stdin must not be empty"), inputs.Resolve(profile, ui))
+	})
+}
+
+func TestUserResetPasswordRunFuncArgs(t *testing.T) {
+	t.Run("should set the email from the arg", func(t *testing.T) {
+		cmd := &CommandResetPasswordRunFunc{}
+		assert.Nil(t, cmd.Args([]string{"one@domain.com"}))
+		assert.Equal(t, "one@domain.com", cmd.inputs.Email)
+	})
+
+	t.Run("should return an error with more than one arg", func(t *testing.T) {
+		cmd := &CommandResetPasswordRunFunc{}
+		assert.Equal(t, errors.New("accepts at most 1 email argument, received 2"), cmd.Args([]string{"one@domain.com", "two@domain.com"}))
+	})
+
+	t.Run("should return an error when the arg differs from the email flag", func(t *testing.T) {
+		cmd := &CommandResetPasswordRunFunc{resetPasswordRunFuncInputs{Email: "one@domain.com"}}
+		assert.Equal(t, errors.New(`cannot specify an email argument along with a different "--email"`), cmd.Args([]string{"two@domain.com"}))
+	})
+}
+
+func TestParseResetPasswordArgs(t *testing.T) {
+	assert.Equal(t,
+		[]interface{}{float64(1), "two", []interface{}{true}, "not json"},
+		parseResetPasswordArgs([]string{"1", `"two"`, "[true]", "not json"}),
+	)
+}
@@ -0,0 +1,36 @@
+package user
+
+import (
+	"sync"
+)
+
+// runWorkers runs the job for each index below n with up to the concurrency in progress at once,
+// reporting the number of jobs done as they complete
+func runWorkers(n, concurrency int, job func(i int), progress func(done int)) {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var done int
+
+	jobCh := make(chan int)
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobCh {
+				job(i)
+
+				mu.Lock()
+				done++
+				progress(done)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		jobCh <- i
+	}
+	close(jobCh)
+
+	wg.Wait()
+}
@@ -0,0 +1,441 @@
+// Package jsruntime runs the functions of a local Realm app in an embedded JavaScript engine
+package jsruntime
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/local"
+
+	"github.com/dop251/goja"
+)
+
+const (
+	// DefaultTimeout is the max time a function can run for, which matches the Realm server
+	DefaultTimeout = 120 * time.Second
+
+	systemUserID = "system"
+
+	// the source is wrapped on its first line so reported line numbers match the source file
+	sourcePrefix = "(function (module, require) { const __initialExports = module.exports; var exports = __initialExports; "
+	sourceSuffix = "\n;return module.exports !== __initialExports ? module.exports : exports; })"
+)
+
+// User is the user a function is run as
+type User struct {
+	ID         string
+	Data       map[string]interface{}
+	CustomData map[string]interface{}
+}
+
+// Options are the options used to run the functions of a local Realm app
+type Options struct {
+	// User is the user the functions are run as, functions are run as the system user if this is empty
+	User User
+
+	// Timeout is the max time a function can run for, DefaultTimeout is used if this is zero
+	Timeout time.Duration
+}
+
+// ExecutionError is the error thrown by a function run locally
+type ExecutionError struct {
+	Message string
+	Stack   string
+}
+
+func (err ExecutionError) Error() string { return err.Message }
+
+// Runtime runs the functions of a local Realm app
+type Runtime struct {
+	opts        Options
+	environment string
+	functions   map[string]local.AppFunction
+	values      map[string]local.AppValue
+
+	mu       sync.Mutex
+	programs map[string]*goja.Program
+}
+
+// New creates a runtime for the functions of the local Realm app
+func New(app local.App, opts Options) *Runtime {
+	if opts.Timeout == 0 {
+		opts.Timeout = DefaultTimeout
+	}
+
+	functions := map[string]local.AppFunction{}
+	for _, fn := range local.AppFunctions(app.AppData) {
+		functions[fn.Name] = fn
+	}
+
+	values := map[string]local.AppValue{}
+	for _, value := range local.AppValues(app.AppData) {
+		values[value.Name] = value
+	}
+
+	return &Runtime{
+		opts:        opts,
+		environment: string(app.Environment()),
+		functions:   functions,
+		values:      values,
+		programs:    map[string]*goja.Program{},
+	}
+}
+
+// HasFunction returns true if the local Realm app has a function of the provided name
+func (r *Runtime) HasFunction(name string) bool {
+	_, ok := r.functions[name]
+	return ok
+}
+
+// FunctionNames returns the names of the local Realm app functions
+func (r *Runtime) FunctionNames() []string {
+	names := make([]string, 0, len(r.functions))
+	for name := range r.functions {
+		names = append(names, name)
+	}
+	return names
+}
+
+// Execute runs the named function with the provided args, the logs captured
+// before any error is thrown are returned along with the error
+func (r *Runtime) Execute(name string, args []interface{}) (realm.ExecutionResults, error) {
+	if !r.HasFunction(name) {
+		return realm.ExecutionResults{}, fmt.Errorf("failed to find function '%s'", name)
+	}
+
+	e := execution{runtime: r, vm: goja.New()}
+	if err := e.setup(); err != nil {
+		return realm.ExecutionResults{}, err
+	}
+
+	timer := time.AfterFunc(r.opts.Timeout, func() {
+		e.vm.Interrupt(fmt.Sprintf("function '%s' timed out after %s", name, r.opts.Timeout))
+	})
+	defer timer.Stop()
+
+	start := time.Now()
+	result, err := e.run(name, args)
+
+	results := realm.ExecutionResults{Logs: e.logs, ErrorLogs: e.errorLogs}
+	results.Stats.ExecutionTime = time.Since(start).String()
+
+	if err != nil {
+		return results, e.toError(err)
+	}
+
+	results.Result = result
+	return results, nil
+}
+
+func (r *Runtime) program(name string) (*goja.Program, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if program, ok := r.programs[name]; ok {
+		return program, nil
+	}
+
+	program, err := goja.Compile(name+".js", sourcePrefix+r.functions[name].Source+sourceSuffix, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile function '%s': %s", name, err)
+	}
+
+	r.programs[name] = program
+	return program, nil
+}
+
+// execution is a single run of a function and the functions it executes
+type execution struct {
+	runtime   *Runtime
+	vm        *goja.Runtime
+	exports   map[string]goja.Callable
+	logs      []string
+	errorLogs []string
+}
+
+func (e *execution) setup() error {
+	e.exports = map[string]goja.Callable{}
+
+	console := e.vm.NewObject()
+	for name, errorLog := range map[string]bool{"log": false, "info": false, "debug": false, "warn": true, "error": true} {
+		errorLog := errorLog
+		if err := console.Set(name, func(call goja.FunctionCall) goja.Value {
+			msg := e.format(call.Arguments)
+			if errorLog {
+				e.errorLogs = append(e.errorLogs, msg)
+			} else {
+				e.logs = append(e.logs, msg)
+			}
+			return goja.Undefined()
+		}); err != nil {
+			return err
+		}
+	}
+	if err := e.vm.Set("console", console); err != nil {
+		return err
+	}
+
+	context, err := e.newContext()
+	if err != nil {
+		return err
+	}
+	return e.vm.Set("context", context)
+}
+
+func (e *execution) newContext() (*goja.Object, error) {
+	vm := e.vm
+	context := vm.NewObject()
+
+	values := vm.NewObject()
+	if err := values.Set("get", func(name string) goja.Value {
+		value, ok := e.runtime.values[name]
+		if !ok {
+			return goja.Undefined()
+		}
+		if value.FromSecret {
+			panic(vm.NewGoError(fmt.Errorf("value '%s' is linked to a secret which is not available when running functions locally", name)))
+		}
+		return vm.ToValue(value.Value)
+	}); err != nil {
+		return nil, err
+	}
+
+	functions := vm.NewObject()
+	if err := functions.Set("execute", func(call goja.FunctionCall) goja.Value {
+		name := call.Argument(0).String()
+		if !e.runtime.HasFunction(name) {
+			panic(vm.NewGoError(fmt.Errorf("function '%s' not found", name)))
+		}
+
+		fn, err := e.function(name)
+		if err != nil {
+			panic(vm.NewGoError(err))
+		}
+
+		var args []goja.Value
+		if len(call.Arguments) > 1 {
+			args = call.Arguments[1:]
+		}
+
+		result, err := fn(goja.Undefined(), args...)
+		if err != nil {
+			panic(err)
+		}
+		return result
+	}); err != nil {
+		return nil, err
+	}
+
+	services := vm.NewObject()
+	if err := services.Set("get", func(name string) goja.Value {
+		panic(vm.NewGoError(fmt.Errorf("service '%s' is not supported when running functions locally", name)))
+	}); err != nil {
+		return nil, err
+	}
+
+	http := vm.NewObject()
+	for _, method := range []string{"get", "post", "put", "patch", "delete", "head"} {
+		method := method
+		if err := http.Set(method, func(goja.FunctionCall) goja.Value {
+			panic(vm.NewGoError(fmt.Errorf("context.http.%s is not supported when running functions locally", method)))
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	environment := vm.NewObject()
+	if err := environment.Set("tag", e.runtime.environment); err != nil {
+		return nil, err
+	}
+
+	for name, value := range map[string]interface{}{
+		"values":      values,
+		"functions":   functions,
+		"services":    services,
+		"http":        http,
+		"environment": environment,
+		"user":        e.user(),
+		"runningAsSystem": func() bool {
+			return e.runtime.opts.User.ID == ""
+		},
+	} {
+		if err := context.Set(name, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return context, nil
+}
+
+func (e *execution) user() map[string]interface{} {
+	u := e.runtime.opts.User
+
+	user := map[string]interface{}{
+		"id":          u.ID,
+		"type":        "normal",
+		"data":        u.Data,
+		"custom_data": u.CustomData,
+		"identities":  []interface{}{},
+	}
+	if u.ID == "" {
+		user["id"] = systemUserID
+		user["type"] = systemUserID
+	}
+	if u.Data == nil {
+		user["data"] = map[string]interface{}{}
+	}
+	if u.CustomData == nil {
+		user["custom_data"] = map[string]interface{}{}
+	}
+	return user
+}
+
+// function returns the function exported by the named source
+func (e *execution) function(name string) (goja.Callable, error) {
+	if fn, ok := e.exports[name]; ok {
+		return fn, nil
+	}
+
+	program, err := e.runtime.program(name)
+	if err != nil {
+		return nil, err
+	}
+
+	wrapper, err := e.vm.RunProgram(program)
+	if err != nil {
+		return nil, err
+	}
+
+	load, ok := goja.AssertFunction(wrapper)
+	if !ok {
+		return nil, fmt.Errorf("failed to load function '%s'", name)
+	}
+
+	module := e.vm.NewObject()
+	if err := module.Set("exports", e.vm.NewObject()); err != nil {
+		return nil, err
+	}
+
+	require := func(id string) goja.Value {
+		panic(e.vm.NewGoError(fmt.Errorf("require('%s') is not supported when running functions locally", id)))
+	}
+
+	exported, err := load(goja.Undefined(), module, e.vm.ToValue(require))
+	if err != nil {
+		return nil, err
+	}
+
+	fn, ok := goja.AssertFunction(exported)
+	if !ok {
+		return nil, fmt.Errorf("function '%s' must export a function", name)
+	}
+
+	e.exports[name] = fn
+	return fn, nil
+}
+
+func (e *execution) run(name string, args []interface{}) (interface{}, error) {
+	fn, err := e.function(name)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]goja.Value, 0, len(args))
+	for _, arg := range args {
+		values = append(values, e.vm.ToValue(arg))
+	}
+
+	result, err := fn(goja.Undefined(), values...)
+	if err != nil {
+		return nil, err
+	}
+
+	if promise, ok := result.Export().(*goja.Promise); ok {
+		switch promise.State() {
+		case goja.PromiseStateFulfilled:
+			result = promise.Result()
+		case goja.PromiseStateRejected:
+			return nil, rejection{promise.Result()}
+		default:
+			return nil, fmt.Errorf("function '%s' returned a promise which never settled", name)
+		}
+	}
+
+	if result == nil || goja.IsUndefined(result) {
+		return nil, nil
+	}
+	return result.Export(), nil
+}
+
+type rejection struct{ value goja.Value }
+
+func (r rejection) Error() string { return r.value.String() }
+
+// toError converts the error returned by the javascript engine into an ExecutionError
+func (e *execution) toError(err error) error {
+	var exception *goja.Exception
+	var interrupted *goja.InterruptedError
+	var rejected rejection
+
+	switch {
+	case errors.As(err, &exception):
+		return ExecutionError{Message: errorMessage(exception.Value()), Stack: exception.String()}
+	case errors.As(err, &interrupted):
+		return ExecutionError{Message: fmt.Sprintf("%v", interrupted.Value()), Stack: interrupted.String()}
+	case errors.As(err, &rejected):
+		var stack string
+		if obj, ok := rejected.value.(*goja.Object); ok {
+			if s := obj.Get("stack"); s != nil && !goja.IsUndefined(s) {
+				stack = s.String()
+			}
+		}
+		return ExecutionError{Message: errorMessage(rejected.value), Stack: stack}
+	}
+	return err
+}
+
+func errorMessage(value goja.Value) string {
+	if value == nil {
+		return "undefined"
+	}
+	if obj, ok := value.(*goja.Object); ok {
+		if msg := obj.Get("message"); msg != nil && !goja.IsUndefined(msg) {
+			return msg.String()
+		}
+	}
+	return value.String()
+}
+
+// format formats the console args the way the Realm server logs them
+func (e *execution) format(args []goja.Value) string {
+	parts := make([]string, 0, len(args))
+	for _, arg := range args {
+		parts = append(parts, e.formatValue(arg))
+	}
+	return strings.Join(parts, " ")
+}
+
+func (e *execution) formatValue(value goja.Value) string {
+	obj, ok := value.(*goja.Object)
+	if !ok {
+		return value.String()
+	}
+
+	if _, isFunction := goja.AssertFunction(obj); isFunction {
+		return "[Function]"
+	}
+	if obj.ClassName() == "Error" {
+		return obj.String()
+	}
+
+	data, err := json.Marshal(obj.Export())
+	if err != nil {
+		return obj.String()
+	}
+	return string(data)
+}
@@ -0,0 +1,188 @@
+package jsruntime
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/local"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestRuntimeExecute(t *testing.T) {
+	setup := func(t *testing.T, functions map[string]string) (local.App, func()) {
+		t.Helper()
+
+		tmpDir, cleanupTmpDir, err := u.NewTempDir("")
+		assert.Nil(t, err)
+
+		files := map[string]string{
+			local.FileRealmConfig.String():                            `{"config_version":20210101,"name":"runtime","environment":"development"}`,
+			filepath.Join(local.NameValues, "greeting.json"):          `{"name":"greeting","value":"hello","from_secret":false}`,
+			filepath.Join(local.NameValues, "host.json"):              `{"name":"host","value":"https://example.com","from_secret":false}`,
+			filepath.Join(local.NameValues, "apiKey.json"):            `{"name":"apiKey","value":"apiKeySecret","from_secret":true}`,
+			filepath.Join(local.NameEnvironments, "development.json"): `{"values":{"host":"https://dev.example.com"}}`,
+		}
+
+		configs := "["
+		for name, src := range functions {
+			if configs != "[" {
+				configs += ","
+			}
+			configs += `{"name":"` + name + `","private":false}`
+			files[filepath.Join(local.NameFunctions, name+".js")] = src
+		}
+		files[filepath.Join(local.NameFunctions, local.FileConfig.String())] = configs + "]"
+
+		for path, contents := range files {
+			assert.Nil(t, os.MkdirAll(filepath.Dir(filepath.Join(tmpDir, path)), os.ModePerm))
+			assert.Nil(t, ioutil.WriteFile(filepath.Join(tmpDir, path), []byte(contents), 0666))
+		}
+
+		app, err := local.LoadApp(tmpDir)
+		assert.Nil(t, err)
+
+		return app, cleanupTmpDir
+	}
+
+	t.Run("should run the function with the provided args and capture its logs", func(t *testing.T) {
+		app, cleanup := setup(t, map[string]string{
+			"sum": `exports = function(a, b) {
+  console.log("adding", a, "and", b, {a: a});
+  console.error("this is fine");
+  return a + b;
+};`,
+		})
+		defer cleanup()
+
+		results, err := New(app, Options{}).Execute("sum", []interface{}{1, 2})
+		assert.Nil(t, err)
+
+		assert.Equal(t, int64(3), results.Result)
+		assert.Equal(t, []string{`adding 1 and 2 {"a":1}`}, results.Logs)
+		assert.Equal(t, []string{"this is fine"}, results.ErrorLogs)
+		assert.True(t, results.Stats.ExecutionTime != "", "expected execution time to be recorded")
+	})
+
+	t.Run("should provide the local values, functions, environment and user in the context", func(t *testing.T) {
+		app, cleanup := setup(t, map[string]string{
+			"main": `exports = async function() {
+  const name = await context.functions.execute("name", "eggcorn");
+  return {
+    greeting: context.values.get("greeting") + " " + name,
+    host: context.values.get("host"),
+    missing: context.values.get("missing") === undefined,
+    environment: context.environment.tag,
+    user: context.user.id,
+    email: context.user.data.email,
+    system: context.runningAsSystem(),
+  };
+};`,
+			"name": `module.exports = (name) => name.toUpperCase();`,
+		})
+		defer cleanup()
+
+		results, err := New(app, Options{User: User{ID: "user1", Data: map[string]interface{}{"email": "user1@example.com"}}}).Execute("main", nil)
+		assert.Nil(t, err)
+
+		assert.Equal(t, map[string]interface{}{
+			"greeting":    "hello EGGCORN",
+			"host":        "https://dev.example.com",
+			"missing":     true,
+			"environment": "development",
+			"user":        "user1",
+			"email":       "user1@example.com",
+			"system":      false,
+		}, results.Result)
+	})
+
+	t.Run("should run as the system user when no user is provided", func(t *testing.T) {
+		app, cleanup := setup(t, map[string]string{
+			"whoami": `exports = () => [context.user.id, context.user.type, context.runningAsSystem()];`,
+		})
+		defer cleanup()
+
+		results, err := New(app, Options{}).Execute("whoami", nil)
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{"system", "system", true}, results.Result)
+	})
+
+	t.Run("should return an error for the unsupported parts of the context", func(t *testing.T) {
+		for _, tc := range []struct {
+			description string
+			src         string
+			expectedErr string
+		}{
+			{
+				description: "services",
+				src:         `exports = () => context.services.get("mongodb-atlas").db("test");`,
+				expectedErr: "service 'mongodb-atlas' is not supported when running functions locally",
+			},
+			{
+				description: "http",
+				src:         `exports = () => context.http.get({url: "https://example.com"});`,
+				expectedErr: "context.http.get is not supported when running functions locally",
+			},
+			{
+				description: "secret values",
+				src:         `exports = () => context.values.get("apiKey");`,
+				expectedErr: "value 'apiKey' is linked to a secret which is not available when running functions locally",
+			},
+			{
+				description: "require",
+				src:         `const _ = require("lodash"); exports = () => _.noop();`,
+				expectedErr: "require('lodash') is not supported when running functions locally",
+			},
+			{
+				description: "missing functions",
+				src:         `exports = () => context.functions.execute("missing");`,
+				expectedErr: "function 'missing' not found",
+			},
+		} {
+			t.Run(tc.description, func(t *testing.T) {
+				app, cleanup := setup(t, map[string]string{"fn": tc.src})
+				defer cleanup()
+
+				_, err := New(app, Options{}).Execute("fn", nil)
+				assert.Equal(t, tc.expectedErr, err.Error())
+			})
+		}
+	})
+
+	t.Run("should return the thrown error along with the logs captured before it", func(t *testing.T) {
+		app, cleanup := setup(t, map[string]string{
+			"fail": `exports = async function() {
+  console.log("about to fail");
+  throw new Error("something bad happened");
+};`,
+		})
+		defer cleanup()
+
+		results, err := New(app, Options{}).Execute("fail", nil)
+		assert.Equal(t, "something bad happened", err.Error())
+		assert.Equal(t, []string{"about to fail"}, results.Logs)
+
+		execErr, ok := err.(ExecutionError)
+		assert.True(t, ok, "expected an execution error")
+		assert.True(t, execErr.Stack != "", "expected the stack to be recorded")
+	})
+
+	t.Run("should stop a function which runs longer than the timeout", func(t *testing.T) {
+		app, cleanup := setup(t, map[string]string{"loop": `exports = () => { while (true) {} };`})
+		defer cleanup()
+
+		_, err := New(app, Options{Timeout: 50 * time.Millisecond}).Execute("loop", nil)
+		assert.Equal(t, "function 'loop' timed out after 50ms", err.Error())
+	})
+
+	t.Run("should return an error when the function does not exist", func(t *testing.T) {
+		app, cleanup := setup(t, nil)
+		defer cleanup()
+
+		_, err := New(app, Options{}).Execute("missing", nil)
+		assert.Equal(t, "failed to find function 'missing'", err.Error())
+	})
+}
@@ -0,0 +1,617 @@
+package local
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// set of app node kinds
+const (
+	AppNodeFunction       AppNodeKind = "function"
+	AppNodeValue          AppNodeKind = "value"
+	AppNodeSecret         AppNodeKind = "secret"
+	AppNodeService        AppNodeKind = "service"
+	AppNodeTrigger        AppNodeKind = "trigger"
+	AppNodeEndpoint       AppNodeKind = "endpoint"
+	AppNodeWebhook        AppNodeKind = "webhook"
+	AppNodeCustomResolver AppNodeKind = "custom_resolver"
+	AppNodeAuthProvider   AppNodeKind = "auth_provider"
+	AppNodeCustomUserData AppNodeKind = "custom_user_data"
+	AppNodeRule           AppNodeKind = "rule"
+	AppNodeLogForwarder   AppNodeKind = "log_forwarder"
+	AppNodeClient         AppNodeKind = "client"
+)
+
+var (
+	jsFunctionCallRegex = regexp.MustCompile(`context\.functions\.execute\(\s*["'` + "`" + `]([^"'` + "`" + `]+)["'` + "`" + `]`)
+	jsValueGetRegex     = regexp.MustCompile(`context\.values\.get\(\s*["'` + "`" + `]([^"'` + "`" + `]+)["'` + "`" + `]`)
+	jsServiceGetRegex   = regexp.MustCompile(`context\.services\.get\(\s*["'` + "`" + `]([^"'` + "`" + `]+)["'` + "`" + `]`)
+	valueExpansionRegex = regexp.MustCompile(`%%values\.([A-Za-z0-9_\-]+)`)
+)
+
+// AppNodeKind is the kind of a node in the app dependency graph
+type AppNodeKind string
+
+// AppNode is a node in the app dependency graph
+type AppNode struct {
+	Kind AppNodeKind
+	Name string
+}
+
+func (n AppNode) String() string { return string(n.Kind) + ":" + n.Name }
+
+// AnalyzeOptions are the options used to analyze a local Realm app
+type AnalyzeOptions struct {
+	// Secrets are the names of the app secrets, since secrets are only stored remotely
+	// they are not reported as unused unless provided
+	Secrets []string
+
+	// IncludePublic reports public functions which are not referenced within the app
+	// as unused, otherwise they are assumed to be called by clients
+	IncludePublic bool
+}
+
+// AppAnalysis is the dependency analysis of a local Realm app
+type AppAnalysis struct {
+	UnusedFunctions []string   `json:"unused_functions"`
+	UnusedValues    []string   `json:"unused_values"`
+	UnusedSecrets   []string   `json:"unused_secrets"`
+	UnusedServices  []string   `json:"unused_services"`
+	CircularCalls   [][]string `json:"circular_calls"`
+
+	name   string
+	graph  appGraph
+	unused map[AppNode]struct{}
+}
+
+// Analyze builds the dependency graph of the local Realm app and reports
+// the functions, values, secrets and services which are not in use along with
+// the function calls which form a cycle
+//
+// Everything reachable from a trigger, endpoint, incoming webhook, custom resolver,
+// auth provider, custom user data config, rule or log forwarder is considered in use
+func Analyze(app App, opts AnalyzeOptions) AppAnalysis {
+	g := newAppGraph()
+
+	components := newAppComponents(app.AppData)
+
+	for _, name := range opts.Secrets {
+		g.define(AppNode{AppNodeSecret, name})
+	}
+
+	for _, value := range components.values {
+		name, _ := value["name"].(string)
+		node := AppNode{AppNodeValue, name}
+		g.define(node)
+
+		if fromSecret, _ := value["from_secret"].(bool); fromSecret {
+			if secret, ok := value["value"].(string); ok {
+				g.link(node, AppNode{AppNodeSecret, secret})
+			}
+		}
+	}
+
+	for _, fn := range AppFunctions(app.AppData) {
+		node := AppNode{AppNodeFunction, fn.Name}
+		g.define(node)
+		g.scanSource(node, fn.Source)
+
+		if !fn.Private && !opts.IncludePublic {
+			g.link(AppNode{AppNodeClient, "clients"}, node)
+		}
+	}
+
+	for _, svc := range components.services {
+		node := AppNode{AppNodeService, svc.name}
+		g.define(node)
+		g.scan(node, svc.config)
+
+		for _, webhook := range svc.webhooks {
+			webhookConfig, _ := webhook[NameConfig].(map[string]interface{})
+			webhookName, _ := webhookConfig["name"].(string)
+
+			webhookNode := AppNode{AppNodeWebhook, svc.name + "/" + webhookName}
+			g.link(webhookNode, node)
+			g.scan(webhookNode, webhookConfig)
+			if src, ok := webhook[NameSource].(string); ok {
+				g.scanSource(webhookNode, src)
+			}
+		}
+
+		if len(svc.rules) > 0 {
+			ruleNode := AppNode{AppNodeRule, svc.name}
+			g.link(ruleNode, node)
+			for _, rule := range svc.rules {
+				g.scan(ruleNode, rule)
+			}
+		}
+	}
+
+	for _, entry := range []struct {
+		kind    AppNodeKind
+		nameKey string
+		configs []map[string]interface{}
+	}{
+		{AppNodeTrigger, "name", components.triggers},
+		{AppNodeEndpoint, "route", components.endpoints},
+		{AppNodeCustomResolver, "field_name", components.customResolvers},
+		{AppNodeAuthProvider, "name", components.authProviders},
+		{AppNodeLogForwarder, "name", components.logForwarders},
+	} {
+		for i, config := range entry.configs {
+			name, _ := config[entry.nameKey].(string)
+			if entry.kind == AppNodeCustomResolver {
+				onType, _ := config["on_type"].(string)
+				name = onType + "." + name
+			}
+			if name == "" {
+				name = fmt.Sprintf("%d", i)
+			}
+			g.scan(AppNode{entry.kind, name}, config)
+		}
+	}
+
+	if len(components.customUserData) > 0 {
+		if enabled, _ := components.customUserData["enabled"].(bool); enabled {
+			g.scan(AppNode{AppNodeCustomUserData, "custom_user_data"}, components.customUserData)
+		}
+	}
+
+	analysis := AppAnalysis{
+		name:   app.Name(),
+		graph:  g,
+		unused: g.unreachable(),
+
+		UnusedFunctions: []string{},
+		UnusedValues:    []string{},
+		UnusedSecrets:   []string{},
+		UnusedServices:  []string{},
+		CircularCalls:   g.cycles(AppNodeFunction),
+	}
+
+	for node := range analysis.unused {
+		switch node.Kind {
+		case AppNodeFunction:
+			analysis.UnusedFunctions = append(analysis.UnusedFunctions, node.Name)
+		case AppNodeValue:
+			analysis.UnusedValues = append(analysis.UnusedValues, node.Name)
+		case AppNodeSecret:
+			analysis.UnusedSecrets = append(analysis.UnusedSecrets, node.Name)
+		case AppNodeService:
+			analysis.UnusedServices = append(analysis.UnusedServices, node.Name)
+		}
+	}
+
+	sort.Strings(analysis.UnusedFunctions)
+	sort.Strings(analysis.UnusedValues)
+	sort.Strings(analysis.UnusedSecrets)
+	sort.Strings(analysis.UnusedServices)
+
+	return analysis
+}
+
+// DOT returns the app dependency graph in the Graphviz DOT language,
+// where the components which are not in use are drawn with a dashed outline
+func (a AppAnalysis) DOT() string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("digraph %q {\n", a.name))
+	sb.WriteString("  rankdir=LR;\n")
+
+	for _, node := range a.graph.sortedNodes() {
+		attrs := fmt.Sprintf("label=%q, shape=%s", string(node.Kind)+"\n"+node.Name, dotShape(node.Kind))
+		if _, ok := a.unused[node]; ok {
+			attrs += ", style=dashed"
+		}
+		sb.WriteString(fmt.Sprintf("  %q [%s];\n", node.String(), attrs))
+	}
+
+	for _, from := range a.graph.sortedNodes() {
+		for _, to := range a.graph.sortedEdges(from) {
+			sb.WriteString(fmt.Sprintf("  %q -> %q;\n", from.String(), to.String()))
+		}
+	}
+
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+func dotShape(kind AppNodeKind) string {
+	switch kind {
+	case AppNodeFunction:
+		return "box"
+	case AppNodeValue, AppNodeSecret:
+		return "note"
+	case AppNodeService:
+		return "cylinder"
+	}
+	return "ellipse"
+}
+
+type appGraph struct {
+	nodes   map[AppNode]struct{}
+	defined map[AppNode]struct{}
+	edges   map[AppNode]map[AppNode]struct{}
+}
+
+func newAppGraph() appGraph {
+	return appGraph{
+		nodes:   map[AppNode]struct{}{},
+		defined: map[AppNode]struct{}{},
+		edges:   map[AppNode]map[AppNode]struct{}{},
+	}
+}
+
+// define adds a node for a component which is declared within the app
+func (g appGraph) define(node AppNode) {
+	g.nodes[node] = struct{}{}
+	g.defined[node] = struct{}{}
+}
+
+func (g appGraph) link(from, to AppNode) {
+	g.nodes[from] = struct{}{}
+	g.nodes[to] = struct{}{}
+	if g.edges[from] == nil {
+		g.edges[from] = map[AppNode]struct{}{}
+	}
+	g.edges[from][to] = struct{}{}
+}
+
+// scanSource links the node to the functions, values and services referenced in the javascript source
+func (g appGraph) scanSource(node AppNode, src string) {
+	g.nodes[node] = struct{}{}
+	for _, ref := range []struct {
+		kind  AppNodeKind
+		regex *regexp.Regexp
+	}{
+		{AppNodeFunction, jsFunctionCallRegex},
+		{AppNodeValue, jsValueGetRegex},
+		{AppNodeService, jsServiceGetRegex},
+	} {
+		for _, match := range ref.regex.FindAllStringSubmatch(src, -1) {
+			g.link(node, AppNode{ref.kind, match[1]})
+		}
+	}
+}
+
+// scan links the node to the functions, values, secrets and services referenced in the config
+func (g appGraph) scan(node AppNode, config interface{}) {
+	g.nodes[node] = struct{}{}
+
+	switch c := config.(type) {
+	case []interface{}:
+		for _, v := range c {
+			g.scan(node, v)
+		}
+	case []map[string]interface{}:
+		for _, v := range c {
+			g.scan(node, v)
+		}
+	case string:
+		for _, match := range valueExpansionRegex.FindAllStringSubmatch(c, -1) {
+			g.link(node, AppNode{AppNodeValue, match[1]})
+		}
+	case map[string]interface{}:
+		// log forwarders and rule expressions reference functions by name
+		if t, _ := c["type"].(string); t == "function" {
+			if name, ok := c["name"].(string); ok {
+				g.link(node, AppNode{AppNodeFunction, name})
+			}
+		}
+		if fn, ok := c["%function"].(map[string]interface{}); ok {
+			if name, ok := fn["name"].(string); ok {
+				g.link(node, AppNode{AppNodeFunction, name})
+			}
+		}
+
+		for k, v := range c {
+			name, isString := v.(string)
+			switch {
+			case isString && name != "" && isFunctionNameKey(k):
+				g.link(node, AppNode{AppNodeFunction, name})
+			case isString && name != "" && isServiceNameKey(k):
+				g.link(node, AppNode{AppNodeService, name})
+			case k == "secret_config":
+				secrets, _ := v.(map[string]interface{})
+				for _, secret := range secrets {
+					if secretName, ok := secret.(string); ok && secretName != "" {
+						g.link(node, AppNode{AppNodeSecret, secretName})
+					}
+				}
+			default:
+				g.scan(node, v)
+			}
+		}
+	}
+}
+
+func isFunctionNameKey(key string) bool {
+	return key == "function_name" || strings.HasSuffix(key, "_function_name") || strings.HasSuffix(key, "FunctionName")
+}
+
+func isServiceNameKey(key string) bool {
+	return key == "service_name" || strings.HasSuffix(key, "_service_name")
+}
+
+// unreachable returns the defined functions, values, secrets and services
+// which cannot be reached from any of the app entry points
+func (g appGraph) unreachable() map[AppNode]struct{} {
+	reached := map[AppNode]struct{}{}
+
+	var queue []AppNode
+	for node := range g.nodes {
+		switch node.Kind {
+		case AppNodeFunction, AppNodeValue, AppNodeSecret, AppNodeService:
+			continue
+		}
+		reached[node] = struct{}{}
+		queue = append(queue, node)
+	}
+
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		for next := range g.edges[node] {
+			if _, ok := reached[next]; ok {
+				continue
+			}
+			reached[next] = struct{}{}
+			queue = append(queue, next)
+		}
+	}
+
+	unused := map[AppNode]struct{}{}
+	for node := range g.defined {
+		if _, ok := reached[node]; !ok {
+			unused[node] = struct{}{}
+		}
+	}
+	return unused
+}
+
+// cycles returns the cycles formed between nodes of the provided kind,
+// each cycle starts and ends with its alphabetically first node
+func (g appGraph) cycles(kind AppNodeKind) [][]string {
+	// tarjan's strongly connected components algorithm
+	var (
+		index   int
+		stack   []AppNode
+		onStack = map[AppNode]bool{}
+		indices = map[AppNode]int{}
+		lowLink = map[AppNode]int{}
+		comps   [][]AppNode
+	)
+
+	neighbors := func(node AppNode) []AppNode {
+		var out []AppNode
+		for _, next := range g.sortedEdges(node) {
+			if next.Kind == kind {
+				out = append(out, next)
+			}
+		}
+		return out
+	}
+
+	var connect func(node AppNode)
+	connect = func(node AppNode) {
+		indices[node] = index
+		lowLink[node] = index
+		index++
+		stack = append(stack, node)
+		onStack[node] = true
+
+		for _, next := range neighbors(node) {
+			if _, ok := indices[next]; !ok {
+				connect(next)
+				if lowLink[next] < lowLink[node] {
+					lowLink[node] = lowLink[next]
+				}
+			} else if onStack[next] && indices[next] < lowLink[node] {
+				lowLink[node] = indices[next]
+			}
+		}
+
+		if lowLink[node] != indices[node] {
+			return
+		}
+
+		var comp []AppNode
+		for {
+			last := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[last] = false
+			comp = append(comp, last)
+			if last == node {
+				break
+			}
+		}
+		comps = append(comps, comp)
+	}
+
+	for _, node := range g.sortedNodes() {
+		if node.Kind != kind {
+			continue
+		}
+		if _, ok := indices[node]; !ok {
+			connect(node)
+		}
+	}
+
+	cycles := [][]string{}
+	for _, comp := range comps {
+		members := make(map[AppNode]bool, len(comp))
+		for _, node := range comp {
+			members[node] = true
+		}
+
+		sort.Slice(comp, func(i, j int) bool { return comp[i].Name < comp[j].Name })
+		start := comp[0]
+
+		if len(comp) == 1 {
+			if _, ok := g.edges[start][start]; ok {
+				cycles = append(cycles, []string{start.Name, start.Name})
+			}
+			continue
+		}
+
+		cycles = append(cycles, g.cyclePath(start, members, neighbors))
+	}
+
+	sort.Slice(cycles, func(i, j int) bool { return strings.Join(cycles[i], " ") < strings.Join(cycles[j], " ") })
+	return cycles
+}
+
+// cyclePath returns the names along a path which leaves and returns to the start node
+// without leaving the strongly connected component of its members
+func (g appGraph) cyclePath(start AppNode, members map[AppNode]bool, neighbors func(AppNode) []AppNode) []string {
+	prev := map[AppNode]AppNode{}
+	queue := []AppNode{start}
+	visited := map[AppNode]bool{start: true}
+
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		for _, next := range neighbors(node) {
+			if !members[next] {
+				continue
+			}
+			if next == start {
+				path := []string{start.Name}
+				for n := node; n != start; n = prev[n] {
+					path = append(path, n.Name)
+				}
+				path = append(path, start.Name)
+
+				// the path was collected from its end
+				for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+					path[i], path[j] = path[j], path[i]
+				}
+				return path
+			}
+			if visited[next] {
+				continue
+			}
+			visited[next] = true
+			prev[next] = node
+			queue = append(queue, next)
+		}
+	}
+	return nil
+}
+
+func (g appGraph) sortedNodes() []AppNode {
+	nodes := make([]AppNode, 0, len(g.nodes))
+	for node := range g.nodes {
+		nodes = append(nodes, node)
+	}
+	sortAppNodes(nodes)
+	return nodes
+}
+
+func (g appGraph) sortedEdges(from AppNode) []AppNode {
+	nodes := make([]AppNode, 0, len(g.edges[from]))
+	for node := range g.edges[from] {
+		nodes = append(nodes, node)
+	}
+	sortAppNodes(nodes)
+	return nodes
+}
+
+func sortAppNodes(nodes []AppNode) {
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Kind != nodes[j].Kind {
+			return nodes[i].Kind < nodes[j].Kind
+		}
+		return nodes[i].Name < nodes[j].Name
+	})
+}
+
+type appComponents struct {
+	values          []map[string]interface{}
+	services        []appService
+	triggers        []map[string]interface{}
+	endpoints       []map[string]interface{}
+	customResolvers []map[string]interface{}
+	authProviders   []map[string]interface{}
+	customUserData  map[string]interface{}
+	logForwarders   []map[string]interface{}
+}
+
+type appService struct {
+	name     string
+	config   map[string]interface{}
+	webhooks []map[string]interface{}
+	rules    []map[string]interface{}
+}
+
+func newAppComponents(appData AppData) appComponents {
+	var ad AppStructureV1
+	switch data := appData.(type) {
+	case *AppRealmConfigJSON:
+		return newAppComponentsV2(data.AppStructureV2)
+	case *AppConfigJSON:
+		ad = data.AppStructureV1
+	case *AppStitchJSON:
+		ad = data.AppStructureV1
+	default:
+		return appComponents{}
+	}
+
+	c := appComponents{
+		values:          ad.Values,
+		triggers:        ad.Triggers,
+		customResolvers: ad.GraphQL.CustomResolvers,
+		authProviders:   ad.AuthProviders,
+		customUserData:  ad.CustomUserDataConfig,
+		logForwarders:   ad.LogForwarders,
+	}
+
+	for _, svc := range ad.Services {
+		c.services = append(c.services, newAppService(svc.Config, svc.IncomingWebhooks, svc.Rules))
+	}
+
+	return c
+}
+
+func newAppComponentsV2(ad AppStructureV2) appComponents {
+	c := appComponents{
+		values:          ad.Values,
+		triggers:        ad.Triggers,
+		endpoints:       ad.Endpoints.Configs,
+		customResolvers: ad.GraphQL.CustomResolvers,
+		customUserData:  ad.Auth.CustomUserData,
+		logForwarders:   ad.LogForwarders,
+	}
+
+	providerNames := make([]string, 0, len(ad.Auth.Providers))
+	for name := range ad.Auth.Providers {
+		providerNames = append(providerNames, name)
+	}
+	sort.Strings(providerNames)
+
+	for _, name := range providerNames {
+		config, _ := ad.Auth.Providers[name].(map[string]interface{})
+		c.authProviders = append(c.authProviders, config)
+	}
+
+	for _, ds := range ad.DataSources {
+		c.services = append(c.services, newAppService(ds.Config, nil, ds.Rules))
+	}
+	for _, svc := range ad.HTTPServices {
+		c.services = append(c.services, newAppService(svc.Config, svc.IncomingWebhooks, svc.Rules))
+	}
+	for _, svc := range ad.Services {
+		c.services = append(c.services, newAppService(svc.Config, svc.IncomingWebhooks, svc.Rules))
+	}
+
+	return c
+}
+
+func newAppService(config map[string]interface{}, webhooks, rules []map[string]interface{}) appService {
+	name, _ := config["name"].(string)
+	return appService{name, config, webhooks, rules}
+}
@@ -0,0 +1,118 @@
+package local
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestAnalyze(t *testing.T) {
+	setup := func(t *testing.T) (App, func()) {
+		t.Helper()
+
+		tmpDir, cleanupTmpDir, err := u.NewTempDir("")
+		assert.Nil(t, err)
+
+		for path, contents := range map[string]string{
+			FileRealmConfig.String(): `{"config_version":20210101,"name":"analyze"}`,
+			filepath.Join(NameFunctions, FileConfig.String()): `[
+	{"name":"main","private":true},
+	{"name":"helper","private":true},
+	{"name":"dead","private":true},
+	{"name":"deadHelper","private":true},
+	{"name":"public","private":false},
+	{"name":"ping","private":true},
+	{"name":"pong","private":true},
+	{"name":"auth","private":true},
+	{"name":"canRead","private":true},
+	{"name":"recurse","private":true}
+]`,
+			filepath.Join(NameFunctions, "main.js"):       `exports = () => { context.functions.execute("helper"); return context.values.get('apiURL'); };`,
+			filepath.Join(NameFunctions, "helper.js"):     "exports = () => context.services.get(`mongodb-atlas`);",
+			filepath.Join(NameFunctions, "dead.js"):       `exports = () => context.functions.execute("deadHelper", context.values.get("deadValue"));`,
+			filepath.Join(NameFunctions, "deadHelper.js"): `exports = () => {};`,
+			filepath.Join(NameFunctions, "public.js"):     `exports = () => {};`,
+			filepath.Join(NameFunctions, "ping.js"):       `exports = () => context.functions.execute("pong");`,
+			filepath.Join(NameFunctions, "pong.js"):       `exports = () => context.functions.execute("ping");`,
+			filepath.Join(NameFunctions, "auth.js"):       `exports = () => context.functions.execute("ping");`,
+			filepath.Join(NameFunctions, "canRead.js"):    `exports = () => true;`,
+			filepath.Join(NameFunctions, "recurse.js"):    `exports = (n) => n && context.functions.execute("recurse", n-1);`,
+			filepath.Join(NameValues, "apiURL.json"):      `{"name":"apiURL","value":"https://example.com","from_secret":false}`,
+			filepath.Join(NameValues, "apiKey.json"):      `{"name":"apiKey","value":"apiKeySecret","from_secret":true}`,
+			filepath.Join(NameValues, "deadValue.json"):   `{"name":"deadValue","value":"dead","from_secret":false}`,
+			filepath.Join(NameValues, "unused.json"):      `{"name":"unused","value":"unused","from_secret":false}`,
+			filepath.Join(NameTriggers, "t1.json"):        `{"name":"t1","type":"SCHEDULED","function_name":"main","config":{"schedule":"0 0 * * 1"}}`,
+			filepath.Join(NameHTTPEndpoints, FileConfig.String()): `[
+	{"route":"/recurse","http_method":"GET","function_name":"recurse"}
+]`,
+			filepath.Join(NameAuth, FileProviders.String()): `{
+	"custom-function": {"name":"custom-function","type":"custom-function","config":{"authFunctionName":"auth"}},
+	"oauth2-google": {"name":"oauth2-google","type":"oauth2-google","config":{"clientId":"%%values.apiKey"},"secret_config":{"clientSecret":"googleSecret"}}
+}`,
+			filepath.Join(NameDataSources, "mongodb-atlas", FileConfig.String()):                    `{"name":"mongodb-atlas","type":"mongodb-atlas"}`,
+			filepath.Join(NameDataSources, "unused-atlas", FileConfig.String()):                     `{"name":"unused-atlas","type":"mongodb-atlas"}`,
+			filepath.Join(NameDataSources, "ruled-atlas", FileConfig.String()):                      `{"name":"ruled-atlas","type":"mongodb-atlas"}`,
+			filepath.Join(NameDataSources, "ruled-atlas", "db", "coll", FileRules.String()):         `{"roles":[{"name":"reader","apply_when":{"%%true":{"%function":{"name":"canRead"}}}}]}`,
+			filepath.Join(NameDataSources, "ruled-atlas", "db", "coll", FileSchema.String()):        `{}`,
+			filepath.Join(NameDataSources, "ruled-atlas", "db", "coll", FileRelationships.String()): `{}`,
+		} {
+			assert.Nil(t, os.MkdirAll(filepath.Dir(filepath.Join(tmpDir, path)), os.ModePerm))
+			assert.Nil(t, ioutil.WriteFile(filepath.Join(tmpDir, path), []byte(contents), 0666))
+		}
+
+		app, err := LoadApp(tmpDir)
+		assert.Nil(t, err)
+
+		return app, cleanupTmpDir
+	}
+
+	t.Run("should report the unused components and circular function calls", func(t *testing.T) {
+		app, cleanup := setup(t)
+		defer cleanup()
+
+		analysis := Analyze(app, AnalyzeOptions{Secrets: []string{"apiKeySecret", "googleSecret", "orphanSecret"}})
+
+		assert.Equal(t, []string{"dead", "deadHelper"}, analysis.UnusedFunctions)
+		assert.Equal(t, []string{"deadValue", "unused"}, analysis.UnusedValues)
+		assert.Equal(t, []string{"orphanSecret"}, analysis.UnusedSecrets)
+		assert.Equal(t, []string{"unused-atlas"}, analysis.UnusedServices)
+		assert.Equal(t, [][]string{{"ping", "pong", "ping"}, {"recurse", "recurse"}}, analysis.CircularCalls)
+	})
+
+	t.Run("should report unreferenced public functions as unused when public functions are included", func(t *testing.T) {
+		app, cleanup := setup(t)
+		defer cleanup()
+
+		analysis := Analyze(app, AnalyzeOptions{IncludePublic: true})
+
+		assert.Equal(t, []string{"dead", "deadHelper", "public"}, analysis.UnusedFunctions)
+		assert.Equal(t, []string{}, analysis.UnusedSecrets)
+	})
+
+	t.Run("should write the dependency graph as dot", func(t *testing.T) {
+		app, cleanup := setup(t)
+		defer cleanup()
+
+		dot := Analyze(app, AnalyzeOptions{}).DOT()
+
+		for _, expected := range []string{
+			`digraph "analyze" {`,
+			`  "function:dead" [label="function\ndead", shape=box, style=dashed];`,
+			`  "function:main" [label="function\nmain", shape=box];`,
+			`  "trigger:t1" -> "function:main";`,
+			`  "rule:ruled-atlas" -> "function:canRead";`,
+			`  "auth_provider:oauth2-google" -> "secret:googleSecret";`,
+			`  "auth_provider:oauth2-google" -> "value:apiKey";`,
+			`  "value:apiKey" -> "secret:apiKeySecret";`,
+			`  "endpoint:/recurse" -> "function:recurse";`,
+			`  "client:clients" -> "function:public";`,
+		} {
+			assert.True(t, strings.Contains(dot, expected+"\n"), "expected dot to contain: %s\n%s", expected, dot)
+		}
+	})
+}
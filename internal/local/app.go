@@ -2,12 +2,13 @@ package local
 
 import (
 	"bytes"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/10gen/realm-cli/internal/cloud/realm"
 )
@@ -201,11 +202,24 @@ func (a App) WriteConfig() error {
 	if err != nil {
 		return err
 	}
-	return WriteFile(filepath.Join(a.RootDir, a.Config.String()), 0666, bytes.NewReader(data))
+
+	path := filepath.Join(a.RootDir, a.Config.String())
+	if isYAML(a.Config.Ext) {
+		return writeYAML(path, data)
+	}
+	return WriteFile(path, 0666, bytes.NewReader(data))
 }
 
 // LoadApp will load the local app data and app config
 func LoadApp(path string) (App, error) {
+	return LoadAppWithOptions(path, LoadOptions{})
+}
+
+// LoadAppWithOptions will load the local app data and app config
+// with the provided options
+func LoadAppWithOptions(path string, opts LoadOptions) (App, error) {
+	start := time.Now()
+
 	app, appOK, appErr := FindApp(path)
 	if appErr != nil {
 		return App{}, appErr
@@ -214,21 +228,38 @@ func LoadApp(path string) (App, error) {
 		return App{}, errFailedToFindApp(path)
 	}
 
-	if err := app.LoadData(app.RootDir); err != nil {
+	l, err := newLoader(opts)
+	if err != nil {
 		return App{}, err
 	}
 
+	if err := app.loadData(app.RootDir, l); err != nil {
+		return App{}, err
+	}
+
+	if err := l.cache.save(app.RootDir); err != nil {
+		return App{}, err
+	}
+
+	if opts.Profile != nil {
+		opts.Profile.Total = time.Since(start)
+	}
+
 	return app, nil
 }
 
 // LoadConfig will load the local app's config
 func (a *App) LoadConfig() error {
-	switch a.Config {
-	case FileRealmConfig:
+	if a.Config.Ext != extJSON && !isYAML(a.Config.Ext) {
+		return fmt.Errorf("invalid config file: %s", a.Config.String())
+	}
+
+	switch a.Config.Name {
+	case NameRealmConfig:
 		a.AppData = &AppRealmConfigJSON{}
-	case FileConfig:
+	case NameConfig:
 		a.AppData = &AppConfigJSON{}
-	case FileStitch:
+	case NameStitch:
 		a.AppData = &AppStitchJSON{}
 	default:
 		return fmt.Errorf("invalid config file: %s", a.Config.String())
@@ -241,14 +272,18 @@ func (a *App) LoadConfig() error {
 		return errFailedToParseAppConfig(path)
 	}
 
-	if err := json.Unmarshal(data, a.AppData); err != nil {
+	if err := unmarshalFileWithOptions(path, data, a.AppData, true); err != nil {
 		return errFailedToParseAppConfig(path)
 	}
 	return nil
 }
 
 var (
-	allConfigFiles = []File{FileRealmConfig, FileConfig, FileStitch}
+	allConfigFiles = []File{
+		FileRealmConfig, FileRealmConfigYAML, {NameRealmConfig, extYML},
+		FileConfig, FileConfigYAML, {NameConfig, extYML},
+		FileStitch, FileStitchYAML, {NameStitch, extYML},
+	}
 )
 
 // FindApp searches upwards for the root of a Realm app project and
@@ -289,3 +324,56 @@ func FindApp(path string) (App, bool, error) {
 
 	return App{}, false, nil
 }
+
+// FindApps searches downwards from the provided path for the root directories
+// of Realm app projects and returns the local app structure of each app found;
+// an app's directory is not searched any further once the app is found,
+// and config files which do not describe a Realm app are skipped
+func FindApps(path string) ([]App, error) {
+	rootDir, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(rootDir); err != nil {
+		if os.IsNotExist(err) {
+			return nil, errFailedToFindApp(path)
+		}
+		return nil, err
+	}
+
+	var apps []App
+
+	var find func(dir string) error
+	find = func(dir string) error {
+		for _, config := range allConfigFiles {
+			if _, err := os.Stat(filepath.Join(dir, config.String())); err != nil {
+				if os.IsNotExist(err) {
+					continue
+				}
+				return err
+			}
+
+			app := App{RootDir: dir, Config: config}
+			if err := app.LoadConfig(); err != nil || app.ConfigVersion() == 0 {
+				continue // not a Realm app config file
+			}
+
+			apps = append(apps, app)
+			return nil
+		}
+
+		dw := directoryWalker{path: dir, onlyDirs: true}
+		return dw.walk(func(file os.FileInfo, path string) error {
+			if file.Name() == nameNodeModules || strings.HasPrefix(file.Name(), ".") {
+				return nil
+			}
+			return find(path)
+		})
+	}
+
+	if err := find(rootDir); err != nil {
+		return nil, err
+	}
+	return apps, nil
+}
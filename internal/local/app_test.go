@@ -209,6 +209,60 @@ func TestFindApp(t *testing.T) {
 	}
 }
 
+func TestFindApps(t *testing.T) {
+	wd, wdErr := os.Getwd()
+	assert.Nil(t, wdErr)
+
+	t.Run("should find every app below the directory and skip invalid configs", func(t *testing.T) {
+		testRoot := filepath.Join(wd, "testdata", realm.AppConfigVersion20210101.String())
+
+		apps, err := FindApps(testRoot)
+		assert.Nil(t, err)
+
+		paths := make([]string, 0, len(apps))
+		for _, app := range apps {
+			paths = append(paths, app.RootDir)
+		}
+		assert.Equal(t, []string{
+			filepath.Join(testRoot, "local"),
+			filepath.Join(testRoot, "nested"),
+			filepath.Join(testRoot, "remote"),
+		}, paths)
+
+		assert.Equal(t, FileRealmConfig, apps[0].Config)
+		assert.Equal(t, "20210101-local", apps[0].Name())
+	})
+
+	t.Run("should not search node_modules or hidden directories", func(t *testing.T) {
+		tmpDir, cleanupTmpDir, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer cleanupTmpDir()
+
+		for _, dir := range []string{"apps/app1", "apps/app2/backend", "node_modules/app3", ".git/app4"} {
+			assert.Nil(t, os.MkdirAll(filepath.Join(tmpDir, dir), os.ModePerm))
+			assert.Nil(t, ioutil.WriteFile(
+				filepath.Join(tmpDir, dir, FileRealmConfig.String()),
+				[]byte(`{"config_version":20210101,"name":"`+filepath.Base(dir)+`"}`),
+				0666,
+			))
+		}
+
+		apps, err := FindApps(tmpDir)
+		assert.Nil(t, err)
+
+		names := make([]string, 0, len(apps))
+		for _, app := range apps {
+			names = append(names, app.Name())
+		}
+		assert.Equal(t, []string{"app1", "backend"}, names)
+	})
+
+	t.Run("should return an error when the directory does not exist", func(t *testing.T) {
+		_, err := FindApps("./some/path")
+		assert.Equal(t, errFailedToFindApp("./some/path"), err)
+	})
+}
+
 func TestAppWriteConfig(t *testing.T) {
 	t.Run("Should write the app config contents successfully", func(t *testing.T) {
 		for _, tc := range []struct {
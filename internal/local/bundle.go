@@ -0,0 +1,496 @@
+package local
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+)
+
+const (
+	extZipHosting = ".hosting" + extZip
+)
+
+var (
+	errBundleMissingApp = errors.New("bundle is missing the app definition")
+)
+
+func errBundleInvalidHostingArchive(archive string) error {
+	return fmt.Errorf("bundle has an invalid hosting archive: %s, it must be a file name alongside the bundle", archive)
+}
+
+func errBundleConfigVersionConversion(from, to realm.AppConfigVersion) error {
+	return fmt.Errorf("cannot convert a bundle with config version %s to config version %s, use 'pull' to export the app with the desired config version instead", from, to)
+}
+
+// Bundle is the single file representation of a local Realm app
+type Bundle struct {
+	AppData AppData
+	Hosting *BundleHosting
+
+	// rootDir is the directory of the bundle file, used to resolve the hosting archive
+	rootDir string
+}
+
+// BundleHosting is the local Realm app hosting data stored in a bundle
+type BundleHosting struct {
+	Metadata json.RawMessage `json:"metadata,omitempty"`
+	Archive  string          `json:"archive,omitempty"`
+}
+
+type bundleJSON struct {
+	App     json.RawMessage `json:"app"`
+	Hosting *BundleHosting  `json:"hosting,omitempty"`
+}
+
+// MarshalJSON marshals the bundle as JSON
+func (b Bundle) MarshalJSON() ([]byte, error) {
+	app, err := json.Marshal(b.AppData)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(bundleJSON{app, b.Hosting})
+}
+
+// UnmarshalJSON unmarshals the bundle from JSON
+func (b *Bundle) UnmarshalJSON(data []byte) error {
+	var bj bundleJSON
+	if err := json.Unmarshal(data, &bj); err != nil {
+		return err
+	}
+	if len(bj.App) == 0 {
+		return errBundleMissingApp
+	}
+
+	var version struct {
+		ConfigVersion realm.AppConfigVersion `json:"config_version"`
+	}
+	if err := json.Unmarshal(bj.App, &version); err != nil {
+		return err
+	}
+
+	var appData AppData
+	switch version.ConfigVersion {
+	case realm.AppConfigVersion20180301:
+		appData = &AppStitchJSON{}
+	case realm.AppConfigVersion20200603:
+		appData = &AppConfigJSON{}
+	case realm.AppConfigVersion20210101:
+		appData = &AppRealmConfigJSON{}
+	default:
+		return fmt.Errorf("bundle has an unsupported config version: %d", version.ConfigVersion)
+	}
+
+	if err := json.Unmarshal(bj.App, appData); err != nil {
+		return err
+	}
+
+	b.AppData = appData
+	b.Hosting = bj.Hosting
+	return nil
+}
+
+// NewBundle returns a bundle of the loaded local Realm app
+// If provided, the app hosting files are written as a zip archive to hostingArchivePath
+func NewBundle(app App, hostingArchivePath string) (Bundle, error) {
+	bundle := Bundle{AppData: app.AppData}
+
+	hostingDir := filepath.Join(app.RootDir, NameHosting)
+
+	metadata, err := readFile(filepath.Join(hostingDir, NameMetadata+extJSON))
+	if err != nil {
+		return Bundle{}, err
+	}
+	if len(metadata) > 0 {
+		bundle.Hosting = &BundleHosting{Metadata: metadata}
+	}
+
+	if hostingArchivePath == "" {
+		return bundle, nil
+	}
+
+	if err := writeHostingArchive(filepath.Join(hostingDir, NameFiles), hostingArchivePath); err != nil {
+		return Bundle{}, err
+	}
+
+	if bundle.Hosting == nil {
+		bundle.Hosting = &BundleHosting{}
+	}
+	bundle.Hosting.Archive = filepath.Base(hostingArchivePath)
+
+	return bundle, nil
+}
+
+// HostingArchivePath returns the path of the hosting archive written alongside the bundle
+func HostingArchivePath(bundlePath string) string {
+	return bundlePath[:len(bundlePath)-len(filepath.Ext(bundlePath))] + extZipHosting
+}
+
+// LoadBundle loads the bundle found at the specified path
+func LoadBundle(path string) (Bundle, error) {
+	data, err := readFileWithOptions(path, true)
+	if err != nil {
+		return Bundle{}, err
+	}
+
+	var bundle Bundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return Bundle{}, fmt.Errorf("failed to parse bundle at %s: %w", path, err)
+	}
+	bundle.rootDir = filepath.Dir(path)
+
+	return bundle, nil
+}
+
+// Write writes the bundle to the specified path
+func (b Bundle) Write(path string) error {
+	data, err := MarshalJSON(b)
+	if err != nil {
+		return err
+	}
+	return WriteFile(path, 0666, bytes.NewReader(data))
+}
+
+// Unbundle writes the bundle as a local Realm app directory structure at rootDir
+// using the specified config version (or the bundle's own config version if none is provided)
+func (b Bundle) Unbundle(rootDir string, configVersion realm.AppConfigVersion) (App, error) {
+	// the hosting archive is always written alongside the bundle, so any other path is rejected
+	if b.Hosting != nil && b.Hosting.Archive != "" {
+		if archive := b.Hosting.Archive; archive != filepath.Base(archive) || archive == "." || archive == ".." {
+			return App{}, errBundleInvalidHostingArchive(archive)
+		}
+	}
+
+	appData, err := b.convert(configVersion)
+	if err != nil {
+		return App{}, err
+	}
+
+	// the function names and sources are written as paths, so any which escape the functions directory are rejected
+	if err := checkBundleFunctionPaths(appData); err != nil {
+		return App{}, err
+	}
+
+	var config File
+	switch appData.(type) {
+	case *AppStitchJSON:
+		config = FileStitch
+	case *AppConfigJSON:
+		config = FileConfig
+	default:
+		config = FileRealmConfig
+	}
+
+	app := App{RootDir: rootDir, Config: config, AppData: appData}
+	if err := app.Write(); err != nil {
+		return App{}, err
+	}
+
+	if b.Hosting == nil {
+		return app, nil
+	}
+
+	hostingDir := filepath.Join(rootDir, NameHosting)
+
+	if len(b.Hosting.Metadata) > 0 {
+		if err := WriteFile(
+			filepath.Join(hostingDir, NameMetadata+extJSON),
+			0666,
+			bytes.NewReader(b.Hosting.Metadata),
+		); err != nil {
+			return App{}, err
+		}
+	}
+
+	if b.Hosting.Archive != "" {
+		archive, err := zip.OpenReader(filepath.Join(b.rootDir, b.Hosting.Archive))
+		if err != nil {
+			return App{}, fmt.Errorf("failed to open hosting archive: %w", err)
+		}
+		defer archive.Close()
+
+		if err := WriteZip(filepath.Join(hostingDir, NameFiles), &archive.Reader); err != nil {
+			return App{}, err
+		}
+	}
+
+	return app, nil
+}
+
+// checkBundleFunctionPaths checks the function names and source paths of the app data stay within the functions directory
+func checkBundleFunctionPaths(appData AppData) error {
+	var paths []string
+	for _, fn := range AppFunctions(appData) {
+		paths = append(paths, fn.Name)
+	}
+	if ad, ok := appData.(*AppRealmConfigJSON); ok {
+		for path := range ad.Functions.Sources {
+			paths = append(paths, path)
+		}
+	}
+
+	for _, path := range paths {
+		if _, err := joinWithin(NameFunctions, path); err != nil {
+			return fmt.Errorf("bundle has an invalid function path: %w", err)
+		}
+	}
+	return nil
+}
+
+// convert returns the bundle app data in the specified config version
+func (b Bundle) convert(configVersion realm.AppConfigVersion) (AppData, error) {
+	from := b.AppData.ConfigVersion()
+	if configVersion == realm.AppConfigVersionZero || configVersion == from {
+		return b.AppData, nil
+	}
+
+	var structureV1 AppStructureV1
+	switch ad := b.AppData.(type) {
+	case *AppStitchJSON:
+		structureV1 = ad.AppStructureV1
+	case *AppConfigJSON:
+		structureV1 = ad.AppStructureV1
+	case *AppRealmConfigJSON:
+		converted, ok := convertAppStructureV2(ad.AppStructureV2)
+		if !ok {
+			return nil, errBundleConfigVersionConversion(from, configVersion)
+		}
+		structureV1 = converted
+	}
+
+	switch configVersion {
+	case realm.AppConfigVersion20180301:
+		structureV1.ConfigVersion = configVersion
+		return &AppStitchJSON{AppDataV1{structureV1}}, nil
+	case realm.AppConfigVersion20200603:
+		structureV1.ConfigVersion = configVersion
+		return &AppConfigJSON{AppDataV1{structureV1}}, nil
+	case realm.AppConfigVersion20210101:
+		return &AppRealmConfigJSON{AppDataV2{convertAppStructureV1(structureV1)}}, nil
+	}
+	return nil, errBundleConfigVersionConversion(from, configVersion)
+}
+
+// set of service types split out of the v1 services in the v2 app structure
+const (
+	serviceTypeMongoDB = "mongodb"
+	serviceTypeHTTP    = "http"
+)
+
+func isDataSourceType(svcType string) bool {
+	switch svcType {
+	case realm.ServiceTypeCluster, realm.ServiceTypeDatalake, serviceTypeMongoDB:
+		return true
+	}
+	return false
+}
+
+// convertAppStructureV1 converts the v1 app structure to the v2 app structure,
+// splitting the data sources and http services out of the services
+func convertAppStructureV1(app AppStructureV1) AppStructureV2 {
+	out := AppStructureV2{
+		ConfigVersion:   realm.AppConfigVersion20210101,
+		ID:              app.ID,
+		Name:            app.Name,
+		Location:        app.Location,
+		DeploymentModel: app.DeploymentModel,
+		Environment:     app.Environment,
+		Environments:    app.Environments,
+		Values:          app.Values,
+		Triggers:        app.Triggers,
+		GraphQL:         app.GraphQL,
+		Hosting:         app.Hosting,
+		Secrets:         app.Secrets,
+		LogForwarders:   app.LogForwarders,
+	}
+
+	if origins, ok := app.Security["allowed_request_origins"].([]interface{}); ok {
+		for _, origin := range origins {
+			if origin, ok := origin.(string); ok {
+				out.AllowedRequestOrigins = append(out.AllowedRequestOrigins, origin)
+			}
+		}
+	}
+
+	if len(app.AuthProviders) > 0 {
+		out.Auth.Providers = make(map[string]interface{}, len(app.AuthProviders))
+		for _, provider := range app.AuthProviders {
+			name, _ := provider["name"].(string)
+			out.Auth.Providers[name] = provider
+		}
+	}
+	out.Auth.CustomUserData = app.CustomUserDataConfig
+
+	if len(app.Sync) > 0 {
+		out.Sync.Config = app.Sync
+	}
+
+	for _, fn := range appFunctionsV1(app) {
+		out.Functions.Configs = append(out.Functions.Configs, fn.Config)
+		if out.Functions.Sources == nil {
+			out.Functions.Sources = map[string]string{}
+		}
+		out.Functions.Sources[filepath.FromSlash(fn.Name)+extJS] = fn.Source
+	}
+
+	for _, svc := range app.Services {
+		svcType, _ := svc.Config["type"].(string)
+		switch {
+		case isDataSourceType(svcType):
+			out.DataSources = append(out.DataSources, DataSourceStructure{svc.Config, svc.Rules})
+		case svcType == serviceTypeHTTP:
+			out.HTTPServices = append(out.HTTPServices, HTTPServiceStructure{svc.Config, svc.IncomingWebhooks, svc.Rules})
+		default:
+			out.Services = append(out.Services, svc)
+		}
+	}
+
+	return out
+}
+
+// convertAppStructureV2 converts the v2 app structure to the v1 app structure,
+// which is only possible when the app only uses what the v1 app structure supports:
+// the functions must each be a single, untranspiled source and there must be no https endpoints
+func convertAppStructureV2(app AppStructureV2) (AppStructureV1, bool) {
+	if len(app.Endpoints.Configs) > 0 {
+		return AppStructureV1{}, false
+	}
+
+	out := AppStructureV1{
+		ID:                   app.ID,
+		Name:                 app.Name,
+		Location:             app.Location,
+		DeploymentModel:      app.DeploymentModel,
+		Environment:          app.Environment,
+		Environments:         app.Environments,
+		Hosting:              app.Hosting,
+		CustomUserDataConfig: app.Auth.CustomUserData,
+		Sync:                 app.Sync.Config,
+		Secrets:              app.Secrets,
+		Triggers:             app.Triggers,
+		GraphQL:              app.GraphQL,
+		Values:               app.Values,
+		LogForwarders:        app.LogForwarders,
+	}
+
+	out.Security = map[string]interface{}{}
+	if len(app.AllowedRequestOrigins) > 0 {
+		origins := make([]interface{}, 0, len(app.AllowedRequestOrigins))
+		for _, origin := range app.AllowedRequestOrigins {
+			origins = append(origins, origin)
+		}
+		out.Security["allowed_request_origins"] = origins
+	}
+
+	providerNames := make([]string, 0, len(app.Auth.Providers))
+	for name := range app.Auth.Providers {
+		providerNames = append(providerNames, name)
+	}
+	sort.Strings(providerNames)
+	for _, name := range providerNames {
+		provider, ok := app.Auth.Providers[name].(map[string]interface{})
+		if !ok {
+			return AppStructureV1{}, false
+		}
+		out.AuthProviders = append(out.AuthProviders, provider)
+	}
+
+	sources := make(map[string]struct{}, len(app.Functions.Sources))
+	for path := range app.Functions.Sources {
+		sources[path] = struct{}{}
+	}
+	for _, config := range app.Functions.Configs {
+		name, _ := config["name"].(string)
+		path := filepath.FromSlash(name) + extJS
+
+		source, ok := app.Functions.Sources[path]
+		if !ok || strings.Contains(name, "/") {
+			return AppStructureV1{}, false
+		}
+		delete(sources, path)
+
+		out.Functions = append(out.Functions, map[string]interface{}{
+			NameConfig: config,
+			NameSource: source,
+		})
+	}
+	if len(sources) > 0 {
+		// the v1 app structure has no place for the sources which are not functions
+		return AppStructureV1{}, false
+	}
+
+	for _, ds := range app.DataSources {
+		out.Services = append(out.Services, ServiceStructure{Config: ds.Config, Rules: ds.Rules})
+	}
+	for _, svc := range app.HTTPServices {
+		out.Services = append(out.Services, ServiceStructure{svc.Config, svc.IncomingWebhooks, svc.Rules})
+	}
+	out.Services = append(out.Services, app.Services...)
+
+	return out, true
+}
+
+func writeHostingArchive(dir, path string) error {
+	if _, err := os.Stat(dir); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("hosting files not found at '%s'", dir)
+		}
+		return err
+	}
+
+	r, err := newDirReader(dir)
+	if err != nil {
+		return err
+	}
+
+	if err := mkdir(filepath.Dir(path)); err != nil {
+		return err
+	}
+
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	w := zip.NewWriter(out)
+
+	for {
+		h, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, h.path)
+		if err != nil {
+			return err
+		}
+
+		data, err := ioutil.ReadAll(r)
+		if err != nil {
+			return err
+		}
+
+		file, err := w.Create(filepath.ToSlash(rel))
+		if err != nil {
+			return err
+		}
+		if _, err := file.Write(data); err != nil {
+			return err
+		}
+	}
+
+	return w.Close()
+}
@@ -0,0 +1,348 @@
+package local
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestBundle(t *testing.T) {
+	wd, wdErr := os.Getwd()
+	assert.Nil(t, wdErr)
+
+	for _, tc := range []struct {
+		description    string
+		project        string
+		expectedConfig File
+	}{
+		{
+			description:    "should bundle and unbundle a 20200603 app",
+			project:        "full_project",
+			expectedConfig: FileConfig,
+		},
+		{
+			description:    "should bundle and unbundle a 20210101 app",
+			project:        "data_sources",
+			expectedConfig: FileRealmConfig,
+		},
+		{
+			description:    "should bundle and unbundle a 20210101 app authored in yaml",
+			project:        "yaml_project",
+			expectedConfig: FileRealmConfig,
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			tmpDir, cleanupTmpDir, err := u.NewTempDir("")
+			assert.Nil(t, err)
+			defer cleanupTmpDir()
+
+			app, err := LoadApp(filepath.Join(wd, "testdata", tc.project))
+			assert.Nil(t, err)
+
+			bundle, err := NewBundle(app, "")
+			assert.Nil(t, err)
+			assert.Nil(t, bundle.Hosting)
+
+			bundlePath := filepath.Join(tmpDir, "app.json")
+			assert.Nil(t, bundle.Write(bundlePath))
+
+			loaded, err := LoadBundle(bundlePath)
+			assert.Nil(t, err)
+
+			unbundled, err := loaded.Unbundle(filepath.Join(tmpDir, "app"), realm.AppConfigVersionZero)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedConfig, unbundled.Config)
+
+			reloaded, err := LoadApp(filepath.Join(tmpDir, "app"))
+			assert.Nil(t, err)
+
+			// compare the app data as it is imported, since empty and nil values are equivalent
+			expected, err := json.Marshal(app.AppData)
+			assert.Nil(t, err)
+			actual, err := json.Marshal(reloaded.AppData)
+			assert.Nil(t, err)
+			assert.Equal(t, string(expected), string(actual))
+		})
+	}
+
+	t.Run("should bundle and unbundle an app with hosting files", func(t *testing.T) {
+		tmpDir, cleanupTmpDir, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer cleanupTmpDir()
+
+		projectRoot := filepath.Join(wd, "testdata", "hosting")
+
+		app, err := LoadApp(projectRoot)
+		assert.Nil(t, err)
+
+		bundlePath := filepath.Join(tmpDir, "app.json")
+		hostingArchivePath := HostingArchivePath(bundlePath)
+		assert.Equal(t, filepath.Join(tmpDir, "app.hosting.zip"), hostingArchivePath)
+
+		bundle, err := NewBundle(app, hostingArchivePath)
+		assert.Nil(t, err)
+		assert.Equal(t, "app.hosting.zip", bundle.Hosting.Archive)
+		assert.Nil(t, bundle.Write(bundlePath))
+
+		loaded, err := LoadBundle(bundlePath)
+		assert.Nil(t, err)
+
+		_, err = loaded.Unbundle(filepath.Join(tmpDir, "app"), realm.AppConfigVersionZero)
+		assert.Nil(t, err)
+
+		expectedMetadata, err := readMetadata(filepath.Join(projectRoot, NameHosting))
+		assert.Nil(t, err)
+		actualMetadata, err := readMetadata(filepath.Join(tmpDir, "app", NameHosting))
+		assert.Nil(t, err)
+		assert.Equal(t, expectedMetadata, actualMetadata)
+
+		for _, path := range []string{
+			filepath.Join(NameHosting, NameFiles, "404.html"),
+			filepath.Join(NameHosting, NameFiles, "index.html"),
+		} {
+			expected, err := ioutil.ReadFile(filepath.Join(projectRoot, path))
+			assert.Nil(t, err)
+
+			actual, err := ioutil.ReadFile(filepath.Join(tmpDir, "app", path))
+			assert.Nil(t, err)
+
+			assert.Equal(t, string(expected), string(actual))
+		}
+	})
+
+	t.Run("should unbundle a 20200603 app as a 20180301 app", func(t *testing.T) {
+		tmpDir, cleanupTmpDir, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer cleanupTmpDir()
+
+		app, err := LoadApp(filepath.Join(wd, "testdata", "full_project"))
+		assert.Nil(t, err)
+
+		bundle, err := NewBundle(app, "")
+		assert.Nil(t, err)
+
+		unbundled, err := bundle.Unbundle(tmpDir, realm.AppConfigVersion20180301)
+		assert.Nil(t, err)
+		assert.Equal(t, FileStitch, unbundled.Config)
+
+		reloaded, err := LoadApp(tmpDir)
+		assert.Nil(t, err)
+		assert.Equal(t, realm.AppConfigVersion20180301, reloaded.ConfigVersion())
+		assert.Equal(t, app.AppData.(*AppConfigJSON).Functions, reloaded.AppData.(*AppStitchJSON).Functions)
+	})
+
+	t.Run("should unbundle a 20200603 app as a 20210101 app and back", func(t *testing.T) {
+		tmpDir, cleanupTmpDir, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer cleanupTmpDir()
+
+		app, err := LoadApp(filepath.Join(wd, "testdata", "full_project"))
+		assert.Nil(t, err)
+
+		bundle, err := NewBundle(app, "")
+		assert.Nil(t, err)
+
+		unbundled, err := bundle.Unbundle(filepath.Join(tmpDir, "v2"), realm.AppConfigVersion20210101)
+		assert.Nil(t, err)
+		assert.Equal(t, FileRealmConfig, unbundled.Config)
+
+		reloaded, err := LoadApp(filepath.Join(tmpDir, "v2"))
+		assert.Nil(t, err)
+		assert.Equal(t, realm.AppConfigVersion20210101, reloaded.ConfigVersion())
+
+		appDataV2 := reloaded.AppData.(*AppRealmConfigJSON)
+		assert.Equal(t, []string{"http://localhost:8080"}, appDataV2.AllowedRequestOrigins)
+		assert.Equal(t, []map[string]interface{}{app.AppData.(*AppConfigJSON).Functions[0][NameConfig].(map[string]interface{})}, appDataV2.Functions.Configs)
+		assert.Equal(t, map[string]string{"test.js": app.AppData.(*AppConfigJSON).Functions[0][NameSource].(string)}, appDataV2.Functions.Sources)
+		assert.Equal(t, map[string]interface{}{"name": "api-key", "type": "api-key", "disabled": false}, appDataV2.Auth.Providers["api-key"])
+		assert.Equal(t, 1, len(appDataV2.HTTPServices))
+		assert.Equal(t, "http", appDataV2.HTTPServices[0].Config["name"])
+		assert.Equal(t, 0, len(appDataV2.Services))
+
+		rebundled, err := NewBundle(reloaded, "")
+		assert.Nil(t, err)
+
+		_, err = rebundled.Unbundle(filepath.Join(tmpDir, "v1"), realm.AppConfigVersion20200603)
+		assert.Nil(t, err)
+
+		roundTripped, err := LoadApp(filepath.Join(tmpDir, "v1"))
+		assert.Nil(t, err)
+
+		// the hosting config is not written with a 20210101 app, so it does not survive the round trip
+		appData := *app.AppData.(*AppConfigJSON)
+		appData.Hosting = nil
+
+		// compare the app data as it is imported, since empty and nil values are equivalent
+		expected, err := json.Marshal(&appData)
+		assert.Nil(t, err)
+		actual, err := json.Marshal(roundTripped.AppData)
+		assert.Nil(t, err)
+		assert.Equal(t, string(expected), string(actual))
+	})
+
+	t.Run("should unbundle a 20210101 app as a 20200603 app", func(t *testing.T) {
+		tmpDir, cleanupTmpDir, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer cleanupTmpDir()
+
+		app, err := LoadApp(filepath.Join(wd, "testdata", "data_sources"))
+		assert.Nil(t, err)
+
+		bundle, err := NewBundle(app, "")
+		assert.Nil(t, err)
+
+		unbundled, err := bundle.Unbundle(tmpDir, realm.AppConfigVersion20200603)
+		assert.Nil(t, err)
+		assert.Equal(t, FileConfig, unbundled.Config)
+
+		reloaded, err := LoadApp(tmpDir)
+		assert.Nil(t, err)
+		assert.Equal(t, realm.AppConfigVersion20200603, reloaded.ConfigVersion())
+
+		services := reloaded.AppData.(*AppConfigJSON).Services
+		assert.Equal(t, 1, len(services))
+		assert.Equal(t, app.AppData.(*AppRealmConfigJSON).DataSources[0].Config, services[0].Config)
+		assert.Equal(t, len(app.AppData.(*AppRealmConfigJSON).DataSources[0].Rules), len(services[0].Rules))
+	})
+
+	t.Run("should fail to unbundle a 20210101 app with https endpoints as a 20200603 app", func(t *testing.T) {
+		bundle := Bundle{AppData: &AppRealmConfigJSON{AppDataV2{AppStructureV2{
+			ConfigVersion: realm.AppConfigVersion20210101,
+			Name:          "endpoints",
+			Endpoints:     EndpointStructure{Configs: []map[string]interface{}{{"route": "/hello"}}},
+		}}}}
+
+		_, err := bundle.Unbundle("", realm.AppConfigVersion20200603)
+		assert.Equal(t, errors.New("cannot convert a bundle with config version 20210101 to config version 20200603, use 'pull' to export the app with the desired config version instead"), err)
+	})
+
+	for _, archive := range []string{"/tmp/app.hosting.zip", "../app.hosting.zip", "hosting/../../app.hosting.zip", ".."} {
+		t.Run("should fail to unbundle a bundle with the hosting archive "+archive, func(t *testing.T) {
+			tmpDir, cleanupTmpDir, err := u.NewTempDir("")
+			assert.Nil(t, err)
+			defer cleanupTmpDir()
+
+			app, err := LoadApp(filepath.Join(wd, "testdata", "data_sources"))
+			assert.Nil(t, err)
+
+			bundle, err := NewBundle(app, "")
+			assert.Nil(t, err)
+			bundle.Hosting = &BundleHosting{Archive: archive}
+
+			_, err = bundle.Unbundle(filepath.Join(tmpDir, "app"), realm.AppConfigVersionZero)
+			assert.Equal(t, errors.New("bundle has an invalid hosting archive: "+archive+", it must be a file name alongside the bundle"), err)
+
+			_, err = os.Stat(filepath.Join(tmpDir, "app"))
+			assert.True(t, os.IsNotExist(err), "expected the app to not be written")
+		})
+	}
+
+	t.Run("should fail to unbundle a bundle with a hosting archive entry outside of the hosting files", func(t *testing.T) {
+		tmpDir, cleanupTmpDir, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer cleanupTmpDir()
+
+		archivePath := filepath.Join(tmpDir, "app.hosting.zip")
+		archive, err := os.Create(archivePath)
+		assert.Nil(t, err)
+
+		w := zip.NewWriter(archive)
+		entry, err := w.Create("../../../evil.txt")
+		assert.Nil(t, err)
+		_, err = entry.Write([]byte("evil"))
+		assert.Nil(t, err)
+		assert.Nil(t, w.Close())
+		assert.Nil(t, archive.Close())
+
+		app, err := LoadApp(filepath.Join(wd, "testdata", "data_sources"))
+		assert.Nil(t, err)
+
+		bundle, err := NewBundle(app, "")
+		assert.Nil(t, err)
+		bundle.Hosting = &BundleHosting{Archive: "app.hosting.zip"}
+		bundle.rootDir = tmpDir
+
+		filesDir := filepath.Join(tmpDir, "app", NameHosting, NameFiles)
+
+		_, err = bundle.Unbundle(filepath.Join(tmpDir, "app"), realm.AppConfigVersionZero)
+		assert.Equal(t, "invalid zip file entry: ../../../evil.txt is outside of "+filesDir, err.Error())
+
+		_, err = os.Stat(filepath.Join(tmpDir, "evil.txt"))
+		assert.True(t, os.IsNotExist(err), "expected the archive entry to not be written")
+	})
+
+	for _, tc := range []struct {
+		description string
+		appData     AppData
+		path        string
+	}{
+		{
+			description: "a function source",
+			appData: &AppRealmConfigJSON{AppDataV2{AppStructureV2{
+				ConfigVersion: realm.AppConfigVersion20210101,
+				Functions: FunctionsStructure{
+					Configs: []map[string]interface{}{{"name": "evil"}},
+					Sources: map[string]string{"../../evil.js": "exports = () => {}"},
+				},
+			}}},
+			path: "../../evil.js",
+		},
+		{
+			description: "a function name",
+			appData: &AppConfigJSON{AppDataV1{AppStructureV1{
+				ConfigVersion: realm.AppConfigVersion20200603,
+				Functions: []map[string]interface{}{{
+					NameConfig: map[string]interface{}{"name": "../../evil"},
+					NameSource: "exports = () => {}",
+				}},
+			}}},
+			path: "../../evil",
+		},
+	} {
+		t.Run("should fail to unbundle a bundle with "+tc.description+" outside of the functions directory", func(t *testing.T) {
+			tmpDir, cleanupTmpDir, err := u.NewTempDir("")
+			assert.Nil(t, err)
+			defer cleanupTmpDir()
+
+			bundle := Bundle{AppData: tc.appData}
+
+			_, err = bundle.Unbundle(filepath.Join(tmpDir, "app"), realm.AppConfigVersionZero)
+			assert.Equal(t, "bundle has an invalid function path: "+tc.path+" is outside of "+NameFunctions, err.Error())
+
+			_, err = os.Stat(filepath.Join(tmpDir, "app"))
+			assert.True(t, os.IsNotExist(err), "expected the app to not be written")
+		})
+	}
+
+	t.Run("should fail to load a bundle without an app", func(t *testing.T) {
+		tmpDir, cleanupTmpDir, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer cleanupTmpDir()
+
+		bundlePath := filepath.Join(tmpDir, "app.json")
+		assert.Nil(t, ioutil.WriteFile(bundlePath, []byte(`{"hosting":{}}`), 0666))
+
+		_, err = LoadBundle(bundlePath)
+		assert.Equal(t, "failed to parse bundle at "+bundlePath+": bundle is missing the app definition", err.Error())
+	})
+
+	t.Run("should fail to load a bundle with an unsupported config version", func(t *testing.T) {
+		tmpDir, cleanupTmpDir, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer cleanupTmpDir()
+
+		bundlePath := filepath.Join(tmpDir, "app.json")
+		assert.Nil(t, ioutil.WriteFile(bundlePath, []byte(`{"app":{"config_version":1}}`), 0666))
+
+		_, err = LoadBundle(bundlePath)
+		assert.Equal(t, "failed to parse bundle at "+bundlePath+": bundle has an unsupported config version: 1", err.Error())
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/10gen/realm-cli/internal/cloud/realm"
 )
@@ -75,7 +76,10 @@ func WriteZip(wd string, zipPkg *zip.Reader) error {
 		return err
 	}
 	for _, zipFile := range zipPkg.File {
-		path := filepath.Join(wd, zipFile.Name)
+		path, err := joinWithin(wd, zipFile.Name)
+		if err != nil {
+			return fmt.Errorf("invalid zip file entry: %w", err)
+		}
 
 		if zipFile.FileInfo().IsDir() {
 			if err := mkdir(path); err != nil {
@@ -97,6 +101,18 @@ func WriteZip(wd string, zipPkg *zip.Reader) error {
 	return nil
 }
 
+// joinWithin joins the name to the directory,
+// erroring if the cleaned path does not stay within the directory
+func joinWithin(dir, name string) (string, error) {
+	path := filepath.Join(dir, filepath.FromSlash(name))
+
+	rel, err := filepath.Rel(dir, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is outside of %s", name, dir)
+	}
+	return path, nil
+}
+
 func mkdir(path string) error {
 	if err := os.MkdirAll(path, os.ModePerm); err != nil {
 		return fmt.Errorf("failed to create directory at %s: %w", path, err)
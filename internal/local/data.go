@@ -21,12 +21,18 @@ type AppData interface {
 	Environment() realm.Environment
 	LoadData(rootDir string) error
 	WriteData(rootDir string) error
+
+	loadData(rootDir string, l *loader) error
 }
 
 // set of supported local names
 const (
 	extJS   = ".js"
 	extJSON = ".json"
+	extMap  = ".map"
+	extTS   = ".ts"
+	extYAML = ".yaml"
+	extYML  = ".yml"
 
 	// app configs
 	NameRealmConfig = "realm_config"
@@ -47,6 +53,10 @@ const (
 	nameNodeModules = "node_modules"
 	NameSource      = "source"
 	NamePackageJSON = "package.json"
+	NameSourceMaps  = "sourcemaps"
+
+	// local only data
+	NameLocalData = ".realm"
 
 	// graphql
 	NameGraphQL         = "graphql"
@@ -88,6 +98,10 @@ var (
 	FileConfig      = File{NameConfig, extJSON}
 	FileStitch      = File{NameStitch, extJSON}
 
+	FileRealmConfigYAML = File{NameRealmConfig, extYAML}
+	FileConfigYAML      = File{NameConfig, extYAML}
+	FileStitchYAML      = File{NameStitch, extYAML}
+
 	// auth
 	FileCustomUserData = File{NameCustomUserData, extJSON}
 	FileProviders      = File{NameProviders, extJSON}
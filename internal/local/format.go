@@ -0,0 +1,365 @@
+package local
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/10gen/realm-cli/internal/utils/flags"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileFormat is the format used to author the local Realm app configuration files
+type FileFormat string
+
+// String returns the file format display
+func (ff FileFormat) String() string { return string(ff) }
+
+// Type returns the file format type
+func (ff FileFormat) Type() string { return flags.TypeString }
+
+// Set validates and sets the file format value
+func (ff *FileFormat) Set(val string) error {
+	newFileFormat := FileFormat(strings.ToLower(val))
+
+	if !isValidFileFormat(newFileFormat) {
+		return errInvalidFileFormat
+	}
+
+	*ff = newFileFormat
+	return nil
+}
+
+// set of supported file formats
+const (
+	FileFormatEmpty FileFormat = ""
+	FileFormatJSON  FileFormat = "json"
+	FileFormatYAML  FileFormat = "yaml"
+)
+
+// FileFormatValues are the supported file format values
+var FileFormatValues = []string{FileFormatJSON.String(), FileFormatYAML.String()}
+
+var (
+	errInvalidFileFormat = fmt.Errorf("unsupported format, use one of [%s] instead", strings.Join(FileFormatValues, ", "))
+)
+
+func isValidFileFormat(ff FileFormat) bool {
+	switch ff {
+	case
+		FileFormatEmpty, // allow FileFormat to be optional
+		FileFormatJSON,
+		FileFormatYAML:
+		return true
+	}
+	return false
+}
+
+// FileFormat returns the format of the local app's config file
+func (a App) FileFormat() FileFormat {
+	if isYAML(a.Config.Ext) {
+		return FileFormatYAML
+	}
+	return FileFormatJSON
+}
+
+const (
+	yamlIndent = 2
+)
+
+var (
+	// the supported extensions for a config file, in order of precedence
+	configExts = []string{extJSON, extYAML, extYML}
+)
+
+// IsConfigFile checks the file is authored in any of the supported formats
+func IsConfigFile(path string) bool {
+	ext := filepath.Ext(path)
+	for _, configExt := range configExts {
+		if ext == configExt {
+			return true
+		}
+	}
+	return false
+}
+
+func isYAML(ext string) bool {
+	return ext == extYAML || ext == extYML
+}
+
+func errDuplicateFile(path string) error {
+	return fmt.Errorf("found multiple files for %s, only one of [%s] may be used", strings.TrimSuffix(path, filepath.Ext(path)), strings.Join(configExts, ", "))
+}
+
+// resolveFile resolves the provided filepath to the existing file authored in any of the supported formats,
+// erroring if the file exists in more than one format
+// if none of the files exist, the original path is returned
+func resolveFile(path string) (string, error) {
+	base := strings.TrimSuffix(path, filepath.Ext(path))
+
+	var found string
+	for _, ext := range configExts {
+		if _, err := os.Stat(base + ext); err != nil {
+			continue
+		}
+		if found != "" {
+			return "", errDuplicateFile(path)
+		}
+		found = base + ext
+	}
+	if found == "" {
+		return path, nil
+	}
+	return found, nil
+}
+
+// writeConfigFile writes the JSON data to the existing config file at the provided filepath,
+// keeping the format it is authored in and preserving its comments if it is authored in YAML
+func writeConfigFile(path string, jsonData []byte) error {
+	path, err := resolveFile(path)
+	if err != nil {
+		return err
+	}
+	if isYAML(filepath.Ext(path)) {
+		return writeYAML(path, jsonData)
+	}
+	return WriteFile(path, 0666, bytes.NewReader(jsonData))
+}
+
+// unmarshalFile unmarshals the file data into out, based on the provided file's extension
+func unmarshalFile(path string, data []byte, out interface{}) error {
+	return unmarshalFileWithOptions(path, data, out, false)
+}
+
+func unmarshalFileWithOptions(path string, data []byte, out interface{}, failOnEmpty bool) error {
+	jsonData, err := fileToJSON(path, data)
+	if err != nil {
+		return err
+	}
+	return unmarshalJSONWithOptions(jsonData, out, failOnEmpty)
+}
+
+// fileToJSON returns the file contents as JSON, converting the contents if the file is authored in YAML
+func fileToJSON(path string, data []byte) ([]byte, error) {
+	if !isYAML(filepath.Ext(path)) {
+		return data, nil
+	}
+
+	jsonData, err := yamlToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse yaml at %s: %w", path, err)
+	}
+	return jsonData, nil
+}
+
+func yamlToJSON(data []byte) ([]byte, error) {
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, nil
+	}
+
+	normalized, err := normalizeYAML(doc)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(normalized)
+}
+
+// normalizeYAML converts any non-string keyed maps into their JSON-compatible equivalent
+func normalizeYAML(v interface{}) (interface{}, error) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, v := range val {
+			normalized, err := normalizeYAML(v)
+			if err != nil {
+				return nil, err
+			}
+			val[k] = normalized
+		}
+		return val, nil
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, v := range val {
+			normalized, err := normalizeYAML(v)
+			if err != nil {
+				return nil, err
+			}
+			out[fmt.Sprint(k)] = normalized
+		}
+		return out, nil
+	case []interface{}:
+		for i, v := range val {
+			normalized, err := normalizeYAML(v)
+			if err != nil {
+				return nil, err
+			}
+			val[i] = normalized
+		}
+		return val, nil
+	}
+	return v, nil
+}
+
+// MarshalYAML returns the yaml representation of the passed in interface
+// The interface is first marshaled as JSON so the same field names and ordering are used
+func MarshalYAML(o interface{}) ([]byte, error) {
+	data, err := json.Marshal(o)
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := jsonToYAMLNode(data)
+	if err != nil {
+		return nil, err
+	}
+	return encodeYAML(node)
+}
+
+func jsonToYAMLNode(data []byte) (*yaml.Node, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	clearYAMLStyle(&node)
+	return &node, nil
+}
+
+// clearYAMLStyle removes the flow and quoting styles inherited from the JSON source
+// so the node is encoded using the default block style
+func clearYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, n := range node.Content {
+		clearYAMLStyle(n)
+	}
+}
+
+func encodeYAML(node *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(yamlIndent)
+
+	if err := encoder.Encode(node); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeYAML writes the JSON data as yaml to the specified path
+// if a yaml file already exists at the path, its comments are preserved
+// for any of the nodes that remain present in the updated data
+func writeYAML(path string, jsonData []byte) error {
+	node, err := jsonToYAMLNode(jsonData)
+	if err != nil {
+		return err
+	}
+
+	existing, err := readFile(path)
+	if err != nil {
+		return err
+	}
+
+	if len(existing) > 0 {
+		var existingNode yaml.Node
+		if err := yaml.Unmarshal(existing, &existingNode); err != nil {
+			return fmt.Errorf("failed to parse yaml at %s: %w", path, err)
+		}
+		node = mergeYAMLNode(&existingNode, node)
+	}
+
+	data, err := encodeYAML(node)
+	if err != nil {
+		return err
+	}
+	return WriteFile(path, 0666, bytes.NewReader(data))
+}
+
+// mergeYAMLNode returns the updated node with the comments of the existing node carried over
+func mergeYAMLNode(existing, updated *yaml.Node) *yaml.Node {
+	updated.HeadComment = existing.HeadComment
+	updated.LineComment = existing.LineComment
+	updated.FootComment = existing.FootComment
+
+	if existing.Kind != updated.Kind {
+		return updated
+	}
+
+	switch updated.Kind {
+	case yaml.DocumentNode:
+		if len(existing.Content) > 0 && len(updated.Content) > 0 {
+			updated.Content[0] = mergeYAMLNode(existing.Content[0], updated.Content[0])
+		}
+	case yaml.MappingNode:
+		existingPairs := make(map[string]int, len(existing.Content)/2)
+		for i := 0; i+1 < len(existing.Content); i += 2 {
+			existingPairs[existing.Content[i].Value] = i
+		}
+		for i := 0; i+1 < len(updated.Content); i += 2 {
+			idx, ok := existingPairs[updated.Content[i].Value]
+			if !ok {
+				continue
+			}
+			updated.Content[i] = mergeYAMLNode(existing.Content[idx], updated.Content[i])
+			updated.Content[i+1] = mergeYAMLNode(existing.Content[idx+1], updated.Content[i+1])
+		}
+	case yaml.SequenceNode:
+		for i := 0; i < len(updated.Content) && i < len(existing.Content); i++ {
+			updated.Content[i] = mergeYAMLNode(existing.Content[i], updated.Content[i])
+		}
+	}
+	return updated
+}
+
+// ConvertFormat rewrites the local app's JSON configuration files to the specified format
+// Files which already exist in the YAML format keep that format, and are updated
+// in place so their comments are preserved, which allows for mixed project trees
+func ConvertFormat(rootDir string, format FileFormat) error {
+	ignorePaths := map[string]struct{}{
+		NameHosting:     {},
+		nameNodeModules: {},
+		NamePackageJSON: {},
+	}
+
+	return walk(rootDir, ignorePaths, func(file os.FileInfo, path string) error {
+		if filepath.Ext(path) != extJSON {
+			return nil
+		}
+
+		base := strings.TrimSuffix(path, extJSON)
+
+		var target string
+		for _, ext := range []string{extYAML, extYML} {
+			if _, err := os.Stat(base + ext); err == nil {
+				target = base + ext
+				break
+			}
+		}
+		if target == "" {
+			if format != FileFormatYAML {
+				return nil
+			}
+			target = base + extYAML
+		}
+
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		if err := writeYAML(target, data); err != nil {
+			return err
+		}
+		return os.Remove(path)
+	})
+}
@@ -0,0 +1,314 @@
+package local
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+var yamlProject = &AppRealmConfigJSON{AppDataV2{AppStructureV2{
+	ConfigVersion:   realm.AppConfigVersion20210101,
+	Name:            "yaml-project",
+	Location:        realm.LocationVirginia,
+	DeploymentModel: realm.DeploymentModelGlobal,
+	Environments: map[string]map[string]interface{}{
+		"development.json": {
+			"values": map[string]interface{}{"apiHost": "https://dev.example.com"},
+		},
+		"production.json": {
+			"values": map[string]interface{}{},
+		},
+	},
+	AllowedRequestOrigins: []string{"http://localhost:8080"},
+	Values: []map[string]interface{}{
+		{"name": "answer", "value": float64(42), "from_secret": false},
+		{"name": "version", "value": "1.0", "from_secret": false},
+	},
+	Auth: AuthStructure{
+		Providers: map[string]interface{}{
+			"anon-user": map[string]interface{}{
+				"name":     "anon-user",
+				"type":     "anon-user",
+				"disabled": false,
+			},
+		},
+	},
+	Functions: FunctionsStructure{
+		Configs: []map[string]interface{}{
+			{"name": "sum", "private": false, "run_as_system": true},
+		},
+		Sources: map[string]string{
+			"sum.js": `exports = function(a, b) {
+  return a + b;
+};
+`,
+		},
+	},
+	Triggers: []map[string]interface{}{
+		{
+			"name": "onInsert",
+			"type": "DATABASE",
+			"config": map[string]interface{}{
+				"operation_types": []interface{}{"INSERT"},
+				"database":        "test",
+				"collection":      "coll1",
+				"service_name":    "mongodb-atlas",
+				"match":           map[string]interface{}{},
+				"full_document":   true,
+			},
+			"event_processors": map[string]interface{}{
+				"FUNCTION": map[string]interface{}{
+					"config": map[string]interface{}{"function_name": "sum"},
+				},
+			},
+			"disabled": false,
+		},
+	},
+	DataSources: []DataSourceStructure{
+		{
+			Config: map[string]interface{}{
+				"name":   "mongodb-atlas",
+				"type":   "mongodb-atlas",
+				"config": map[string]interface{}{"clusterName": "Cluster0"},
+			},
+			Rules: []map[string]interface{}{
+				{
+					"database":   "test",
+					"collection": "coll1",
+					"roles": []interface{}{
+						map[string]interface{}{
+							"name":              "default",
+							"apply_when":        map[string]interface{}{},
+							"insert":            true,
+							"delete":            true,
+							"search":            true,
+							"additional_fields": map[string]interface{}{},
+						},
+					},
+					"filters":       []interface{}{},
+					"schema":        map[string]interface{}{},
+					"relationships": map[string]interface{}{},
+				},
+			},
+		},
+	},
+}}}
+
+func TestFileFormatSet(t *testing.T) {
+	for _, tc := range []struct {
+		value          string
+		expectedFormat FileFormat
+		expectedErr    error
+	}{
+		{"json", FileFormatJSON, nil},
+		{"YAML", FileFormatYAML, nil},
+		{"", FileFormatEmpty, nil},
+		{"toml", FileFormatEmpty, errors.New("unsupported format, use one of [json, yaml] instead")},
+	} {
+		t.Run("should set the file format for value: "+tc.value, func(t *testing.T) {
+			var ff FileFormat
+
+			assert.Equal(t, tc.expectedErr, ff.Set(tc.value))
+			assert.Equal(t, tc.expectedFormat, ff)
+		})
+	}
+}
+
+func TestIsConfigFile(t *testing.T) {
+	for _, tc := range []struct {
+		path     string
+		expected bool
+	}{
+		{"tests/sum.json", true},
+		{"tests/sum.yaml", true},
+		{"tests/sum.yml", true},
+		{"tests/sum.js", false},
+		{"tests/README", false},
+	} {
+		t.Run("should check the file format of "+tc.path, func(t *testing.T) {
+			assert.Equal(t, tc.expected, IsConfigFile(tc.path))
+		})
+	}
+}
+
+func TestLoadAppYAML(t *testing.T) {
+	wd, wdErr := os.Getwd()
+	assert.Nil(t, wdErr)
+
+	projectRoot := filepath.Join(wd, "testdata", "yaml_project")
+
+	t.Run("should load a project authored with a mix of json and yaml files", func(t *testing.T) {
+		app, err := LoadApp(projectRoot)
+		assert.Nil(t, err)
+
+		assert.Equal(t, App{
+			RootDir: projectRoot,
+			Config:  FileRealmConfigYAML,
+			AppData: yamlProject,
+		}, app)
+		assert.Equal(t, FileFormatYAML, app.FileFormat())
+	})
+
+	t.Run("should error when a file is authored in multiple formats", func(t *testing.T) {
+		tmpDir, cleanupTmpDir, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer cleanupTmpDir()
+
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(tmpDir, FileRealmConfigYAML.String()), []byte("config_version: 20210101\nname: dupes\n"), 0666))
+		assert.Nil(t, os.Mkdir(filepath.Join(tmpDir, NameTriggers), os.ModePerm))
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(tmpDir, NameTriggers, "t1.json"), []byte(`{"name":"t1"}`), 0666))
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(tmpDir, NameTriggers, "t1.yml"), []byte("name: t1\n"), 0666))
+
+		_, err = LoadApp(tmpDir)
+		assert.Equal(t, errors.New("found multiple files for "+filepath.Join(tmpDir, NameTriggers, "t1")+", only one of [.json, .yaml, .yml] may be used"), err)
+	})
+
+	t.Run("should error when a config file is authored in multiple formats", func(t *testing.T) {
+		tmpDir, cleanupTmpDir, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer cleanupTmpDir()
+
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(tmpDir, FileRealmConfigYAML.String()), []byte("config_version: 20210101\nname: dupes\n"), 0666))
+		assert.Nil(t, os.Mkdir(filepath.Join(tmpDir, NameGraphQL), os.ModePerm))
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(tmpDir, NameGraphQL, "config.json"), []byte(`{"use_natural_pluralization":true}`), 0666))
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(tmpDir, NameGraphQL, "config.yaml"), []byte("use_natural_pluralization: false\n"), 0666))
+
+		_, err = LoadApp(tmpDir)
+		assert.Equal(t, errors.New("found multiple files for "+filepath.Join(tmpDir, NameGraphQL, "config")+", only one of [.json, .yaml, .yml] may be used"), err)
+	})
+
+	t.Run("should error with an invalid yaml config file", func(t *testing.T) {
+		tmpDir, cleanupTmpDir, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer cleanupTmpDir()
+
+		path := filepath.Join(tmpDir, FileRealmConfigYAML.String())
+		assert.Nil(t, ioutil.WriteFile(path, []byte("config_version: [20210101\n"), 0666))
+
+		_, _, err = FindApp(tmpDir)
+		assert.Equal(t, errors.New("failed to parse app config at "+path), err)
+	})
+}
+
+func TestAppWriteConfigYAML(t *testing.T) {
+	t.Run("should preserve the comments of an existing yaml config file", func(t *testing.T) {
+		tmpDir, cleanupTmpDir, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer cleanupTmpDir()
+
+		path := filepath.Join(tmpDir, FileRealmConfigYAML.String())
+		assert.Nil(t, ioutil.WriteFile(path, []byte(`# the app configuration
+config_version: 20210101
+name: yaml-project # the app name
+location: US-VA
+`), 0666))
+
+		app, ok, err := FindApp(tmpDir)
+		assert.Nil(t, err)
+		assert.True(t, ok, "expected to find app")
+
+		appData := app.AppData.(*AppRealmConfigJSON)
+		appData.AppStructureV2.ID = "yaml-project-abcde"
+		appData.AllowedRequestOrigins = []string{"http://localhost:8080"}
+
+		assert.Nil(t, app.WriteConfig())
+
+		data, err := ioutil.ReadFile(path)
+		assert.Nil(t, err)
+		assert.Equal(t, `# the app configuration
+config_version: 20210101
+app_id: yaml-project-abcde
+name: yaml-project # the app name
+location: US-VA
+allowed_request_origins:
+  - http://localhost:8080
+`, string(data))
+	})
+}
+
+func TestConvertFormat(t *testing.T) {
+	setup := func(t *testing.T) (string, func()) {
+		tmpDir, cleanupTmpDir, err := u.NewTempDir("")
+		assert.Nil(t, err)
+
+		for path, contents := range map[string]string{
+			FileRealmConfig.String():                    `{"config_version":20210101,"name":"convert","allowed_request_origins":[]}`,
+			filepath.Join(NameValues, "version.json"):   `{"name":"version","value":"1.0","from_secret":false}`,
+			filepath.Join(NameValues, "enabled.json"):   `{"name":"enabled","value":"true","from_secret":false}`,
+			filepath.Join(NameTriggers, "t1.json"):      `{"name":"t1","disabled":true}`,
+			filepath.Join(NameTriggers, "t1.yml"):       "# keep me\nname: t1\ndisabled: false # updated remotely\n",
+			filepath.Join(NameFunctions, "sum.js"):      "exports = function() {};",
+			filepath.Join(NameHosting, "metadata.json"): `[]`,
+		} {
+			assert.Nil(t, os.MkdirAll(filepath.Dir(filepath.Join(tmpDir, path)), os.ModePerm))
+			assert.Nil(t, ioutil.WriteFile(filepath.Join(tmpDir, path), []byte(contents), 0666))
+		}
+
+		return tmpDir, cleanupTmpDir
+	}
+
+	t.Run("should rewrite json files as yaml", func(t *testing.T) {
+		tmpDir, cleanupTmpDir := setup(t)
+		defer cleanupTmpDir()
+
+		assert.Nil(t, ConvertFormat(tmpDir, FileFormatYAML))
+
+		for _, tc := range []struct {
+			path     string
+			contents string
+		}{
+			{FileRealmConfigYAML.String(), "config_version: 20210101\nname: convert\nallowed_request_origins: []\n"},
+			{filepath.Join(NameValues, "version.yaml"), "name: version\nvalue: \"1.0\"\nfrom_secret: false\n"},
+			{filepath.Join(NameValues, "enabled.yaml"), "name: enabled\nvalue: \"true\"\nfrom_secret: false\n"},
+			{filepath.Join(NameTriggers, "t1.yml"), "# keep me\nname: t1\ndisabled: true # updated remotely\n"},
+			{filepath.Join(NameFunctions, "sum.js"), "exports = function() {};"},
+			{filepath.Join(NameHosting, "metadata.json"), "[]"},
+		} {
+			data, err := ioutil.ReadFile(filepath.Join(tmpDir, tc.path))
+			assert.Nil(t, err)
+			assert.Equal(t, tc.contents, string(data))
+		}
+
+		for _, path := range []string{
+			FileRealmConfig.String(),
+			filepath.Join(NameValues, "version.json"),
+			filepath.Join(NameTriggers, "t1.json"),
+		} {
+			_, err := os.Stat(filepath.Join(tmpDir, path))
+			assert.True(t, os.IsNotExist(err), "expected %s to be removed", path)
+		}
+
+		app, err := LoadApp(tmpDir)
+		assert.Nil(t, err)
+		assert.Equal(t, FileRealmConfigYAML, app.Config)
+		assert.Equal(t, []map[string]interface{}{
+			{"name": "enabled", "value": "true", "from_secret": false},
+			{"name": "version", "value": "1.0", "from_secret": false},
+		}, app.AppData.(*AppRealmConfigJSON).Values)
+	})
+
+	t.Run("should only update existing yaml files when writing json", func(t *testing.T) {
+		tmpDir, cleanupTmpDir := setup(t)
+		defer cleanupTmpDir()
+
+		assert.Nil(t, ConvertFormat(tmpDir, FileFormatJSON))
+
+		for _, path := range []string{
+			FileRealmConfig.String(),
+			filepath.Join(NameValues, "version.json"),
+			filepath.Join(NameTriggers, "t1.yml"),
+		} {
+			_, err := os.Stat(filepath.Join(tmpDir, path))
+			assert.Nil(t, err)
+		}
+
+		_, err := os.Stat(filepath.Join(tmpDir, NameTriggers, "t1.json"))
+		assert.True(t, os.IsNotExist(err), "expected t1.json to be removed")
+	})
+}
@@ -0,0 +1,131 @@
+package local
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// AppFunction is a function of a local Realm app
+type AppFunction struct {
+	Name    string
+	Private bool
+	Source  string
+	Config  map[string]interface{}
+}
+
+// AppFunctions returns the functions of the local Realm app data
+func AppFunctions(appData AppData) []AppFunction {
+	var functions []AppFunction
+
+	switch ad := appData.(type) {
+	case *AppRealmConfigJSON:
+		for _, config := range ad.Functions.Configs {
+			name, _ := config["name"].(string)
+			functions = append(functions, newAppFunction(config, ad.Functions.Sources[filepath.FromSlash(name)+extJS]))
+		}
+	case *AppConfigJSON:
+		functions = appFunctionsV1(ad.AppStructureV1)
+	case *AppStitchJSON:
+		functions = appFunctionsV1(ad.AppStructureV1)
+	}
+
+	return functions
+}
+
+func appFunctionsV1(ad AppStructureV1) []AppFunction {
+	functions := make([]AppFunction, 0, len(ad.Functions))
+	for _, fn := range ad.Functions {
+		config, _ := fn[NameConfig].(map[string]interface{})
+		source, _ := fn[NameSource].(string)
+		functions = append(functions, newAppFunction(config, source))
+	}
+	return functions
+}
+
+func newAppFunction(config map[string]interface{}, source string) AppFunction {
+	name, _ := config["name"].(string)
+	private, _ := config["private"].(bool)
+	return AppFunction{name, private, source, config}
+}
+
+// FunctionSourcePath returns the path to the source of the named function in the local Realm app,
+// this is the TypeScript source of a function bundled from TypeScript
+func (a App) FunctionSourcePath(name string) string {
+	if _, ok := a.AppData.(*AppRealmConfigJSON); ok {
+		path := filepath.Join(a.RootDir, NameFunctions, filepath.FromSlash(name))
+		if _, err := os.Stat(path + extTS); err == nil {
+			return path + extTS
+		}
+		return path + extJS
+	}
+	return filepath.Join(a.RootDir, NameFunctions, name, FileSource.String())
+}
+
+// FunctionSourceMap returns the source map of the named function
+// if it was bundled from the local sources
+func (a App) FunctionSourceMap(name string) (string, bool) {
+	ad, ok := a.AppData.(*AppRealmConfigJSON)
+	if !ok {
+		return "", false
+	}
+	sourceMap, ok := ad.Functions.SourceMaps[filepath.FromSlash(name)+extJS]
+	return sourceMap, ok
+}
+
+// WriteFunction writes the function config and source to the local Realm app,
+// replacing the function of the same name if one exists
+func (a App) WriteFunction(fn AppFunction) error {
+	config := make(map[string]interface{}, len(fn.Config)+1)
+	for k, v := range fn.Config {
+		config[k] = v
+	}
+	config["name"] = fn.Name
+
+	switch ad := a.AppData.(type) {
+	case *AppRealmConfigJSON:
+		ad.Functions.Configs = replaceFunctionConfig(ad.Functions.Configs, fn.Name, config)
+		if ad.Functions.Sources == nil {
+			ad.Functions.Sources = map[string]string{}
+		}
+		ad.Functions.Sources[filepath.FromSlash(fn.Name)+extJS] = fn.Source
+
+		return writeFunctionsV2(a.RootDir, FunctionsStructure{
+			Configs: ad.Functions.Configs,
+			Sources: map[string]string{filepath.FromSlash(fn.Name) + extJS: fn.Source},
+		})
+	case *AppConfigJSON:
+		return writeFunctionV1(a.RootDir, &ad.AppStructureV1, config, fn.Source)
+	case *AppStitchJSON:
+		return writeFunctionV1(a.RootDir, &ad.AppStructureV1, config, fn.Source)
+	}
+	return errors.New("failed to write function to unknown app structure")
+}
+
+func replaceFunctionConfig(configs []map[string]interface{}, name string, config map[string]interface{}) []map[string]interface{} {
+	for i, c := range configs {
+		if n, _ := c["name"].(string); n == name {
+			configs[i] = config
+			return configs
+		}
+	}
+	return append(configs, config)
+}
+
+func writeFunctionV1(rootDir string, ad *AppStructureV1, config map[string]interface{}, source string) error {
+	function := map[string]interface{}{NameConfig: config, NameSource: source}
+
+	var found bool
+	for i, fn := range ad.Functions {
+		if fnConfig, ok := fn[NameConfig].(map[string]interface{}); ok && fnConfig["name"] == config["name"] {
+			ad.Functions[i] = function
+			found = true
+			break
+		}
+	}
+	if !found {
+		ad.Functions = append(ad.Functions, function)
+	}
+
+	return writeFunctionsV1(rootDir, []map[string]interface{}{function})
+}
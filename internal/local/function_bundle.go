@@ -0,0 +1,262 @@
+package local
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/evanw/esbuild/pkg/api"
+)
+
+const bundleGlobalName = "__realmFunction"
+
+var (
+	// relativeImportPattern matches the import or require of another local source file
+	relativeImportPattern = regexp.MustCompile(`(\bfrom\s*|\bimport\s*\(?\s*|\brequire\s*\(\s*)["']\.{1,2}/`)
+
+	// realmExportsPattern matches the Realm style `exports = ...` of a function
+	realmExportsPattern = regexp.MustCompile(`(?m)^\s*exports\s*=`)
+)
+
+// bundleFunctions bundles each function written in TypeScript or which imports
+// other local source files into a single CommonJS source Realm can run.
+// Only the JavaScript sources of the functions are kept, along with the source maps
+// of the bundled functions; the local sources only imported by them are dropped
+func bundleFunctions(rootDir string, functions FunctionsStructure) (FunctionsStructure, error) {
+	dir := filepath.Join(rootDir, NameFunctions)
+
+	bundled := FunctionsStructure{
+		Configs: functions.Configs,
+		Sources: make(map[string]string, len(functions.Sources)),
+	}
+	for path, src := range functions.Sources {
+		if filepath.Ext(path) == extJS {
+			bundled.Sources[path] = src
+		}
+	}
+
+	entries := make(map[string]struct{}, len(functions.Configs))
+	for _, config := range functions.Configs {
+		if name, _ := config["name"].(string); name != "" {
+			entries[filepath.FromSlash(name)+extJS] = struct{}{}
+		}
+	}
+
+	sourceMaps := map[string]string{}
+	imports := map[string]struct{}{}
+	for _, config := range functions.Configs {
+		name, _ := config["name"].(string)
+		if name == "" {
+			continue
+		}
+
+		entry := filepath.FromSlash(name) + extTS
+		src, ok := functions.Sources[entry]
+		if !ok {
+			entry = filepath.FromSlash(name) + extJS
+			if src, ok = functions.Sources[entry]; !ok || !relativeImportPattern.MatchString(src) {
+				continue
+			}
+		}
+
+		source, sourceMap, inputs, err := bundleFunction(dir, entry, src)
+		if err != nil {
+			return FunctionsStructure{}, err
+		}
+
+		path := filepath.FromSlash(name) + extJS
+		bundled.Sources[path] = source
+		sourceMaps[path] = sourceMap
+
+		for _, input := range inputs {
+			imports[input] = struct{}{}
+		}
+	}
+
+	for path := range imports {
+		if _, ok := entries[path]; !ok {
+			delete(bundled.Sources, path)
+		}
+	}
+
+	if len(sourceMaps) > 0 {
+		bundled.SourceMaps = sourceMaps
+	}
+	return bundled, nil
+}
+
+// bundleFunction bundles the function entry source, relative to the functions directory,
+// and the local sources it imports. Packages are left to be required from the app dependencies.
+// The bundled source and source map are returned along with the paths of the bundled sources
+func bundleFunction(dir, entry, src string) (string, string, []string, error) {
+	entryPath := filepath.Join(dir, entry)
+
+	var plugins []api.Plugin
+	if realmExportsPattern.MatchString(src) {
+		plugins = append(plugins, realmExportsPlugin(entryPath, src))
+	}
+
+	result := api.Build(api.BuildOptions{
+		EntryPoints:   []string{entryPath},
+		AbsWorkingDir: dir,
+		Outfile:       filepath.Join(dir, strings.TrimSuffix(entry, filepath.Ext(entry))+extJS),
+		Bundle:        true,
+		Write:         false,
+		Format:        api.FormatIIFE,
+		GlobalName:    bundleGlobalName,
+		Footer: map[string]string{
+			"js": fmt.Sprintf("exports = %[1]s && %[1]s.__esModule ? %[1]s.default : %[1]s;", bundleGlobalName),
+		},
+		Platform:  api.PlatformNode,
+		Packages:  api.PackagesExternal,
+		Target:    api.ES2015,
+		Sourcemap: api.SourceMapExternal,
+		Metafile:  true,
+		LogLevel:  api.LogLevelSilent,
+		Plugins:   plugins,
+	})
+	if len(result.Errors) > 0 {
+		return "", "", nil, fmt.Errorf("failed to bundle function source %s: %s", entryPath, bundleErrorMessage(result.Errors[0]))
+	}
+
+	var metafile struct {
+		Inputs map[string]interface{} `json:"inputs"`
+	}
+	if err := json.Unmarshal([]byte(result.Metafile), &metafile); err != nil {
+		return "", "", nil, fmt.Errorf("failed to bundle function source %s: %w", entryPath, err)
+	}
+
+	inputs := make([]string, 0, len(metafile.Inputs))
+	for input := range metafile.Inputs {
+		inputs = append(inputs, filepath.FromSlash(input))
+	}
+
+	var source, sourceMap string
+	for _, file := range result.OutputFiles {
+		if filepath.Ext(file.Path) == extMap {
+			sourceMap = string(file.Contents)
+		} else {
+			source = string(file.Contents)
+		}
+	}
+	return source, sourceMap, inputs, nil
+}
+
+// realmExportsPlugin supports the Realm style `exports = ...` in the entry source,
+// which otherwise only reassigns the exports of the bundled module
+func realmExportsPlugin(entryPath, src string) api.Plugin {
+	loader := api.LoaderJS
+	if filepath.Ext(entryPath) == extTS {
+		loader = api.LoaderTS
+	}
+
+	return api.Plugin{
+		Name: "realm-exports",
+		Setup: func(build api.PluginBuild) {
+			build.OnLoad(api.OnLoadOptions{Filter: "^" + regexp.QuoteMeta(entryPath) + "$"}, func(args api.OnLoadArgs) (api.OnLoadResult, error) {
+				contents := src + "\nmodule.exports = exports;\n"
+				return api.OnLoadResult{Contents: &contents, Loader: loader}, nil
+			})
+		},
+	}
+}
+
+func bundleErrorMessage(msg api.Message) string {
+	if msg.Location == nil {
+		return msg.Text
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", msg.Location.File, msg.Location.Line, msg.Location.Column+1, msg.Text)
+}
+
+// FunctionSourceMapPath returns the path to the source map of the bundled function in the local Realm app
+func FunctionSourceMapPath(rootDir, name string) string {
+	return filepath.Join(rootDir, NameLocalData, NameSourceMaps, filepath.FromSlash(name)+extJS+extMap)
+}
+
+// WriteSourceMaps replaces the source maps written to disk with those of the functions
+// bundled from the local Realm app's sources
+func (a App) WriteSourceMaps() error {
+	ad, ok := a.AppData.(*AppRealmConfigJSON)
+	if !ok {
+		return nil
+	}
+	return writeSourceMaps(a.RootDir, ad.Functions.SourceMaps)
+}
+
+// ReadSourceMaps replaces the source maps of the functions bundled from the local Realm app's
+// sources with those written to disk when the app was last pushed, so the error stack traces
+// of the deployed functions are mapped with the source maps they were deployed with
+func (a App) ReadSourceMaps() error {
+	ad, ok := a.AppData.(*AppRealmConfigJSON)
+	if !ok {
+		return nil
+	}
+
+	dir := filepath.Join(a.RootDir, NameLocalData, NameSourceMaps)
+	if _, err := os.Stat(dir); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	sourceMaps := map[string]string{}
+	if err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(path, extJS+extMap) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		sourceMaps[strings.TrimSuffix(rel, extMap)] = string(data)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if len(sourceMaps) == 0 {
+		return nil
+	}
+	if ad.Functions.SourceMaps == nil {
+		ad.Functions.SourceMaps = sourceMaps
+		return nil
+	}
+	for path, sourceMap := range sourceMaps {
+		ad.Functions.SourceMaps[path] = sourceMap
+	}
+	return nil
+}
+
+// writeSourceMaps replaces the source maps of the local Realm app
+func writeSourceMaps(rootDir string, sourceMaps map[string]string) error {
+	dir := filepath.Join(rootDir, NameLocalData, NameSourceMaps)
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+
+	for path, sourceMap := range sourceMaps {
+		if err := WriteFile(
+			filepath.Join(dir, path+extMap),
+			0666,
+			bytes.NewReader([]byte(sourceMap)),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,288 @@
+package local
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/dop251/goja"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestBundleFunctions(t *testing.T) {
+	helperSrc := `export function shout(s: string): string {
+  return s.toUpperCase() + "!";
+}
+`
+
+	runFunction := func(t *testing.T, src string, args ...interface{}) interface{} {
+		t.Helper()
+
+		vm := goja.New()
+		_, err := vm.RunString(src)
+		assert.Nil(t, err)
+
+		fn, ok := goja.AssertFunction(vm.Get("exports"))
+		assert.True(t, ok, "expected exports to be a function")
+
+		values := make([]goja.Value, 0, len(args))
+		for _, arg := range args {
+			values = append(values, vm.ToValue(arg))
+		}
+
+		result, err := fn(goja.Undefined(), values...)
+		assert.Nil(t, err)
+		return result.Export()
+	}
+
+	for _, tc := range []struct {
+		description string
+		entry       string
+		src         string
+	}{
+		{
+			description: "should bundle a typescript function with a default export",
+			entry:       "greet.ts",
+			src: `import { shout } from "./lib/helper";
+
+export default function(name: string): string {
+  return shout("hello " + name);
+}
+`,
+		},
+		{
+			description: "should bundle a typescript function with a realm style export",
+			entry:       "greet.ts",
+			src: `import { shout } from "./lib/helper";
+
+exports = function(name: string): string {
+  return shout("hello " + name);
+};
+`,
+		},
+		{
+			description: "should bundle a javascript function which requires a local source",
+			entry:       "greet.js",
+			src: `const { shout } = require("./lib/helper");
+
+exports = function(name) {
+  return shout("hello " + name);
+};
+`,
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			tmpDir, teardown, err := u.NewTempDir("")
+			assert.Nil(t, err)
+			defer teardown()
+
+			dir := filepath.Join(tmpDir, NameFunctions)
+			assert.Nil(t, WriteFile(filepath.Join(dir, "lib", "helper.ts"), 0666, strings.NewReader(helperSrc)))
+			assert.Nil(t, WriteFile(filepath.Join(dir, tc.entry), 0666, strings.NewReader(tc.src)))
+
+			functions, err := bundleFunctions(tmpDir, FunctionsStructure{
+				Configs: []map[string]interface{}{{"name": "greet"}},
+				Sources: map[string]string{
+					filepath.Join("lib", "helper.ts"): helperSrc,
+					tc.entry:                          tc.src,
+				},
+			})
+			assert.Nil(t, err)
+
+			assert.Equal(t, 1, len(functions.Sources))
+			assert.Equal(t, "HELLO WORLD!", runFunction(t, functions.Sources["greet.js"], "world"))
+
+			var sourceMap struct {
+				Sources []string `json:"sources"`
+			}
+			assert.Nil(t, json.Unmarshal([]byte(functions.SourceMaps["greet.js"]), &sourceMap))
+			sort.Strings(sourceMap.Sources)
+			assert.Equal(t, []string{tc.entry, "lib/helper.ts"}, sourceMap.Sources)
+
+			_, err = os.Stat(filepath.Join(tmpDir, NameLocalData))
+			assert.True(t, os.IsNotExist(err), "expected no source maps to be written while bundling")
+		})
+	}
+
+	t.Run("should drop the javascript sources only imported by bundled functions", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer teardown()
+
+		helperJS := "exports.shout = (s) => s.toUpperCase() + '!';\n"
+		greetJS := "const { shout } = require('./lib/helper');\nexports = (name) => shout('hello ' + name);\n"
+		otherJS := "exports = () => require('./lib/helper').shout('other');\n"
+
+		dir := filepath.Join(tmpDir, NameFunctions)
+		assert.Nil(t, WriteFile(filepath.Join(dir, "lib", "helper.js"), 0666, strings.NewReader(helperJS)))
+		assert.Nil(t, WriteFile(filepath.Join(dir, "greet.js"), 0666, strings.NewReader(greetJS)))
+		assert.Nil(t, WriteFile(filepath.Join(dir, "other.js"), 0666, strings.NewReader(otherJS)))
+
+		functions, err := bundleFunctions(tmpDir, FunctionsStructure{
+			Configs: []map[string]interface{}{{"name": "greet"}, {"name": "other"}},
+			Sources: map[string]string{
+				filepath.Join("lib", "helper.js"): helperJS,
+				"greet.js":                        greetJS,
+				"other.js":                        otherJS,
+			},
+		})
+		assert.Nil(t, err)
+
+		paths := make([]string, 0, len(functions.Sources))
+		for path := range functions.Sources {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		assert.Equal(t, []string{"greet.js", "other.js"}, paths)
+		assert.Equal(t, "HELLO WORLD!", runFunction(t, functions.Sources["greet.js"], "world"))
+	})
+
+	t.Run("should leave javascript functions without local imports as is", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer teardown()
+
+		src := "const _ = require(\"lodash\");\nexports = function() { return 1; };\n"
+
+		functions, err := bundleFunctions(tmpDir, FunctionsStructure{
+			Configs: []map[string]interface{}{{"name": "one"}},
+			Sources: map[string]string{"one.js": src},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, FunctionsStructure{
+			Configs: []map[string]interface{}{{"name": "one"}},
+			Sources: map[string]string{"one.js": src},
+		}, functions)
+	})
+
+	t.Run("should return an error when a function fails to bundle", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer teardown()
+
+		src := "import { missing } from \"./missing\";\nexport default () => missing;\n"
+		assert.Nil(t, WriteFile(filepath.Join(tmpDir, NameFunctions, "broken.ts"), 0666, strings.NewReader(src)))
+
+		_, err = bundleFunctions(tmpDir, FunctionsStructure{
+			Configs: []map[string]interface{}{{"name": "broken"}},
+			Sources: map[string]string{"broken.ts": src},
+		})
+		assert.Equal(t, errors.New(`failed to bundle function source `+filepath.Join(tmpDir, NameFunctions, "broken.ts")+`: broken.ts:1:25: Could not resolve "./missing"`), err)
+	})
+}
+
+func TestLoadAppBundledFunctions(t *testing.T) {
+	tmpDir, teardown, err := u.NewTempDir("")
+	assert.Nil(t, err)
+	defer teardown()
+
+	app := NewApp(tmpDir, "test-app-abcde", "test-app", realm.LocationVirginia, realm.DeploymentModelGlobal, realm.EnvironmentNone, realm.AppConfigVersion20210101)
+	assert.Nil(t, app.Write())
+	assert.Nil(t, app.WriteFunction(AppFunction{Name: "plain", Source: "exports = () => 'plain';\n"}))
+
+	dir := filepath.Join(tmpDir, NameFunctions)
+	assert.Nil(t, WriteFile(filepath.Join(dir, "util.ts"), 0666, strings.NewReader("export const value: string = 'bundled';\n")))
+	assert.Nil(t, WriteFile(filepath.Join(dir, "typed.ts"), 0666, strings.NewReader("import { value } from './util';\nexport default () => value;\n")))
+	assert.Nil(t, WriteFile(filepath.Join(dir, FileConfig.String()), 0666, strings.NewReader(`[{"name": "plain"}, {"name": "typed"}]`)))
+
+	loaded, err := LoadApp(tmpDir)
+	assert.Nil(t, err)
+
+	functions := AppFunctions(loaded.AppData)
+	assert.Equal(t, 2, len(functions))
+	assert.Equal(t, "exports = () => 'plain';\n", functions[0].Source)
+	assert.True(t, strings.Contains(functions[1].Source, "exports = __realmFunction"), "expected typed function to be bundled:\n%s", functions[1].Source)
+
+	_, ok := loaded.FunctionSourceMap("plain")
+	assert.False(t, ok, "expected plain function to not have a source map")
+	_, ok = loaded.FunctionSourceMap("typed")
+	assert.True(t, ok, "expected typed function to have a source map")
+
+	assert.Equal(t, filepath.Join(dir, "typed.ts"), loaded.FunctionSourcePath("typed"))
+	assert.Equal(t, filepath.Join(dir, "plain.js"), loaded.FunctionSourcePath("plain"))
+}
+
+func TestAppWriteSourceMaps(t *testing.T) {
+	tmpDir, teardown, err := u.NewTempDir("")
+	assert.Nil(t, err)
+	defer teardown()
+
+	app := NewApp(tmpDir, "test-app-abcde", "test-app", realm.LocationVirginia, realm.DeploymentModelGlobal, realm.EnvironmentNone, realm.AppConfigVersion20210101)
+	assert.Nil(t, app.Write())
+
+	dir := filepath.Join(tmpDir, NameFunctions)
+	assert.Nil(t, WriteFile(filepath.Join(dir, "typed.ts"), 0666, strings.NewReader("export default (): number => 1;\n")))
+	assert.Nil(t, WriteFile(filepath.Join(dir, FileConfig.String()), 0666, strings.NewReader(`[{"name": "typed"}]`)))
+	assert.Nil(t, WriteFile(FunctionSourceMapPath(tmpDir, "stale"), 0666, strings.NewReader("{}")))
+
+	loaded, err := LoadApp(tmpDir)
+	assert.Nil(t, err)
+
+	_, err = os.Stat(FunctionSourceMapPath(tmpDir, "typed"))
+	assert.True(t, os.IsNotExist(err), "expected loading the app to not write the source maps")
+
+	assert.Nil(t, loaded.WriteSourceMaps())
+
+	sourceMap, ok := loaded.FunctionSourceMap("typed")
+	assert.True(t, ok, "expected typed function to have a source map")
+
+	saved, err := ioutil.ReadFile(FunctionSourceMapPath(tmpDir, "typed"))
+	assert.Nil(t, err)
+	assert.Equal(t, sourceMap, string(saved))
+
+	_, err = os.Stat(FunctionSourceMapPath(tmpDir, "stale"))
+	assert.True(t, os.IsNotExist(err), "expected the stale source maps to be removed")
+}
+
+func TestAppReadSourceMaps(t *testing.T) {
+	tmpDir, teardown, err := u.NewTempDir("")
+	assert.Nil(t, err)
+	defer teardown()
+
+	app := NewApp(tmpDir, "test-app-abcde", "test-app", realm.LocationVirginia, realm.DeploymentModelGlobal, realm.EnvironmentNone, realm.AppConfigVersion20210101)
+	assert.Nil(t, app.Write())
+
+	dir := filepath.Join(tmpDir, NameFunctions)
+	assert.Nil(t, WriteFile(filepath.Join(dir, "typed.ts"), 0666, strings.NewReader("export default (): number => 1;\n")))
+	assert.Nil(t, WriteFile(filepath.Join(dir, FileConfig.String()), 0666, strings.NewReader(`[{"name": "typed"}, {"name": "nested/deployed"}]`)))
+
+	t.Run("should leave the bundled source maps as is without any written to disk", func(t *testing.T) {
+		loaded, err := LoadApp(tmpDir)
+		assert.Nil(t, err)
+
+		bundled, ok := loaded.FunctionSourceMap("typed")
+		assert.True(t, ok, "expected typed function to have a source map")
+
+		assert.Nil(t, loaded.ReadSourceMaps())
+
+		sourceMap, ok := loaded.FunctionSourceMap("typed")
+		assert.True(t, ok, "expected typed function to have a source map")
+		assert.Equal(t, bundled, sourceMap)
+	})
+
+	t.Run("should replace the bundled source maps with those written to disk", func(t *testing.T) {
+		assert.Nil(t, WriteFile(FunctionSourceMapPath(tmpDir, "typed"), 0666, strings.NewReader(`{"version":3}`)))
+		assert.Nil(t, WriteFile(FunctionSourceMapPath(tmpDir, "nested/deployed"), 0666, strings.NewReader(`{"version":3,"sources":[]}`)))
+
+		loaded, err := LoadApp(tmpDir)
+		assert.Nil(t, err)
+
+		assert.Nil(t, loaded.ReadSourceMaps())
+
+		sourceMap, ok := loaded.FunctionSourceMap("typed")
+		assert.True(t, ok, "expected typed function to have a source map")
+		assert.Equal(t, `{"version":3}`, sourceMap)
+
+		sourceMap, ok = loaded.FunctionSourceMap("nested/deployed")
+		assert.True(t, ok, "expected nested function to have a source map")
+		assert.Equal(t, `{"version":3,"sources":[]}`, sourceMap)
+	})
+}
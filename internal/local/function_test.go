@@ -0,0 +1,152 @@
+package local
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestAppWriteFunction(t *testing.T) {
+	for _, tc := range []struct {
+		configVersion realm.AppConfigVersion
+		config        File
+		sourcePath    []string
+	}{
+		{realm.AppConfigVersion20210101, FileRealmConfig, []string{NameFunctions, "greet.js"}},
+		{realm.AppConfigVersion20200603, FileConfig, []string{NameFunctions, "greet", FileSource.String()}},
+		{realm.AppConfigVersion20180301, FileStitch, []string{NameFunctions, "greet", FileSource.String()}},
+	} {
+		t.Run(fmt.Sprintf("with config version %d should add and then replace a function", tc.configVersion), func(t *testing.T) {
+			tmpDir, cleanupTmpDir, err := u.NewTempDir("")
+			assert.Nil(t, err)
+			defer cleanupTmpDir()
+
+			app := NewApp(tmpDir, "test-app-abcde", "test-app", realm.LocationIreland, realm.DeploymentModelGlobal, realm.EnvironmentNone, tc.configVersion)
+			app.Config = tc.config
+			assert.Nil(t, app.Write())
+
+			sourcePath := filepath.Join(append([]string{tmpDir}, tc.sourcePath...)...)
+			assert.Equal(t, sourcePath, app.FunctionSourcePath("greet"))
+
+			assert.Nil(t, app.WriteFunction(AppFunction{
+				Name:   "greet",
+				Source: "exports = () => 'hello';",
+				Config: map[string]interface{}{"private": true},
+			}))
+			assert.Nil(t, app.WriteFunction(AppFunction{
+				Name:   "greet",
+				Source: "exports = () => 'hi';",
+				Config: map[string]interface{}{"private": false},
+			}))
+
+			src, err := ioutil.ReadFile(sourcePath)
+			assert.Nil(t, err)
+			assert.Equal(t, "exports = () => 'hi';", string(src))
+
+			loaded, err := LoadApp(tmpDir)
+			assert.Nil(t, err)
+			assert.Equal(t, []AppFunction{{
+				Name:   "greet",
+				Source: "exports = () => 'hi';",
+				Config: map[string]interface{}{"name": "greet", "private": false},
+			}}, AppFunctions(loaded.AppData))
+		})
+	}
+}
+
+func TestAppWriteFunctionYAML(t *testing.T) {
+	wd, wdErr := os.Getwd()
+	assert.Nil(t, wdErr)
+
+	setup := func(t *testing.T) (string, func()) {
+		t.Helper()
+
+		tmpDir, cleanupTmpDir, err := u.NewTempDir("")
+		assert.Nil(t, err)
+
+		assert.Nil(t, copyTestdata(filepath.Join(wd, "testdata", "yaml_project"), tmpDir))
+		return tmpDir, cleanupTmpDir
+	}
+
+	t.Run("should update the existing yaml functions config and preserve its comments", func(t *testing.T) {
+		tmpDir, cleanupTmpDir := setup(t)
+		defer cleanupTmpDir()
+
+		configPath := filepath.Join(tmpDir, NameFunctions, FileConfigYAML.String())
+		assert.Nil(t, ioutil.WriteFile(configPath, []byte(`# the app functions
+- name: sum # adds numbers
+  private: false
+  run_as_system: true
+`), 0666))
+
+		app, err := LoadApp(tmpDir)
+		assert.Nil(t, err)
+
+		assert.Nil(t, app.WriteFunction(AppFunction{
+			Name:   "greet",
+			Source: "exports = () => 'hello';",
+			Config: map[string]interface{}{"private": true},
+		}))
+
+		_, err = os.Stat(filepath.Join(tmpDir, NameFunctions, FileConfig.String()))
+		assert.True(t, os.IsNotExist(err), "expected no json functions config to be written")
+
+		data, err := ioutil.ReadFile(configPath)
+		assert.Nil(t, err)
+		assert.Equal(t, `# the app functions
+- name: sum # adds numbers
+  private: false
+  run_as_system: true
+- name: greet
+  private: true
+`, string(data))
+
+		loaded, err := LoadApp(tmpDir)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(AppFunctions(loaded.AppData)))
+	})
+
+	t.Run("should error when the functions config exists in multiple formats", func(t *testing.T) {
+		tmpDir, cleanupTmpDir := setup(t)
+		defer cleanupTmpDir()
+
+		app, err := LoadApp(tmpDir)
+		assert.Nil(t, err)
+
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(tmpDir, NameFunctions, FileConfig.String()), []byte("[]"), 0666))
+
+		err = app.WriteFunction(AppFunction{Name: "greet", Source: "exports = () => 'hello';"})
+		assert.Equal(t, errors.New("found multiple files for "+filepath.Join(tmpDir, NameFunctions, NameConfig)+", only one of [.json, .yaml, .yml] may be used"), err)
+	})
+}
+
+func copyTestdata(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		if info.IsDir() {
+			return os.MkdirAll(target, os.ModePerm)
+		}
+
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(target, data, 0666)
+	})
+}
@@ -0,0 +1,390 @@
+package local
+
+import (
+	"crypto/md5"
+	"encoding/gob"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultLoadWorkers = 16
+)
+
+// LoadOptions are the options used to load a local Realm app
+type LoadOptions struct {
+	// CachePath is the filepath of the cache of parsed app files,
+	// files are parsed every time the app is loaded if this is empty
+	CachePath string
+
+	// Workers is the max number of files read and parsed at once
+	Workers int
+
+	// Profile records the time spent loading each of the app components if set
+	Profile *LoadProfile
+}
+
+// LoadProfile is the time spent loading a local Realm app
+type LoadProfile struct {
+	Total      time.Duration
+	Components []LoadProfileComponent
+
+	mu sync.Mutex
+}
+
+// LoadProfileComponent is the time spent loading a local Realm app component
+type LoadProfileComponent struct {
+	Name     string
+	Files    int
+	Cached   int
+	Duration time.Duration
+}
+
+// add reserves a spot for the component so components are reported
+// in the order they are loaded rather than the order they finish
+func (p *LoadProfile) add(name string) int {
+	if p == nil {
+		return -1
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.Components = append(p.Components, LoadProfileComponent{Name: name})
+	return len(p.Components) - 1
+}
+
+func (p *LoadProfile) set(idx int, stats *loadStats, duration time.Duration) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	p.Components[idx].Files = stats.files
+	p.Components[idx].Cached = stats.cached
+	p.Components[idx].Duration = duration
+}
+
+// loader reads and parses the local Realm app files
+type loader struct {
+	workers chan struct{}
+	cache   *appFileCache
+	profile *LoadProfile
+	stats   *loadStats
+}
+
+type loadStats struct {
+	mu     sync.Mutex
+	files  int
+	cached int
+}
+
+func (s *loadStats) record(cached bool) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.files++
+	if cached {
+		s.cached++
+	}
+}
+
+func newLoader(opts LoadOptions) (*loader, error) {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = defaultLoadWorkers
+	}
+
+	var cache *appFileCache
+	if opts.CachePath != "" {
+		c, err := loadAppFileCache(opts.CachePath)
+		if err != nil {
+			return nil, err
+		}
+		cache = c
+	}
+
+	return &loader{
+		workers: make(chan struct{}, workers),
+		cache:   cache,
+		profile: opts.Profile,
+	}, nil
+}
+
+// load runs the named app component's load function as part of the group
+func (l *loader) load(g *loadGroup, component string, fn func(l *loader) error) {
+	idx := l.profile.add(component)
+
+	g.Go(func() error {
+		cl := *l
+		cl.stats = &loadStats{}
+
+		start := time.Now()
+		err := fn(&cl)
+
+		l.profile.set(idx, cl.stats, time.Since(start))
+		return err
+	})
+}
+
+// readData reads the file at the provided path and returns its contents as JSON,
+// a missing file returns no data and no error
+func (l *loader) readData(path string) ([]byte, error) {
+	l.workers <- struct{}{}
+	defer func() { <-l.workers }()
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	l.stats.record(false)
+
+	return fileToJSON(path, data)
+}
+
+// parseData parses the JSON or YAML file at the provided path into out, reusing the value
+// parsed when the file was last loaded if its contents have not changed since,
+// a missing file leaves out unset and returns no error
+func (l *loader) parseData(path string, out interface{}) error {
+	l.workers <- struct{}{}
+	defer func() { <-l.workers }()
+
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	entry, entryOK := l.cache.get(path)
+	if entryOK &&
+		entry.Size == fileInfo.Size() &&
+		entry.LastModified == fileInfo.ModTime().UnixNano() &&
+		entry.assign(out) {
+		l.stats.record(true)
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	hash := fmt.Sprintf("%x", md5.Sum(data))
+	if entryOK && entry.Hash == hash && entry.assign(out) {
+		entry.Size = fileInfo.Size()
+		entry.LastModified = fileInfo.ModTime().UnixNano()
+		l.cache.set(path, entry)
+
+		l.stats.record(true)
+		return nil
+	}
+
+	jsonData, err := fileToJSON(path, data)
+	if err != nil {
+		return err
+	}
+	if err := unmarshalJSON(jsonData, out); err != nil {
+		return err
+	}
+	l.stats.record(false)
+
+	if len(jsonData) == 0 {
+		return nil
+	}
+
+	l.cache.set(path, appFileCacheEntry{
+		Hash:         hash,
+		Size:         fileInfo.Size(),
+		LastModified: fileInfo.ModTime().UnixNano(),
+		Value:        reflect.ValueOf(out).Elem().Interface(),
+	})
+	return nil
+}
+
+// readSource reads the source file at the provided path
+func (l *loader) readSource(path string) (string, error) {
+	l.workers <- struct{}{}
+	defer func() { <-l.workers }()
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	l.stats.record(false)
+
+	return string(data), nil
+}
+
+// loadGroup runs functions concurrently and reports the error of the first function,
+// in the order the functions were added, which fails
+type loadGroup struct {
+	wg   sync.WaitGroup
+	errs []*error
+}
+
+// Go runs the function in a new goroutine
+func (g *loadGroup) Go(fn func() error) {
+	err := new(error)
+	g.errs = append(g.errs, err)
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		*err = fn()
+	}()
+}
+
+// Wait blocks until all of the functions have returned
+func (g *loadGroup) Wait() error {
+	g.wg.Wait()
+	for _, err := range g.errs {
+		if *err != nil {
+			return *err
+		}
+	}
+	return nil
+}
+
+func init() {
+	// the parsed values are stored in the cache as interfaces, so their types must be registered
+	gob.Register(map[string]interface{}{})
+	gob.Register([]interface{}{})
+	gob.Register([]map[string]interface{}{})
+}
+
+// appFileCache stores the parsed values of the local app files, keyed by filepath,
+// so the files which have not changed since the app was last loaded are not parsed again
+type appFileCache struct {
+	path    string
+	dirty   bool
+	mu      sync.Mutex
+	entries map[string]appFileCacheEntry
+	visited map[string]struct{}
+}
+
+type appFileCacheEntry struct {
+	Hash         string
+	Size         int64
+	LastModified int64
+	Value        interface{}
+}
+
+// assign sets out to the cached value, and reports whether the value is of the type out points to
+func (entry appFileCacheEntry) assign(out interface{}) bool {
+	v := reflect.ValueOf(out).Elem()
+	cached := reflect.ValueOf(entry.Value)
+	if !cached.IsValid() || cached.Type() != v.Type() {
+		return false
+	}
+	v.Set(cached)
+	return true
+}
+
+func loadAppFileCache(cachePath string) (*appFileCache, error) {
+	cache := appFileCache{
+		path:    cachePath,
+		entries: map[string]appFileCacheEntry{},
+		visited: map[string]struct{}{},
+	}
+
+	file, err := os.Open(cachePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &cache, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	if err := gob.NewDecoder(file).Decode(&cache.entries); err != nil {
+		// a corrupt cache is discarded and rebuilt
+		cache.entries = map[string]appFileCacheEntry{}
+		cache.dirty = true
+	}
+	return &cache, nil
+}
+
+func (cache *appFileCache) get(path string) (appFileCacheEntry, bool) {
+	if cache == nil {
+		return appFileCacheEntry{}, false
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	cache.visited[path] = struct{}{}
+
+	entry, ok := cache.entries[path]
+	return entry, ok
+}
+
+func (cache *appFileCache) set(path string, entry appFileCacheEntry) {
+	if cache == nil {
+		return
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	cache.dirty = true
+	cache.entries[path] = entry
+}
+
+// save writes the cache to disk, removing the entries of files
+// within the app root directory which no longer exist
+func (cache *appFileCache) save(rootDir string) error {
+	if cache == nil {
+		return nil
+	}
+
+	prefix := rootDir + string(filepath.Separator)
+	for path := range cache.entries {
+		if _, ok := cache.visited[path]; ok || !strings.HasPrefix(path, prefix) {
+			continue
+		}
+		delete(cache.entries, path)
+		cache.dirty = true
+	}
+
+	if !cache.dirty {
+		return nil
+	}
+
+	dir := filepath.Dir(cache.path)
+	if err := mkdir(dir); err != nil {
+		return err
+	}
+
+	// the cache is written to a temporary file and then renamed, so that another
+	// process loading an app at the same time never reads a partially written cache
+	file, err := ioutil.TempFile(dir, filepath.Base(cache.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name()) // no longer exists once renamed
+
+	if err := gob.NewEncoder(file).Encode(cache.entries); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), cache.path)
+}
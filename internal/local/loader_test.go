@@ -0,0 +1,235 @@
+package local
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestLoadAppWithOptions(t *testing.T) {
+	wd, wdErr := os.Getwd()
+	assert.Nil(t, wdErr)
+
+	t.Run("should load the same app data regardless of the number of workers", func(t *testing.T) {
+		for _, project := range []string{"full_project", "data_sources", "yaml_project"} {
+			expected, err := LoadAppWithOptions(filepath.Join(wd, "testdata", project), LoadOptions{Workers: 1})
+			assert.Nil(t, err)
+
+			actual, err := LoadAppWithOptions(filepath.Join(wd, "testdata", project), LoadOptions{Workers: 64})
+			assert.Nil(t, err)
+
+			assert.Equal(t, expected, actual)
+		}
+	})
+
+	t.Run("should report the time spent loading each component", func(t *testing.T) {
+		var profile LoadProfile
+
+		_, err := LoadAppWithOptions(filepath.Join(wd, "testdata", "data_sources"), LoadOptions{Profile: &profile})
+		assert.Nil(t, err)
+
+		names := make([]string, 0, len(profile.Components))
+		for _, component := range profile.Components {
+			names = append(names, component.Name)
+		}
+		assert.Equal(t, []string{
+			NameSecrets,
+			NameEnvironments,
+			NameValues,
+			NameAuth,
+			NameSync,
+			NameFunctions,
+			NameTriggers,
+			NameGraphQL,
+			NameServices,
+			NameDataSources,
+			NameHTTPEndpoints,
+			"endpoints",
+			NameLogForwarders,
+		}, names)
+
+		dataSources := profile.Components[9]
+		assert.Equal(t, 10, dataSources.Files)
+		assert.Equal(t, 0, dataSources.Cached)
+		assert.True(t, profile.Total > 0, "expected total load time to be recorded")
+	})
+
+	t.Run("should report the first error in the order the components are loaded", func(t *testing.T) {
+		tmpDir, cleanupTmpDir, err := u.NewTempDir("")
+		assert.Nil(t, err)
+		defer cleanupTmpDir()
+
+		for path, contents := range map[string]string{
+			FileRealmConfig.String():                  `{"config_version":20210101,"name":"errors"}`,
+			filepath.Join(NameValues, "value.json"):   `{"name":`,
+			filepath.Join(NameTriggers, "t1.json"):    `{"name":`,
+			filepath.Join(NameEnvironments, "d.json"): `{"values":{}}`,
+		} {
+			assert.Nil(t, os.MkdirAll(filepath.Dir(filepath.Join(tmpDir, path)), os.ModePerm))
+			assert.Nil(t, ioutil.WriteFile(filepath.Join(tmpDir, path), []byte(contents), 0666))
+		}
+
+		for i := 0; i < 10; i++ {
+			_, err := LoadAppWithOptions(tmpDir, LoadOptions{})
+			assert.Equal(t, "unexpected end of JSON input", err.Error())
+		}
+	})
+}
+
+func TestLoadAppWithCache(t *testing.T) {
+	setup := func(t *testing.T) (string, string, func()) {
+		t.Helper()
+
+		tmpDir, cleanupTmpDir, err := u.NewTempDir("")
+		assert.Nil(t, err)
+
+		appDir := filepath.Join(tmpDir, "app")
+		for path, contents := range map[string]string{
+			FileRealmConfig.String():                  `{"config_version":20210101,"name":"cache"}`,
+			filepath.Join(NameValues, "version.json"): `{"name":"version","value":"1.0","from_secret":false}`,
+			filepath.Join(NameTriggers, "t1.yaml"):    "name: t1\ndisabled: false\n",
+		} {
+			assert.Nil(t, os.MkdirAll(filepath.Dir(filepath.Join(appDir, path)), os.ModePerm))
+			assert.Nil(t, ioutil.WriteFile(filepath.Join(appDir, path), []byte(contents), 0666))
+		}
+
+		return appDir, filepath.Join(tmpDir, ".app-cache", "default.gob"), cleanupTmpDir
+	}
+
+	filesLoaded := func(profile *LoadProfile) (int, int) {
+		var files, cached int
+		for _, component := range profile.Components {
+			files += component.Files
+			cached += component.Cached
+		}
+		return files, cached
+	}
+
+	t.Run("should reuse the parsed files which have not changed", func(t *testing.T) {
+		appDir, cachePath, cleanup := setup(t)
+		defer cleanup()
+
+		var first LoadProfile
+		expected, err := LoadAppWithOptions(appDir, LoadOptions{CachePath: cachePath, Profile: &first})
+		assert.Nil(t, err)
+
+		files, cached := filesLoaded(&first)
+		assert.Equal(t, 2, files)
+		assert.Equal(t, 0, cached)
+
+		_, err = os.Stat(cachePath)
+		assert.Nil(t, err)
+
+		var second LoadProfile
+		actual, err := LoadAppWithOptions(appDir, LoadOptions{CachePath: cachePath, Profile: &second})
+		assert.Nil(t, err)
+		assert.Equal(t, expected, actual)
+
+		files, cached = filesLoaded(&second)
+		assert.Equal(t, 2, files)
+		assert.Equal(t, 2, cached)
+	})
+
+	t.Run("should use the cached value of a file without parsing it again", func(t *testing.T) {
+		appDir, cachePath, cleanup := setup(t)
+		defer cleanup()
+
+		_, err := LoadAppWithOptions(appDir, LoadOptions{CachePath: cachePath})
+		assert.Nil(t, err)
+
+		cache, err := loadAppFileCache(cachePath)
+		assert.Nil(t, err)
+
+		// a cached value which no longer matches the file contents can only have come from the cache
+		for path := range cache.entries {
+			cache.get(path) // keep the entries when saved
+		}
+		triggerPath := filepath.Join(appDir, NameTriggers, "t1.yaml")
+		entry := cache.entries[triggerPath]
+		entry.Value = map[string]interface{}{"name": "t1", "disabled": false, "config": map[string]interface{}{"match": nil, "tags": []interface{}{"cached"}}}
+		cache.set(triggerPath, entry)
+		assert.Nil(t, cache.save(appDir))
+
+		app, err := LoadAppWithOptions(appDir, LoadOptions{CachePath: cachePath})
+		assert.Nil(t, err)
+
+		assert.Equal(t, []map[string]interface{}{{
+			"name":     "t1",
+			"disabled": false,
+			"config":   map[string]interface{}{"match": nil, "tags": []interface{}{"cached"}},
+		}}, app.AppData.(*AppRealmConfigJSON).Triggers)
+	})
+
+	t.Run("should parse the files which have changed", func(t *testing.T) {
+		appDir, cachePath, cleanup := setup(t)
+		defer cleanup()
+
+		_, err := LoadAppWithOptions(appDir, LoadOptions{CachePath: cachePath})
+		assert.Nil(t, err)
+
+		triggerPath := filepath.Join(appDir, NameTriggers, "t1.yaml")
+		assert.Nil(t, ioutil.WriteFile(triggerPath, []byte("name: t1\ndisabled: true\n"), 0666))
+
+		// touch the unchanged file so only its contents hash can be used
+		later := time.Now().Add(time.Minute)
+		assert.Nil(t, os.Chtimes(filepath.Join(appDir, NameValues, "version.json"), later, later))
+
+		var profile LoadProfile
+		app, err := LoadAppWithOptions(appDir, LoadOptions{CachePath: cachePath, Profile: &profile})
+		assert.Nil(t, err)
+
+		files, cached := filesLoaded(&profile)
+		assert.Equal(t, 2, files)
+		assert.Equal(t, 1, cached)
+
+		assert.Equal(t, []map[string]interface{}{{"name": "t1", "disabled": true}}, app.AppData.(*AppRealmConfigJSON).Triggers)
+	})
+
+	t.Run("should remove the entries of files which no longer exist", func(t *testing.T) {
+		appDir, cachePath, cleanup := setup(t)
+		defer cleanup()
+
+		_, err := LoadAppWithOptions(appDir, LoadOptions{CachePath: cachePath})
+		assert.Nil(t, err)
+
+		assert.Nil(t, os.Remove(filepath.Join(appDir, NameTriggers, "t1.yaml")))
+
+		_, err = LoadAppWithOptions(appDir, LoadOptions{CachePath: cachePath})
+		assert.Nil(t, err)
+
+		cache, err := loadAppFileCache(cachePath)
+		assert.Nil(t, err)
+		assert.False(t, cache.dirty, "expected the cache to be readable")
+
+		entries := cache.entries
+
+		paths := make([]string, 0, len(entries))
+		for path := range entries {
+			paths = append(paths, path)
+		}
+		assert.Equal(t, []string{filepath.Join(appDir, NameValues, "version.json")}, paths)
+	})
+
+	t.Run("should rebuild a corrupt cache", func(t *testing.T) {
+		appDir, cachePath, cleanup := setup(t)
+		defer cleanup()
+
+		assert.Nil(t, os.MkdirAll(filepath.Dir(cachePath), os.ModePerm))
+		assert.Nil(t, ioutil.WriteFile(cachePath, []byte("{"), 0666))
+
+		_, err := LoadAppWithOptions(appDir, LoadOptions{CachePath: cachePath})
+		assert.Nil(t, err)
+
+		cache, err := loadAppFileCache(cachePath)
+		assert.Nil(t, err)
+		assert.False(t, cache.dirty, "expected the cache to be readable")
+
+		entries := cache.entries
+		assert.Equal(t, 2, len(entries))
+	})
+}
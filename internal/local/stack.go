@@ -0,0 +1,154 @@
+package local
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-sourcemap/sourcemap"
+)
+
+const (
+	// stackFunctionFile is the file name used in the stack traces of the running function
+	stackFunctionFile = "function.js"
+
+	snippetContextLines = 1
+)
+
+// stackLocationPattern matches the file, line and column of an error stack trace frame
+var stackLocationPattern = regexp.MustCompile(`([^\s()]+):(\d+):(\d+)`)
+
+// SourceLocation is a location in a source file of the local Realm app
+type SourceLocation struct {
+	Path    string
+	Line    int
+	Column  int
+	Snippet []string
+}
+
+// String returns the location as "path:line:column", with the path relative to the app root directory
+func (l SourceLocation) String() string {
+	return fmt.Sprintf("%s:%d:%d", l.Path, l.Line, l.Column)
+}
+
+// Lines returns the location followed by the lines of its code snippet
+func (l SourceLocation) Lines() []string {
+	return append([]string{l.String()}, l.Snippet...)
+}
+
+// MapStackTrace maps the function locations found in the error stack trace to the
+// source files of the local Realm app, applying the source maps of bundled functions.
+// Locations of the running function itself are expected to be reported as "function.js"
+func (a App) MapStackTrace(functionName, stack string) []SourceLocation {
+	functions := map[string]struct{}{}
+	for _, fn := range AppFunctions(a.AppData) {
+		functions[fn.Name] = struct{}{}
+	}
+
+	var locations []SourceLocation
+	seen := map[string]struct{}{}
+	sourceMaps := map[string]*sourcemap.Consumer{}
+
+	for _, match := range stackLocationPattern.FindAllStringSubmatch(stack, -1) {
+		name := stackFunctionName(match[1], functionName)
+		if _, ok := functions[name]; !ok {
+			continue
+		}
+
+		line, _ := strconv.Atoi(match[2])
+		column, _ := strconv.Atoi(match[3])
+
+		location, ok := a.functionLocation(name, line, column, sourceMaps)
+		if !ok {
+			continue
+		}
+		if _, ok := seen[location.String()]; ok {
+			continue
+		}
+		seen[location.String()] = struct{}{}
+
+		location.Snippet = a.sourceSnippet(location)
+		locations = append(locations, location)
+	}
+	return locations
+}
+
+func stackFunctionName(file, functionName string) string {
+	if file == stackFunctionFile {
+		return functionName
+	}
+
+	name := strings.TrimPrefix(filepath.ToSlash(file), NameFunctions+"/")
+	name = strings.TrimSuffix(name, extJS)
+	name = strings.TrimSuffix(name, extTS)
+	return strings.TrimSuffix(name, "/"+NameSource)
+}
+
+func (a App) functionLocation(name string, line, column int, sourceMaps map[string]*sourcemap.Consumer) (SourceLocation, bool) {
+	data, bundled := a.FunctionSourceMap(name)
+	if !bundled {
+		path, err := filepath.Rel(a.RootDir, a.FunctionSourcePath(name))
+		if err != nil {
+			return SourceLocation{}, false
+		}
+		return SourceLocation{Path: filepath.ToSlash(path), Line: line, Column: column}, true
+	}
+
+	consumer, ok := sourceMaps[name]
+	if !ok {
+		var err error
+		if consumer, err = sourcemap.Parse("", []byte(data)); err != nil {
+			return SourceLocation{}, false
+		}
+		sourceMaps[name] = consumer
+	}
+
+	// source map columns are zero based while stack trace columns are one based
+	source, _, sourceLine, sourceColumn, ok := consumer.Source(line, column-1)
+	if !ok {
+		return SourceLocation{}, false
+	}
+	return SourceLocation{
+		Path:   NameFunctions + "/" + filepath.ToSlash(source),
+		Line:   sourceLine,
+		Column: sourceColumn + 1,
+	}, true
+}
+
+// sourceSnippet returns the source lines surrounding the location, with the location's line marked
+func (a App) sourceSnippet(location SourceLocation) []string {
+	data, err := ioutil.ReadFile(filepath.Join(a.RootDir, filepath.FromSlash(location.Path)))
+	if err != nil {
+		return nil
+	}
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	if location.Line < 1 || location.Line > len(lines) {
+		return nil
+	}
+
+	from, to := location.Line-snippetContextLines, location.Line+snippetContextLines
+	if from < 1 {
+		from = 1
+	}
+	if to > len(lines) {
+		to = len(lines)
+	}
+
+	width := len(strconv.Itoa(to))
+
+	snippet := make([]string, 0, to-from+2)
+	for n := from; n <= to; n++ {
+		marker := " "
+		if n == location.Line {
+			marker = ">"
+		}
+		snippet = append(snippet, fmt.Sprintf("%s %*d | %s", marker, width, n, lines[n-1]))
+		if n == location.Line && location.Column > 0 {
+			snippet = append(snippet, fmt.Sprintf("  %*s | %s^", width, "", strings.Repeat(" ", location.Column-1)))
+		}
+	}
+	return snippet
+}
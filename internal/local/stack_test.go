@@ -0,0 +1,86 @@
+package local
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestAppMapStackTrace(t *testing.T) {
+	tmpDir, teardown, err := u.NewTempDir("")
+	assert.Nil(t, err)
+	defer teardown()
+
+	app := NewApp(tmpDir, "test-app-abcde", "test-app", realm.LocationVirginia, realm.DeploymentModelGlobal, realm.EnvironmentNone, realm.AppConfigVersion20210101)
+	assert.Nil(t, app.Write())
+	assert.Nil(t, app.WriteFunction(AppFunction{
+		Name:   "plain",
+		Source: "exports = function() {\n  const user = undefined;\n  return user.name;\n};\n",
+	}))
+
+	dir := filepath.Join(tmpDir, NameFunctions)
+	assert.Nil(t, WriteFile(filepath.Join(dir, "lib", "check.ts"), 0666, strings.NewReader(`export function check(value: number): number {
+  if (value < 0) {
+    throw new Error("negative");
+  }
+  return value;
+}
+`)))
+	assert.Nil(t, WriteFile(filepath.Join(dir, "typed.ts"), 0666, strings.NewReader(`import { check } from "./lib/check";
+
+export default function(value: number): number {
+  return check(value);
+}
+`)))
+	assert.Nil(t, WriteFile(filepath.Join(dir, FileConfig.String()), 0666, strings.NewReader(`[{"name": "plain"}, {"name": "typed"}]`)))
+
+	loaded, err := LoadApp(tmpDir)
+	assert.Nil(t, err)
+
+	t.Run("should map the running function locations to its local source", func(t *testing.T) {
+		locations := loaded.MapStackTrace("plain", `TypeError: Cannot access member 'name' of undefined
+	at exports (function.js:3:14(5))
+	at function_wrapper.js:5:30(18)
+	at <eval>:13:8(3)
+	at native`)
+
+		assert.Equal(t, []SourceLocation{{
+			Path:   "functions/plain.js",
+			Line:   3,
+			Column: 14,
+			Snippet: []string{
+				"  2 |   const user = undefined;",
+				"> 3 |   return user.name;",
+				"    |              ^",
+				"  4 | };",
+			},
+		}}, locations)
+		assert.Equal(t, "functions/plain.js:3:14", locations[0].String())
+	})
+
+	t.Run("should map the locations of a bundled function through its source map", func(t *testing.T) {
+		source := AppFunctions(loaded.AppData)[1].Source
+
+		var line, column int
+		for i, l := range strings.Split(source, "\n") {
+			if idx := strings.Index(l, "throw new Error"); idx >= 0 {
+				line, column = i+1, idx+1
+			}
+		}
+		assert.True(t, line > 0, "expected bundled source to throw the error:\n%s", source)
+
+		locations := loaded.MapStackTrace("other", "Error: negative\n\tat check (typed.js:"+strconv.Itoa(line)+":"+strconv.Itoa(column)+"(7))")
+		assert.Equal(t, 1, len(locations))
+		assert.Equal(t, "functions/lib/check.ts:3:5", locations[0].String())
+		assert.Equal(t, "> 3 |     throw new Error(\"negative\");", locations[0].Snippet[1])
+	})
+
+	t.Run("should ignore the locations of unknown functions", func(t *testing.T) {
+		assert.Equal(t, 0, len(loaded.MapStackTrace("missing", "Error: bad\n\tat exports (function.js:1:1(0))\n\tat other.js:2:3")))
+	})
+}
@@ -2,13 +2,12 @@ package local
 
 import (
 	"bytes"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 )
 
 // GraphQLStructure represents the Realm app graphql structure
@@ -30,31 +29,61 @@ type ServiceStructure struct {
 	Rules            []map[string]interface{} `json:"rules"`
 }
 
-func parseEnvironments(rootDir string) (map[string]map[string]interface{}, error) {
-	out := map[string]map[string]interface{}{}
+func (l *loader) parseEnvironments(rootDir string) (map[string]map[string]interface{}, error) {
+	var names []string
+	paths := map[string]string{}
 
 	dw := directoryWalker{
 		path:      filepath.Join(rootDir, NameEnvironments),
 		onlyFiles: true,
 	}
 	if err := dw.walk(func(file os.FileInfo, path string) error {
-		o, err := parseJSON(path)
-		if err != nil {
-			return err
+		name := file.Name()
+		if ext := filepath.Ext(name); isYAML(ext) {
+			name = strings.TrimSuffix(name, ext) + extJSON // environments are keyed by their json file name
+		}
+		if _, ok := paths[name]; ok {
+			return errDuplicateFile(path)
 		}
-		out[file.Name()] = o
+
+		names = append(names, name)
+		paths[name] = path
 		return nil
 	}); err != nil {
 		return nil, err
 	}
 
-	if len(out) == 0 {
+	if len(names) == 0 {
 		return nil, nil
 	}
+
+	var mu sync.Mutex
+	out := make(map[string]map[string]interface{}, len(names))
+
+	var g loadGroup
+	for _, name := range names {
+		name, path := name, paths[name]
+		g.Go(func() error {
+			o, err := l.parseJSON(path)
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			out[name] = o
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
 	return out, nil
 }
 
-func parseFunctions(rootDir string) ([]map[string]interface{}, error) {
+func (l *loader) parseFunctions(rootDir string) ([]map[string]interface{}, error) {
 	if _, err := os.Stat(rootDir); err != nil {
 		if os.IsNotExist(err) {
 			return nil, nil
@@ -62,37 +91,54 @@ func parseFunctions(rootDir string) ([]map[string]interface{}, error) {
 		return nil, err
 	}
 
-	var out []map[string]interface{}
+	var paths []string
 
 	dw := directoryWalker{path: rootDir, onlyDirs: true}
 	if walkErr := dw.walk(func(file os.FileInfo, path string) error {
 		if strings.Contains(path, nameNodeModules) {
 			return nil // skip node_modules
 		}
+		paths = append(paths, path)
+		return nil
+	}); walkErr != nil {
+		return nil, walkErr
+	}
 
-		config, configErr := parseJSON(filepath.Join(path, FileConfig.String()))
-		if configErr != nil {
-			return configErr
-		}
+	if len(paths) == 0 {
+		return nil, nil
+	}
 
-		src, srcErr := parseJavascript(path, FileSource)
-		if srcErr != nil {
-			return srcErr
-		}
+	out := make([]map[string]interface{}, len(paths))
+
+	var g loadGroup
+	for i, path := range paths {
+		i, path := i, path
+		g.Go(func() error {
+			config, configErr := l.parseJSON(filepath.Join(path, FileConfig.String()))
+			if configErr != nil {
+				return configErr
+			}
 
-		out = append(out, map[string]interface{}{
-			NameConfig: config,
-			NameSource: src,
+			src, srcErr := l.parseJavascript(path, FileSource)
+			if srcErr != nil {
+				return srcErr
+			}
+
+			out[i] = map[string]interface{}{
+				NameConfig: config,
+				NameSource: src,
+			}
+			return nil
 		})
-		return nil
-	}); walkErr != nil {
-		return nil, walkErr
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
 	}
 
 	return out, nil
 }
 
-func parseGraphQL(rootDir string) (GraphQLStructure, bool, error) {
+func (l *loader) parseGraphQL(rootDir string) (GraphQLStructure, bool, error) {
 	dir := filepath.Join(rootDir, NameGraphQL)
 
 	if _, err := os.Stat(dir); err != nil {
@@ -102,12 +148,12 @@ func parseGraphQL(rootDir string) (GraphQLStructure, bool, error) {
 		return GraphQLStructure{}, false, err
 	}
 
-	config, configErr := parseJSON(filepath.Join(dir, FileConfig.String()))
+	config, configErr := l.parseJSON(filepath.Join(dir, FileConfig.String()))
 	if configErr != nil {
 		return GraphQLStructure{}, false, configErr
 	}
 
-	customResolvers, customResolversErr := parseJSONFiles(filepath.Join(dir, NameCustomResolvers))
+	customResolvers, customResolversErr := l.parseJSONFiles(filepath.Join(dir, NameCustomResolvers))
 	if customResolversErr != nil {
 		return GraphQLStructure{}, false, customResolversErr
 	}
@@ -115,41 +161,37 @@ func parseGraphQL(rootDir string) (GraphQLStructure, bool, error) {
 	return GraphQLStructure{config, customResolvers}, true, nil
 }
 
-func parseJavascript(rootDir string, file File) (string, error) {
-	src, err := ioutil.ReadFile(filepath.Join(rootDir, file.String()))
-	if err != nil {
-		return "", err
-	}
-	return string(src), nil
+func (l *loader) parseJavascript(rootDir string, file File) (string, error) {
+	return l.readSource(filepath.Join(rootDir, file.String()))
 }
 
-func parseJSON(path string) (map[string]interface{}, error) {
-	data, dataErr := readFile(path)
-	if dataErr != nil {
-		return nil, dataErr
+func (l *loader) parseJSON(path string) (map[string]interface{}, error) {
+	path, err := resolveFile(path)
+	if err != nil {
+		return nil, err
 	}
 
 	var out map[string]interface{}
-	if err := unmarshalJSON(data, &out); err != nil {
+	if err := l.parseData(path, &out); err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func parseJSONArray(path string) ([]map[string]interface{}, error) {
-	data, dataErr := readFile(path)
-	if dataErr != nil {
-		return nil, dataErr
+func (l *loader) parseJSONArray(path string) ([]map[string]interface{}, error) {
+	path, err := resolveFile(path)
+	if err != nil {
+		return nil, err
 	}
 
 	var out []map[string]interface{}
-	if err := unmarshalJSON(data, &out); err != nil {
+	if err := l.parseData(path, &out); err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func parseJSONFiles(rootDir string) ([]map[string]interface{}, error) {
+func (l *loader) parseJSONFiles(rootDir string) ([]map[string]interface{}, error) {
 	if _, err := os.Stat(rootDir); err != nil {
 		if os.IsNotExist(err) {
 			return nil, nil
@@ -157,83 +199,130 @@ func parseJSONFiles(rootDir string) ([]map[string]interface{}, error) {
 		return nil, err
 	}
 
-	out := make([]map[string]interface{}, 0)
+	var paths []string
+	names := map[string]struct{}{}
 
 	dw := directoryWalker{path: rootDir, onlyFiles: true}
 	if walkErr := dw.walk(func(file os.FileInfo, path string) error {
-		o, err := parseJSON(path)
-		if err != nil {
-			return err
+		name := strings.TrimSuffix(file.Name(), filepath.Ext(file.Name()))
+		if _, ok := names[name]; ok {
+			return errDuplicateFile(path)
 		}
+		names[name] = struct{}{}
 
-		out = append(out, o)
+		paths = append(paths, path)
 		return nil
 	}); walkErr != nil {
 		return nil, walkErr
 	}
 
+	out := make([]map[string]interface{}, len(paths))
+
+	var g loadGroup
+	for i, path := range paths {
+		i, path := i, path
+		g.Go(func() error {
+			o, err := l.parseJSON(path)
+			out[i] = o
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
 	return out, nil
 }
 
-func parseSecrets(rootDir string) (SecretsStructure, error) {
-	path := filepath.Join(rootDir, FileSecrets.String())
-
-	if _, err := os.Stat(path); err != nil {
-		if os.IsNotExist(err) {
-			return SecretsStructure{}, nil
-		}
+func (l *loader) parseSecrets(rootDir string) (SecretsStructure, error) {
+	path, err := resolveFile(filepath.Join(rootDir, FileSecrets.String()))
+	if err != nil {
 		return SecretsStructure{}, err
 	}
 
-	data, dataErr := readFile(path)
+	data, dataErr := l.readData(path) // secrets are never written to the cache
 	if dataErr != nil {
 		return SecretsStructure{}, dataErr
 	}
 
 	var secrets SecretsStructure
-	if err := json.Unmarshal(data, &secrets); err != nil {
+	if err := unmarshalJSON(data, &secrets); err != nil {
 		return SecretsStructure{}, err
 	}
 	return secrets, nil
 }
 
-func parseServices(rootDir string) ([]ServiceStructure, error) {
-	var out []ServiceStructure
+func (l *loader) parseServices(rootDir string) ([]ServiceStructure, error) {
+	var paths []string
 
 	dw := directoryWalker{
 		path:     filepath.Join(rootDir, NameServices),
 		onlyDirs: true,
 	}
 	if walkErr := dw.walk(func(file os.FileInfo, path string) error {
-		var svc ServiceStructure
+		paths = append(paths, path)
+		return nil
+	}); walkErr != nil {
+		return nil, walkErr
+	}
 
-		config, err := parseJSON(filepath.Join(path, FileConfig.String()))
-		if err != nil {
-			return err
-		}
-		svc.Config = config
+	if len(paths) == 0 {
+		return nil, nil
+	}
 
-		webhooks, err := parseFunctions(filepath.Join(path, NameIncomingWebhooks))
-		if err != nil {
-			return err
-		}
-		svc.IncomingWebhooks = webhooks
+	out := make([]ServiceStructure, len(paths))
 
-		rules, err := parseJSONFiles(filepath.Join(path, NameRules))
-		if err != nil {
-			return err
-		}
-		svc.Rules = rules
+	var g loadGroup
+	for i, path := range paths {
+		i, path := i, path
+		g.Go(func() error {
+			var svc ServiceStructure
 
-		out = append(out, svc)
-		return nil
-	}); walkErr != nil {
-		return nil, walkErr
+			config, err := l.parseJSON(filepath.Join(path, FileConfig.String()))
+			if err != nil {
+				return err
+			}
+			svc.Config = config
+
+			webhooks, err := l.parseFunctions(filepath.Join(path, NameIncomingWebhooks))
+			if err != nil {
+				return err
+			}
+			svc.IncomingWebhooks = webhooks
+
+			rules, err := l.parseJSONFiles(filepath.Join(path, NameRules))
+			if err != nil {
+				return err
+			}
+			svc.Rules = rules
+
+			out[i] = svc
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
 	}
 
 	return out, nil
 }
 
+// flattenFunction returns the function config with its source inlined,
+// which converts a function loaded from disk (keyed by config and source)
+// into the shape the function is exported as
+func flattenFunction(function map[string]interface{}) map[string]interface{} {
+	config, ok := function[NameConfig].(map[string]interface{})
+	if !ok {
+		return function
+	}
+	out := make(map[string]interface{}, len(config)+1)
+	for k, v := range config {
+		out[k] = v
+	}
+	out[NameSource] = function[NameSource]
+	return out
+}
+
 func writeSecrets(rootDir string, secrets SecretsStructure) error {
 	if len(secrets.AuthProviders) == 0 && len(secrets.Services) == 0 {
 		return nil
@@ -341,6 +430,7 @@ func writeServices(rootDir string, services []ServiceStructure) error {
 			return err
 		}
 		for _, webhook := range svc.IncomingWebhooks {
+			webhook = flattenFunction(webhook)
 			src, ok := webhook[NameSource].(string)
 			if !ok {
 				return errors.New("error writing services")
@@ -379,7 +469,7 @@ func writeServices(rootDir string, services []ServiceStructure) error {
 				return err
 			}
 			if err := WriteFile(
-				filepath.Join(dirSvc, NameRules, fmt.Sprintf("%s%s", rule["name"], extJSON)),
+				filepath.Join(dirSvc, NameRules, serviceRuleName(rule)+extJSON),
 				0666,
 				bytes.NewReader(data),
 			); err != nil {
@@ -390,6 +480,17 @@ func writeServices(rootDir string, services []ServiceStructure) error {
 	return nil
 }
 
+// serviceRuleName returns the name of the service rule file,
+// the rules of a data source are named by their database and collection
+func serviceRuleName(rule map[string]interface{}) string {
+	if name, ok := rule["name"].(string); ok {
+		return name
+	}
+	database, _ := rule["database"].(string)
+	collection, _ := rule["collection"].(string)
+	return database + "." + collection
+}
+
 func writeTriggers(rootDir string, triggers []map[string]interface{}) error {
 	for _, trigger := range triggers {
 		name, ok := trigger["name"].(string)
@@ -70,62 +70,67 @@ func (a AppDataV1) Environment() realm.Environment {
 
 // LoadData will load the local Realm app data
 func (a *AppDataV1) LoadData(rootDir string) error {
-	secrets, err := parseSecrets(rootDir)
+	l, err := newLoader(LoadOptions{})
 	if err != nil {
 		return err
 	}
-	a.Secrets = secrets
-
-	environments, err := parseEnvironments(rootDir)
-	if err != nil {
-		return err
-	}
-	a.Environments = environments
-
-	values, err := parseJSONFiles(filepath.Join(rootDir, NameValues))
-	if err != nil {
-		return err
-	}
-	a.Values = values
-
-	authProviders, err := parseJSONFiles(filepath.Join(rootDir, NameAuthProviders))
-	if err != nil {
-		return err
-	}
-	a.AuthProviders = authProviders
-
-	functions, err := parseFunctions(filepath.Join(rootDir, NameFunctions))
-	if err != nil {
-		return err
-	}
-	a.Functions = functions
-
-	triggers, err := parseJSONFiles(filepath.Join(rootDir, NameTriggers))
-	if err != nil {
-		return err
-	}
-	a.Triggers = triggers
+	return a.loadData(rootDir, l)
+}
 
-	graphql, ok, err := parseGraphQL(rootDir)
-	if err != nil {
-		return err
-	} else if ok {
-		a.GraphQL = graphql
-	}
+func (a *AppDataV1) loadData(rootDir string, l *loader) error {
+	var g loadGroup
+
+	l.load(&g, NameSecrets, func(l *loader) (err error) {
+		a.Secrets, err = l.parseSecrets(rootDir)
+		return
+	})
+
+	l.load(&g, NameEnvironments, func(l *loader) (err error) {
+		a.Environments, err = l.parseEnvironments(rootDir)
+		return
+	})
+
+	l.load(&g, NameValues, func(l *loader) (err error) {
+		a.Values, err = l.parseJSONFiles(filepath.Join(rootDir, NameValues))
+		return
+	})
+
+	l.load(&g, NameAuthProviders, func(l *loader) (err error) {
+		a.AuthProviders, err = l.parseJSONFiles(filepath.Join(rootDir, NameAuthProviders))
+		return
+	})
+
+	l.load(&g, NameFunctions, func(l *loader) (err error) {
+		a.Functions, err = l.parseFunctions(filepath.Join(rootDir, NameFunctions))
+		return
+	})
+
+	l.load(&g, NameTriggers, func(l *loader) (err error) {
+		a.Triggers, err = l.parseJSONFiles(filepath.Join(rootDir, NameTriggers))
+		return
+	})
+
+	l.load(&g, NameGraphQL, func(l *loader) error {
+		graphql, ok, err := l.parseGraphQL(rootDir)
+		if err != nil {
+			return err
+		} else if ok {
+			a.GraphQL = graphql
+		}
+		return nil
+	})
 
-	services, err := parseServices(rootDir)
-	if err != nil {
-		return err
-	}
-	a.Services = services
+	l.load(&g, NameServices, func(l *loader) (err error) {
+		a.Services, err = l.parseServices(rootDir)
+		return
+	})
 
-	logForwarders, err := parseJSONFiles(filepath.Join(rootDir, NameLogForwarders))
-	if err != nil {
-		return err
-	}
-	a.LogForwarders = logForwarders
+	l.load(&g, NameLogForwarders, func(l *loader) (err error) {
+		a.LogForwarders, err = l.parseJSONFiles(filepath.Join(rootDir, NameLogForwarders))
+		return
+	})
 
-	return nil
+	return g.Wait()
 }
 
 // ConfigData marshals the config data out to JSON
@@ -149,6 +154,7 @@ func (a AppDataV1) ConfigData() ([]byte, error) {
 		DeploymentModel:      a.DeploymentModel(),
 		Environment:          a.Environment(),
 		Security:             a.Security,
+		Hosting:              a.Hosting,
 		CustomUserDataConfig: a.CustomUserDataConfig,
 		Sync:                 a.Sync,
 	}
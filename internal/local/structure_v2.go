@@ -4,9 +4,9 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/10gen/realm-cli/internal/cloud/realm"
 )
@@ -52,6 +52,10 @@ type DataSourceStructure struct {
 type FunctionsStructure struct {
 	Configs []map[string]interface{} `json:"config,omitempty"`
 	Sources map[string]string        `json:"sources,omitempty"`
+
+	// SourceMaps are the source maps of the functions bundled from local sources,
+	// these are only kept locally and never sent to Realm
+	SourceMaps map[string]string `json:"-"`
 }
 
 // HTTPServiceStructure represents the v2 Realm app http endpoint structure
@@ -108,89 +112,91 @@ func (a AppDataV2) Environment() realm.Environment {
 
 // LoadData will load the local Realm app data
 func (a *AppDataV2) LoadData(rootDir string) error {
-	secrets, err := parseSecrets(rootDir)
-	if err != nil {
-		return err
-	}
-	a.Secrets = secrets
-
-	environments, err := parseEnvironments(rootDir)
-	if err != nil {
-		return err
-	}
-	a.Environments = environments
-
-	values, err := parseJSONFiles(filepath.Join(rootDir, NameValues))
-	if err != nil {
-		return err
-	}
-	a.Values = values
-
-	auth, err := parseAuth(rootDir)
-	if err != nil {
-		return err
-	}
-	a.Auth = auth
-
-	sync, err := parseSync(rootDir)
-	if err != nil {
-		return err
-	}
-	a.Sync = sync
-
-	functions, err := parseFunctionsV2(rootDir)
-	if err != nil {
-		return err
-	}
-	a.Functions = functions
-
-	triggers, err := parseJSONFiles(filepath.Join(rootDir, NameTriggers))
-	if err != nil {
-		return err
-	}
-	a.Triggers = triggers
-
-	graphql, ok, err := parseGraphQL(rootDir)
-	if err != nil {
-		return err
-	} else if ok {
-		a.GraphQL = graphql
-	}
-
-	services, err := parseServices(rootDir)
-	if err != nil {
-		return err
-	}
-	a.Services = services
-
-	dataSources, err := parseDataSources(rootDir)
-	if err != nil {
-		return err
-	}
-	a.DataSources = dataSources
-
-	httpServices, err := parseHTTPServices(rootDir)
-	if err != nil {
-		return err
-	}
-	a.HTTPServices = httpServices
-
-	endpoints, err := parseEndpointsV2(rootDir)
+	l, err := newLoader(LoadOptions{})
 	if err != nil {
 		return err
 	}
-	a.Endpoints = endpoints
-
-	logForwarders, err := parseJSONFiles(filepath.Join(rootDir, NameLogForwarders))
-	if err != nil {
-		return err
-	}
-	a.LogForwarders = logForwarders
+	return a.loadData(rootDir, l)
+}
 
-	return nil
+func (a *AppDataV2) loadData(rootDir string, l *loader) error {
+	var g loadGroup
+
+	l.load(&g, NameSecrets, func(l *loader) (err error) {
+		a.Secrets, err = l.parseSecrets(rootDir)
+		return
+	})
+
+	l.load(&g, NameEnvironments, func(l *loader) (err error) {
+		a.Environments, err = l.parseEnvironments(rootDir)
+		return
+	})
+
+	l.load(&g, NameValues, func(l *loader) (err error) {
+		a.Values, err = l.parseJSONFiles(filepath.Join(rootDir, NameValues))
+		return
+	})
+
+	l.load(&g, NameAuth, func(l *loader) (err error) {
+		a.Auth, err = l.parseAuth(rootDir)
+		return
+	})
+
+	l.load(&g, NameSync, func(l *loader) (err error) {
+		a.Sync, err = l.parseSync(rootDir)
+		return
+	})
+
+	l.load(&g, NameFunctions, func(l *loader) (err error) {
+		a.Functions, err = l.parseFunctionsV2(rootDir)
+		return
+	})
+
+	l.load(&g, NameTriggers, func(l *loader) (err error) {
+		a.Triggers, err = l.parseJSONFiles(filepath.Join(rootDir, NameTriggers))
+		return
+	})
+
+	l.load(&g, NameGraphQL, func(l *loader) error {
+		graphql, ok, err := l.parseGraphQL(rootDir)
+		if err != nil {
+			return err
+		} else if ok {
+			a.GraphQL = graphql
+		}
+		return nil
+	})
+
+	l.load(&g, NameServices, func(l *loader) (err error) {
+		a.Services, err = l.parseServices(rootDir)
+		return
+	})
+
+	l.load(&g, NameDataSources, func(l *loader) (err error) {
+		a.DataSources, err = l.parseDataSources(rootDir)
+		return
+	})
+
+	l.load(&g, NameHTTPEndpoints, func(l *loader) (err error) {
+		a.HTTPServices, err = l.parseHTTPServices(rootDir)
+		return
+	})
+
+	// endpoints are loaded from the http_endpoints/config file
+	l.load(&g, "endpoints", func(l *loader) (err error) {
+		a.Endpoints, err = l.parseEndpointsV2(rootDir)
+		return
+	})
+
+	l.load(&g, NameLogForwarders, func(l *loader) (err error) {
+		a.LogForwarders, err = l.parseJSONFiles(filepath.Join(rootDir, NameLogForwarders))
+		return
+	})
+
+	return g.Wait()
 }
 
-func parseAuth(rootDir string) (AuthStructure, error) {
+func (l *loader) parseAuth(rootDir string) (AuthStructure, error) {
 	dir := filepath.Join(rootDir, NameAuth)
 
 	if _, err := os.Stat(dir); err != nil {
@@ -200,12 +206,12 @@ func parseAuth(rootDir string) (AuthStructure, error) {
 		return AuthStructure{}, err
 	}
 
-	customUserData, err := parseJSON(filepath.Join(dir, FileCustomUserData.String()))
+	customUserData, err := l.parseJSON(filepath.Join(dir, FileCustomUserData.String()))
 	if err != nil {
 		return AuthStructure{}, err
 	}
 
-	providers, err := parseJSON(filepath.Join(dir, FileProviders.String()))
+	providers, err := l.parseJSON(filepath.Join(dir, FileProviders.String()))
 	if err != nil {
 		return AuthStructure{}, err
 	}
@@ -213,7 +219,7 @@ func parseAuth(rootDir string) (AuthStructure, error) {
 	return AuthStructure{customUserData, providers}, nil
 }
 
-func parseFunctionsV2(rootDir string) (FunctionsStructure, error) {
+func (l *loader) parseFunctionsV2(rootDir string) (FunctionsStructure, error) {
 	dir := filepath.Join(rootDir, NameFunctions)
 
 	if _, err := os.Stat(dir); err != nil {
@@ -223,38 +229,55 @@ func parseFunctionsV2(rootDir string) (FunctionsStructure, error) {
 		return FunctionsStructure{}, err
 	}
 
-	configs, err := parseJSONArray(filepath.Join(dir, FileConfig.String()))
+	configs, err := l.parseJSONArray(filepath.Join(dir, FileConfig.String()))
 	if err != nil {
 		return FunctionsStructure{}, err
 	}
 
-	sources := map[string]string{}
+	var paths []string
 	if err := walk(dir, map[string]struct{}{nameNodeModules: {}}, func(file os.FileInfo, path string) error {
-		if filepath.Ext(path) != extJS {
-			return nil // looking for javascript files
+		if ext := filepath.Ext(path); ext != extJS && ext != extTS {
+			return nil // looking for javascript and typescript files
 		}
+		paths = append(paths, path)
+		return nil
+	}); err != nil {
+		return FunctionsStructure{}, err
+	}
 
-		pathRelative, err := filepath.Rel(dir, path)
-		if err != nil {
-			return err
-		}
+	var mu sync.Mutex
+	sources := make(map[string]string, len(paths))
 
-		data, err := ioutil.ReadFile(path)
-		if err != nil {
-			return err
-		}
+	var g loadGroup
+	for _, path := range paths {
+		path := path
+		g.Go(func() error {
+			pathRelative, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
 
-		sources[pathRelative] = string(data)
-		return nil
-	}); err != nil {
+			src, err := l.readSource(path)
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			sources[pathRelative] = src
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
 		return FunctionsStructure{}, err
 	}
 
-	return FunctionsStructure{configs, sources}, nil
+	return bundleFunctions(rootDir, FunctionsStructure{Configs: configs, Sources: sources})
 }
 
 // TODO (REALMC-10879): support endpoints in older config versions
-func parseEndpointsV2(rootDir string) (EndpointStructure, error) {
+func (l *loader) parseEndpointsV2(rootDir string) (EndpointStructure, error) {
 	dir := filepath.Join(rootDir, NameHTTPEndpoints)
 
 	if _, err := os.Stat(dir); err != nil {
@@ -264,7 +287,7 @@ func parseEndpointsV2(rootDir string) (EndpointStructure, error) {
 		return EndpointStructure{}, err
 	}
 
-	configs, err := parseJSONArray(filepath.Join(dir, FileConfig.String()))
+	configs, err := l.parseJSONArray(filepath.Join(dir, FileConfig.String()))
 	if err != nil {
 		return EndpointStructure{}, err
 	}
@@ -272,130 +295,192 @@ func parseEndpointsV2(rootDir string) (EndpointStructure, error) {
 	return EndpointStructure{configs}, nil
 }
 
-func parseDataSources(rootDir string) ([]DataSourceStructure, error) {
-	var out []DataSourceStructure
+func (l *loader) parseDataSources(rootDir string) ([]DataSourceStructure, error) {
+	var paths []string
 
 	dw := directoryWalker{
 		path:     filepath.Join(rootDir, NameDataSources),
 		onlyDirs: true,
 	}
 	if err := dw.walk(func(file os.FileInfo, path string) error {
-		config, err := parseJSON(filepath.Join(path, FileConfig.String()))
-		if err != nil {
-			return err
-		}
+		paths = append(paths, path)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	out := make([]DataSourceStructure, len(paths))
+
+	var g loadGroup
+	for i, path := range paths {
+		i, path := i, path
+		g.Go(func() error {
+			config, err := l.parseJSON(filepath.Join(path, FileConfig.String()))
+			if err != nil {
+				return err
+			}
 
-		var rules []map[string]interface{}
+			rules, err := l.parseDataSourceRules(path)
+			if err != nil {
+				return err
+			}
 
-		dbs := directoryWalker{path: path, onlyDirs: true}
-		if err := dbs.walk(func(db os.FileInfo, dbPath string) error {
+			out[i] = DataSourceStructure{config, rules}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-			colls := directoryWalker{path: dbPath, onlyDirs: true}
-			if err := colls.walk(func(coll os.FileInfo, collPath string) error {
-				// A valid data sources folder contains at least one of:
-				// - a rules.json file
-				// - a pair of files, schema.json and relationships.json
-				// If neither of these conditions are true (e.g. there is only a schema.json
-				// file, or there are no files in the directory at all), we should error
+func (l *loader) parseDataSourceRules(dataSourceDir string) ([]map[string]interface{}, error) {
+	type collection struct {
+		db, coll, path string
+	}
 
-				// If we are not using app schemas, a valid data sources folder should contain all of
-				// these files and we should error otherwise
+	var colls []collection
 
-				rule, err := parseJSON(filepath.Join(collPath, FileRules.String()))
-				if err != nil {
-					return err
-				}
+	dbs := directoryWalker{path: dataSourceDir, onlyDirs: true}
+	if err := dbs.walk(func(db os.FileInfo, dbPath string) error {
+		dw := directoryWalker{path: dbPath, onlyDirs: true}
+		return dw.walk(func(coll os.FileInfo, collPath string) error {
+			colls = append(colls, collection{db.Name(), coll.Name(), collPath})
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
 
-				schemaBody, err := parseJSON(filepath.Join(collPath, FileSchema.String()))
-				if err != nil {
-					return err
-				}
-				if schemaBody == nil {
-					schemaBody = map[string]interface{}{}
-				}
+	if len(colls) == 0 {
+		return nil, nil
+	}
 
-				relationships, err := parseJSON(filepath.Join(collPath, FileRelationships.String()))
-				if err != nil {
-					return err
-				}
-				if relationships == nil {
-					relationships = map[string]interface{}{}
-				}
+	rules := make([]map[string]interface{}, len(colls))
 
-				if rule == nil {
-					if schemaBody == nil || relationships == nil {
-						return fmt.Errorf("collection dir %s should contain a rules.json file and/or both schema.json and relationships.json files", collPath)
-					}
-
-					rule = map[string]interface{}{
-						"database":   db.Name(),
-						"collection": coll.Name(),
-					}
-				} else {
-					if len(rule) == 0 {
-						return errors.New("rules file cannot be empty")
-					}
-				}
+	var g loadGroup
+	for i, coll := range colls {
+		i, coll := i, coll
+		g.Go(func() error {
+			// A valid data sources folder contains at least one of:
+			// - a rules.json file
+			// - a pair of files, schema.json and relationships.json
+			// If neither of these conditions are true (e.g. there is only a schema.json
+			// file, or there are no files in the directory at all), we should error
 
-				rule[NameSchema] = schemaBody
-				rule[NameRelationships] = relationships
-				rules = append(rules, rule)
+			// If we are not using app schemas, a valid data sources folder should contain all of
+			// these files and we should error otherwise
 
-				return nil
-			}); err != nil {
+			rule, err := l.parseJSON(filepath.Join(coll.path, FileRules.String()))
+			if err != nil {
 				return err
 			}
-			return nil
-		}); err != nil {
-			return err
-		}
 
-		out = append(out, DataSourceStructure{config, rules})
-		return nil
-	}); err != nil {
+			schemaBody, err := l.parseJSON(filepath.Join(coll.path, FileSchema.String()))
+			if err != nil {
+				return err
+			}
+			if schemaBody == nil {
+				schemaBody = map[string]interface{}{}
+			}
+
+			relationships, err := l.parseJSON(filepath.Join(coll.path, FileRelationships.String()))
+			if err != nil {
+				return err
+			}
+			if relationships == nil {
+				relationships = map[string]interface{}{}
+			}
+
+			if rule == nil {
+				if schemaBody == nil || relationships == nil {
+					return fmt.Errorf("collection dir %s should contain a rules.json file and/or both schema.json and relationships.json files", coll.path)
+				}
+
+				rule = map[string]interface{}{
+					"database":   coll.db,
+					"collection": coll.coll,
+				}
+			} else {
+				if len(rule) == 0 {
+					return errors.New("rules file cannot be empty")
+				}
+			}
+
+			rule[NameSchema] = schemaBody
+			rule[NameRelationships] = relationships
+			rules[i] = rule
+
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return rules, nil
 }
 
-func parseHTTPServices(rootDir string) ([]HTTPServiceStructure, error) {
-	var out []HTTPServiceStructure
+func (l *loader) parseHTTPServices(rootDir string) ([]HTTPServiceStructure, error) {
+	var paths []string
 
 	dw := directoryWalker{
 		path:     filepath.Join(rootDir, NameHTTPEndpoints),
 		onlyDirs: true,
 	}
 	if err := dw.walk(func(file os.FileInfo, path string) error {
-		config, err := parseJSON(filepath.Join(path, FileConfig.String()))
-		if err != nil {
-			return err
-		}
+		paths = append(paths, path)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
 
-		webhooks, err := parseFunctions(filepath.Join(path, NameIncomingWebhooks))
-		if err != nil {
-			return err
-		}
-		if webhooks == nil {
-			webhooks = []map[string]interface{}{}
-		}
+	if len(paths) == 0 {
+		return nil, nil
+	}
 
-		rules, err := parseJSONFiles(filepath.Join(path, NameRules))
-		if err != nil {
-			return err
-		}
-		if rules == nil {
-			rules = []map[string]interface{}{}
-		}
+	out := make([]HTTPServiceStructure, len(paths))
 
-		out = append(out, HTTPServiceStructure{config, webhooks, rules})
-		return nil
-	}); err != nil {
+	var g loadGroup
+	for i, path := range paths {
+		i, path := i, path
+		g.Go(func() error {
+			config, err := l.parseJSON(filepath.Join(path, FileConfig.String()))
+			if err != nil {
+				return err
+			}
+
+			webhooks, err := l.parseFunctions(filepath.Join(path, NameIncomingWebhooks))
+			if err != nil {
+				return err
+			}
+			if webhooks == nil {
+				webhooks = []map[string]interface{}{}
+			}
+
+			rules, err := l.parseJSONFiles(filepath.Join(path, NameRules))
+			if err != nil {
+				return err
+			}
+			if rules == nil {
+				rules = []map[string]interface{}{}
+			}
+
+			out[i] = HTTPServiceStructure{config, webhooks, rules}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func parseSync(rootDir string) (SyncStructure, error) {
+func (l *loader) parseSync(rootDir string) (SyncStructure, error) {
 	dir := filepath.Join(rootDir, NameSync)
 
 	if _, err := os.Stat(dir); err != nil {
@@ -405,7 +490,7 @@ func parseSync(rootDir string) (SyncStructure, error) {
 		return SyncStructure{}, err
 	}
 
-	config, err := parseJSON(filepath.Join(dir, FileConfig.String()))
+	config, err := l.parseJSON(filepath.Join(dir, FileConfig.String()))
 	if err != nil {
 		return SyncStructure{}, err
 	}
@@ -485,11 +570,7 @@ func writeFunctionsV2(rootDir string, functions FunctionsStructure) error {
 		return err
 	}
 
-	if err := WriteFile(
-		filepath.Join(dir, FileConfig.String()),
-		0666,
-		bytes.NewReader(data),
-	); err != nil {
+	if err := writeConfigFile(filepath.Join(dir, FileConfig.String()), data); err != nil {
 		return err
 	}
 
@@ -664,6 +745,7 @@ func writeHTTPServices(rootDir string, httpServices []HTTPServiceStructure) erro
 			return err
 		}
 		for _, webhook := range httpService.IncomingWebhooks {
+			webhook = flattenFunction(webhook)
 			src, ok := webhook[NameSource].(string)
 			if !ok {
 				return errors.New("error writing http endpoints")
@@ -17,7 +17,10 @@ func TestParseFunctionsV2(t *testing.T) {
 	testRoot := filepath.Join(wd, "testdata/functions")
 
 	t.Run("should return the parsed functions directory with nested javascript files", func(t *testing.T) {
-		functions, err := parseFunctionsV2(testRoot)
+		l, err := newLoader(LoadOptions{})
+		assert.Nil(t, err)
+
+		functions, err := l.parseFunctionsV2(testRoot)
 		assert.Nil(t, err)
 		assert.Equal(t, FunctionsStructure{
 			Configs: []map[string]interface{}{{
@@ -45,7 +48,10 @@ func TestParseDataSources(t *testing.T) {
 	testRoot := filepath.Join(wd, "testdata/data_sources")
 
 	t.Run("should return the parsed data sources directory with nested rules and schema", func(t *testing.T) {
-		dataSources, err := parseDataSources(testRoot)
+		l, err := newLoader(LoadOptions{})
+		assert.Nil(t, err)
+
+		dataSources, err := l.parseDataSources(testRoot)
 		assert.Nil(t, err)
 		assert.Equal(t, []DataSourceStructure{{
 			Config: map[string]interface{}{
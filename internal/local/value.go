@@ -0,0 +1,41 @@
+package local
+
+// AppValue is a value of a local Realm app
+type AppValue struct {
+	Name       string
+	Value      interface{}
+	FromSecret bool
+}
+
+// AppValues returns the values of the local Realm app data,
+// with any values set by the app's current environment applied
+// (environments are keyed by their json file name)
+func AppValues(appData AppData) []AppValue {
+	var values []map[string]interface{}
+	var environments map[string]map[string]interface{}
+
+	switch ad := appData.(type) {
+	case *AppRealmConfigJSON:
+		values, environments = ad.Values, ad.Environments
+	case *AppConfigJSON:
+		values, environments = ad.Values, ad.Environments
+	case *AppStitchJSON:
+		values, environments = ad.Values, ad.Environments
+	}
+
+	environmentValues, _ := environments[string(appData.Environment())+extJSON][NameValues].(map[string]interface{})
+
+	out := make([]AppValue, 0, len(values))
+	for _, value := range values {
+		name, _ := value["name"].(string)
+		fromSecret, _ := value["from_secret"].(bool)
+
+		v := value["value"]
+		if ev, ok := environmentValues[name]; ok && !fromSecret {
+			v = ev
+		}
+
+		out = append(out, AppValue{name, v, fromSecret})
+	}
+	return out
+}
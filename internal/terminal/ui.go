@@ -15,6 +15,8 @@ type UI interface {
 	Ask(answer interface{}, questions ...*survey.Question) error
 	AskOne(answer interface{}, prompt survey.Prompt) error
 	Confirm(format string, args ...interface{}) (bool, error)
+	In() io.Reader
+	Out() io.Writer
 	Print(logs ...Log)
 	Spinner(message string, opts SpinnerOptions) Spinner
 }
@@ -76,6 +78,14 @@ func (ui *ui) Confirm(format string, args ...interface{}) (bool, error) {
 	)
 }
 
+func (ui *ui) In() io.Reader {
+	return ui.in.Reader
+}
+
+func (ui *ui) Out() io.Writer {
+	return ui.out.Writer
+}
+
 func (ui *ui) Print(logs ...Log) {
 	for _, l := range logs {
 		output, err := l.Print(ui.config.OutputFormat)
@@ -0,0 +1,20 @@
+package clock
+
+import (
+	"time"
+)
+
+// Clock provides the current time and timers, so commands can be tested against a fake clock
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// System is the clock of the system
+type System struct{}
+
+// Now returns the current time
+func (System) Now() time.Time { return time.Now() }
+
+// After waits for the duration to elapse and then sends the current time on the returned channel
+func (System) After(d time.Duration) <-chan time.Time { return time.After(d) }
@@ -0,0 +1,45 @@
+package flags
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const day = 24 * time.Hour
+
+// Duration is a duration flag
+type Duration struct {
+	Duration time.Duration
+}
+
+// Type returns the duration flag type
+func (d Duration) Type() string {
+	return "Duration"
+}
+
+func (d Duration) String() string {
+	if d.Duration == 0 {
+		return ""
+	}
+	return d.Duration.String()
+}
+
+// Set parses the duration value, which may also be a number of days (e.g. 180d)
+func (d *Duration) Set(val string) error {
+	if days := strings.TrimSuffix(val, "d"); days != val {
+		if n, err := strconv.ParseFloat(days, 64); err == nil {
+			d.Duration = time.Duration(n * float64(day))
+			return nil
+		}
+	}
+
+	duration, err := time.ParseDuration(val)
+	if err != nil {
+		return fmt.Errorf("unrecognized duration string: %s", val)
+	}
+
+	d.Duration = duration
+	return nil
+}
@@ -0,0 +1,36 @@
+package flags
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestDurationSet(t *testing.T) {
+	for _, tc := range []struct {
+		description string
+		input       string
+		output      string
+	}{
+		{"a duration in milliseconds", "250ms", "250ms"},
+		{"a duration in seconds", "1.5s", "1.5s"},
+		{"a duration in minutes and seconds", "2m30s", "2m30s"},
+		{"a duration in days", "180d", "4320h0m0s"},
+		{"a duration in fractional days", "1.5d", "36h0m0s"},
+	} {
+		t.Run("should parse "+tc.description, func(t *testing.T) {
+			duration := new(Duration)
+
+			assert.Nil(t, duration.Set(tc.input))
+
+			assert.Equal(t, tc.output, duration.String())
+		})
+	}
+
+	t.Run("should return an error for an invalid duration", func(t *testing.T) {
+		duration := new(Duration)
+
+		assert.Equal(t, errors.New("unrecognized duration string: 10"), duration.Set("10"))
+	})
+}
@@ -191,6 +191,24 @@ func (f StringFlag) Register(fs *pflag.FlagSet) {
 	registerFlag(fs, f.Meta)
 }
 
+// IntFlag is an int flag
+type IntFlag struct {
+	Meta
+	Value        *int
+	DefaultValue int
+}
+
+// Register registers the int flag with the provided flag set
+func (f IntFlag) Register(fs *pflag.FlagSet) {
+	if f.Shorthand == "" {
+		fs.IntVar(f.Value, f.Name, f.DefaultValue, f.Usage.String())
+	} else {
+		fs.IntVarP(f.Value, f.Name, f.Shorthand, f.DefaultValue, f.Usage.String())
+	}
+
+	registerFlag(fs, f.Meta)
+}
+
 // StringArrayFlag is a string array flag
 type StringArrayFlag struct {
 	Meta
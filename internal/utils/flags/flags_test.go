@@ -0,0 +1,63 @@
+package flags
+
+import (
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+
+	"github.com/spf13/pflag"
+)
+
+func TestStringFlag(t *testing.T) {
+	for _, tc := range []struct {
+		description   string
+		args          []string
+		expectedValue string
+	}{
+		{
+			description:   "should set the default value when the flag is omitted",
+			expectedValue: "default",
+		},
+		{
+			description:   "should set the provided value",
+			args:          []string{"--local=value"},
+			expectedValue: "value",
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			var value string
+
+			fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			StringFlag{
+				Value:        &value,
+				Meta:         Meta{Name: "local"},
+				DefaultValue: "default",
+			}.Register(fs)
+
+			assert.Nil(t, fs.Parse(tc.args))
+			assert.Equal(t, tc.expectedValue, value)
+		})
+	}
+}
+
+func TestIntFlag(t *testing.T) {
+	t.Run("should set the default value when the flag is omitted", func(t *testing.T) {
+		var value int
+
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		IntFlag{Value: &value, Meta: Meta{Name: "requests"}, DefaultValue: 100}.Register(fs)
+
+		assert.Nil(t, fs.Parse(nil))
+		assert.Equal(t, 100, value)
+	})
+
+	t.Run("should set the provided value", func(t *testing.T) {
+		var value int
+
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		IntFlag{Value: &value, Meta: Meta{Name: "requests"}, DefaultValue: 100}.Register(fs)
+
+		assert.Nil(t, fs.Parse([]string{"--requests=500"}))
+		assert.Equal(t, 500, value)
+	})
+}
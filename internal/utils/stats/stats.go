@@ -0,0 +1,15 @@
+package stats
+
+import (
+	"math"
+	"time"
+)
+
+// Percentile returns the nearest-rank percentile of the sorted durations
+func Percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,35 @@
+package stats
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestPercentile(t *testing.T) {
+	sorted := make([]time.Duration, 0, 20)
+	for i := 1; i <= 20; i++ {
+		sorted = append(sorted, time.Duration(i)*time.Millisecond)
+	}
+
+	for _, tc := range []struct {
+		p        float64
+		expected time.Duration
+	}{
+		{0, time.Millisecond},
+		{50, 10 * time.Millisecond},
+		{95, 19 * time.Millisecond},
+		{99, 20 * time.Millisecond},
+		{100, 20 * time.Millisecond},
+	} {
+		t.Run(fmt.Sprintf("should return the nearest-rank p%v", tc.p), func(t *testing.T) {
+			assert.Equal(t, tc.expected, Percentile(sorted, tc.p))
+		})
+	}
+
+	t.Run("should return the only duration", func(t *testing.T) {
+		assert.Equal(t, time.Second, Percentile([]time.Duration{time.Second}, 99))
+	})
+}
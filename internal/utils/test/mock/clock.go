@@ -0,0 +1,28 @@
+package mock
+
+import (
+	"time"
+)
+
+// Clock is a mocked clock whose timers fire immediately,
+// advancing the current time by their duration
+type Clock struct {
+	Time  time.Time
+	Waits []time.Duration
+}
+
+// Now returns the current time of the mocked clock
+func (c *Clock) Now() time.Time {
+	return c.Time
+}
+
+// After advances the current time of the mocked clock by the duration
+// and records the wait, the returned channel already has the new time sent on it
+func (c *Clock) After(d time.Duration) <-chan time.Time {
+	c.Time = c.Time.Add(d)
+	c.Waits = append(c.Waits, d)
+
+	ch := make(chan time.Time, 1)
+	ch <- c.Time
+	return ch
+}
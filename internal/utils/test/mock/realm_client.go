@@ -44,14 +44,26 @@ type RealmClient struct {
 	DeleteSecretFn func(groupID, appID, secretID string) error
 	UpdateSecretFn func(groupID, appID, secretID, name, value string) error
 
-	CreateAPIKeyFn      func(groupID, appID, apiKeyName string) (realm.APIKey, error)
+	APIKeysFn       func(groupID, appID string) ([]realm.APIKey, error)
+	CreateAPIKeyFn  func(groupID, appID, apiKeyName string) (realm.APIKey, error)
+	DeleteAPIKeyFn  func(groupID, appID, apiKeyID string) error
+	DisableAPIKeyFn func(groupID, appID, apiKeyID string) error
+	EnableAPIKeyFn  func(groupID, appID, apiKeyID string) error
+
 	CreateUserFn        func(groupID, appID, email, password string) (realm.User, error)
 	DeleteUserFn        func(groupID, appID, userID string) error
 	DisableUserFn       func(groupID, appID, userID string) error
 	EnableUserFn        func(groupID, appID, userID string) error
 	FindUsersFn         func(groupID, appID string, filter realm.UserFilter) ([]realm.User, error)
+	UsersPagesFn        func(groupID, appID string, filter realm.UserFilter, handlePage func(page []realm.User) error) error
 	RevokeUserSessionFn func(groupID, appID, userID string) error
 
+	ConfirmPendingUserFn            func(groupID, appID, email string) error
+	DeletePendingUserFn             func(groupID, appID, email string) error
+	ResendPendingUserConfirmationFn func(groupID, appID, email string) error
+	SendResetPasswordEmailFn        func(clientAppID, email string) error
+	CallResetPasswordFunctionFn     func(clientAppID, email, password string, args []interface{}) error
+
 	HostingAssetsFn                func(groupID, appID string) ([]realm.HostingAsset, error)
 	HostingAssetUploadFn           func(groupID, appID, rootDir string, asset realm.HostingAsset) error
 	HostingAssetRemoveFn           func(groupID, appID, path string) error
@@ -59,9 +71,12 @@ type RealmClient struct {
 	HostingCacheInvalidateFn       func(groupID, appID, path string) error
 
 	FunctionsFn               func(groupID, appID string) ([]realm.Function, error)
+	FunctionFn                func(groupID, appID, functionID string) (realm.FunctionDefinition, error)
+	UpdateFunctionFn          func(groupID, appID, functionID string, function realm.FunctionDefinition) error
 	AppDebugExecuteFunctionFn func(groupID, appID, userID, name string, args []interface{}) (realm.ExecutionResults, error)
 
-	LogsFn func(groupID, appID string, opts realm.LogsOptions) (realm.Logs, error)
+	LogsFn      func(groupID, appID string, opts realm.LogsOptions) (realm.Logs, error)
+	LogsPagesFn func(groupID, appID string, opts realm.LogsOptions, handlePage func(page realm.Logs) error) (bool, error)
 
 	SchemaModelsFn func(groupID, appID, language string) ([]realm.SchemaModel, error)
 
@@ -247,6 +262,16 @@ func (rc RealmClient) DependenciesStatus(groupID, appID string) (realm.Dependenc
 	return rc.Client.DependenciesStatus(groupID, appID)
 }
 
+// APIKeys calls the mocked APIKeys implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) APIKeys(groupID, appID string) ([]realm.APIKey, error) {
+	if rc.APIKeysFn != nil {
+		return rc.APIKeysFn(groupID, appID)
+	}
+	return rc.Client.APIKeys(groupID, appID)
+}
+
 // CreateAPIKey calls the mocked CreateAPIKey implementation if provided,
 // otherwise the call falls back to the underlying realm.Client implementation.
 // NOTE: this may panic if the underlying realm.Client is left undefined
@@ -257,6 +282,36 @@ func (rc RealmClient) CreateAPIKey(groupID, appID, apiKeyName string) (realm.API
 	return rc.Client.CreateAPIKey(groupID, appID, apiKeyName)
 }
 
+// DeleteAPIKey calls the mocked DeleteAPIKey implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) DeleteAPIKey(groupID, appID, apiKeyID string) error {
+	if rc.DeleteAPIKeyFn != nil {
+		return rc.DeleteAPIKeyFn(groupID, appID, apiKeyID)
+	}
+	return rc.Client.DeleteAPIKey(groupID, appID, apiKeyID)
+}
+
+// DisableAPIKey calls the mocked DisableAPIKey implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) DisableAPIKey(groupID, appID, apiKeyID string) error {
+	if rc.DisableAPIKeyFn != nil {
+		return rc.DisableAPIKeyFn(groupID, appID, apiKeyID)
+	}
+	return rc.Client.DisableAPIKey(groupID, appID, apiKeyID)
+}
+
+// EnableAPIKey calls the mocked EnableAPIKey implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) EnableAPIKey(groupID, appID, apiKeyID string) error {
+	if rc.EnableAPIKeyFn != nil {
+		return rc.EnableAPIKeyFn(groupID, appID, apiKeyID)
+	}
+	return rc.Client.EnableAPIKey(groupID, appID, apiKeyID)
+}
+
 // Secrets calls the mocked Secrets implementation if provided,
 // otherwise the call falls back to the underlying realm.Client implementation.
 // NOTE: this may panic if the underlying realm.Client is left undefined
@@ -347,6 +402,16 @@ func (rc RealmClient) FindUsers(groupID, appID string, filter realm.UserFilter)
 	return rc.Client.FindUsers(groupID, appID, filter)
 }
 
+// UsersPages calls the mocked UsersPages implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) UsersPages(groupID, appID string, filter realm.UserFilter, handlePage func(page []realm.User) error) error {
+	if rc.UsersPagesFn != nil {
+		return rc.UsersPagesFn(groupID, appID, filter, handlePage)
+	}
+	return rc.Client.UsersPages(groupID, appID, filter, handlePage)
+}
+
 // RevokeUserSessions calls the mocked RevokeUserSessions implementation if provided,
 // otherwise the call falls back to the underlying realm.Client implementation.
 // NOTE: this may panic if the underlying realm.Client is left undefined
@@ -357,6 +422,56 @@ func (rc RealmClient) RevokeUserSessions(groupID, appID, userID string) error {
 	return rc.Client.RevokeUserSessions(groupID, appID, userID)
 }
 
+// ConfirmPendingUser calls the mocked ConfirmPendingUser implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) ConfirmPendingUser(groupID, appID, email string) error {
+	if rc.ConfirmPendingUserFn != nil {
+		return rc.ConfirmPendingUserFn(groupID, appID, email)
+	}
+	return rc.Client.ConfirmPendingUser(groupID, appID, email)
+}
+
+// DeletePendingUser calls the mocked DeletePendingUser implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) DeletePendingUser(groupID, appID, email string) error {
+	if rc.DeletePendingUserFn != nil {
+		return rc.DeletePendingUserFn(groupID, appID, email)
+	}
+	return rc.Client.DeletePendingUser(groupID, appID, email)
+}
+
+// ResendPendingUserConfirmation calls the mocked ResendPendingUserConfirmation implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) ResendPendingUserConfirmation(groupID, appID, email string) error {
+	if rc.ResendPendingUserConfirmationFn != nil {
+		return rc.ResendPendingUserConfirmationFn(groupID, appID, email)
+	}
+	return rc.Client.ResendPendingUserConfirmation(groupID, appID, email)
+}
+
+// SendResetPasswordEmail calls the mocked SendResetPasswordEmail implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) SendResetPasswordEmail(clientAppID, email string) error {
+	if rc.SendResetPasswordEmailFn != nil {
+		return rc.SendResetPasswordEmailFn(clientAppID, email)
+	}
+	return rc.Client.SendResetPasswordEmail(clientAppID, email)
+}
+
+// CallResetPasswordFunction calls the mocked CallResetPasswordFunction implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) CallResetPasswordFunction(clientAppID, email, password string, args []interface{}) error {
+	if rc.CallResetPasswordFunctionFn != nil {
+		return rc.CallResetPasswordFunctionFn(clientAppID, email, password, args)
+	}
+	return rc.Client.CallResetPasswordFunction(clientAppID, email, password, args)
+}
+
 // ExportDependencies calls the mocked ExportDependencies implementation if provided,
 // otherwise the call falls back to the underlying realm.Client implementation.
 // NOTE: this may panic if the underlying realm.Client is left undefined
@@ -457,6 +572,26 @@ func (rc RealmClient) Functions(groupID, appID string) ([]realm.Function, error)
 	return rc.Client.Functions(groupID, appID)
 }
 
+// Function calls the mocked Function implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) Function(groupID, appID, functionID string) (realm.FunctionDefinition, error) {
+	if rc.FunctionFn != nil {
+		return rc.FunctionFn(groupID, appID, functionID)
+	}
+	return rc.Client.Function(groupID, appID, functionID)
+}
+
+// UpdateFunction calls the mocked UpdateFunction implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) UpdateFunction(groupID, appID, functionID string, function realm.FunctionDefinition) error {
+	if rc.UpdateFunctionFn != nil {
+		return rc.UpdateFunctionFn(groupID, appID, functionID, function)
+	}
+	return rc.Client.UpdateFunction(groupID, appID, functionID, function)
+}
+
 // AppDebugExecuteFunction calls the mocked AppDebugExecuteFunction implementation if provided,
 // otherwise the call falls back to the underlying realm.Client implementation.
 // NOTE: this may panic if the underlying realm.Client is left undefined
@@ -477,6 +612,16 @@ func (rc RealmClient) Logs(groupID, appID string, opts realm.LogsOptions) (realm
 	return rc.Client.Logs(groupID, appID, opts)
 }
 
+// LogsPages calls the mocked LogsPages implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) LogsPages(groupID, appID string, opts realm.LogsOptions, handlePage func(page realm.Logs) error) (bool, error) {
+	if rc.LogsPagesFn != nil {
+		return rc.LogsPagesFn(groupID, appID, opts, handlePage)
+	}
+	return rc.Client.LogsPages(groupID, appID, opts, handlePage)
+}
+
 // SchemaModels calls the mocked SchemaModels implementation if provided,
 // otherwise the call falls back to the underlying realm.Client implementation.
 // NOTE: this may panic if the underlying realm.Client is left undefined
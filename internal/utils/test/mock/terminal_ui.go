@@ -20,6 +20,7 @@ type UIOptions struct {
 	AutoConfirm bool
 	UseColors   bool
 	UseJSON     bool
+	In          io.Reader
 }
 
 func newUIConfig(options UIOptions) terminal.UIConfig {
@@ -61,7 +62,7 @@ func NewUI() (*bytes.Buffer, terminal.UI) {
 func NewUIWithOptions(options UIOptions, writer io.Writer) terminal.UI {
 	return ui{terminal.NewUI(
 		newUIConfig(options),
-		nil,
+		options.In,
 		writer,
 		writer,
 	)}